@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/dataio"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func runBackup(ctx context.Context, client *mongo.Client, args []string) error {
+	now := time.Now().UTC()
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	out := fs.String("out", "backup-"+now.Format("20060102T150405Z")+".tar.gz", "archive to write")
+	fs.Parse(args)
+
+	dir, err := os.MkdirTemp("", "datatool-backup-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	manifest := dataio.Manifest{Version: dataio.ArchiveVersion, CreatedAt: now}
+	dbs := databases()
+	keys := make([]string, 0, len(dbs))
+	for key := range dbs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		names, err := repository.Collections(ctx, client, dbs[key])
+		if err != nil {
+			return err
+		}
+		db := dataio.ArchiveDatabase{Key: key, Name: dbs[key]}
+		for _, name := range names {
+			db.Collections = append(db.Collections, dataio.ArchiveCollection{Name: name})
+		}
+		manifest.Databases = append(manifest.Databases, db)
+	}
+
+	// Every collection is read at the same point in time, so a purchase
+	// or points entry is either wholly in the backup or not at all.
+	err = repository.Snapshot(ctx, client, func(ctx context.Context) error {
+		for i, db := range manifest.Databases {
+			for j, c := range db.Collections {
+				path := filepath.Join(dir, filepath.FromSlash(dataio.ArchiveEntry(db.Key, c.Name)))
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					return err
+				}
+				n, err := exportCollection(ctx, client, db.Name, c.Name, nil, dataio.JSONL, path)
+				if err != nil {
+					return fmt.Errorf("%s.%s: %w", db.Name, c.Name, err)
+				}
+				manifest.Databases[i].Collections[j].Documents = n
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := dataio.WriteArchive(file, manifest, dir); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	for _, db := range manifest.Databases {
+		for _, c := range db.Collections {
+			log.Printf("%s.%s: %d documents", db.Name, c.Name, c.Documents)
+		}
+	}
+	log.Printf("Wrote backup to %s", *out)
+	return nil
+}
+
+func runRestore(ctx context.Context, client *mongo.Client, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("want exactly one archive to restore")
+	}
+	file, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	dbs := databases()
+	var (
+		batch         []bson.Raw
+		batchKey      string
+		batchColl     string
+		restored      int
+		checkManifest = func(m dataio.Manifest) error {
+			for _, db := range m.Databases {
+				name, ok := dbs[db.Key]
+				if !ok {
+					return fmt.Errorf("archive has unknown database %q", db.Key)
+				}
+				var names []string
+				for _, c := range db.Collections {
+					names = append(names, c.Name)
+				}
+				if err := repository.EnsureEmpty(ctx, client, name, names); err != nil {
+					return err
+				}
+			}
+			log.Printf("Restoring backup taken at %s", m.CreatedAt.Format(time.RFC3339))
+			return nil
+		}
+	)
+	flush := func() error {
+		if err := repository.InsertDocs(ctx, client, dbs[batchKey], batchColl, batch); err != nil {
+			return fmt.Errorf("%s.%s: %w", dbs[batchKey], batchColl, err)
+		}
+		restored += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+	err = dataio.ReadArchive(file, checkManifest, func(key, collection string, doc bson.Raw) error {
+		if key != batchKey || collection != batchColl || len(batch) == importBatch {
+			if err := flush(); err != nil {
+				return err
+			}
+			batchKey, batchColl = key, collection
+		}
+		batch = append(batch, doc)
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return fmt.Errorf("%w (%d documents were restored before the error)", err, restored)
+	}
+	log.Printf("Restored %d documents", restored)
+	return nil
+}
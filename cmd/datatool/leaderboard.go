@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// leaderboards are the collections written by the leaderboard command, which
+// publishes them to the docs branch.
+var leaderboards = []string{"CrocEnLeader", "WordleEn", "ScramyEn", "GeographyPoints", "WordGridPoints", "AnimePoints"}
+
+func runLeaderboard(ctx context.Context, client *mongo.Client, args []string) error {
+	fs := flag.NewFlagSet("leaderboard", flag.ExitOnError)
+	out := fs.String("out", "leaderboard.json", "file to write")
+	fs.Parse(args)
+
+	allData := make(map[string]interface{})
+	for _, coll := range leaderboards {
+		data, err := repository.CountIDOccurrences(client, coll, 0, repository.WindowAll)
+		if err != nil {
+			log.Printf("Error fetching data for %s: %v", coll, err)
+			continue
+		}
+		allData[coll] = data
+	}
+
+	jsonData, err := json.MarshalIndent(allData, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out, jsonData, 0o644); err != nil {
+		return err
+	}
+	log.Printf("Exported leaderboards to %s", *out)
+	return nil
+}
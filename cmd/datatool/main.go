@@ -0,0 +1,100 @@
+// Command datatool exports, imports, backs up and restores the bot's MongoDB
+// data. It reads the same configuration as the bot: CONFIG_FILE and the
+// environment, of which only MONGO_URI is needed.
+//
+//	datatool export [-c WordleEn,ScramyEn] [-format jsonl|csv] [-chat ID] [-user ID] [-since DATE] [-until DATE] [-out DIR]
+//	datatool import [-c COLLECTION] FILE...
+//	datatool backup [-out FILE]
+//	datatool restore FILE
+//	datatool leaderboard [-out FILE]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, client *mongo.Client, args []string) error
+}
+
+var commands = []command{
+	{"export", "export collections to JSON Lines or CSV files", runExport},
+	{"import", "import JSON Lines or CSV files, replacing documents with the same _id", runImport},
+	{"backup", "write a consistent backup archive of both databases", runBackup},
+	{"restore", "restore a backup archive into empty databases", runRestore},
+	{"leaderboard", "write the all-time leaderboards as JSON", runLeaderboard},
+}
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		usage()
+	}
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == os.Args[1] {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		usage()
+	}
+
+	if _, err := config.Load(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+	client := repository.DbManager()
+	if client == nil {
+		log.Fatal("Could not connect to database")
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	defer client.Disconnect(context.Background())
+
+	if err := cmd.run(ctx, client, os.Args[2:]); err != nil {
+		log.Fatalf("%s: %v", cmd.name, err)
+	}
+}
+
+func usage() {
+	var sb strings.Builder
+	sb.WriteString("usage: datatool <command> [flags]\n\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(&sb, "  %-12s %s\n", c.name, c.summary)
+	}
+	sb.WriteString("\nRun datatool <command> -h for its flags.")
+	log.Fatal(sb.String())
+}
+
+// databases maps the databases the bot uses to the keys they are known by in
+// flags and archives.
+func databases() map[string]string {
+	return map[string]string{
+		"main":     config.App.Mongo.Database,
+		"settings": config.App.Mongo.SettingsDatabase,
+	}
+}
+
+// databaseFlag adds -db to fs and returns a func resolving it to a name.
+func databaseFlag(fs *flag.FlagSet) func() (string, error) {
+	key := fs.String("db", "main", "database: main or settings")
+	return func() (string, error) {
+		name, ok := databases()[*key]
+		if !ok {
+			return "", fmt.Errorf("unknown database %q, want main or settings", *key)
+		}
+		return name, nil
+	}
+}
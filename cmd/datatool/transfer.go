@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/dataio"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// importBatch is how many documents are written per bulk write.
+const importBatch = 1000
+
+func runExport(ctx context.Context, client *mongo.Client, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	database := databaseFlag(fs)
+	only := fs.String("c", "", "comma-separated collections to export (default all)")
+	format := fs.String("format", "jsonl", "file format: jsonl or csv")
+	chatID := fs.Int64("chat", 0, "only documents of this chat")
+	userID := fs.Int("user", 0, "only documents of this user")
+	since := fs.String("since", "", "only documents written on or after this date (2006-01-02 or RFC 3339)")
+	until := fs.String("until", "", "only documents written before this date")
+	out := fs.String("out", ".", "directory to write <collection>.<format> files to")
+	fs.Parse(args)
+
+	db, err := database()
+	if err != nil {
+		return err
+	}
+	f, err := dataio.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+	filter := repository.DocFilter{ChatID: *chatID, UserID: *userID}
+	if filter.Since, err = parseDate(*since); err != nil {
+		return err
+	}
+	if filter.Until, err = parseDate(*until); err != nil {
+		return err
+	}
+
+	names, err := repository.Collections(ctx, client, db)
+	if err != nil {
+		return err
+	}
+	if *only != "" {
+		names = strings.Split(*only, ",")
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		return err
+	}
+	for _, name := range names {
+		path := filepath.Join(*out, name+"."+f.Ext())
+		n, err := exportCollection(ctx, client, db, name, filter.BSON(), f, path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		log.Printf("Exported %d documents of %s to %s", n, name, path)
+	}
+	return nil
+}
+
+func exportCollection(ctx context.Context, client *mongo.Client, db, collection string, filter bson.D, f dataio.Format, path string) (int, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	n := 0
+	if f == dataio.CSV {
+		var docs []bson.Raw
+		err = repository.EachDoc(ctx, client, db, collection, filter, func(doc bson.Raw) error {
+			docs = append(docs, append(bson.Raw(nil), doc...))
+			return nil
+		})
+		if err == nil {
+			n, err = len(docs), dataio.WriteCSV(file, docs)
+		}
+	} else {
+		w := dataio.NewJSONLWriter(file)
+		err = repository.EachDoc(ctx, client, db, collection, filter, func(doc bson.Raw) error {
+			n++
+			return w.Write(doc)
+		})
+		if err == nil {
+			err = w.Flush()
+		}
+	}
+	if err != nil {
+		return n, err
+	}
+	return n, file.Close()
+}
+
+func runImport(ctx context.Context, client *mongo.Client, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	database := databaseFlag(fs)
+	into := fs.String("c", "", "collection to import into (default: the file name without extension)")
+	fs.Parse(args)
+
+	db, err := database()
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("no files to import")
+	}
+	if *into != "" && fs.NArg() > 1 {
+		return fmt.Errorf("-c needs exactly one file")
+	}
+	for _, path := range fs.Args() {
+		collection := *into
+		if collection == "" {
+			collection = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		res, err := importFile(ctx, client, db, collection, path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		log.Printf("Imported %s into %s: %d new, %d replaced", path, collection, res.Inserted, res.Replaced)
+	}
+	return nil
+}
+
+func importFile(ctx context.Context, client *mongo.Client, db, collection, path string) (repository.UpsertResult, error) {
+	var total repository.UpsertResult
+	f, err := dataio.FormatOf(path)
+	if err != nil {
+		return total, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return total, err
+	}
+	defer file.Close()
+
+	var batch []bson.Raw
+	flush := func() error {
+		res, err := repository.UpsertDocs(ctx, client, db, collection, batch)
+		total.Inserted += res.Inserted
+		total.Replaced += res.Replaced
+		batch = batch[:0]
+		return err
+	}
+	err = dataio.Read(file, f, func(doc bson.Raw) error {
+		batch = append(batch, doc)
+		if len(batch) == importBatch {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return total, err
+	}
+	return total, flush()
+}
+
+// parseDate accepts a day, taken as midnight UTC, or an RFC 3339 time. An
+// empty string is the zero time.
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, want 2006-01-02 or RFC 3339", s)
+	}
+	return t, nil
+}
@@ -1,24 +1,448 @@
 package config
 
 import (
-	"log"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BotConfig holds the credentials and toggle for a single Telegram bot.
+// A bot runs only when it has a token and has not been disabled.
+type BotConfig struct {
+	Token    string `json:"token" yaml:"token"`
+	Disabled bool   `json:"disabled" yaml:"disabled"`
+}
+
+// Enabled reports whether the bot should be started.
+func (b BotConfig) Enabled() bool {
+	return b.Token != "" && !b.Disabled
+}
+
+// Bots lists every bot started from main.
+type Bots struct {
+	Word       BotConfig `json:"word" yaml:"word"`
+	Category   BotConfig `json:"category" yaml:"category"`
+	Instagram  BotConfig `json:"instagram" yaml:"instagram"`
+	Translator BotConfig `json:"translator" yaml:"translator"`
+	Font       BotConfig `json:"font" yaml:"font"`
+	Mod        BotConfig `json:"mod" yaml:"mod"`
+	Schedule   BotConfig `json:"schedule" yaml:"schedule"`
+}
+
+// Mongo holds the connection string and database names.
+type Mongo struct {
+	URI              string `json:"uri" yaml:"uri"`
+	Database         string `json:"database" yaml:"database"`
+	SettingsDatabase string `json:"settings_database" yaml:"settings_database"`
+}
+
+// LLM holds the endpoints and keys used for AI replies, translation and speech.
+type LLM struct {
+	BaseURL       string `json:"base_url" yaml:"base_url"`
+	APIKey        string `json:"api_key" yaml:"api_key"`
+	OllamaModel   string `json:"ollama_model" yaml:"ollama_model"`
+	AssemblyAIKey string `json:"assemblyai_key" yaml:"assemblyai_key"`
+	APINinjasKey  string `json:"api_ninjas_key" yaml:"api_ninjas_key"`
+	ElevenLabsKey string `json:"elevenlabs_key" yaml:"elevenlabs_key"`
+}
+
+// Features holds switches that change runtime behaviour without a rebuild.
+type Features struct {
+	Debug        bool   `json:"debug" yaml:"debug"`
+	HealthServer bool   `json:"health_server" yaml:"health_server"`
+	HTTPAddr     string `json:"http_addr" yaml:"http_addr"`
+}
+
+// Update delivery modes.
+const (
+	ModePolling = "polling"
+	ModeWebhook = "webhook"
 )
 
+// Webhook selects how bots receive updates. In webhook mode every bot
+// registers PublicURL plus a per-bot secret path with Telegram, and Telegram
+// must echo Secret in the X-Telegram-Bot-Api-Secret-Token header.
+type Webhook struct {
+	Mode      string `json:"mode" yaml:"mode"`
+	PublicURL string `json:"public_url" yaml:"public_url"`
+	Secret    string `json:"secret" yaml:"secret"`
+}
+
+// Enabled reports whether updates are delivered by webhook.
+func (w Webhook) Enabled() bool {
+	return w.Mode == ModeWebhook
+}
+
+// Seasons splits the leaderboards into seasons of LengthDays days. Season 1
+// starts at midnight UTC on Start (YYYY-MM-DD). Changing either value
+// renumbers the seasons, so set them once. Seasons that ended before the bot
+// first ran with seasons are never archived or rewarded.
+type Seasons struct {
+	Start      string `json:"start" yaml:"start"`
+	LengthDays int    `json:"length_days" yaml:"length_days"`
+}
+
+// Words configures the word-guess games' word bank. The embedded packs are
+// always available; when RemoteURL is set its list is fetched in the
+// background every RefreshMinutes and added on top. A chat is not given a
+// word it had in its last NoRepeat rounds.
+type Words struct {
+	RemoteURL      string `json:"remote_url" yaml:"remote_url"`
+	RefreshMinutes int    `json:"refresh_minutes" yaml:"refresh_minutes"`
+	NoRepeat       int    `json:"no_repeat" yaml:"no_repeat"`
+}
+
+// seasonDate is the layout of Seasons.Start.
+const seasonDate = "2006-01-02"
+
+// Number returns the season t falls in, or 0 if t is before season 1.
+func (s Seasons) Number(t time.Time) int {
+	start, err := time.Parse(seasonDate, s.Start)
+	if err != nil || s.LengthDays <= 0 || t.Before(start) {
+		return 0
+	}
+	return int(t.Sub(start)/(time.Duration(s.LengthDays)*24*time.Hour)) + 1
+}
+
+// Bounds returns when season n starts and when it ends (exclusive).
+func (s Seasons) Bounds(n int) (time.Time, time.Time) {
+	start, _ := time.Parse(seasonDate, s.Start)
+	from := start.AddDate(0, 0, (n-1)*s.LengthDays)
+	return from, from.AddDate(0, 0, s.LengthDays)
+}
+
+// Config is the full application configuration.
 type Config struct {
-	CatTelegramToken string
-	MongoURI         string
-	DatabaseName     string
+	Bots     Bots     `json:"bots" yaml:"bots"`
+	Mongo    Mongo    `json:"mongo" yaml:"mongo"`
+	AdminIDs []int64  `json:"admin_ids" yaml:"admin_ids"`
+	LLM      LLM      `json:"llm" yaml:"llm"`
+	Features Features `json:"features" yaml:"features"`
+	Webhook  Webhook  `json:"webhook" yaml:"webhook"`
+	Seasons  Seasons  `json:"seasons" yaml:"seasons"`
+	Words    Words    `json:"words" yaml:"words"`
 }
 
-var App Config
+// App is the configuration in effect for the running process. It starts out
+// with the defaults so packages that read it before main calls Load still see
+// usable database names.
+var App = Default()
+
+// Default returns the configuration used when neither a file nor the
+// environment override a value.
+func Default() Config {
+	return Config{
+		Mongo: Mongo{
+			Database:         "Telegram",
+			SettingsDatabase: "TelegramBot",
+		},
+		AdminIDs: []int64{1006461736},
+		LLM: LLM{
+			BaseURL:     "https://api.llm7.io/v1",
+			OllamaModel: "super-mario-llama",
+		},
+		Features: Features{
+			Debug:        true,
+			HealthServer: true,
+			HTTPAddr:     ":8080",
+		},
+		Webhook: Webhook{
+			Mode: ModePolling,
+		},
+		Seasons: Seasons{
+			Start:      "2026-01-05",
+			LengthDays: 28,
+		},
+		Words: Words{
+			RemoteURL:      "https://raw.githubusercontent.com/MUSTAFA-A-KHAN/json-data-hub/refs/heads/main/words.json",
+			RefreshMinutes: 360,
+			NoRepeat:       50,
+		},
+	}
+}
 
-func Load(token string) {
-	App = Config{
-		CatTelegramToken: token,
-		DatabaseName:     "Telegram",
+// Load builds the configuration from the defaults, then the file named by
+// CONFIG_FILE (if set), then the environment. The result is validated and
+// stored in App.
+func Load() (Config, error) {
+	cfg := Default()
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := cfg.LoadFile(path); err != nil {
+			return cfg, err
+		}
+	}
+	if err := cfg.ApplyEnv(os.Getenv); err != nil {
+		return cfg, err
 	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	App = cfg
+	return cfg, nil
+}
+
+// LoadFile overlays the values found in a JSON or YAML file. The format is
+// chosen from the extension; fields missing from the file are left unchanged.
+func (c *Config) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, c)
+	case ".json":
+		err = json.Unmarshal(data, c)
+	default:
+		return fmt.Errorf("unsupported config file type %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// ApplyEnv overlays values from the environment. getenv is usually os.Getenv;
+// tests pass a map lookup instead. Empty variables are ignored.
+func (c *Config) ApplyEnv(getenv func(string) string) error {
+	str := func(key string, dst *string) {
+		if v := getenv(key); v != "" {
+			*dst = v
+		}
+	}
+	var errs []error
+	integer := func(key string, dst *int) {
+		if v := getenv(key); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				return
+			}
+			*dst = n
+		}
+	}
+	boolean := func(key string, dst *bool) {
+		if v := getenv(key); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				return
+			}
+			*dst = b
+		}
+	}
+
+	// TEST and CATEGORY are the names the word and category bots were
+	// deployed with originally.
+	str("TEST", &c.Bots.Word.Token)
+	str("WORD_BOT_TOKEN", &c.Bots.Word.Token)
+	str("CATEGORY", &c.Bots.Category.Token)
+	str("CATEGORY_BOT_TOKEN", &c.Bots.Category.Token)
+	str("INSTAGRAM_BOT_TOKEN", &c.Bots.Instagram.Token)
+	str("TRANSLATOR_BOT_TOKEN", &c.Bots.Translator.Token)
+	str("FONT_BOT_TOKEN", &c.Bots.Font.Token)
+	str("MOD_BOT_TOKEN", &c.Bots.Mod.Token)
+	str("SCHEDULE_BOT_TOKEN", &c.Bots.Schedule.Token)
+
+	boolean("DISABLE_WORD_BOT", &c.Bots.Word.Disabled)
+	boolean("DISABLE_CATEGORY_BOT", &c.Bots.Category.Disabled)
+	boolean("DISABLE_INSTAGRAM_BOT", &c.Bots.Instagram.Disabled)
+	boolean("DISABLE_TRANSLATOR_BOT", &c.Bots.Translator.Disabled)
+	boolean("DISABLE_FONT_BOT", &c.Bots.Font.Disabled)
+	boolean("DISABLE_MOD_BOT", &c.Bots.Mod.Disabled)
+	boolean("DISABLE_SCHEDULE_BOT", &c.Bots.Schedule.Disabled)
+
+	str("MONGO_URI", &c.Mongo.URI)
+	str("MONGO_DATABASE", &c.Mongo.Database)
+	str("MONGO_SETTINGS_DATABASE", &c.Mongo.SettingsDatabase)
+
+	if v := getenv("ADMIN_IDS"); v != "" {
+		ids, err := parseIDs(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("ADMIN_IDS: %w", err))
+		} else {
+			c.AdminIDs = ids
+		}
+	}
+
+	str("LLM_BASE_URL", &c.LLM.BaseURL)
+	str("LLM_API_KEY", &c.LLM.APIKey)
+	str("OLLAMA_MODEL", &c.LLM.OllamaModel)
+	str("ASSEMBLYAI_KEY", &c.LLM.AssemblyAIKey)
+	str("API_NINJAS_KEY", &c.LLM.APINinjasKey)
+	str("ELEVENLABS_KEY", &c.LLM.ElevenLabsKey)
+
+	boolean("BOT_DEBUG", &c.Features.Debug)
+	boolean("HEALTH_SERVER", &c.Features.HealthServer)
+	str("HTTP_ADDR", &c.Features.HTTPAddr)
+
+	// Render exposes the service URL; WEBHOOK_URL wins when both are set.
+	str("UPDATE_MODE", &c.Webhook.Mode)
+	str("RENDER_EXTERNAL_URL", &c.Webhook.PublicURL)
+	str("WEBHOOK_URL", &c.Webhook.PublicURL)
+	str("WEBHOOK_SECRET", &c.Webhook.Secret)
+
+	str("SEASON_START", &c.Seasons.Start)
+	integer("SEASON_LENGTH_DAYS", &c.Seasons.LengthDays)
+
+	// WORDS_REMOTE_URL=off turns the remote list off.
+	str("WORDS_REMOTE_URL", &c.Words.RemoteURL)
+	if strings.EqualFold(c.Words.RemoteURL, "off") {
+		c.Words.RemoteURL = ""
+	}
+	integer("WORDS_REFRESH_MINUTES", &c.Words.RefreshMinutes)
+	integer("WORDS_NO_REPEAT", &c.Words.NoRepeat)
+
+	return errors.Join(errs...)
+}
+
+// Validate reports every problem with the configuration at once so a broken
+// deployment can be fixed in a single pass.
+func (c Config) Validate() error {
+	var errs []error
+
+	if c.Mongo.URI == "" {
+		errs = append(errs, errors.New("mongo uri is required (MONGO_URI)"))
+	} else if !strings.HasPrefix(c.Mongo.URI, "mongodb://") && !strings.HasPrefix(c.Mongo.URI, "mongodb+srv://") {
+		errs = append(errs, errors.New("mongo uri must start with mongodb:// or mongodb+srv://"))
+	}
+	if c.Mongo.Database == "" {
+		errs = append(errs, errors.New("mongo database name is required"))
+	}
+	if c.Mongo.SettingsDatabase == "" {
+		errs = append(errs, errors.New("mongo settings database name is required"))
+	}
+
+	if len(c.AdminIDs) == 0 {
+		errs = append(errs, errors.New("at least one admin id is required (ADMIN_IDS)"))
+	}
+	for _, id := range c.AdminIDs {
+		if id <= 0 {
+			errs = append(errs, fmt.Errorf("invalid admin id %d", id))
+		}
+	}
+
+	if c.LLM.BaseURL != "" {
+		if u, err := url.Parse(c.LLM.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid llm base url %q", c.LLM.BaseURL))
+		}
+	}
+	if (c.Features.HealthServer || c.Webhook.Enabled()) && c.Features.HTTPAddr == "" {
+		errs = append(errs, errors.New("http addr is required when the health server or webhooks are enabled"))
+	}
+
+	switch c.Webhook.Mode {
+	case ModePolling:
+	case ModeWebhook:
+		if u, err := url.Parse(c.Webhook.PublicURL); err != nil || u.Scheme != "https" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("webhook mode needs an https public url (WEBHOOK_URL), got %q", c.Webhook.PublicURL))
+		}
+		if !webhookSecretPattern.MatchString(c.Webhook.Secret) {
+			errs = append(errs, errors.New("webhook secret must be 1-256 characters of A-Z, a-z, 0-9, _ or - (WEBHOOK_SECRET)"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown update mode %q; use %q or %q", c.Webhook.Mode, ModePolling, ModeWebhook))
+	}
+
+	if _, err := time.Parse(seasonDate, c.Seasons.Start); err != nil {
+		errs = append(errs, fmt.Errorf("season start %q must be a date like 2026-01-05 (SEASON_START)", c.Seasons.Start))
+	}
+	if c.Seasons.LengthDays <= 0 {
+		errs = append(errs, fmt.Errorf("season length must be at least one day, got %d (SEASON_LENGTH_DAYS)", c.Seasons.LengthDays))
+	}
+
+	if c.Words.RemoteURL != "" {
+		if u, err := url.Parse(c.Words.RemoteURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid words remote url %q (WORDS_REMOTE_URL)", c.Words.RemoteURL))
+		}
+		if c.Words.RefreshMinutes <= 0 {
+			errs = append(errs, fmt.Errorf("words refresh must be at least one minute, got %d (WORDS_REFRESH_MINUTES)", c.Words.RefreshMinutes))
+		}
+	}
+	if c.Words.NoRepeat < 0 {
+		errs = append(errs, fmt.Errorf("words no-repeat window cannot be negative, got %d (WORDS_NO_REPEAT)", c.Words.NoRepeat))
+	}
+
+	// Two bots polling with the same token steal each other's updates.
+	seen := make(map[string]string)
+	enabled := 0
+	for _, b := range c.Bots.list() {
+		if !b.cfg.Enabled() {
+			continue
+		}
+		enabled++
+		if other, ok := seen[b.cfg.Token]; ok {
+			errs = append(errs, fmt.Errorf("%s and %s bots share the same token", other, b.name))
+			continue
+		}
+		seen[b.cfg.Token] = b.name
+	}
+	if enabled == 0 {
+		errs = append(errs, errors.New("no bot is enabled; set at least one bot token"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// IsAdmin reports whether userID is one of the configured admins.
+func (c Config) IsAdmin(userID int64) bool {
+	for _, id := range c.AdminIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// OwnerID is the first configured admin; reports and log dumps are sent there.
+func (c Config) OwnerID() int64 {
+	if len(c.AdminIDs) == 0 {
+		return 0
+	}
+	return c.AdminIDs[0]
+}
+
+// webhookSecretPattern is the character set Telegram accepts for secret_token.
+var webhookSecretPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+type namedBot struct {
+	name string
+	cfg  BotConfig
+}
+
+func (b Bots) list() []namedBot {
+	return []namedBot{
+		{"word", b.Word},
+		{"category", b.Category},
+		{"instagram", b.Instagram},
+		{"translator", b.Translator},
+		{"font", b.Font},
+		{"mod", b.Mod},
+		{"schedule", b.Schedule},
+	}
+}
 
-	if App.CatTelegramToken == "" {
-		log.Fatal("BOT_TOKEN is not set")
+func parseIDs(s string) ([]int64, error) {
+	var ids []int64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a numeric user id", part)
+		}
+		ids = append(ids, id)
 	}
+	return ids, nil
 }
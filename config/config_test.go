@@ -0,0 +1,186 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func envMap(m map[string]string) func(string) string {
+	return func(key string) string { return m[key] }
+}
+
+func TestFileThenEnvPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	yamlData := "bots:\n  word:\n    token: file-word\n  mod:\n    token: file-mod\nmongo:\n  uri: mongodb://file\nadmin_ids: [1, 2]\nfeatures:\n  debug: false\n"
+	if err := os.WriteFile(path, []byte(yamlData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Default()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	err := cfg.ApplyEnv(envMap(map[string]string{
+		"TEST":      "env-word",
+		"MONGO_URI": "mongodb://env",
+	}))
+	if err != nil {
+		t.Fatalf("ApplyEnv: %v", err)
+	}
+
+	if cfg.Bots.Word.Token != "env-word" {
+		t.Errorf("word token = %q, want env value", cfg.Bots.Word.Token)
+	}
+	if cfg.Bots.Mod.Token != "file-mod" {
+		t.Errorf("mod token = %q, want file value", cfg.Bots.Mod.Token)
+	}
+	if cfg.Mongo.URI != "mongodb://env" {
+		t.Errorf("mongo uri = %q", cfg.Mongo.URI)
+	}
+	if cfg.Mongo.Database != "Telegram" {
+		t.Errorf("database default lost: %q", cfg.Mongo.Database)
+	}
+	if cfg.Features.Debug {
+		t.Error("debug should be off from file")
+	}
+	if !cfg.IsAdmin(2) || cfg.IsAdmin(1006461736) || cfg.OwnerID() != 1 {
+		t.Errorf("admin ids = %v", cfg.AdminIDs)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
+
+func TestLoadJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"bots":{"category":{"token":"cat","disabled":true}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := Default()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	if cfg.Bots.Category.Token != "cat" || cfg.Bots.Category.Enabled() {
+		t.Errorf("category = %+v", cfg.Bots.Category)
+	}
+}
+
+func TestApplyEnvRejectsBadValues(t *testing.T) {
+	cfg := Default()
+	err := cfg.ApplyEnv(envMap(map[string]string{
+		"ADMIN_IDS": "12, abc",
+		"BOT_DEBUG": "maybe",
+	}))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"ADMIN_IDS", "BOT_DEBUG"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	cfg.AdminIDs = nil
+	cfg.LLM.BaseURL = "not a url"
+	cfg.Bots.Word.Token = "same"
+	cfg.Bots.Category.Token = "same"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"mongo uri", "admin id", "llm base url", "same token"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	cfg = Default()
+	cfg.Mongo.URI = "mongodb://localhost"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "no bot is enabled") {
+		t.Errorf("expected no-bot error, got %v", err)
+	}
+	cfg.Bots.Schedule.Token = "t"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
+
+func TestValidateWebhook(t *testing.T) {
+	cfg := Default()
+	cfg.Mongo.URI = "mongodb://localhost"
+	cfg.Bots.Word.Token = "t"
+	cfg.Webhook = Webhook{Mode: ModeWebhook, PublicURL: "http://insecure", Secret: "bad secret!"}
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "https public url") || !strings.Contains(err.Error(), "webhook secret") {
+		t.Fatalf("expected url and secret errors, got %v", err)
+	}
+
+	cfg.Webhook = Webhook{Mode: ModeWebhook, PublicURL: "https://bot.example.com", Secret: "abc_DEF-123"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+
+	cfg.Webhook.Mode = "push"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "unknown update mode") {
+		t.Errorf("expected mode error, got %v", err)
+	}
+}
+
+func TestSeasons(t *testing.T) {
+	s := Seasons{Start: "2026-01-05", LengthDays: 28}
+	start := time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC)
+
+	if got := s.Number(start.Add(-time.Second)); got != 0 {
+		t.Errorf("Number before start = %d; want 0", got)
+	}
+	if got := s.Number(start); got != 1 {
+		t.Errorf("Number at start = %d; want 1", got)
+	}
+	if got := s.Number(start.AddDate(0, 0, 28)); got != 2 {
+		t.Errorf("Number after 28 days = %d; want 2", got)
+	}
+
+	from, until := s.Bounds(3)
+	if !from.Equal(start.AddDate(0, 0, 56)) || !until.Equal(start.AddDate(0, 0, 84)) {
+		t.Errorf("Bounds(3) = %v, %v", from, until)
+	}
+
+	cfg := Default()
+	cfg.Mongo.URI = "mongodb://localhost"
+	cfg.Bots.Word.Token = "t"
+	cfg.Seasons = Seasons{Start: "soon", LengthDays: 0}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "season start") || !strings.Contains(err.Error(), "season length") {
+		t.Errorf("expected season errors, got %v", err)
+	}
+}
+
+func TestWords(t *testing.T) {
+	cfg := Default()
+	err := cfg.ApplyEnv(func(key string) string {
+		return map[string]string{"WORDS_REMOTE_URL": "off", "WORDS_NO_REPEAT": "10"}[key]
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Words.RemoteURL != "" || cfg.Words.NoRepeat != 10 {
+		t.Errorf("Words = %+v; want no remote url and a window of 10", cfg.Words)
+	}
+
+	cfg.Mongo.URI = "mongodb://localhost"
+	cfg.Bots.Word.Token = "t"
+	cfg.Words = Words{RemoteURL: "ftp://example.com/words", NoRepeat: -1}
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "words remote url") || !strings.Contains(err.Error(), "words refresh") || !strings.Contains(err.Error(), "no-repeat") {
+		t.Errorf("expected words errors, got %v", err)
+	}
+}
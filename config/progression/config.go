@@ -29,6 +29,9 @@ var Config = struct {
 	WeeklyRewardCoins   int
 	PlayCoins           int
 	WinCoins            int
+	SeasonRewardPlaces  int // how many players of each season leaderboard are rewarded
+	SeasonRewardXP      int // for first place; lower places get a falling share
+	SeasonRewardCoins   int
 }{
 	PlayXP:              10,
 	WinXP:               20,
@@ -39,6 +42,9 @@ var Config = struct {
 	WeeklyRewardCoins:   100,
 	PlayCoins:           5,
 	WinCoins:            10,
+	SeasonRewardPlaces:  3,
+	SeasonRewardXP:      300,
+	SeasonRewardCoins:   300,
 }
 
 // GetLevelFromXP calculates the current level based on total XP.
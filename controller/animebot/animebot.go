@@ -9,8 +9,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/closeguess"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
 	"github.com/agnivade/levenshtein"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
@@ -32,6 +34,7 @@ type GameState struct {
 	Active     bool
 	Question   AnimeData
 	Start_time time.Time
+	Guessers   map[int]string // everyone who has answered this round
 }
 
 var activeGames = make(map[int64]*GameState)
@@ -75,6 +78,7 @@ func HandleAnimeCommand(bot *tgbotapi.BotAPI, chatID int64, client *mongo.Client
 		Active:     true,
 		Question:   question,
 		Start_time: time.Now(),
+		Guessers:   make(map[int]string),
 	}
 
 	text := "<b>Anime Emote Guess!</b>\n\nGuess the anime or character from these emotes:\n" + question.Emotes + "\n\nType your guess!"
@@ -88,18 +92,21 @@ func IsAnimeActive(chatID int64) bool {
 	return exists && state.Active
 }
 
-func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client, chatID int64, text string) {
+// HandleGuess checks an answer to the chat's question. A correct one returns
+// the round's result: the winner against everyone else who answered.
+func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, store repository.Store, chatID int64, text string) *game.Result {
 	if message == nil {
-		return
+		return nil
 	}
 
 	activeGamesMu.Lock()
 	state, exists := activeGames[chatID]
 	if !exists || !state.Active {
 		activeGamesMu.Unlock()
-		return
+		return nil
 	}
 	question := state.Question
+	state.Guessers[message.From.ID] = message.From.FirstName
 	activeGamesMu.Unlock()
 
 	correct := false
@@ -114,26 +121,46 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 
 	if correct {
 		activeGamesMu.Lock()
+		if activeGames[chatID] != state {
+			// Someone else got there first.
+			activeGamesMu.Unlock()
+			return nil
+		}
 		delete(activeGames, chatID)
+		res := &game.Result{}
+		for id, name := range state.Guessers {
+			points := 0
+			if id == message.From.ID {
+				points = 1
+			}
+			res.Scores = append(res.Scores, game.Score{Player: game.Player{ID: id, Name: name}, Points: points})
+		}
 		activeGamesMu.Unlock()
 
 		points := 10
-		go repository.InsertWordleBonusDoc(message.From.ID, message.From.FirstName, chatID, client, "AnimePoints", points)
+		if store != nil {
+			go store.InsertWordleBonusDoc(message.From.ID, message.From.FirstName, chatID, "AnimePoints", points)
 
-		// ⚡ Add Progression Integration (Award XP/Coins)
-		go func(uID int64, username string) {
-			if client != nil {
-				service.AwardGameResult(client, uID, username, true) // Winner
-			}
-		}(int64(message.From.ID), message.From.FirstName)
+			// ⚡ Add Progression Integration (Award XP/Coins)
+			go func(uID int64, username string) {
+				service.AwardGameResult(store, uID, username, true) // Winner
+			}(int64(message.From.ID), message.From.FirstName)
+		}
 
 		successMsg := "🎉 Correct! It was <b>" + bestAnswer + "</b>!\nYou earned " + strconv.Itoa(points) + " points!"
 		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Play Again 🎌", "anime_start")))
 		view.SendMessagehtmlWithButtons(bot, chatID, successMsg, markup)
-	} else {
-		// Not correct, maybe close but give a generic message
-		// view.SendMessagehtml(bot, chatID, "❌ Nope, try again!")
+		return res
 	}
+
+	// Not correct, but maybe close to one of the answers.
+	for _, ans := range question.Answers {
+		if allowed := allowedEdits(ans); closeguess.Near(text, ans, allowed) {
+			game.CloseGuess(bot, store, message, text, ans, allowed)
+			break
+		}
+	}
+	return nil
 }
 
 func checkAnswerFuzzy(guess, answer string) bool {
@@ -145,16 +172,20 @@ func checkAnswerFuzzy(guess, answer string) bool {
 		return true
 	}
 
-	dist := levenshtein.ComputeDistance(g, a)
-	// allow 2 edits for longer words
-	maxDist := len(a) / 4
+	return levenshtein.ComputeDistance(g, a) <= allowedEdits(a)
+}
+
+// allowedEdits is how many typos an answer forgives: one for every four
+// letters, between one and three.
+func allowedEdits(answer string) int {
+	maxDist := len(answer) / 4
 	if maxDist < 1 {
 		maxDist = 1
 	}
 	if maxDist > 3 {
 		maxDist = 3
 	}
-	return dist <= maxDist
+	return maxDist
 }
 
 func CancelAnime(chatID int64) bool {
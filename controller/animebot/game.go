@@ -0,0 +1,53 @@
+package animebot
+
+import (
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Game is the Anime quiz for the game engine. Its rounds last seconds, so
+// they are not persisted.
+var Game game.Game = anime{}
+
+type anime struct{}
+
+func (anime) Info() game.Info {
+	return game.Info{
+		Name:        "anime",
+		Title:       "Anime",
+		Description: "Start an Anime quiz",
+	}
+}
+
+func (anime) Scoreboard() string {
+	return "AnimePoints"
+}
+
+func (anime) Start(env game.Env, chatID int64, p game.Player) {
+	HandleAnimeCommand(env.Bot, chatID, env.Client)
+}
+
+func (anime) Active(chatID int64) bool {
+	return IsAnimeActive(chatID)
+}
+
+func (anime) Guess(env game.Env, msg *tgbotapi.Message) *game.Result {
+	return HandleGuess(env.Bot, msg, env.Store, msg.Chat.ID, msg.Text)
+}
+
+func (anime) Hint(env game.Env, msg *tgbotapi.Message) bool {
+	HandleAnimeHint(env.Bot, msg.Chat.ID)
+	return true
+}
+
+func (anime) Cancel(env game.Env, chatID int64) bool {
+	return CancelAnime(chatID)
+}
+
+func (anime) Snapshot(int64) (any, bool) {
+	return nil, false
+}
+
+func (anime) Restore(func(target any) error) (int, error) {
+	return 0, nil
+}
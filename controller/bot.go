@@ -1,27 +1,30 @@
 package controller
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"log"
-	"net/http"
-	"os"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/animebot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
 	collectibleController "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/collectible"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/commands"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/geographybot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/scramybot"
-	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/translator"
-	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/wordgridbot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/webhook"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/wordlebot"
-	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model/validator"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
 	installOllama "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/installOllama"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/rotation"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/teams"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordbank"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordguess"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -29,16 +32,17 @@ import (
 
 // ChatStateDoc is the MongoDB-serializable version of ChatState
 type ChatStateDoc struct {
-	ChatID            int64     `bson:"_id"`
-	Word              string    `bson:"word"`
-	User              int       `bson:"user"`
-	LeadTimestamp     time.Time `bson:"lead_timestamp"`
-	Leader            string    `bson:"leader"`
-	LastHintTimestamp time.Time `bson:"last_hint_timestamp"`
-	LastHintTypeSent  int       `bson:"last_hint_type_sent"`
+	ChatID            int64           `bson:"_id"`
+	Word              string          `bson:"word"`
+	User              int             `bson:"user"`
+	LeadTimestamp     time.Time       `bson:"lead_timestamp"`
+	Leader            string          `bson:"leader"`
+	LastHintTimestamp time.Time       `bson:"last_hint_timestamp"`
+	HintsGiven        int             `bson:"hints_given"`
+	Timer             wordguess.Timer `bson:"timer"`
 }
 
-// saveChatStateAsync asynchronously saves a chat state to MongoDB.
+// saveChatStateAsync saves a chat state to MongoDB in the background.
 func saveChatStateAsync(chatID int64, state *ChatState) {
 	state.RLock()
 	doc := ChatStateDoc{
@@ -48,16 +52,17 @@ func saveChatStateAsync(chatID int64, state *ChatState) {
 		LeadTimestamp:     state.LeadTimestamp,
 		Leader:            state.Leader,
 		LastHintTimestamp: state.LastHintTimestamp,
-		LastHintTypeSent:  state.LastHintTypeSent,
+		HintsGiven:        state.HintsGiven,
+		Timer:             state.Timer,
 	}
 	state.RUnlock()
 
-	go func() {
+	repository.SaveAsync(func() {
 		client := repository.DbManager()
 		if client != nil {
 			repository.SaveGameState(client, "ChatStates", chatID, doc)
 		}
-	}()
+	})
 }
 
 // ChatState holds the state for a specific chat, including the current word and user explaining it.
@@ -68,7 +73,8 @@ type ChatState struct {
 	LeadTimestamp     time.Time
 	Leader            string
 	LastHintTimestamp time.Time
-	LastHintTypeSent  int // 0 or 1 to track which hint was last sent
+	HintsGiven        int // hints given for the current word
+	Timer             wordguess.Timer
 }
 
 var (
@@ -76,6 +82,8 @@ var (
 	chatStates = make(map[int64]*ChatState)
 	// stateMutex ensures safe access to the chatStates map.
 	stateMutex = &sync.RWMutex{}
+	// roundTimers keeps the time of the group rounds.
+	roundTimers *commands.RoundTimers
 )
 
 const (
@@ -163,12 +171,84 @@ func (cs *ChatState) isLeaderActive(duration time.Duration) bool {
 // reset resets the chat state.
 func (cs *ChatState) reset(chatID int64) {
 	cs.Lock()
+	cs.clear()
+	cs.Unlock()
+	saveChatStateAsync(chatID, cs)
+}
+
+// clear ends the round. The caller holds the lock.
+func (cs *ChatState) clear() {
 	cs.Word = ""
+	cs.HintsGiven = 0
 	cs.User = 0
 	cs.LeadTimestamp = time.Time{}
 	cs.Leader = ""
+	cs.Timer = wordguess.Timer{}
+}
+
+// roundOver reports whether nobody is leading, or the leader ran out of time
+// or went quiet. The caller holds the lock.
+func (cs *ChatState) roundOver(s wordguess.ChatSettings) bool {
+	if cs.User == 0 {
+		return true
+	}
+	if cs.Timer.Ends.IsZero() {
+		// Led before the round timers started keeping time.
+		return time.Since(cs.LeadTimestamp) >= s.RoundTime()
+	}
+	return cs.Timer.Expired(time.Now(), s.IdleTime()) != wordguess.NotExpired
+}
+
+// chatRounds lets the round timers read and end this bot's rounds.
+type chatRounds struct{}
+
+func (chatRounds) Round(chatID int64) commands.Round {
+	cs := getOrCreateChatState(chatID)
+	cs.RLock()
+	defer cs.RUnlock()
+	return commands.Round{Word: cs.Word, Leader: cs.User, LeaderName: cs.Leader, Started: cs.LeadTimestamp, Timer: cs.Timer}
+}
+
+func (chatRounds) SetTimer(chatID int64, started time.Time, t wordguess.Timer) bool {
+	cs := getOrCreateChatState(chatID)
+	cs.Lock()
+	running := cs.User != 0 && cs.LeadTimestamp.Equal(started)
+	if running {
+		cs.Timer = t
+	}
 	cs.Unlock()
-	saveChatStateAsync(chatID, cs)
+	if running {
+		saveChatStateAsync(chatID, cs)
+	}
+	return running
+}
+
+func (chatRounds) End(chatID int64, started time.Time) bool {
+	cs := getOrCreateChatState(chatID)
+	cs.Lock()
+	running := cs.User != 0 && cs.LeadTimestamp.Equal(started)
+	if running {
+		cs.clear()
+	}
+	cs.Unlock()
+	if running {
+		saveChatStateAsync(chatID, cs)
+	}
+	return running
+}
+
+// resumeRoundTimers carries on the countdowns of the rounds loaded from
+// MongoDB.
+func resumeRoundTimers() {
+	stateMutex.RLock()
+	chatIDs := make([]int64, 0, len(chatStates))
+	for chatID := range chatStates {
+		chatIDs = append(chatIDs, chatID)
+	}
+	stateMutex.RUnlock()
+	for _, chatID := range chatIDs {
+		roundTimers.Resume(chatID)
+	}
 }
 
 // loadSavedChatStates loads states from MongoDB into the chatStates map
@@ -190,7 +270,8 @@ func loadSavedChatStates(client *mongo.Client) {
 			LeadTimestamp:     doc.LeadTimestamp,
 			Leader:            doc.Leader,
 			LastHintTimestamp: doc.LastHintTimestamp,
-			LastHintTypeSent:  doc.LastHintTypeSent,
+			HintsGiven:        doc.HintsGiven,
+			Timer:             doc.Timer,
 		}
 		chatStates[doc.ChatID] = cs
 	}
@@ -198,14 +279,13 @@ func loadSavedChatStates(client *mongo.Client) {
 }
 
 // StartBot initializes and starts the bot
-func StartBot(token string) error {
-	go startHTTPServer() //start http server with go routine
-	bot, err := tgbotapi.NewBotAPI(token)
+func StartBot(ctx context.Context, cfg config.Config) error {
+	bot, err := tgbotapi.NewBotAPI(cfg.Bots.Word.Token)
 	if err != nil {
 		return err
 	}
 
-	bot.Debug = true
+	bot.Debug = cfg.Features.Debug
 	log.Printf("Authorized on account %s", bot.Self.UserName)
 
 	// Create a single MongoDB client instance once
@@ -213,12 +293,17 @@ func StartBot(token string) error {
 	if client == nil {
 		return fmt.Errorf("failed to connect to MongoDB")
 	}
+	store := repository.NewMongoStore(client)
+	wordbank.UseStore(wordbank.Default, store)
+	rotation.UseStore(rotation.Default, store)
+	teams.UseStore(teams.Default, store)
+	games := commands.NewEngine(game.Env{Bot: bot, Client: client, Store: store})
+	games.Restore()
+	go service.RunSeasons(ctx, store)
 
 	loadSavedChatStates(client)
-	wordlebot.LoadSavedStates(client)
-	scramybot.LoadSavedStates(client)
-	geographybot.LoadSavedStates(client)
-	wordgridbot.LoadSavedStates(client)
+	roundTimers = commands.NewRoundTimers(ctx, bot, store, chatRounds{})
+	resumeRoundTimers()
 	geographybot.LoadGeographyData()
 
 	if err := wordlebot.LoadWordleWords(); err != nil {
@@ -231,21 +316,24 @@ func StartBot(token string) error {
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
-	updates, err := bot.GetUpdatesChan(u)
+	updates, err := webhook.Updates(cfg, "word", cfg.Bots.Word.Token, func() (<-chan tgbotapi.Update, error) {
+		return bot.GetUpdatesChan(u)
+	})
 	if err != nil {
 		return err
 	}
 
-	// Pass the client instance to handleMessage and handleCallbackQuery via closure
-	for update := range updates {
+	r := newRouter(games)
+	webhook.Serve(ctx, updates, func(update tgbotapi.Update) {
 		if update.Message != nil {
-			go handleMessage(bot, update.Message, client)
+			handleMessage(r, games, bot, update.Message, client, store)
 		} else if update.CallbackQuery != nil {
-			go handleCallbackQuery(bot, update.CallbackQuery, client)
+			handleCallbackQuery(r, bot, update.CallbackQuery)
 		} else if update.InlineQuery != nil {
-			go handleInlineQuery(bot, update.InlineQuery)
+			handleInlineQuery(bot, update.InlineQuery)
 		}
-	}
+	})
+	bot.StopReceivingUpdates()
 
 	return nil
 }
@@ -257,250 +345,21 @@ var customWordState = make(map[int64]int64)
 var customWordMutex = &sync.Mutex{}
 
 // handleMessage processes incoming messages and handles commands and guesses.
-func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client) {
+func handleMessage(r *router.Router, games *game.Engine, bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client, store repository.Store) {
 	chatID := message.Chat.ID
-	adminID := int64(1006461736)
 
 	chatState := getOrCreateChatState(chatID)
 
 	log.Printf("[%s] %s", message.From.UserName, message.Text)
 
+	if r.DispatchMessage(message) {
+		return
+	}
+
 	// New DM scenario: if chat is private, bot gives hint and user guesses
 	if message.Chat.IsPrivate() {
 		fmt.Println("------------------------------------------" + message.Command() + "------------------------------------------")
 		text := message.Text
-		switch message.Command() {
-		case "ai_on":
-			aiModeMutex.Lock()
-			aiModeUsers[chatID] = true
-			aiModeMutex.Unlock()
-			view.SendMessage(bot, chatID, "AI mode is now enabled! Enjoy the smart responses.")
-			return
-		case "ai_off":
-			aiModeMutex.Lock()
-			delete(aiModeUsers, chatID)
-			aiModeMutex.Unlock()
-			view.SendMessage(bot, chatID, "AI mode has been disabled.")
-			return
-		case "profile":
-			handleProfileCommand(bot, message, client)
-			return
-		case "daily":
-			handleDailyCommand(bot, message, client)
-			return
-		case "weekly":
-			handleWeeklyCommand(bot, message, client)
-			return
-		case "rules":
-			rulesText := "🎮 *Game Rules*\n\n" +
-				"👥 *Players*\n" +
-				"🧠 — Guess the word by typing your answer.\n" +
-				"🕵️ — Use `/hint` to get clues (wait 1 min between hints).\n" +
-				"🏳️ — Use `/reveal` if you give up (available after 10 mins).\n\n" +
-				"👑 *Leaders*\n" +
-				"🏅 — Claim leadership via button or `/word` command.\n" +
-				"🗣️ — Explain the word without saying it directly.\n" +
-				"🔄 — Drop leadership or get a new word via buttons.\n\n" +
-				"🌍 *General*\n" +
-				"🤝 — Be respectful and fair.\n" +
-				"🎉 — Have fun and enjoy the game!\n\n" +
-				"💡 *Tip:* Type `/word` to start or `/rules` to view this menu."
-			msg := tgbotapi.NewMessage(chatID, rulesText)
-			msg.ParseMode = "Markdown"
-			_, err := bot.Send(msg)
-			if err != nil {
-				log.Printf("Failed to send rules message: %v", err)
-			}
-			return
-		case "addBot":
-			button := tgbotapi.NewInlineKeyboardButtonURL("add to group", "https://t.me/Croco_rebirth_bot?startgroup=true")
-			view.SendMessageWithKeyboardButton(bot, chatID, "Unlock my full potential by adding me to a group chat!", button)
-		case "start":
-			args := message.CommandArguments()
-			if args == "collectibles" {
-				collectibleController.ShowHub(bot, message.Chat.ID, message.From.ID, client)
-				return
-			} else if args == "shop" {
-				showShop(bot, message.Chat.ID, message.From.ID, client)
-				return
-			} else if strings.HasPrefix(args, "custom_word_") {
-				parts := strings.Split(args, "_")
-				if len(parts) == 3 {
-					groupChatIDStr := parts[2]
-					var groupChatID int64
-					if _, err := fmt.Sscanf(groupChatIDStr, "%d", &groupChatID); err == nil {
-						groupState := getOrCreateChatState(groupChatID)
-						groupState.RLock()
-						isLeader := groupState.User == message.From.ID
-						groupState.RUnlock()
-						if isLeader {
-							customWordMutex.Lock()
-							customWordState[int64(message.From.ID)] = groupChatID
-							customWordMutex.Unlock()
-							msg := tgbotapi.NewMessage(chatID, "Type the word you want the group to guess (must be a valid English word):")
-							msg.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
-							bot.Send(msg)
-						} else {
-							view.SendMessage(bot, chatID, "You are not the current leader in that group.")
-						}
-					}
-				}
-				return
-			} else {
-				buttons := tgbotapi.NewInlineKeyboardMarkup(
-					tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Get Hint"+telegramReactions[20], "hint")))
-				view.SendMessageWithButtons(bot, message.Chat.ID, "Heyyy! Got a word for ya 😏 Tap the button below if you need a lil hint 👇", buttons)
-				return
-			}
-		case "exportdata":
-			if message.From.ID != int(adminID) {
-				log.Printf("not an admin")
-				return
-			}
-
-			// Helper function to export and send data
-			exportAndSend := func(name string, filename string) error {
-				data, err := service.ExportAllData(client, name)
-				if err != nil {
-					return fmt.Errorf("failed to export %s data: %w", name, err)
-				}
-				err = view.SendFile(bot, adminID, filename, data)
-				if err != nil {
-					return fmt.Errorf("failed to send %s data file: %w", name, err)
-				}
-				return nil
-			}
-
-			// Export and send both datasets
-			if err := exportAndSend("CrocEnLeader", "croc_en_leader_data.json"); err != nil {
-				view.SendMessage(bot, chatID, err.Error())
-				return
-			}
-			if err := exportAndSend("CrocEn", "croc_en_data.json"); err != nil {
-				view.SendMessage(bot, chatID, err.Error())
-				return
-			}
-
-			view.SendMessage(bot, chatID, "Both datasets exported and sent to admin successfully.")
-			return
-		case "stats":
-			view.SendMessage(bot, chatID, "Group stats are not available in a DM. You can view global stats using /statsglobal or /leaderstatsglobal.")
-		case "leaderstats":
-			view.SendMessage(bot, chatID, "Group stats are not available in a DM. You can view global stats using /statsglobal or /leaderstatsglobal.")
-		case "statsglobal":
-			buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Global", "statsglobal_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Global", "statsglobal_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Global", "statsglobal_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Global 🌍", "statsglobal_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Global 🔠", "statsglobal_wordgrid")))
-			view.SendMessageWithButtons(bot, chatID, "🐊🇮🇳\n📊 Choose global stats to view:", buttons)
-		case "statsimageglobal":
-			markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-			imgBytes, err := service.GenerateLeaderboardImage(client, "CrocEn", 0, "Word Guess Global Leaderboard")
-			if err == nil {
-				photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-				photo.ReplyMarkup = markup
-				bot.Send(photo)
-			}
-		case "leaderstatsglobal":
-			result := service.LeaderBoardList(client, "CrocEnLeader", 0)
-			view.SendMessagehtml(bot, message.Chat.ID, result)
-		case "collectibles":
-			if message.Chat.IsPrivate() {
-				collectibleController.ShowHub(bot, message.Chat.ID, message.From.ID, client)
-			} else {
-				botUsername := bot.Self.UserName
-				link := fmt.Sprintf("https://t.me/%s?start=collectibles", botUsername)
-				markup := tgbotapi.NewInlineKeyboardMarkup(
-					tgbotapi.NewInlineKeyboardRow(
-						tgbotapi.NewInlineKeyboardButtonURL("🌟 Go to Collectibles Hub", link),
-					),
-				)
-				view.SendMessageWithButtons(bot, message.Chat.ID, "Click the button below to visit the Collectibles Hub!", markup)
-			}
-		case "shop":
-			if message.Chat.IsPrivate() {
-				// Handle shop in DM
-				showShop(bot, message.Chat.ID, message.From.ID, client)
-			} else {
-				// Send a link to DM
-				botUsername := bot.Self.UserName
-				link := fmt.Sprintf("https://t.me/%s?start=shop", botUsername)
-				markup := tgbotapi.NewInlineKeyboardMarkup(
-					tgbotapi.NewInlineKeyboardRow(
-						tgbotapi.NewInlineKeyboardButtonURL("🛒 Go to Shop", link),
-					),
-				)
-				view.SendMessageWithButtons(bot, message.Chat.ID, "Click the button below to visit the Emoji Shop!", markup)
-			}
-		case "mystats":
-			buttons := tgbotapi.NewInlineKeyboardMarkup(
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonData("Word Guess", "stats_wordguess"),
-					tgbotapi.NewInlineKeyboardButtonData("Wordle", "stats_wordle"),
-				),
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonData("Scramy", "stats_scramy"),
-				),
-			)
-			view.SendMessageWithButtons(bot, chatID, "🐊🇮🇳\n📊 Choose game stats to view:", buttons)
-		case "installAI":
-			logs, err := installOllama.Install(true)
-			logsText := strings.Join(logs, "\n")
-			if err != nil {
-				view.SendMessage(bot, chatID, logsText+"\nLogs:\n"+err.Error())
-			}
-			view.SendMessage(bot, chatID, logsText+"\nLogs:\n")
-		case "buildModel":
-			// Prepare the command
-			output, err := installOllama.BuildOllamaModel()
-			if err != nil {
-				view.SendMessage(bot, chatID, "Build fail Error:"+err.Error())
-			}
-			view.SendMessage(bot, chatID, "\nLogs:\n"+output)
-		case "anime":
-			animebot.HandleAnimeCommand(bot, chatID, client)
-			return
-		case "addwordlepoints":
-			if message.From.ID != int(adminID) {
-				return
-			}
-			parts := strings.Fields(message.Text)
-			if len(parts) < 3 {
-				view.SendMessage(bot, chatID, "Usage: /addwordlepoints <userID> <points> [name]")
-				return
-			}
-			var userID int
-			var points int
-			if _, err := fmt.Sscanf(parts[1], "%d", &userID); err != nil {
-				view.SendMessage(bot, chatID, "Invalid userID. Must be a number.")
-				return
-			}
-			if _, err := fmt.Sscanf(parts[2], "%d", &points); err != nil {
-				view.SendMessage(bot, chatID, "Invalid points. Must be a number.")
-				return
-			}
-			name := "Unknown"
-			if len(parts) > 3 {
-				name = strings.Join(parts[3:], " ")
-			}
-			go repository.InsertWordleBonusDoc(userID, name, chatID, client, "WordleEn", points)
-			view.SendMessage(bot, chatID, fmt.Sprintf("Added %d Wordle points for user %d (%s)", points, userID, name))
-			return
-		case "report":
-			msgstr, _ := MessageToJSONString(message)
-			view.SendMessage(bot, adminID, msgstr)
-			view.SendMessage(bot, chatID, "Thank you! Your report has been successfully submitted.")
-		case "getlogs":
-			logFilePath := "output.log"
-			data, err := os.ReadFile(logFilePath)
-			if err != nil {
-				view.SendMessage(bot, chatID, fmt.Sprintf("Failed to read log file: %v", err))
-				return
-			}
-			err = view.SendFile(bot, adminID, "output.log", data)
-			if err != nil {
-				view.SendMessage(bot, chatID, fmt.Sprintf("Failed to send log file: %v", err))
-				return
-			}
-			view.SendMessage(bot, chatID, "Log file sent to admin successfully.")
-		}
 
 		aiModeMutex.Lock()
 		aiOn := aiModeUsers[chatID]
@@ -550,22 +409,23 @@ func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mong
 		chatState.RLock()
 		wordEmpty := chatState.Word == ""
 		lastHint := chatState.LastHintTimestamp
-		lastHintType := chatState.LastHintTypeSent
+		hintsGiven := chatState.HintsGiven
 		chatState.RUnlock()
-		// Start a new game if no word or lead expired
-		if wordEmpty || time.Since(chatState.LeadTimestamp) >= 640*time.Second {
-			word, err := model.GetRandomWord()
+		// Start a new game if no word or lead expired, unless the message is
+		// a guess in a minigame running here
+		if (wordEmpty || time.Since(chatState.LeadTimestamp) >= 640*time.Second) && (message.IsCommand() || !games.Active(chatID)) {
+			word, err := wordguess.NextWord(chatID, store)
 			if err != nil {
 				view.SendMessage(bot, chatID, "Oops! Unable to fetch a word right now. Please try again later.")
 				return
 			}
 			chatState.Lock()
 			chatState.Word = word
+			chatState.HintsGiven = 0
 			chatState.User = message.From.ID
 			chatState.Leader = message.From.FirstName
 			chatState.LeadTimestamp = time.Now()
 			chatState.LastHintTimestamp = time.Time{}
-			chatState.LastHintTypeSent = 0
 			chatState.Unlock()
 			saveChatStateAsync(chatID, chatState)
 			return
@@ -580,21 +440,19 @@ func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mong
 			}
 
 			chatState.RLock()
-			var hint string
-			if lastHintType == 0 {
-				hint = model.GenerateMeaningHint(chatState.Word)
-			} else {
-				hint = model.GenerateMeaningHint(chatState.Word)
-				hint = hint + "\n" + model.GenerateHint(chatState.Word)
-				hint = hint + "\n" + model.GenerateAuroraHint(chatState.Word)
-			}
+			word := chatState.Word
 			chatState.RUnlock()
+			hint, ok := wordguess.NextHint(wordguess.GetChatSettings(chatID, store), word, hintsGiven)
+			if !ok {
+				view.SendMessage(bot, chatID, hint)
+				return
+			}
 
 			view.SendMessage(bot, chatID, hint)
 
 			chatState.Lock()
 			chatState.LastHintTimestamp = time.Now()
-			chatState.LastHintTypeSent = 1 - lastHintType
+			chatState.HintsGiven = hintsGiven + 1
 			chatState.Unlock()
 			saveChatStateAsync(chatID, chatState)
 			return
@@ -653,37 +511,19 @@ func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mong
 			}
 		}
 
-		if collectibleController.CheckAndHandlePendingMarketplaceListing(bot, message, client) {
+		if collectibleController.CheckAndHandlePendingMarketplaceListing(bot, message, store) {
 			return
 		}
 
-		// Check if Wordle is active for DM
-		if wordlebot.IsWordleActive(chatID) {
-			wordlebot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if scramybot.IsScramyActive(chatID) {
-			scramybot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if geographybot.IsGeographyActive(chatID) {
-			geographybot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if wordgridbot.IsWordGridActive(chatID) {
-			wordgridbot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if animebot.IsAnimeActive(chatID) {
-			animebot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
+		// Every active minigame checks the message as a guess
+		games.Guess(message)
 
 		// Check user's guess in DM
 		chatState.RLock()
 		word := chatState.Word
 		chatState.RUnlock()
 
-		if service.NormalizeAndComparePlural(message.Text, word) && message.From.ID == chatState.User {
+		if wordguess.Matches(wordguess.GetChatSettings(chatID, store).Language, message.Text, word) && message.From.ID == chatState.User {
 			view.SendMessage(bot, chatID, fmt.Sprintf("🦉 Correct! %s ! You guessed the word '%s' correctly!", telegramReactions[7], word))
 			view.ReactToMessage(bot.Token, chatID, message.MessageID, telegramReactions[17], true)
 			view.ReactToMessage(bot.Token, chatID, message.MessageID, "⚡", true)
@@ -697,10 +537,10 @@ func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mong
 						log.Printf("Recovered from panic in InsertDoc goroutine: %v", r)
 					}
 				}()
-				repository.InsertDoc(message.From.ID, message.From.FirstName, chatID, client, "CrocEn")
+				store.InsertDoc(message.From.ID, message.From.FirstName, chatID, "CrocEn")
 			}()
 			chatState.reset(chatID)
-			word, err := model.GetRandomWord()
+			word, err := wordguess.NextWord(chatID, store)
 			if err != nil {
 				view.SendMessage(bot, chatID, "Failed to load next word.")
 				chatState.reset(chatID)
@@ -709,10 +549,10 @@ func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mong
 
 			chatState.Lock()
 			chatState.Word = word
+			chatState.HintsGiven = 0
 			chatState.User = message.From.ID
 			chatState.LeadTimestamp = time.Now()
 			chatState.LastHintTimestamp = time.Time{}
-			chatState.LastHintTypeSent = 0
 			chatState.Unlock()
 			saveChatStateAsync(chatID, chatState)
 
@@ -721,1355 +561,73 @@ func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mong
 		return
 	}
 
-	// Existing group chat handling
-	switch message.Command() {
-	case "getButton":
-		Announcement := strings.Split(message.Text, "  ")
-		if len(Announcement) > 1 {
-			parts := strings.Split(Announcement[2], " ")
-			if len(parts) > 2 {
-				url := parts[0]
-				messageText := strings.Join(parts[1:], " ")
-				button := tgbotapi.NewInlineKeyboardButtonURL(messageText, url)
-				view.SendMessageWithKeyboardButton(bot, message.Chat.ID, Announcement[1], button)
-			}
-		}
-	case "start":
-		if message.CommandArguments() == "collectibles" {
-			collectibleController.ShowHub(bot, message.Chat.ID, message.From.ID, client)
-		} else if message.CommandArguments() == "shop" {
-			showShop(bot, message.Chat.ID, message.From.ID, client)
-		} else {
-			welcomeMsg := "👋 *Welcome to Croco Bot!*\n\n" +
-				"🐊 I'm your friendly group game host.\n\n" +
-				"🎮 *Quick Start:*\n" +
-				"📝 `/word` — Start a new game\n" +
-				"📖 `/rules` — Learn how to play\n" +
-				"📊 `/stats` — View your scores\n\n" +
-				"🎉 Have fun!"
-			view.SendMessage(bot, message.Chat.ID, welcomeMsg)
-		}
-	case "setting":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Wordle View 🖼️", "setting_wordle_view"),
-				tgbotapi.NewInlineKeyboardButtonData("Wordle Color 🎨", "setting_wordle_color"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Scramy Letters 🔠", "setting_scramy_letters"),
-				tgbotapi.NewInlineKeyboardButtonData("Geography Settings 🌍", "setting_geography_main"),
-			),
-		)
-		view.SendMessageWithButtons(bot, message.Chat.ID, "⚙️ *Settings*\nChoose a setting to configure:", buttons)
-	case "geosettings":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("MCQ Mode (Multiple Choice)", "setting_geo_mode_mcq"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Text Guess Mode", "setting_geo_mode_text"),
-			),
-		)
-		view.SendMessageWithButtons(bot, message.Chat.ID, "⚙️ *Geography Mode*\nChoose how you want to play Geography:\n- *MCQ Mode*: Buttons to select the answer.\n- *Text Guess Mode*: Type out your guess (5 attempts).", buttons)
-	case "stats":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Group", "statsgroup_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Group", "statsgroup_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Group", "statsgroup_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Group 🌍", "statsgroup_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Group 🔠", "statsgroup_wordgrid")))
-		view.SendMessageWithButtons(bot, chatID, "🐊🇮🇳\n📊 Choose group stats to view:", buttons)
-	case "statsimage":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Group", "statsimg_group_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Group", "statsimg_group_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Group", "statsimg_group_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Group 🌍", "statsimg_group_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Group 🔠", "statsimg_group_wordgrid")))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "CrocEn", chatID, "Word Guess Group Leaderboard")
-		if err == nil {
-			photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-			photo.ReplyMarkup = markup
-			bot.Send(photo)
-		}
-	case "leaderstats":
-		result := service.LeaderBoardList(client, "CrocEnLeader", message.Chat.ID)
-		view.SendMessagehtml(bot, message.Chat.ID, result)
-	case "collectibles":
-		if message.Chat.IsPrivate() {
-			collectibleController.ShowHub(bot, message.Chat.ID, message.From.ID, client)
-		} else {
-			botUsername := bot.Self.UserName
-			link := fmt.Sprintf("https://t.me/%s?start=collectibles", botUsername)
-			markup := tgbotapi.NewInlineKeyboardMarkup(
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonURL("🌟 Go to Collectibles Hub", link),
-				),
-			)
-			view.SendMessageWithButtons(bot, message.Chat.ID, "Click the button below to visit the Collectibles Hub!", markup)
-		}
-	case "shop":
-		if message.Chat.IsPrivate() {
-			// Handle shop in DM
-			showShop(bot, message.Chat.ID, message.From.ID, client)
-		} else {
-			// Send a link to DM
-			botUsername := bot.Self.UserName
-			link := fmt.Sprintf("https://t.me/%s?start=shop", botUsername)
-			markup := tgbotapi.NewInlineKeyboardMarkup(
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonURL("🛒 Go to Shop", link),
-				),
-			)
-			view.SendMessageWithButtons(bot, message.Chat.ID, "Click the button below to visit the Emoji Shop!", markup)
-		}
-	case "statsglobal":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Global", "statsglobal_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Global", "statsglobal_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Global", "statsglobal_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Global 🌍", "statsglobal_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Global 🔠", "statsglobal_wordgrid")))
-		view.SendMessageWithButtons(bot, chatID, "🐊🇮🇳\n📊 Choose global stats to view:", buttons)
-	case "statsimageglobal":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "CrocEn", 0, "Word Guess Global Leaderboard")
-		if err == nil {
-			photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-			photo.ReplyMarkup = markup
-			bot.Send(photo)
-		}
-	case "leaderstatsglobal":
-		result := service.LeaderBoardList(client, "CrocEnLeader", 0)
-		view.SendMessagehtml(bot, message.Chat.ID, result)
-	case "mystats":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Word Guess", "stats_wordguess"),
-				tgbotapi.NewInlineKeyboardButtonData("Wordle", "stats_wordle"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Scramy", "stats_scramy"),
-			),
-		)
-		view.SendMessageWithButtons(bot, chatID, "🐊🇮🇳\n📊 Choose game stats to view:", buttons)
-		// view.ReplyToMessage(bot, message.MessageID, chatID, result)
-	case "rules":
-		rulesText := "🎮 *Game Rules*\n\n" +
-			"👥 *Players*\n" +
-			"🧠 — Guess the word by typing your answer.\n" +
-			"🕵️ — Use `/hint` to get clues (wait 1 min between hints).\n" +
-			"🏳️ — Use `/reveal` if you give up (available after 10 mins).\n\n" +
-			"👑 *Leaders*\n" +
-			"🏅 — Claim leadership by clicking 'Explain' or using the appropriate command.\n" +
-			"🗣️ — Explain the word without saying it directly.\n" +
-			"🔄 — Drop leadership or get a new word via buttons.\n\n" +
-			"🌍 *General*\n" +
-			"🤝 — Be respectful and fair.\n" +
-			"🎉 — Have fun and enjoy the game!\n\n" +
-			"💡 *Tip:* Type `/word` to start or `/rules` to view this menu."
-		msg := tgbotapi.NewMessage(chatID, rulesText)
-		msg.ParseMode = "Markdown"
-		_, err := bot.Send(msg)
-		if err != nil {
-			log.Printf("Failed to send rules message: %v", err)
-		}
-		return
-	case "addwordlepoints":
-		if message.From.ID != int(adminID) {
-			return
-		}
-		parts := strings.Fields(message.Text)
-		if len(parts) < 3 {
-			view.SendMessage(bot, chatID, "Usage: /addwordlepoints <userID> <points> [name]")
-			return
-		}
-		var userID int
-		var points int
-		if _, err := fmt.Sscanf(parts[1], "%d", &userID); err != nil {
-			view.SendMessage(bot, chatID, "Invalid userID. Must be a number.")
-			return
-		}
-		if _, err := fmt.Sscanf(parts[2], "%d", &points); err != nil {
-			view.SendMessage(bot, chatID, "Invalid points. Must be a number.")
-			return
-		}
-		name := "Unknown"
-		if len(parts) > 3 {
-			name = strings.Join(parts[3:], " ")
-		}
-		go repository.InsertWordleBonusDoc(userID, name, chatID, client, "WordleEn", points)
-		view.SendMessage(bot, chatID, fmt.Sprintf("Added %d Wordle points for user %d (%s)", points, userID, name))
-		return
-	case "report":
-		// if len(message.Text) > 7 {
-		// reportMessage := message.Text[7:]
-		// adminMessage := fmt.Sprintf("Report from @%s FromID-(%d) ChatID-(%d) From-(%s):\n Message-%s", message.From.UserName, message.From.ID, message.Chat.ID, message.From.FirstName, reportMessage)
-		// view.SendMessage(bot, adminID, adminMessage)
-		msgstr, _ := MessageToJSONString(message)
-		view.SendMessage(bot, adminID, msgstr)
-		view.SendMessage(bot, chatID, "Your report has been submitted. Thank you!")
-		// } else {
-		// 	view.SendMessage(bot, chatID, "Please provide a message with your report. Usage: /report [your message]")
-		// }
-	case "wordle":
-		wordlebot.HandleWordleCommand(bot, chatID, message.From.FirstName, client)
-		return
-	case "scramy":
-		scramybot.HandleScramyCommand(bot, chatID, message.From.FirstName)
-		return
-	case "geography":
-		geographybot.HandleGeographyCommand(bot, chatID, message.From.FirstName, client)
-		return
-	case "wordgrid":
-		wordgridbot.StartWordGridGame(bot, chatID, client)
-		return
-	case "grideasy":
-		wordgridbot.StartWordGridEasyGame(bot, chatID, client)
-		return
-	case "anime":
-		animebot.HandleAnimeCommand(bot, chatID, client)
-		return
-	case "geohint":
-		geographybot.HandleGeographyHint(bot, message, client, chatID, translator.NewTextTranslator())
-		return
-	case "cancelgeo":
-		if geographybot.CancelGeography(chatID) {
-			view.SendMessage(bot, chatID, "Geography game cancelled.")
-		} else {
-			view.SendMessage(bot, chatID, "No active Geography game.")
-		}
-	case "animehint":
-		animebot.HandleAnimeHint(bot, chatID)
-		return
-	case "cancelanime":
-		if animebot.CancelAnime(chatID) {
-			view.SendMessage(bot, chatID, "Anime game cancelled.")
-		} else {
-			view.SendMessage(bot, chatID, "No active Anime game.")
-		}
-	case "cancelwordgrid":
-		wordgridbot.HandleCancelWordGrid(bot, chatID)
-		return
-	case "word":
-		chatState.RLock()
-		wordEmpty := chatState.Word == ""
-		leadExpired := time.Since(chatState.LeadTimestamp) >= 120*time.Second
-		chatState.RUnlock()
-
-		if wordEmpty || leadExpired {
-			word, err := model.GetRandomWord()
-			if err != nil {
-				view.SendMessage(bot, message.Chat.ID, "Failed to fetch a word.")
-				return
-			}
-
-			buttons := createMultiButtonKeyboard([][]string{
-				{" 🗣️ Explain ", "explain"},
-				{"Wordle 🟩🟨", "wordle_start"},
-				{"Scramy 𒅒𒈔𒅒", "scramy_start"},
-			})
-
-			chatState.Lock()
-			chatState.Word = word
-			chatState.User = 0
-			chatState.Leader = ""
-			chatState.Unlock()
-			saveChatStateAsync(chatID, chatState)
+	// Existing group chat handling: anything that is not a command is a guess
+	games.Guess(message)
 
-			// THE CHARACTER UPGRADE:
-			sendCharacterAction(bot, chatID, StickerOwlWatching,
-				"🦉 *The Owl ruffles its feathers in the moonlight.*\n\"A new secret has been placed in the forest. Who will step forward to explain?\"")
+	chatState.RLock()
+	word := chatState.Word
+	user := chatState.User
+	leader := chatState.Leader
+	hints := chatState.HintsGiven
+	chatState.RUnlock()
 
-			view.SendMessageWithButtons(bot, chatID, "🐊 *The Crocodile peeks from the reeds, waiting...*", buttons)
+	if user != 0 && message.From.ID == user {
+		if commands.CheckLeaderMessage(bot, store, message, word) {
+			chatState.reset(chatID)
 		} else {
-			sentMsg, err := view.SendMessage(bot, message.Chat.ID, "A game is currently in progress.")
-			deleteWarningMessage(bot, message, sentMsg, err)
-		}
-	case "game":
-		buttons := createMultiButtonKeyboard([][]string{
-			{" 🗣️ Explain ", "explain"},
-			{"Wordle 🟩🟨", "wordle_start"},
-			{"Scramy 𒅒𒈔𒅒", "scramy_start"},
-		})
-		view.SendMessageWithButtons(bot, chatID, "🐊 *The Crocodile peeks from the reeds, waiting...*", buttons)
-	case "hint":
-		chatState.RLock()
-		wordEmpty := chatState.Word == ""
-		lastHint := chatState.LastHintTimestamp
-		lastHintType := chatState.LastHintTypeSent
-		chatState.RUnlock()
-
-		if wordEmpty {
-			buttons := createSingleButtonKeyboard(" 🗣️ Explain ", "explain")
-			view.SendMessageWithButtons(bot, message.Chat.ID, "No active game right now. Click below to start one!", buttons)
-			return
+			roundTimers.LeaderActive(chatID)
 		}
+	}
 
-		if !lastHint.IsZero() && time.Since(lastHint) < 1*time.Minute {
-			sentMsg, err := view.SendMessage(bot, message.Chat.ID, "Please wait a minute before requesting another hint.")
-			deleteWarningMessage(bot, message, sentMsg, err)
+	if user != 0 && wordguess.Matches(wordguess.GetChatSettings(chatID, store).Language, message.Text, word) && message.From.ID != user {
+		chatState.reset(chatID)
+		if commands.TeamGuess(bot, store, message, user, leader, word, hints) {
 			return
 		}
 
-		chatState.RLock()
-		var hint string
-		if lastHintType == 0 {
-			hint = model.GenerateMeaningHint(chatState.Word)
-		} else {
-			hint = model.GenerateMeaningHint(chatState.Word)
-			hint = hint + "\n" + model.GenerateHint(chatState.Word)
-			hint = hint + "\n" + model.GenerateAuroraHint(chatState.Word)
-		}
-		chatState.RUnlock()
-
 		// THE CHARACTER UPGRADE:
-		sendCharacterAction(bot, chatID, StickerCrocCurious, "🐊 *The Crocodile tilts his head.*\n\"Stuck already? I thought humans were supposed to be the smart ones!\"")
-
-		time.Sleep(1 * time.Second) // Let the Croc's joke land
-
-		// Send chat action "typing" before sending hint
-		chatAction := tgbotapi.NewChatAction(chatID, tgbotapi.ChatTyping)
-		bot.Send(chatAction)
+		victoryText := fmt.Sprintf("🎊 *The forest erupts in cheers!*\n\n🦉 \"Correct. The word was indeed *%s*.\"\n🐊 \"WOW! [%s](tg://user?id=%d) is a genius! Can we play again? Can we?!\"",
+			word, message.From.FirstName, message.From.ID)
 
-		// Escape MarkdownV2 special characters in hint text
-		escapedHint := escapeMarkdownV2(hint)
-
-		// Wrap escaped hint text in spoiler formatting for Telegram MarkdownV2
-		spoilerHint := "||" + escapedHint + "||"
-		msg := tgbotapi.NewMessage(chatID, spoilerHint)
-		msg.ParseMode = "MarkdownV2"
-		_, err := bot.Send(msg)
-		if err != nil {
-			log.Printf("Failed to send hint message with spoiler formatting: %v", err)
+		if StickerCrocHappy != "" {
+			view.SendSticker(bot, chatID, StickerCrocHappy)
 		}
+		buttons := createMultiButtonKeyboard([][]string{
+			{"🌟 Claim Leadership 🙋", "explain"},
+			{"Start Wordle! 🟩🟨", "wordle_start"},
+		})
+		view.SendMessageWithButtons(bot, chatID, victoryText, buttons)
 
-		chatState.Lock()
-		chatState.LastHintTimestamp = time.Now()
-		chatState.LastHintTypeSent = 1 - lastHintType
-		chatState.Unlock()
-		saveChatStateAsync(chatID, chatState)
-	case "reveal":
-		chatState.RLock()
-		word := chatState.Word
-		leadTime := chatState.LeadTimestamp
-		chatState.RUnlock()
-
-		if time.Since(leadTime) >= 600*time.Second {
-			buttons := createMultiButtonKeyboard([][]string{
-				{" 🗣️ Explain ", "explain"},
-				{"Wordle 🟩🟨", "wordle_start"},
-			})
-			view.SendMessageWithButtons(bot, message.Chat.ID, fmt.Sprintf("The word was: %s", word), buttons)
-
-			chatState.reset(chatID)
-		} else {
-			sentMsg, err := view.SendMessage(bot, message.Chat.ID, "Please wait for 10 minutes before revealing the word.")
-			deleteWarningMessage(bot, message, sentMsg, err)
-		}
-	default:
-		// Check if Wordle is active for group chat
-		if wordlebot.IsWordleActive(chatID) {
-			wordlebot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if scramybot.IsScramyActive(chatID) {
-			scramybot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if geographybot.IsGeographyActive(chatID) {
-			geographybot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if wordgridbot.IsWordGridActive(chatID) {
-			wordgridbot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if animebot.IsAnimeActive(chatID) {
-			animebot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		chatState.RLock()
-		word := chatState.Word
-		user := chatState.User
-		leader := chatState.Leader
-		chatState.RUnlock()
-
-		if user != 0 && service.NormalizeAndComparePlural(message.Text, word) && message.From.ID != user {
-			chatState.reset(chatID)
-
-			// THE CHARACTER UPGRADE:
-			victoryText := fmt.Sprintf("🎊 *The forest erupts in cheers!*\n\n🦉 \"Correct. The word was indeed *%s*.\"\n🐊 \"WOW! [%s](tg://user?id=%d) is a genius! Can we play again? Can we?!\"",
-				word, message.From.FirstName, message.From.ID)
-
-			if StickerCrocHappy != "" {
-				view.SendSticker(bot, chatID, StickerCrocHappy)
-			}
-			buttons := createMultiButtonKeyboard([][]string{
-				{"🌟 Claim Leadership 🙋", "explain"},
-				{"Start Wordle! 🟩🟨", "wordle_start"},
-			})
-			view.SendMessageWithButtons(bot, chatID, victoryText, buttons)
-
-			go view.ReactToMessage(bot.Token, chatID, message.MessageID, "🔥", true)
-			go view.ReactToMessage(bot.Token, chatID, message.MessageID, "⚡", true)
-			go repository.InsertDoc(message.From.ID, message.From.FirstName, message.Chat.ID, client, "CrocEn")
-			go repository.InsertDoc(user, leader, message.Chat.ID, client, "CrocEnLeader")
-
-		}
+		go view.ReactToMessage(bot.Token, chatID, message.MessageID, "🔥", true)
+		go view.ReactToMessage(bot.Token, chatID, message.MessageID, "⚡", true)
+		commands.RewardGuess(store, message, user, leader, hints)
+	} else if user != 0 && message.From.ID != user {
+		game.CloseGuess(bot, store, message, message.Text, word, 0)
 	}
 }
 
-// handleCallbackQuery processes incoming callback queries and handles the "explain" action.
-func handleCallbackQuery(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client *mongo.Client) {
-	if strings.HasPrefix(callback.Data, "geo_ans_") {
-		geographybot.HandleGeographyCallback(bot, callback.Message.Chat.ID, callback.From.ID, callback.From.FirstName, callback.Data, callback.ID, callback.Message.MessageID, client)
-		return
-	}
-	if handleShopCallback(bot, callback, client) {
-		return
-	}
-	if collectibleController.HandleCallback(bot, callback, client) {
+// handleCallbackQuery dispatches button presses through the router; anything
+// unregistered is checked as a guess of the current word.
+func handleCallbackQuery(r *router.Router, bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) {
+	if r.DispatchCallback(callback) {
 		return
 	}
 
 	chatID := callback.Message.Chat.ID
 	chatState := getOrCreateChatState(chatID)
 
-	switch callback.Data {
-	case "statsglobal_wordguess":
-		markup := service.LeaderBoardListButtons(client, "CrocEn", 0, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsglobal_wordle":
-		markup := service.LeaderBoardListButtons(client, "WordleEn", 0, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsglobal_scramy":
-		markup := service.LeaderBoardListButtons(client, "ScramyEn", 0, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsglobal_geography":
-		markup := service.LeaderBoardListButtons(client, "GeographyPoints", 0, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsglobal_wordgrid":
-		markup := service.LeaderBoardListButtons(client, "WordGridPoints", 0, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsglobal_anime":
-		markup := service.LeaderBoardListButtons(client, "AnimePoints", 0, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsgroup_wordguess":
-		markup := service.LeaderBoardListButtons(client, "CrocEn", chatID, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsgroup_wordle":
-		markup := service.LeaderBoardListButtons(client, "WordleEn", chatID, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsgroup_scramy":
-		markup := service.LeaderBoardListButtons(client, "ScramyEn", chatID, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsgroup_geography":
-		markup := service.LeaderBoardListButtons(client, "GeographyPoints", chatID, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsgroup_wordgrid":
-		markup := service.LeaderBoardListButtons(client, "WordGridPoints", chatID, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsimg_global_wordguess":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "CrocEn", 0, "Word Guess Global Leaderboard")
-		if err == nil {
-			err = view.EditMessageMediaWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, imgBytes, "leaderboard.png", &markup)
-			if err != nil {
-				// Fallback if message wasn't a photo previously
-				photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-				photo.ReplyMarkup = markup
-				bot.Send(photo)
-				bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-			}
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		return
-	case "statsimg_global_geography":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "GeographyPoints", 0, "Geography Global Leaderboard")
-		if err == nil {
-			err = view.EditMessageMediaWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, imgBytes, "leaderboard.png", &markup)
-			if err != nil {
-				// Fallback if message wasn't a photo previously
-				photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-				photo.ReplyMarkup = markup
-				bot.Send(photo)
-				bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-			}
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		return
-	case "statsimg_global_wordgrid":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "WordGridPoints", 0, "Word Grid Global Leaderboard")
-		if err == nil {
-			err = view.EditMessageMediaWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, imgBytes, "leaderboard.png", &markup)
-			if err != nil {
-				// Fallback if message wasn't a photo previously
-				photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-				photo.ReplyMarkup = markup
-				bot.Send(photo)
-				bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-			}
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		return
-	case "statsimg_global_wordle":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "WordleEn", 0, "Wordle Global Leaderboard")
-		if err == nil {
-			err = view.EditMessageMediaWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, imgBytes, "leaderboard.png", &markup)
-			if err != nil {
-				// Fallback if message wasn't a photo previously
-				photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-				photo.ReplyMarkup = markup
-				bot.Send(photo)
-				bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-			}
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		return
-	case "statsimg_group_geography":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Group", "statsimg_group_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Group", "statsimg_group_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Group", "statsimg_group_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Group 🌍", "statsimg_group_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Group 🔠", "statsimg_group_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "GeographyPoints", chatID, "Geography Group Leaderboard")
-		if err == nil {
-			err = view.EditMessageMediaWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, imgBytes, "leaderboard.png", &markup)
-			if err != nil {
-				// Fallback if message wasn't a photo previously
-				photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-				photo.ReplyMarkup = markup
-				bot.Send(photo)
-				bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-			}
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		return
-	case "statsimg_group_wordgrid":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Group", "statsimg_group_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Group", "statsimg_group_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Group", "statsimg_group_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Group 🌍", "statsimg_group_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Group 🔠", "statsimg_group_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "WordGridPoints", chatID, "Word Grid Group Leaderboard")
-		if err == nil {
-			err = view.EditMessageMediaWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, imgBytes, "leaderboard.png", &markup)
-			if err != nil {
-				// Fallback if message wasn't a photo previously
-				photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-				photo.ReplyMarkup = markup
-				bot.Send(photo)
-				bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-			}
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		return
-	case "statsimg_global_scramy":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "ScramyEn", 0, "Scramy Global Leaderboard")
-		if err == nil {
-			err = view.EditMessageMediaWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, imgBytes, "leaderboard.png", &markup)
-			if err != nil {
-				// Fallback if message wasn't a photo previously
-				photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-				photo.ReplyMarkup = markup
-				bot.Send(photo)
-				bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-			}
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		return
-	case "statsimg_group_wordguess":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Group", "statsimg_group_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Group", "statsimg_group_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Group", "statsimg_group_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Group 🌍", "statsimg_group_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Group 🔠", "statsimg_group_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "CrocEn", chatID, "Word Guess Group Leaderboard")
-		if err == nil {
-			err = view.EditMessageMediaWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, imgBytes, "leaderboard.png", &markup)
-			if err != nil {
-				// Fallback if message wasn't a photo previously
-				photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-				photo.ReplyMarkup = markup
-				bot.Send(photo)
-				bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-			}
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		return
-	case "statsimg_group_wordle":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Group", "statsimg_group_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Group", "statsimg_group_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Group", "statsimg_group_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Group 🌍", "statsimg_group_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Group 🔠", "statsimg_group_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "WordleEn", chatID, "Wordle Group Leaderboard")
-		if err == nil {
-			err = view.EditMessageMediaWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, imgBytes, "leaderboard.png", &markup)
-			if err != nil {
-				// Fallback if message wasn't a photo previously
-				photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-				photo.ReplyMarkup = markup
-				bot.Send(photo)
-				bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-			}
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		return
-	case "statsimg_group_scramy":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Group", "statsimg_group_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Group", "statsimg_group_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Group", "statsimg_group_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Group 🌍", "statsimg_group_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Group 🔠", "statsimg_group_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "ScramyEn", chatID, "Scramy Group Leaderboard")
-		if err == nil {
-			err = view.EditMessageMediaWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, imgBytes, "leaderboard.png", &markup)
-			if err != nil {
-				// Fallback if message wasn't a photo previously
-				photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-				photo.ReplyMarkup = markup
-				bot.Send(photo)
-				bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-			}
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		return
-	case "statsimg_global_anime":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Anime Image Global 🎌", "statsimg_global_anime")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "AnimePoints", 0, "Anime Global Leaderboard")
-		if err == nil {
-			err = view.EditMessageMediaWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, imgBytes, "leaderboard.png", &markup)
-			if err != nil {
-				// Fallback if message wasn't a photo previously
-				photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-				photo.ReplyMarkup = markup
-				bot.Send(photo)
-				bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-			}
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		return
-	case "mystats_main":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Word Guess", "stats_wordguess"),
-				tgbotapi.NewInlineKeyboardButtonData("Wordle", "stats_wordle"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Scramy", "stats_scramy"),
-				tgbotapi.NewInlineKeyboardButtonData("Anime", "statsglobal_anime"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "🐊🇮🇳\n📊 Choose game stats to view:")
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_geography_main":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Mode (MCQ/Text) 🎮", "setting_geography_mode"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Question Types ❓", "setting_geo_questions"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "settings_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Geography Settings*\nChoose a setting to configure:")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_geography_mode":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("MCQ Mode (Multiple Choice)", "setting_geo_mode_mcq"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Text Guess Mode", "setting_geo_mode_text"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "setting_geography_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Geography Mode*\nChoose how you want to play Geography:\n- *MCQ Mode*: Buttons to select the answer.\n- *Text Guess Mode*: Type out your guess (5 attempts).")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_geo_questions":
-		settings := geographybot.GetChatSettings(chatID, client)
-		getBtn := func(key, label string) string {
-			if settings.QuestionTypes[key] {
-				return "✅ " + label
-			}
-			return "❌ " + label
-		}
-
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("capital", "Capital"), "toggle_geo_capital"),
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("flag", "Flag"), "toggle_geo_flag"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("region", "Region"), "toggle_geo_region"),
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("landmark", "Landmark (Image)"), "toggle_geo_landmark"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("country_from_capital", "Country from Capital"), "toggle_geo_country_from_capital"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("landmark_name", "Landmark Name (Image)"), "toggle_geo_landmark_name"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "setting_geography_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Geography Question Types*\nToggle which types of questions can appear:")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_geo_mode_mcq", "setting_geo_mode_text":
-		mode := "mcq"
-		if callback.Data == "setting_geo_mode_text" {
-			mode = "text"
-		}
-		err := geographybot.UpdateGeographyMode(chatID, mode, client)
-		if err != nil {
-			log.Printf("Failed to update geography mode: %v", err)
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Failed to update setting."))
-			return
-		}
-		modeStr := "MCQ Mode"
-		if mode == "text" {
-			modeStr = "Text Guess Mode"
-		}
-
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Geography Settings", "setting_geography_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, fmt.Sprintf("✅ *Geography mode updated to %s!*", modeStr))
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Settings saved!"))
-		return
-	case "toggle_geo_capital", "toggle_geo_flag", "toggle_geo_region", "toggle_geo_landmark", "toggle_geo_country_from_capital", "toggle_geo_landmark_name":
-		qType := strings.TrimPrefix(callback.Data, "toggle_geo_")
-		err := geographybot.ToggleGeographyQuestionType(chatID, qType, client)
-		if err != nil {
-			log.Printf("Failed to toggle geography question type: %v", err)
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Failed to update setting"))
-			return
-		}
-
-		// Re-render the menu
-		settings := geographybot.GetChatSettings(chatID, client)
-		getBtn := func(key, label string) string {
-			if settings.QuestionTypes[key] {
-				return "✅ " + label
-			}
-			return "❌ " + label
-		}
-
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("capital", "Capital"), "toggle_geo_capital"),
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("flag", "Flag"), "toggle_geo_flag"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("region", "Region"), "toggle_geo_region"),
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("landmark", "Landmark (Image)"), "toggle_geo_landmark"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("country_from_capital", "Country from Capital"), "toggle_geo_country_from_capital"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("landmark_name", "Landmark Name (Image)"), "toggle_geo_landmark_name"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "setting_geography_main"),
-			),
-		)
-
-		// Use edit message reply markup to update the buttons in place
-		editMarkup := tgbotapi.NewEditMessageReplyMarkup(chatID, callback.Message.MessageID, buttons)
-		bot.Send(editMarkup)
-
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_scramy_letters":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Squared 🔠", "set_scramy_squared"),
-				tgbotapi.NewInlineKeyboardButtonData("Normal abc", "set_scramy_normal"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "settings_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Scramy Letters Setting*\nChoose the letter style for Scramy:")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_scramy_letters_new":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Squared 🔠", "set_scramy_squared_new"),
-				tgbotapi.NewInlineKeyboardButtonData("Normal abc", "set_scramy_normal_new"),
-				tgbotapi.NewInlineKeyboardButtonData("H1 📝", "set_scramy_h1_new"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "refresh_scramy_game"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageReplyMarkup(chatID, callback.Message.MessageID, buttons)
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "set_scramy_squared":
-		scramybot.UpdateScramyLetterView(chatID, "squared", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Scramy letters updated to *Squared*.")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy set to Squared"))
-		return
-	case "set_scramy_h1":
-		scramybot.UpdateScramyLetterView(chatID, "h1", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Scramy letters updated to *H1*.")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy letters updated!"))
-		return
-	case "set_scramy_normal":
-		scramybot.UpdateScramyLetterView(chatID, "normal", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Scramy letters updated to *Normal*.")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy set to Normal"))
-		return
-	case "stats_wordguess":
-		result := service.GetUserStatsByID(client, callback.From.ID)
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "mystats_main")))
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, result)
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeHTML
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "stats_wordle":
-		result := service.GetWordleUserStatsByID(client, callback.From.ID)
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "mystats_main")))
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, result)
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeHTML
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "stats_scramy":
-		result := service.GetScramyUserStatsByID(client, callback.From.ID)
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "mystats_main")))
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, result)
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeHTML
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "settings_main":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Wordle View 🖼️", "setting_wordle_view"),
-				tgbotapi.NewInlineKeyboardButtonData("Wordle Color 🎨", "setting_wordle_color"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Scramy Letters 🔠", "setting_scramy_letters"),
-				tgbotapi.NewInlineKeyboardButtonData("Geography Settings 🌍", "setting_geography_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Settings*\nChoose a setting to configure:")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_wordle_color":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Classic (🟥)", "set_wordle_color_classic"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Dark Mode (⬛)", "set_wordle_color_dark"),
-				tgbotapi.NewInlineKeyboardButtonData("Light Mode (⬜)", "set_wordle_color_light"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "settings_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Wordle Color Setting*\nChoose the color used for missing letters:")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_wordle_color_new":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Classic (🟥)", "set_wordle_color_classic_new"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Dark Mode (⬛)", "set_wordle_color_dark_new"),
-				tgbotapi.NewInlineKeyboardButtonData("Light Mode (⬜)", "set_wordle_color_light_new"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "refresh_wordle_game"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageReplyMarkup(chatID, callback.Message.MessageID, buttons)
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_wordle_view":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Text View 📝", "set_wordle_view_text"),
-				tgbotapi.NewInlineKeyboardButtonData("Image View 🖼️", "set_wordle_view_image"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "settings_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Wordle View Setting*\nChoose how you want Wordle results to be displayed:")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "refresh_wordle_game":
-		wordlebot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "refresh_scramy_game":
-		scramybot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_wordle_view_new":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Text View 📝", "set_wordle_view_text_new"),
-				tgbotapi.NewInlineKeyboardButtonData("Image View 🖼️", "set_wordle_view_image_new"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "refresh_wordle_game"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageReplyMarkup(chatID, callback.Message.MessageID, buttons)
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "set_wordle_view_text":
-		wordlebot.UpdateWordleViewType(chatID, "text", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Wordle view updated to *Text*.")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "View set to Text"))
-		return
-	case "set_wordle_view_image":
-		wordlebot.UpdateWordleViewType(chatID, "image", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Wordle view updated to *Image*.")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "View set to Image"))
-		return
-	case "set_wordle_color_classic":
-		wordlebot.UpdateWordleColor(chatID, "classic", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Wordle color updated to *Classic* (🟥).")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Color set to Classic"))
-		return
-	case "set_wordle_color_dark":
-		wordlebot.UpdateWordleColor(chatID, "dark", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Wordle color updated to *Dark* (⬛).")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Color set to Dark"))
-		return
-	case "set_wordle_color_light":
-		wordlebot.UpdateWordleColor(chatID, "light", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Wordle color updated to *Light* (⬜).")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Color set to Light"))
-		return
-	case "set_scramy_squared_new":
-		scramybot.UpdateScramyLetterView(chatID, "squared", client)
-		scramybot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy set to Squared"))
-		return
-	case "set_scramy_normal_new":
-		scramybot.UpdateScramyLetterView(chatID, "normal", client)
-		scramybot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy set to Normal"))
-		return
-	case "set_scramy_h1_new":
-		scramybot.UpdateScramyLetterView(chatID, "h1", client)
-		scramybot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy set to H1"))
-		return
-	case "set_wordle_view_text_new":
-		wordlebot.UpdateWordleViewType(chatID, "text", client)
-		wordlebot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "View set to Text"))
-		return
-	case "set_wordle_view_image_new":
-		wordlebot.UpdateWordleViewType(chatID, "image", client)
-		wordlebot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "View set to Image"))
-		return
-	case "set_wordle_color_classic_new":
-		wordlebot.UpdateWordleColor(chatID, "classic", client)
-		wordlebot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Color set to Classic"))
-		return
-	case "set_wordle_color_dark_new":
-		wordlebot.UpdateWordleColor(chatID, "dark", client)
-		wordlebot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Color set to Dark"))
-		return
-	case "set_wordle_color_light_new":
-		wordlebot.UpdateWordleColor(chatID, "light", client)
-		wordlebot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Color set to Light"))
-		return
-	case "wordle_start":
-		wordlebot.HandleWordleCommand(bot, chatID, callback.From.FirstName, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Wordle Started!"))
-		return
-	case "wordgrid_start":
-		wordgridbot.StartWordGridGame(bot, chatID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Word Grid Started!"))
-		return
-	case "wordgrid_start_easy":
-		wordgridbot.StartWordGridEasyGame(bot, chatID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Word Grid Easy Started!"))
-		return
-	case "cancel_new_wordle":
-		if wordlebot.CancelPendingGame(bot, chatID, callback.From.FirstName) {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Cancelled new game request."))
-		} else {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "No pending game request to cancel."))
-		}
-		return
-	case "scramy_start":
-		scramybot.HandleScramyCommand(bot, chatID, callback.From.FirstName)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy Started!"))
-		return
-	case "geography_start":
-		geographybot.HandleGeographyCommand(bot, chatID, callback.From.FirstName, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Geography Started!"))
-		return
-	case "anime_start":
-		animebot.HandleAnimeCommand(bot, chatID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Anime Started!"))
-		return
-	case "cancel_new_scramy":
-		if scramybot.CancelPendingGame(bot, chatID, callback.From.FirstName) {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Cancelled new Scramy game request."))
-		} else {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "No pending game request to cancel."))
-		}
-		return
-	case "explain":
-		chatState.Lock()
-		if chatState.User != callback.From.ID && chatState.User != 0 && time.Since(chatState.LeadTimestamp) < 600*time.Second {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("%s is already explaining the word. Please wait for your turn, %s.", chatState.Leader, callback.From.UserName)))
-			chatState.Unlock()
-			saveChatStateAsync(chatID, chatState)
-			return
-		}
-		if chatState.User == callback.From.ID {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, chatState.Word))
-			chatState.Unlock()
-			saveChatStateAsync(chatID, chatState)
-			return
-		}
-		if chatState.User == 0 || (time.Since(chatState.LeadTimestamp) >= 600*time.Second && chatState.User != callback.From.ID) {
-			chatState.User = callback.From.ID
-
-			// THE CHARACTER UPGRADE: Owl nods in approval
-			if StickerOwlNodding != "" {
-				view.SendSticker(bot, chatID, StickerOwlNodding)
-			}
-
-			word, err := model.GetRandomWord()
-			if err != nil {
-				chatState.Unlock()
-				saveChatStateAsync(chatID, chatState)
-				return
-			}
-			customWordLink := fmt.Sprintf("https://t.me/%s?start=custom_word_%d", bot.Self.UserName, chatID)
-			buttons := tgbotapi.NewInlineKeyboardMarkup(
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonData("See word 👀", "explain"),
-				),
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonURL("Custom Word ✍️", customWordLink),
-				),
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonData("Next ⏭️", "next"),
-				),
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonData("Wordle 🟩🟨", "wordle_start"),
-				),
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonData("Changed my mind ❌", "droplead"),
-				),
-			)
-			chatState.Word = word
-
-			// THE CHARACTER UPGRADE:
-			text := fmt.Sprintf("🐊 *The Crocodile splashes happily!*\n\"Look! [%s](tg://user?id=%d) is going to tell us a story! I'm all ears... and teeth!\"",
-				callback.From.FirstName, callback.From.ID)
-
-			view.SendMessageWithButtons(bot, chatID, text, buttons)
-
-			// The Owl gives a stern warning
-			time.Sleep(800 * time.Millisecond)
-			// view.SendMessage(bot, chatID, "🦉 *The Owl blinks slowly.*\n\"Be precise, traveler. Do not speak the word itself.\"")
-
-			// Remove the inline keyboard (buttons) from the "claim leadership" message when someone starts leading
-			editMarkup := tgbotapi.NewEditMessageReplyMarkup(callback.Message.Chat.ID, callback.Message.MessageID, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
-			_, err = bot.Send(editMarkup)
-			if err != nil {
-				log.Printf("Failed to remove inline keyboard: %v", err)
-			}
-		}
-		chatState.Leader = callback.From.FirstName
-		chatState.LeadTimestamp = time.Now()
-		chatState.Unlock()
-		saveChatStateAsync(chatID, chatState)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, chatState.Word))
-
-	case "next":
-		chatState.Lock()
-		if chatState.User != callback.From.ID && chatState.User != 0 {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("%s is already explaining the word. %s", chatState.Leader, callback.From.UserName)))
-			chatState.Unlock()
-			saveChatStateAsync(chatID, chatState)
-			return
-		}
-		if chatState.User == 0 {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("%s Please click on see word/claim Leadership", callback.From.FirstName)))
-			chatState.Unlock()
-			saveChatStateAsync(chatID, chatState)
-			return
-		}
-		chatState.User = callback.From.ID
-		chatState.Leader = callback.From.FirstName
-		chatState.Word, _ = model.GetRandomWord()
-		chatState.Unlock()
-		saveChatStateAsync(chatID, chatState)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, chatState.Word))
-
-	case "droplead":
-		chatState.Lock()
-		if chatState.User != callback.From.ID {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, "You are not the current leader, so you cannot drop the lead!"))
-			chatState.Unlock()
-			saveChatStateAsync(chatID, chatState)
-			return
-		}
-		// Delete the callback message when user selects "Changed my mind"
-		deleteMsg := tgbotapi.NewDeleteMessage(callback.Message.Chat.ID, callback.Message.MessageID)
-		_, err := bot.DeleteMessage(deleteMsg)
-		if err != nil {
-			log.Printf("Failed to delete message on droplead: %v", err)
-		}
-		chatState.Unlock()
-		saveChatStateAsync(chatID, chatState)
+	// Any other button is checked as a guess of the current word.
+	chatState.RLock()
+	word := chatState.Word
+	chatState.RUnlock()
+	if service.NormalizeAndComparePlural(callback.Message.Text, word) {
 		buttons := createSingleButtonKeyboard("🌟 Claim Leadership 🙋", "explain")
-		view.SendMessageWithButtons(bot, callback.Message.Chat.ID, fmt.Sprintf("%s refused to lead -> %s \n", callback.From.FirstName, chatState.Word), buttons)
+		view.SendMessageWithButtons(bot, callback.Message.Chat.ID, fmt.Sprintf("%s! %s guessed the word correctly.", telegramReactions[0], callback.From.FirstName), buttons)
 		chatState.reset(chatID)
-
-	case "hint":
-		chatState.RLock()
-		wordEmpty := chatState.Word == ""
-		lastHint := chatState.LastHintTimestamp
-		lastHintType := chatState.LastHintTypeSent
-		chatState.RUnlock()
-
-		if wordEmpty {
-			if callback.Message.Chat.IsPrivate() {
-				word, err := model.GetRandomWord()
-				if err != nil {
-					view.SendMessage(bot, chatID, "Failed to load next word.")
-					chatState.reset(chatID)
-					return
-				}
-
-				chatState.Lock()
-				chatState.Word = word
-				chatState.User = callback.From.ID
-				chatState.LeadTimestamp = time.Now()
-				chatState.LastHintTimestamp = time.Time{}
-				chatState.LastHintTypeSent = 0
-				chatState.Unlock()
-				saveChatStateAsync(chatID, chatState)
-			} else {
-				buttons := createMultiButtonKeyboard([][]string{
-					{" 🗣️ Explain ", "explain"},
-					{"Wordle 🟩🟨", "wordle_start"},
-				})
-				view.SendMessageWithButtons(bot, callback.Message.Chat.ID, "No active game right now. Click below to start one!", buttons)
-				bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-				return
-			}
-		}
-
-		if !lastHint.IsZero() && time.Since(lastHint) < 1*time.Minute {
-			sentMsg, err := view.SendMessage(bot, chatID, "Please take a minute before requesting another hint.")
-			deleteWarningMessage(bot, callback.Message, sentMsg, err)
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-
-			return
-		}
-
-		chatState.RLock()
-		var hint string
-		if lastHintType == 0 {
-			hint = model.GenerateMeaningHint(chatState.Word)
-		} else {
-			hint = model.GenerateMeaningHint(chatState.Word)
-			hint = hint + "\n" + model.GenerateHint(chatState.Word)
-			hint = hint + "\n" + model.GenerateAuroraHint(chatState.Word)
-		}
-		chatState.RUnlock()
-
-		chatAction := tgbotapi.NewChatAction(callback.Message.Chat.ID, tgbotapi.ChatTyping)
-		bot.Send(chatAction)
-
-		escapedHint := escapeMarkdownV2(hint)
-		spoilerHint := "||" + escapedHint + "||"
-		msg := tgbotapi.NewMessage(callback.Message.Chat.ID, spoilerHint)
-		msg.ParseMode = "MarkdownV2"
-		_, err := bot.Send(msg)
-		if err != nil {
-			log.Printf("Failed to send hint message with spoiler formatting: %v", err)
-		}
-
-		chatState.Lock()
-		chatState.LastHintTimestamp = time.Now()
-		chatState.LastHintTypeSent = 1 - lastHintType
-		chatState.Unlock()
-		saveChatStateAsync(chatID, chatState)
-
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-	default:
-		chatState.RLock()
-		word := chatState.Word
-		chatState.RUnlock()
-		if service.NormalizeAndComparePlural(callback.Message.Text, word) {
-			buttons := createSingleButtonKeyboard("🌟 Claim Leadership 🙋", "explain")
-			view.SendMessageWithButtons(bot, callback.Message.Chat.ID, fmt.Sprintf("%s! %s guessed the word correctly.", telegramReactions[0], callback.From.FirstName), buttons)
-			chatState.reset(chatID)
-		}
 	}
 	bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
 }
 
-func MessageToJSONString(message *tgbotapi.Message) (string, error) {
-	jsonBytes, err := json.MarshalIndent(message, "", "  ")
-	if err != nil {
-		return "", err
-	}
-	return string(jsonBytes), nil
-}
-
 // escapeMarkdownV2 escapes special characters for Telegram MarkdownV2 formatting
 func escapeMarkdownV2(text string) string {
 	var builder strings.Builder
@@ -2157,14 +715,6 @@ func handleInlineQuery(bot *tgbotapi.BotAPI, inlineQuery *tgbotapi.InlineQuery)
 	}
 }
 
-// startHTTPServer starts a simple HTTP server for health checks
-func startHTTPServer() {
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		fmt.Fprintf(w, "Bot is running!")
-	})
-	log.Fatal(http.ListenAndServe(":8080", nil))
-}
-
 // createMultiButtonKeyboard creates an inline keyboard markup with multiple buttons
 func createMultiButtonKeyboard(buttonsData [][]string) tgbotapi.InlineKeyboardMarkup {
 	var rows [][]tgbotapi.InlineKeyboardButton
@@ -2177,51 +727,3 @@ func createMultiButtonKeyboard(buttonsData [][]string) tgbotapi.InlineKeyboardMa
 	}
 	return tgbotapi.NewInlineKeyboardMarkup(rows...)
 }
-
-func handleProfileCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client) {
-	username := message.From.UserName
-	if username == "" {
-		username = message.From.FirstName
-	}
-
-	profile, err := repository.GetUserProfile(client, int64(message.From.ID), username)
-	if err != nil {
-		view.SendMessage(bot, message.Chat.ID, "Error retrieving profile. Please try again later.")
-		return
-	}
-
-	html := service.FormatUserProfile(profile)
-	view.SendMessagehtml(bot, message.Chat.ID, html)
-}
-
-func handleDailyCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client) {
-	username := message.From.UserName
-	if username == "" {
-		username = message.From.FirstName
-	}
-
-	xp, coins, err := service.ClaimDailyReward(client, int64(message.From.ID), username)
-	if err != nil {
-		view.SendMessage(bot, message.Chat.ID, "❌ "+err.Error())
-		return
-	}
-
-	msg := fmt.Sprintf("🎁 *Daily Reward Claimed!*\n\nEarned:\n✨ +%d XP\n🪙 +%d Coins", xp, coins)
-	view.SendMessage(bot, message.Chat.ID, msg)
-}
-
-func handleWeeklyCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client) {
-	username := message.From.UserName
-	if username == "" {
-		username = message.From.FirstName
-	}
-
-	xp, coins, err := service.ClaimWeeklyReward(client, int64(message.From.ID), username)
-	if err != nil {
-		view.SendMessage(bot, message.Chat.ID, "❌ "+err.Error())
-		return
-	}
-
-	msg := fmt.Sprintf("🎉 *Weekly Reward Claimed!*\n\nEarned:\n✨ +%d XP\n🪙 +%d Coins", xp, coins)
-	view.SendMessage(bot, message.Chat.ID, msg)
-}
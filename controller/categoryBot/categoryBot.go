@@ -1,11 +1,10 @@
 package categorybot
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"log"
 	"math/rand"
-	"os"
 	"strconv"
 	"strings"
 	"sync"
@@ -13,18 +12,22 @@ import (
 	"unicode"
 
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
-	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/animebot"
 	collectibleController "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/collectible"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/commands"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/geographybot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/scramybot"
-	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/translator"
-	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/wordgridbot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/webhook"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/wordlebot"
-	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model/validator"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
 	installOllama "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/installOllama"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/rotation"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/teams"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordbank"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordguess"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
 	tgbotapiv5Ovy "github.com/OvyFlash/telegram-bot-api"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
@@ -32,14 +35,6 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func MessageToJSONString(message *tgbotapi.Message) (string, error) {
-	jsonBytes, err := json.MarshalIndent(message, "", "  ")
-	if err != nil {
-		return "", err
-	}
-	return string(jsonBytes), nil
-}
-
 // escapeMarkdownV2 escapes special characters for Telegram MarkdownV2 formatting
 func escapeMarkdownV2(text string) string {
 	var builder strings.Builder
@@ -84,16 +79,17 @@ func replaceWord(original, word string) string {
 
 // CategoryChatStateDoc is the MongoDB-serializable version of ChatState
 type CategoryChatStateDoc struct {
-	ChatID            int64     `bson:"_id"`
-	Word              string    `bson:"word"`
-	User              int       `bson:"user"`
-	LeadTimestamp     time.Time `bson:"lead_timestamp"`
-	Leader            string    `bson:"leader"`
-	LastHintTimestamp time.Time `bson:"last_hint_timestamp"`
-	LastHintTypeSent  int       `bson:"last_hint_type_sent"`
+	ChatID            int64           `bson:"_id"`
+	Word              string          `bson:"word"`
+	User              int             `bson:"user"`
+	LeadTimestamp     time.Time       `bson:"lead_timestamp"`
+	Leader            string          `bson:"leader"`
+	LastHintTimestamp time.Time       `bson:"last_hint_timestamp"`
+	HintsGiven        int             `bson:"hints_given"`
+	Timer             wordguess.Timer `bson:"timer"`
 }
 
-// saveCategoryChatStateAsync asynchronously saves a chat state to MongoDB.
+// saveCategoryChatStateAsync saves a chat state to MongoDB in the background.
 func saveCategoryChatStateAsync(chatID int64, state *ChatState) {
 	state.RLock()
 	doc := CategoryChatStateDoc{
@@ -103,16 +99,17 @@ func saveCategoryChatStateAsync(chatID int64, state *ChatState) {
 		LeadTimestamp:     state.LeadTimestamp,
 		Leader:            state.Leader,
 		LastHintTimestamp: state.LastHintTimestamp,
-		LastHintTypeSent:  state.LastHintTypeSent,
+		HintsGiven:        state.HintsGiven,
+		Timer:             state.Timer,
 	}
 	state.RUnlock()
 
-	go func() {
+	repository.SaveAsync(func() {
 		client := repository.DbManager()
 		if client != nil {
 			repository.SaveGameState(client, "CategoryChatStates", chatID, doc)
 		}
-	}()
+	})
 }
 
 // loadSavedCategoryChatStates loads states from MongoDB into the chatStates map
@@ -134,7 +131,8 @@ func loadSavedCategoryChatStates(client *mongo.Client) {
 			LeadTimestamp:     doc.LeadTimestamp,
 			Leader:            doc.Leader,
 			LastHintTimestamp: doc.LastHintTimestamp,
-			LastHintTypeSent:  doc.LastHintTypeSent,
+			HintsGiven:        doc.HintsGiven,
+			Timer:             doc.Timer,
 		}
 		chatStates[doc.ChatID] = cs
 	}
@@ -149,7 +147,8 @@ type ChatState struct {
 	LeadTimestamp     time.Time
 	Leader            string
 	LastHintTimestamp time.Time
-	LastHintTypeSent  int // 0 or 1 to track which hint was last sent
+	HintsGiven        int // hints given for the current word
+	Timer             wordguess.Timer
 }
 
 var (
@@ -157,6 +156,8 @@ var (
 	chatStates = make(map[int64]*ChatState)
 	// stateMutex ensures safe access to the chatStates map.
 	stateMutex = &sync.RWMutex{}
+	// roundTimers keeps the time of the group rounds.
+	roundTimers *commands.RoundTimers
 
 	// aiLastResponse stores the last AI response per chat for follow-up hints
 	aiLastResponse  = make(map[int64]string)
@@ -258,29 +259,100 @@ func (cs *ChatState) isLeaderActive(duration time.Duration) bool {
 // reset resets the chat state.
 func (cs *ChatState) reset(chatID int64) {
 	cs.Lock()
+	cs.clear()
+	cs.Unlock()
+	saveCategoryChatStateAsync(chatID, cs)
+}
+
+// clear ends the round. The caller holds the lock.
+func (cs *ChatState) clear() {
 	cs.Word = ""
+	cs.HintsGiven = 0
 	cs.User = 0
 	cs.LeadTimestamp = time.Time{}
 	cs.Leader = ""
+	cs.Timer = wordguess.Timer{}
+}
+
+// roundOver reports whether nobody is leading, or the leader ran out of time
+// or went quiet. The caller holds the lock.
+func (cs *ChatState) roundOver(s wordguess.ChatSettings) bool {
+	if cs.User == 0 {
+		return true
+	}
+	if cs.Timer.Ends.IsZero() {
+		// Led before the round timers started keeping time.
+		return time.Since(cs.LeadTimestamp) >= s.RoundTime()
+	}
+	return cs.Timer.Expired(time.Now(), s.IdleTime()) != wordguess.NotExpired
+}
+
+// chatRounds lets the round timers read and end this bot's rounds.
+type chatRounds struct{}
+
+func (chatRounds) Round(chatID int64) commands.Round {
+	cs := getOrCreateChatState(chatID)
+	cs.RLock()
+	defer cs.RUnlock()
+	return commands.Round{Word: cs.Word, Leader: cs.User, LeaderName: cs.Leader, Started: cs.LeadTimestamp, Timer: cs.Timer}
+}
+
+func (chatRounds) SetTimer(chatID int64, started time.Time, t wordguess.Timer) bool {
+	cs := getOrCreateChatState(chatID)
+	cs.Lock()
+	running := cs.User != 0 && cs.LeadTimestamp.Equal(started)
+	if running {
+		cs.Timer = t
+	}
 	cs.Unlock()
-	saveCategoryChatStateAsync(chatID, cs)
+	if running {
+		saveCategoryChatStateAsync(chatID, cs)
+	}
+	return running
+}
+
+func (chatRounds) End(chatID int64, started time.Time) bool {
+	cs := getOrCreateChatState(chatID)
+	cs.Lock()
+	running := cs.User != 0 && cs.LeadTimestamp.Equal(started)
+	if running {
+		cs.clear()
+	}
+	cs.Unlock()
+	if running {
+		saveCategoryChatStateAsync(chatID, cs)
+	}
+	return running
+}
+
+// resumeRoundTimers carries on the countdowns of the rounds loaded from
+// MongoDB.
+func resumeRoundTimers() {
+	stateMutex.RLock()
+	chatIDs := make([]int64, 0, len(chatStates))
+	for chatID := range chatStates {
+		chatIDs = append(chatIDs, chatID)
+	}
+	stateMutex.RUnlock()
+	for _, chatID := range chatIDs {
+		roundTimers.Resume(chatID)
+	}
 }
 
 // StartBot initializes and starts the bot
-func StartBot(token string) error {
-	config.Load(token)
+func StartBot(ctx context.Context, cfg config.Config) error {
 
 	// Create a single MongoDB client instance once
 	client := repository.DbManager()
 	if client == nil {
 		return fmt.Errorf("failed to connect to MongoDB")
 	}
+	store := repository.NewMongoStore(client)
+	wordbank.UseStore(wordbank.Default, store)
+	rotation.UseStore(rotation.Default, store)
+	teams.UseStore(teams.Default, store)
 
 	loadSavedCategoryChatStates(client)
-	wordlebot.LoadSavedStates(client)
-	scramybot.LoadSavedStates(client)
-	geographybot.LoadSavedStates(client)
-	wordgridbot.LoadSavedStates(client)
 	geographybot.LoadGeographyData()
 
 	if err := wordlebot.LoadWordleWords(); err != nil {
@@ -291,30 +363,37 @@ func StartBot(token string) error {
 		log.Printf("Warning: failed to load Scramy words: %v", err)
 	}
 
-	bot, err := tgbotapi.NewBotAPI(token)
+	bot, err := tgbotapi.NewBotAPI(cfg.Bots.Category.Token)
 	if err != nil {
 		return err
 	}
 
-	bot.Debug = true
+	bot.Debug = cfg.Features.Debug
 	log.Printf("Authorized on account %s", bot.Self.UserName)
+	roundTimers = commands.NewRoundTimers(ctx, bot, store, chatRounds{})
+	resumeRoundTimers()
 
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
-	updates, err := bot.GetUpdatesChan(u)
+	updates, err := webhook.Updates(cfg, "category", cfg.Bots.Category.Token, func() (<-chan tgbotapi.Update, error) {
+		return bot.GetUpdatesChan(u)
+	})
 	if err != nil {
 		return err
 	}
 
-	// Pass the client instance to handleMessage and handleCallbackQuery via closure
-	for update := range updates {
+	games := commands.NewEngine(game.Env{Bot: bot, Client: client, Store: store})
+	games.Restore()
+	r := newRouter(games)
+	webhook.Serve(ctx, updates, func(update tgbotapi.Update) {
 		if update.Message != nil {
-			go handleMessage(bot, update.Message, client)
+			handleMessage(r, games, bot, update.Message, client, store)
 		} else if update.CallbackQuery != nil {
-			go handleCallbackQuery(bot, update.CallbackQuery, client)
+			handleCallbackQuery(r, bot, update.CallbackQuery)
 		}
-	}
+	})
+	bot.StopReceivingUpdates()
 
 	return nil
 }
@@ -404,7 +483,7 @@ func rememberUser(message *tgbotapi.Message) {
 }
 
 func sendEphemeralPrompt(message *tgbotapi.Message) {
-	// ephemeralBot, err := tgbotapiv5Ovy.NewBotAPI(config.App.CatTelegramToken)
+	// ephemeralBot, err := tgbotapiv5Ovy.NewBotAPI(config.App.Bots.Category.Token)
 	// if err != nil {
 	// 	log.Printf("Failed to create ephemeral bot: %v", err)
 	// 	return
@@ -834,9 +913,8 @@ func extractTextMentionRecipient(message *tgbotapi.Message) (int64, bool) {
 }
 
 // handleMessage processes incoming messages and handles commands and guesses.
-func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client) {
+func handleMessage(r *router.Router, games *game.Engine, bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client, store repository.Store) {
 	chatID := message.Chat.ID
-	adminID := int64(1006461736)
 	rememberUser(message)
 	if message.Command() == "receive" {
 		deliverStoredWhispers(bot, message)
@@ -862,310 +940,39 @@ func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mong
 
 	log.Printf("[%s] %s", message.From.UserName, message.Text)
 
+	if r.DispatchMessage(message) {
+		return
+	}
+
 	// New DM scenario: if chat is private, bot gives hint and user guesses
 	if message.Chat.IsPrivate() {
 		fmt.Println("------------------------------------------" + message.Command() + "------------------------------------------")
 		text := message.Text
-		switch message.Command() {
-		case "ai_on":
-			aiModeMutex.Lock()
-			aiModeUsers[chatID] = true
-			aiModeMutex.Unlock()
-			view.SendMessage(bot, chatID, "AI mode is now enabled! Enjoy the smart responses.")
-			return
-		case "ai_off":
-			aiModeMutex.Lock()
-			delete(aiModeUsers, chatID)
-			aiModeMutex.Unlock()
-			view.SendMessage(bot, chatID, "AI mode has been disabled.")
-			return
-		case "profile":
-			handleProfileCommand(bot, message, client)
-			return
-		case "daily":
-			handleDailyCommand(bot, message, client)
-			return
-		case "weekly":
-			handleWeeklyCommand(bot, message, client)
-			return
-		case "rules":
-			rulesText := "🎮 *Game Rules*\n\n" +
-				"👥 *Players*\n" +
-				"🧠 — Guess the word by typing your answer.\n" +
-				"🕵️ — Use `/hint` to get clues (wait 1 min between hints).\n" +
-				"🏳️ — Use `/reveal` if you give up (available after 10 mins).\n\n" +
-				"👑 *Leaders*\n" +
-				"🏅 — Claim leadership via button or `/word` command.\n" +
-				"🗣️ — Explain the word without saying it directly.\n" +
-				"🔄 — Drop leadership or get a new word via buttons.\n\n" +
-				"🌍 *General*\n" +
-				"🤝 — Be respectful and fair.\n" +
-				"🎉 — Have fun and enjoy the game!\n\n" +
-				"💡 *Tip:* Type `/word` to start or `/rules` to view this menu."
-			msg := tgbotapi.NewMessage(chatID, rulesText)
-			msg.ParseMode = "Markdown"
-			_, err := bot.Send(msg)
-			if err != nil {
-				log.Printf("Failed to send rules message: %v", err)
-			}
-			return
-		case "addBot":
-			button := tgbotapi.NewInlineKeyboardButtonURL("add to group", "https://t.me/Croco_rebirth_bot?startgroup=true")
-			view.SendMessageWithKeyboardButton(bot, chatID, "Unlock my full potential by adding me to a group chat!", button)
-		case "start":
-			args := message.CommandArguments()
-			if args == "collectibles" {
-				collectibleController.ShowHub(bot, message.Chat.ID, message.From.ID, client)
-				return
-			} else if args == "shop" {
-				showShop(bot, message.Chat.ID, message.From.ID, client)
-				return
-			} else if strings.HasPrefix(args, "custom_word_") {
-				parts := strings.Split(args, "_")
-				if len(parts) == 3 {
-					groupChatIDStr := parts[2]
-					var groupChatID int64
-					if _, err := fmt.Sscanf(groupChatIDStr, "%d", &groupChatID); err == nil {
-						groupState := getOrCreateChatState(groupChatID)
-						groupState.RLock()
-						isLeader := groupState.User == message.From.ID
-						groupState.RUnlock()
-						if isLeader {
-							customWordMutex.Lock()
-							customWordState[int64(message.From.ID)] = groupChatID
-							customWordMutex.Unlock()
-							msg := tgbotapi.NewMessage(chatID, "Type the word you want the group to guess (must be a valid English word):")
-							msg.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
-							bot.Send(msg)
-						} else {
-							view.SendMessage(bot, chatID, "You are not the current leader in that group.")
-						}
-					}
-				}
-				return
-			} else {
-				buttons := tgbotapi.NewInlineKeyboardMarkup(
-					tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Get Hint"+telegramReactions[20], "hint")))
-				view.SendMessageWithButtons(bot, message.Chat.ID, "Heyyy! Got a word for ya 😏 Tap the button below if you need a lil hint 👇", buttons)
-				return
-			}
-		case "wordle":
-			wordlebot.HandleWordleCommand(bot, chatID, message.From.FirstName, client)
-			return
-		case "scramy":
-			scramybot.HandleScramyCommand(bot, chatID, message.From.FirstName)
-			return
-		case "geography":
-			geographybot.HandleGeographyCommand(bot, chatID, message.From.FirstName, client)
-			return
-		case "wordgrid":
-			wordgridbot.StartWordGridGame(bot, chatID, client)
-			return
-		case "grideasy":
-			wordgridbot.StartWordGridEasyGame(bot, chatID, client)
-			return
-		case "anime":
-			animebot.HandleAnimeCommand(bot, chatID, client)
-			return
-		case "animehint":
-			animebot.HandleAnimeHint(bot, chatID)
-			return
-		case "geohint":
-			geographybot.HandleGeographyHint(bot, message, client, chatID, translator.NewTextTranslator())
-			return
-
-		case "cancelgeo":
-			if geographybot.CancelGeography(chatID) {
-				view.SendMessage(bot, chatID, "Geography game cancelled.")
-			} else {
-				view.SendMessage(bot, chatID, "No active Geography game.")
-			}
-		case "exportdata":
-			if message.From.ID != int(adminID) {
-				log.Printf("not an admin")
-				return
-			}
-
-			// Helper function to export and send data
-			exportAndSend := func(name string, filename string) error {
-				data, err := service.ExportAllData(client, name)
-				if err != nil {
-					return fmt.Errorf("failed to export %s data: %w", name, err)
-				}
-				err = view.SendFile(bot, adminID, filename, data)
-				if err != nil {
-					return fmt.Errorf("failed to send %s data file: %w", name, err)
-				}
-				return nil
-			}
-
-			// Export and send both datasets
-			if err := exportAndSend("CrocEnLeader", "croc_en_leader_data.json"); err != nil {
-				view.SendMessage(bot, chatID, err.Error())
-				return
-			}
-			if err := exportAndSend("CrocEn", "croc_en_data.json"); err != nil {
-				view.SendMessage(bot, chatID, err.Error())
-				return
-			}
-
-			view.SendMessage(bot, chatID, "Both datasets exported and sent to admin successfully.")
-			return
-		case "stats":
-			view.SendMessage(bot, chatID, "Group stats are not available in a DM. You can view global stats using /statsglobal or /leaderstatsglobal.")
-		case "leaderstats":
-			view.SendMessage(bot, chatID, "Group stats are not available in a DM. You can view global stats using /statsglobal or /leaderstatsglobal.")
-		case "statsglobal":
-			buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Global", "statsglobal_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Global", "statsglobal_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Global", "statsglobal_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Global 🌍", "statsglobal_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Anime Global 🎌", "statsglobal_anime")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Global 🔠", "statsglobal_wordgrid")))
-			view.SendMessageWithButtons(bot, chatID, "🐊🇮🇳\n📊 Choose global stats to view:", buttons)
-		case "statsimageglobal":
-			markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Anime Image Global 🎌", "statsimg_global_anime")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-			imgBytes, err := service.GenerateLeaderboardImage(client, "CrocEn", 0, "Word Guess Global Leaderboard")
-			if err == nil {
-				photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-				photo.ReplyMarkup = markup
-				bot.Send(photo)
-			}
-		case "leaderstatsglobal":
-			result := service.LeaderBoardList(client, "CrocEnLeader", 0)
-			view.SendMessagehtml(bot, message.Chat.ID, result)
-		case "collectibles":
-			if message.Chat.IsPrivate() {
-				collectibleController.ShowHub(bot, message.Chat.ID, message.From.ID, client)
-			} else {
-				botUsername := bot.Self.UserName
-				link := fmt.Sprintf("https://t.me/%s?start=collectibles", botUsername)
-				markup := tgbotapi.NewInlineKeyboardMarkup(
-					tgbotapi.NewInlineKeyboardRow(
-						tgbotapi.NewInlineKeyboardButtonURL("🌟 Go to Collectibles Hub", link),
-					),
-				)
-				view.SendMessageWithButtons(bot, message.Chat.ID, "Click the button below to visit the Collectibles Hub!", markup)
-			}
-		case "shop":
-			if message.Chat.IsPrivate() {
-				// Handle shop in DM
-				showShop(bot, message.Chat.ID, message.From.ID, client)
-			} else {
-				// Send a link to DM
-				botUsername := bot.Self.UserName
-				link := fmt.Sprintf("https://t.me/%s?start=shop", botUsername)
-				markup := tgbotapi.NewInlineKeyboardMarkup(
-					tgbotapi.NewInlineKeyboardRow(
-						tgbotapi.NewInlineKeyboardButtonURL("🛒 Go to Shop", link),
-					),
-				)
-				view.SendMessageWithButtons(bot, message.Chat.ID, "Click the button below to visit the Emoji Shop!", markup)
-			}
-		case "mystats":
-			buttons := tgbotapi.NewInlineKeyboardMarkup(
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonData("Word Guess", "stats_wordguess"),
-					tgbotapi.NewInlineKeyboardButtonData("Wordle", "stats_wordle"),
-				),
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonData("Scramy", "stats_scramy"),
-					tgbotapi.NewInlineKeyboardButtonData("Anime", "statsglobal_anime"),
-				),
-			)
-			view.SendMessageWithButtons(bot, chatID, "🐊🇮🇳\n📊 Choose game stats to view:", buttons)
-		case "installAI":
-			logs, err := installOllama.Install(true)
-			logsText := strings.Join(logs, "\n")
-			if err != nil {
-				view.SendMessage(bot, chatID, logsText+"\nLogs:\n"+err.Error())
-			}
-			view.SendMessage(bot, chatID, logsText+"\nLogs:\n")
-		case "buildModel":
-			// Prepare the command
-			output, err := installOllama.BuildOllamaModel()
-			if err != nil {
-				view.SendMessage(bot, chatID, "Build fail Error:"+err.Error())
-			}
-			view.SendMessage(bot, chatID, "\nLogs:\n"+output)
-		case "addwordlepoints":
-			if message.From.ID != int(adminID) {
-				return
-			}
-			parts := strings.Fields(message.Text)
-			if len(parts) < 3 {
-				view.SendMessage(bot, chatID, "Usage: /addwordlepoints <userID> <points> [name]")
-				return
-			}
-			var userID int
-			var points int
-			if _, err := fmt.Sscanf(parts[1], "%d", &userID); err != nil {
-				view.SendMessage(bot, chatID, "Invalid userID. Must be a number.")
-				return
-			}
-			if _, err := fmt.Sscanf(parts[2], "%d", &points); err != nil {
-				view.SendMessage(bot, chatID, "Invalid points. Must be a number.")
-				return
-			}
-			name := "Unknown"
-			if len(parts) > 3 {
-				name = strings.Join(parts[3:], " ")
-			}
-			go repository.InsertWordleBonusDoc(userID, name, chatID, client, "WordleEn", points)
-			view.SendMessage(bot, chatID, fmt.Sprintf("Added %d Wordle points for user %d (%s)", points, userID, name))
-			return
-		case "report":
-			msgstr, _ := MessageToJSONString(message)
-			view.SendMessage(bot, adminID, msgstr)
-			view.SendMessage(bot, chatID, "Thank you! Your report has been successfully submitted.")
-		case "getlogs":
-			logFilePath := "output.log"
-			data, err := os.ReadFile(logFilePath)
-			if err != nil {
-				view.SendMessage(bot, chatID, fmt.Sprintf("Failed to read log file: %v", err))
-				return
-			}
-			err = view.SendFile(bot, adminID, "output.log", data)
-			if err != nil {
-				view.SendMessage(bot, chatID, fmt.Sprintf("Failed to send log file: %v", err))
-				return
-			}
-			view.SendMessage(bot, chatID, "Log file sent to admin successfully.")
-		case "getButton":
-			Announcement := strings.Split(message.Text, "  ")
-			if len(Announcement) > 1 {
-				parts := strings.Split(Announcement[2], " ")
-				if len(parts) > 2 {
-					url := parts[0]
-					messageText := strings.Join(parts[1:], " ")
-					button := tgbotapi.NewInlineKeyboardButtonURL(messageText, url)
-					view.SendMessageWithKeyboardButton(bot, message.Chat.ID, Announcement[1], button)
-				}
-			}
-		case "hint":
-			break
-		case "reveal":
-			break
-		case "":
-			break
-		default:
+		// /hint and /reveal are handled below as part of the DM word game.
+		if cmd := message.Command(); cmd != "" && cmd != "hint" && cmd != "reveal" {
 			view.SendMessage(bot, chatID, "OOPS! not supported in DM.")
 		}
 
 		chatState.RLock()
 		wordEmpty := chatState.Word == ""
 		lastHint := chatState.LastHintTimestamp
-		lastHintType := chatState.LastHintTypeSent
+		hintsGiven := chatState.HintsGiven
 		chatState.RUnlock()
-		// Start a new game if no word or lead expired
-		if wordEmpty || !chatState.isLeaderActive(640*time.Second) {
-			word, err := model.GetRandomWord()
+		// Start a new game if no word or lead expired, unless the message is
+		// a guess in a minigame running here
+		if (wordEmpty || !chatState.isLeaderActive(640*time.Second)) && (message.IsCommand() || !games.Active(chatID)) {
+			word, err := wordguess.NextWord(chatID, store)
 			if err != nil {
 				view.SendMessage(bot, chatID, "Oops! Unable to fetch a word right now. Please try again later.")
 				return
 			}
 			chatState.Lock()
 			chatState.Word = word
+			chatState.HintsGiven = 0
 			chatState.User = message.From.ID
 			chatState.Leader = message.From.FirstName
 			chatState.LeadTimestamp = time.Now()
 			chatState.LastHintTimestamp = time.Time{}
-			chatState.LastHintTypeSent = 0
 			chatState.Unlock()
 			saveCategoryChatStateAsync(chatID, chatState)
 			return
@@ -1228,21 +1035,19 @@ func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mong
 
 			// Fallback to existing hint logic if AI mode is off
 			chatState.RLock()
-			var hint string
-			if lastHintType == 0 {
-				hint = model.GenerateMeaningHint(chatState.Word)
-			} else {
-				hint = model.GenerateMeaningHint(chatState.Word)
-				hint = hint + "\n" + model.GenerateHint(chatState.Word)
-				hint = hint + "\n" + model.GenerateAuroraHint(chatState.Word)
-			}
+			word := chatState.Word
 			chatState.RUnlock()
+			hint, ok := wordguess.NextHint(wordguess.GetChatSettings(chatID, store), word, hintsGiven)
+			if !ok {
+				view.SendMessage(bot, chatID, hint)
+				return
+			}
 
 			view.SendMessage(bot, chatID, hint)
 
 			chatState.Lock()
 			chatState.LastHintTimestamp = time.Now()
-			chatState.LastHintTypeSent = 1 - lastHintType
+			chatState.HintsGiven = hintsGiven + 1
 			chatState.Unlock()
 			saveCategoryChatStateAsync(chatID, chatState)
 			return
@@ -1301,33 +1106,15 @@ func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mong
 			}
 		}
 
-		// Check if Wordle is active for DM
-		if wordlebot.IsWordleActive(chatID) {
-			wordlebot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if scramybot.IsScramyActive(chatID) {
-			scramybot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if geographybot.IsGeographyActive(chatID) {
-			geographybot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if wordgridbot.IsWordGridActive(chatID) {
-			wordgridbot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if animebot.IsAnimeActive(chatID) {
-			animebot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
+		// Every active minigame checks the message as a guess
+		games.Guess(message)
 
 		// Check user's guess in DM
 		chatState.RLock()
 		word := chatState.Word
 		chatState.RUnlock()
 
-		if service.NormalizeAndComparePlural(message.Text, word) && message.From.ID == chatState.User {
+		if wordguess.Matches(wordguess.GetChatSettings(chatID, store).Language, message.Text, word) && message.From.ID == chatState.User {
 			view.SendMessage(bot, chatID, fmt.Sprintf("%s ! You guessed the word '%s' correctly!", telegramReactions[7], word))
 			view.ReactToMessage(bot.Token, chatID, message.MessageID, telegramReactions[rand.Intn(8)+13], true)
 			view.ReactToMessage(bot.Token, chatID, message.MessageID, telegramReactions[rand.Intn(8)+13], true)
@@ -1337,7 +1124,7 @@ func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mong
 						log.Printf("Recovered from panic in InsertDoc goroutine: %v", r)
 					}
 				}()
-				repository.InsertDoc(message.From.ID, message.From.FirstName, chatID, client, "CrocEn")
+				store.InsertDoc(message.From.ID, message.From.FirstName, chatID, "CrocEn")
 			}()
 
 			chatState.reset(chatID)
@@ -1387,7 +1174,7 @@ func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mong
 			//
 		}
 
-		if collectibleController.CheckAndHandlePendingMarketplaceListing(bot, message, client) {
+		if collectibleController.CheckAndHandlePendingMarketplaceListing(bot, message, store) {
 			return
 		}
 
@@ -1395,1595 +1182,104 @@ func handleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mong
 		return
 	}
 
-	// Existing group chat handling
+	// Existing group chat handling: anything that is not a command is a guess
 	text := message.Text
-	switch message.Command() {
-	case "getButton":
-		Announcement := strings.Split(message.Text, "  ")
-		if len(Announcement) > 1 {
-			parts := strings.Split(Announcement[2], " ")
-			if len(parts) > 2 {
-				url := parts[0]
-				messageText := strings.Join(parts[1:], " ")
-				button := tgbotapi.NewInlineKeyboardButtonURL(messageText, url)
-				view.SendMessageWithKeyboardButton(bot, message.Chat.ID, Announcement[1], button)
-			}
-		}
-	case "ai_on":
-		view.SendMessage(bot, chatID, "AI mode is now enabled! Enjoy the smart responses.")
-		aiModeMutex.Lock()
-		aiModeUsers[chatID] = true
-		aiModeMutex.Unlock()
-		view.SendMessage(bot, chatID, "AI mode is now enabled! Enjoy the smart responses.")
-		return
-	case "start":
-		if message.CommandArguments() == "collectibles" {
-			collectibleController.ShowHub(bot, message.Chat.ID, message.From.ID, client)
-		} else if message.CommandArguments() == "shop" {
-			showShop(bot, message.Chat.ID, message.From.ID, client)
-		} else {
-			welcomeMsg := "👋 *Welcome to Category Bot!*\n\n" +
-				"📚 I'm your friendly group category game host.\n\n" +
-				"🎮 *Quick Start:*\n" +
-				"📝 `/word` — Start a new game\n" +
-				"📖 `/rules` — Learn how to play\n" +
-				"📊 `/stats` — View your scores\n\n" +
-				"🎉 Have fun!"
-			view.SendMessage(bot, message.Chat.ID, welcomeMsg)
-		}
-	case "setting":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Wordle View 🖼️", "setting_wordle_view"),
-				tgbotapi.NewInlineKeyboardButtonData("Wordle Color 🎨", "setting_wordle_color"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Scramy Letters 🔠", "setting_scramy_letters"),
-				tgbotapi.NewInlineKeyboardButtonData("Geography Settings 🌍", "setting_geography_main"),
-			),
-		)
-		view.SendMessageWithButtonsV2(message.Chat.ID, "⚙️ *Settings*\nChoose a setting to configure:", buttons, message.From.ID, message.EphemeralMessageID)
-		return
-	case "geosettings":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("MCQ Mode (Multiple Choice)", "setting_geo_mode_mcq"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Text Guess Mode", "setting_geo_mode_text"),
-			),
-		)
-		view.SendMessageWithButtons(bot, message.Chat.ID, "⚙️ *Geography Mode*\nChoose how you want to play Geography:\n- *MCQ Mode*: Buttons to select the answer.\n- *Text Guess Mode*: Type out your guess (5 attempts).", buttons)
-		return
-	case "wordle":
-		wordlebot.HandleWordleCommand(bot, chatID, message.From.FirstName, client)
-		return
-	case "scramy":
-		scramybot.HandleScramyCommand(bot, chatID, message.From.FirstName)
-		return
-	case "geography":
-		geographybot.HandleGeographyCommand(bot, chatID, message.From.FirstName, client)
-		return
-	case "wordgrid":
-		wordgridbot.StartWordGridGame(bot, chatID, client)
-		return
-	case "grideasy":
-		wordgridbot.StartWordGridEasyGame(bot, chatID, client)
-		return
-	case "anime":
-		animebot.HandleAnimeCommand(bot, chatID, client)
-		return
-	case "animehint":
-		animebot.HandleAnimeHint(bot, chatID)
-		return
-	case "richmessage":
-		// Dummy command to demonstrate SendRichMessage with table, image, and text
-		photoMedia := tgbotapiv5Ovy.NewInputMediaPhoto(tgbotapiv5Ovy.FileURL("https://wallpapers.com/images/hd/celebratory-congratulations-banner-qeo95d2enk0nay3r.jpg"))
-		richMessage := tgbotapiv5Ovy.NewInputRichMessageBlocks(
-			tgbotapiv5Ovy.InputRichBlockSectionHeading{
-				Type: "heading",
-				Text: "📋 Demo Rich Message",
-				Size: 2,
-			},
-			tgbotapiv5Ovy.InputRichBlockParagraph{
-				Type: "paragraph",
-				Text: "This is a demonstration of Telegram's rich message format using the OvyFlash library. It includes headings, paragraphs, tables, and images.",
-			},
-			tgbotapiv5Ovy.InputRichBlockDivider{
-				Type: "divider",
-			},
-			tgbotapiv5Ovy.InputRichBlockTable{
-				Type: "table",
-				Cells: [][]tgbotapiv5Ovy.RichBlockTableCell{
-					{
-						{Text: "Player", IsHeader: true, Align: "left", Valign: "middle"},
-						{Text: "Score", IsHeader: true, Align: "center", Valign: "middle"},
-						{Text: "Rank", IsHeader: true, Align: "center", Valign: "middle"},
-					},
-					{
-						{Text: "Alice", Align: "left", Valign: "middle"},
-						{Text: "1250", Align: "center", Valign: "middle"},
-						{Text: "🥇", Align: "center", Valign: "middle"},
-					},
-					{
-						{Text: "Bob", Align: "left", Valign: "middle"},
-						{Text: "980", Align: "center", Valign: "middle"},
-						{Text: "🥈", Align: "center", Valign: "middle"},
-					},
-					{
-						{Text: "Charlie", Align: "left", Valign: "middle"},
-						{Text: "720", Align: "center", Valign: "middle"},
-						{Text: "🥉", Align: "center", Valign: "middle"},
-					},
-				},
-				IsBordered: true,
-				IsStriped:  true,
-				Caption:    "🏆 Top Players Leaderboard",
-			},
-			tgbotapiv5Ovy.InputRichBlockParagraph{
-				Type: "paragraph",
-				Text: "Below is a random image fetched for demonstration:",
-			},
-			tgbotapiv5Ovy.InputRichBlockPhoto{
-				Type:  "photo",
-				Photo: photoMedia,
-				Caption: &tgbotapiv5Ovy.RichBlockCaption{
-					Text: "📸 Random Demo Image",
-				},
-			},
-		)
-		view.SendRichMessage(chatID, richMessage)
-		return
-	case "scramyleaderboard":
-		// Fetch real scramy leaderboard data (global)
-		photoMedia := tgbotapiv5Ovy.NewInputMediaPhoto(tgbotapiv5Ovy.FileURL("https://wallpapers.com/images/hd/celebratory-congratulations-banner-qeo95d2enk0nay3r.jpg"))
-		idCounts, err := repository.CountIDOccurrences(client, "ScramyEn", 0)
-		if err != nil {
-			log.Printf("Error fetching scramy leaderboard: %v", err)
-			view.SendMessage(bot, chatID, "Failed to fetch leaderboard data.")
-			return
-		}
-
-		limit := 10
-		if len(idCounts) < limit {
-			limit = len(idCounts)
-		}
-
-		// Generate leaderboard image
-		// imgBytes, imgErr := service.GenerateLeaderboardImage(client, "ScramyEn", 0, "🔀 Scramy Leaderboard")
-
-		// Build table cells for the rich message
-		rankEmojis := []string{"🥇", "🥈", "🥉"}
-		cells := make([][]tgbotapiv5Ovy.RichBlockTableCell, 0, limit+1)
-		// Header row
-		cells = append(cells, []tgbotapiv5Ovy.RichBlockTableCell{
-			{Text: "Rank", IsHeader: true, Align: "center", Valign: "middle"},
-			{Text: "Player", IsHeader: true, Align: "left", Valign: "middle"},
-			{Text: "Score", IsHeader: true, Align: "center", Valign: "middle"},
-		})
-
-		for i := 0; i < limit; i++ {
-			count := idCounts[i]
-			name := fmt.Sprintf("%v", count["Name"])
-			score := fmt.Sprintf("%v", count["count"]) + " 💎"
-
-			var userID int
-			if id, ok := count["_id"]; ok {
-				switch v := id.(type) {
-				case int32:
-					userID = int(v)
-				case int64:
-					userID = int(v)
-				case int:
-					userID = v
-				}
-			}
-
-			// Fetch and append equipped emojis to the user's name
-			equippedEmojis, eqErr := repository.GetEquippedEmojis(client, userID)
-			if eqErr == nil && len(equippedEmojis) > 0 {
-				name += " " + strings.Join(equippedEmojis, "")
-			}
-
-			rankDisplay := fmt.Sprintf("%d", i+1)
-			if i < 3 {
-				rankDisplay = rankEmojis[i]
-			} else {
-				rankDisplay = "⭐ " + rankDisplay
-			}
-
-			cells = append(cells, []tgbotapiv5Ovy.RichBlockTableCell{
-				{Text: rankDisplay, Align: "center", Valign: "middle"},
-				{Text: name, Align: "left", Valign: "middle"},
-				{Text: score, Align: "center", Valign: "middle"},
-			})
-		}
-
-		// Build the rich message blocks
-		var blocks []tgbotapiv5Ovy.InputRichBlock
-
-		blocks = append(blocks,
-			tgbotapiv5Ovy.InputRichBlockPhoto{
-				Type:  "photo",
-				Photo: photoMedia,
-				Caption: &tgbotapiv5Ovy.RichBlockCaption{
-					Text: "📸",
-				},
-			},
-			tgbotapiv5Ovy.InputRichBlockSectionHeading{
-				Type: "heading",
-				Text: "🔀 Scramy Leaderboard",
-				Size: 2,
-			},
-			tgbotapiv5Ovy.InputRichBlockParagraph{
-				Type: "paragraph",
-				Text: "Top players ranked by their Scramy scores across all groups:",
-			},
-		)
+	games.Guess(message)
 
-		// Add the leaderboard image if generation succeeded
-		// // if imgErr == nil && len(imgBytes) > 0 {
-		// 	photoMedia := tgbotapiv5Ovy.NewInputMediaPhoto(tgbotapiv5Ovy.FileBytes{
-		// 		Name:  "leaderboard.png",
-		// 		Bytes: imgBytes,
-		// 	})
-		// 	blocks = append(blocks, tgbotapiv5Ovy.InputRichBlockPhoto{
-		// 		Type:  "photo",
-		// 		Photo: photoMedia,
-		// 	})
-		// }
-
-		blocks = append(blocks,
-			tgbotapiv5Ovy.InputRichBlockDivider{Type: "divider"},
-			tgbotapiv5Ovy.InputRichBlockTable{
-				Type:       "table",
-				Cells:      cells,
-				IsBordered: true,
-				IsStriped:  true,
-				Caption:    "🏆 Top Players",
-			},
-			tgbotapiv5Ovy.InputRichBlockParagraph{
-				Type: "paragraph",
-				Text: "✨ Keep it up and aim for the top! Play /scramy to earn more points.",
-			},
-		)
+	chatState.RLock()
+	word := chatState.Word
+	user := chatState.User
+	leader := chatState.Leader
+	hints := chatState.HintsGiven
+	chatState.RUnlock()
 
-		richMessage := tgbotapiv5Ovy.NewInputRichMessageBlocks(blocks...)
-		view.SendRichMessage(chatID, richMessage)
-		return
-	case "geohint":
-		geographybot.HandleGeographyHint(bot, message, client, chatID, translator.NewTextTranslator())
-		return
-	case "cancelgeo":
-		if geographybot.CancelGeography(chatID) {
-			view.SendMessage(bot, chatID, "Geography game cancelled.")
-		} else {
-			view.SendMessage(bot, chatID, "No active Geography game.")
-		}
-	case "cancelwordgrid":
-		wordgridbot.HandleCancelWordGrid(bot, chatID)
-		return
-	case "stats":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Group", "statsgroup_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Group", "statsgroup_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Group", "statsgroup_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Group 🌍", "statsgroup_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Group 🔠", "statsgroup_wordgrid")))
-		view.SendMessageWithButtons(bot, chatID, "🐊🇮🇳\n📊 Choose group stats to view:", buttons)
-	case "statsimage":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Group", "statsimg_group_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Group", "statsimg_group_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Group", "statsimg_group_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Group 🌍", "statsimg_group_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Group 🔠", "statsimg_group_wordgrid")))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "CrocEn", chatID, "Word Guess Group Leaderboard")
-		if err == nil {
-			photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-			photo.ReplyMarkup = markup
-			bot.Send(photo)
-		}
-	case "leaderstats":
-		result := service.LeaderBoardList(client, "CrocEnLeader", message.Chat.ID)
-		view.SendMessagehtml(bot, message.Chat.ID, result)
-	case "collectibles":
-		if message.Chat.IsPrivate() {
-			collectibleController.ShowHub(bot, message.Chat.ID, message.From.ID, client)
-		} else {
-			botUsername := bot.Self.UserName
-			link := fmt.Sprintf("https://t.me/%s?start=collectibles", botUsername)
-			markup := tgbotapi.NewInlineKeyboardMarkup(
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonURL("🌟 Go to Collectibles Hub", link),
-				),
-			)
-			view.SendMessageWithButtons(bot, message.Chat.ID, "Click the button below to visit the Collectibles Hub!", markup)
-		}
-	case "shop":
-		if message.Chat.IsPrivate() {
-			// Handle shop in DM
-			showShop(bot, message.Chat.ID, message.From.ID, client)
+	if user != 0 && message.From.ID == user {
+		if commands.CheckLeaderMessage(bot, store, message, word) {
+			chatState.reset(chatID)
 		} else {
-			// Send a link to DM
-			botUsername := bot.Self.UserName
-			link := fmt.Sprintf("https://t.me/%s?start=shop", botUsername)
-			markup := tgbotapi.NewInlineKeyboardMarkup(
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonURL("🛒 Go to Shop", link),
-				),
-			)
-			view.SendMessageWithButtons(bot, message.Chat.ID, "Click the button below to visit the Emoji Shop!", markup)
+			roundTimers.LeaderActive(chatID)
 		}
-	case "statsglobal":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Global", "statsglobal_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Global", "statsglobal_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Global", "statsglobal_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Global 🌍", "statsglobal_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Anime Global 🎌", "statsglobal_anime")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Global 🔠", "statsglobal_wordgrid")))
-		view.SendMessageWithButtons(bot, chatID, "🐊🇮🇳\n📊 Choose global stats to view:", buttons)
-	case "statsimageglobal":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Anime Image Global 🎌", "statsimg_global_anime")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "CrocEn", 0, "Word Guess Global Leaderboard")
-		if err == nil {
-			photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-			photo.ReplyMarkup = markup
-			bot.Send(photo)
+	}
+
+	if user != 0 && wordguess.Matches(wordguess.GetChatSettings(chatID, store).Language, message.Text, word) && message.From.ID != user {
+		chatState.reset(chatID)
+		if !commands.TeamGuess(bot, store, message, user, leader, word, hints) {
+			buttons := createSingleButtonKeyboard("🌟 Claim Leadership 🙋", "explain")
+			view.SendMessageWithButtons(bot, message.Chat.ID, fmt.Sprintf("%s! %s guessed the word %s.\n /word", telegramReactions[7], message.From.FirstName, word), buttons)
+			go view.ReactToMessage(bot.Token, chatID, message.MessageID, telegramReactions[rand.Intn(8)+13], true)
+			go view.ReactToMessage(bot.Token, chatID, message.MessageID, telegramReactions[rand.Intn(8)+13], true)
+			commands.RewardGuess(store, message, user, leader, hints)
 		}
-	case "leaderstatsglobal":
-		result := service.LeaderBoardList(client, "CrocEnLeader", 0)
-		view.SendMessagehtml(bot, message.Chat.ID, result)
-	case "mystats":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Word Guess", "stats_wordguess"),
-				tgbotapi.NewInlineKeyboardButtonData("Wordle", "stats_wordle"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Scramy", "stats_scramy"),
-			),
-		)
-		view.SendMessageWithButtons(bot, chatID, "🐊🇮🇳\n📊 Choose game stats to view:", buttons)
-		// view.ReplyToMessage(bot, message.MessageID, chatID, result)
-	case "rules":
-		rulesText := "🎮 *Game Rules*\n\n" +
-			"👥 *Players*\n" +
-			"🧠 — Guess the word by typing your answer.\n" +
-			"🕵️ — Use `/hint` to get clues (wait 1 min between hints).\n" +
-			"🏳️ — Use `/reveal` if you give up (available after 10 mins).\n\n" +
-			"👑 *Leaders*\n" +
-			"🏅 — Claim leadership by clicking 'Explain' or using the appropriate command.\n" +
-			"🗣️ — Explain the word without saying it directly.\n" +
-			"🔄 — Drop leadership or get a new word via buttons.\n\n" +
-			"🌍 *General*\n" +
-			"🤝 — Be respectful and fair.\n" +
-			"🎉 — Have fun and enjoy the game!\n\n" +
-			"💡 *Tip:* Type `/word` to start or `/rules` to view this menu."
-		msg := tgbotapi.NewMessage(chatID, rulesText)
-		msg.ParseMode = "Markdown"
-		_, err := bot.Send(msg)
+	} else if user != 0 && message.From.ID != user {
+		game.CloseGuess(bot, store, message, message.Text, word, 0)
+	}
+	aiModeMutex.Lock()
+	aiOn := aiModeUsers[chatID]
+	aiModeMutex.Unlock()
+	if aiOn && strings.Contains(text, "Jarvis") {
+		// AI processing here
+		wordChannel, errChannel := installOllama.RunOllama(text)
+
+		// Send the initial message (could be an empty string or placeholder)
+		initialMsg := tgbotapi.NewMessage(chatID, "Thinking...")
+		initialMessage, err := bot.Send(initialMsg)
 		if err != nil {
-			log.Printf("Failed to send rules message: %v", err)
-		}
-		return
-	case "addwordlepoints":
-		if message.From.ID != int(adminID) {
-			return
-		}
-		parts := strings.Fields(message.Text)
-		if len(parts) < 3 {
-			view.SendMessage(bot, chatID, "Usage: /addwordlepoints <userID> <points> [name]")
-			return
-		}
-		var userID int
-		var points int
-		if _, err := fmt.Sscanf(parts[1], "%d", &userID); err != nil {
-			view.SendMessage(bot, chatID, "Invalid userID. Must be a number.")
-			return
-		}
-		if _, err := fmt.Sscanf(parts[2], "%d", &points); err != nil {
-			view.SendMessage(bot, chatID, "Invalid points. Must be a number.")
+			log.Println("Failed to send initial message:", err)
 			return
 		}
-		name := "Unknown"
-		if len(parts) > 3 {
-			name = strings.Join(parts[3:], " ")
-		}
-		go repository.InsertWordleBonusDoc(userID, name, chatID, client, "WordleEn", points)
-		view.SendMessage(bot, chatID, fmt.Sprintf("Added %d Wordle points for user %d (%s)", points, userID, name))
-		return
-	case "report":
-		// if len(message.Text) > 7 {
-		// reportMessage := message.Text[7:]
-		// adminMessage := fmt.Sprintf("Report from @%s FromID-(%d) ChatID-(%d) From-(%s):\n Message-%s", message.From.UserName, message.From.ID, message.Chat.ID, message.From.FirstName, reportMessage)
-		// view.SendMessage(bot, adminID, adminMessage)
-		msgstr, _ := MessageToJSONString(message)
-		view.SendMessage(bot, adminID, msgstr)
-		view.SendMessage(bot, chatID, "Your report has been submitted. Thank you!")
-		// } else {
-		// 	view.SendMessage(bot, chatID, "Please provide a message with your report. Usage: /report [your message]")
-		// }
-	case "game":
-		buttons := createMultiButtonKeyboard([][]string{
-			{"Lead Croc 🗣️  ", "explain"},
-			{"Wordle 🟩🟨", "wordle_start"},
-			{"Scramy 𒅒𒈔𒅒", "scramy_start"},
-		})
-		view.SendMessageWithButtons(bot, chatID, "🐊 *The Crocodile peeks from the reeds, waiting...*", buttons)
-	case "word":
-		chatState.RLock()
-		wordEmpty := chatState.Word == ""
-		leadExpired := time.Since(chatState.LeadTimestamp) >= 120*time.Second
-		chatState.RUnlock()
-
-		if wordEmpty || leadExpired {
-			word, err := model.GetRandomWord()
-			if err != nil {
-				view.SendMessage(bot, message.Chat.ID, "Failed to fetch a word.")
-				return
-			}
 
-			buttons := createSingleButtonKeyboard(" 🗣️ Explain ", "explain")
+		// Start a variable to accumulate the text as we receive each word
+		var accumulatedText string
 
-			chatState.Lock()
-			chatState.Word = word
-			chatState.User = 0
-			chatState.Leader = ""
-			chatState.Unlock()
-			saveCategoryChatStateAsync(chatID, chatState)
+		// Process words as they arrive
+		for word := range wordChannel {
+			// Accumulate the word and append it to the message content
+			accumulatedText += word + " "
 
-			view.SendSticker(bot, chatID, "CAACAgUAAxkBAAEwCnNnYW-OkgV7Odt9osVwoBSzLC6vsAACMhMAAj45CFdCstMoIYiPfjYE")
-			view.SendMessageWithButtons(bot, message.Chat.ID, "The word is ready! Click 'Explain' to start explaining it.", buttons)
-		} else {
-			sentMsg, err := view.SendMessage(bot, message.Chat.ID, "A game is currently in progress.")
-			deleteWarningMessage(bot, message, sentMsg, err)
+			// Update the same message with the accumulated text
+			editedMsg := tgbotapi.NewEditMessageText(chatID, initialMessage.MessageID, strings.TrimSpace(accumulatedText))
+			_, err := bot.Send(editedMsg)
+			if err != nil {
+				log.Println("Failed to update message:", err)
+			}
 		}
-	case "hint":
-		chatState.RLock()
-		wordEmpty := chatState.Word == ""
-		lastHint := chatState.LastHintTimestamp
-		lastHintType := chatState.LastHintTypeSent
-		chatState.RUnlock()
 
-		if wordEmpty {
-			buttons := createSingleButtonKeyboard(" 🗣️ Explain ", "explain")
-			view.SendMessageWithButtons(bot, message.Chat.ID, "No active game right now. Click below to start one!", buttons)
+		// If an error occurs during execution, send it to the user
+		if err := <-errChannel; err != nil {
+			// Send an error message if something goes wrong
+			errorMsg := tgbotapi.NewMessage(chatID, err.Error())
+			_, err := bot.Send(errorMsg)
+			if err != nil {
+				log.Println("Failed to send error message:", err)
+			}
 			return
 		}
+		//
+	}
+	fmt.Print(text, "escaped jarvis-----------------------------------------")
+}
 
-		if !lastHint.IsZero() && time.Since(lastHint) < 1*time.Minute {
-			sentMsg, err := view.SendMessage(bot, message.Chat.ID, "Please wait a minute before requesting another hint.")
-			deleteWarningMessage(bot, message, sentMsg, err)
-			return
-		}
-		aiModeMutex.Lock()
-		aiOn := aiModeUsers[chatID]
-		aiModeMutex.Unlock()
+// handleCallbackQuery dispatches button presses through the router; anything
+// unregistered is checked as a guess of the current word.
+func handleCallbackQuery(r *router.Router, bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) {
+	if r.DispatchCallback(callback) {
+		return
+	}
 
-		if aiOn {
-			// Use AI to generate hint based on current word
-			word := chatState.Word
-			if word == "" {
-				view.SendMessage(bot, chatID, "No active word to provide a hint for.")
-				return
-			}
+	chatID := callback.Message.Chat.ID
+	chatState := getOrCreateChatState(chatID)
 
-			wordChannel, errChannel := installOllama.RunOllama("Give me a riddle about a " + word)
-
-			initialMsg := tgbotapi.NewMessage(chatID, "Thinking...")
-			initialMessage, err := bot.Send(initialMsg)
-			if err != nil {
-				log.Println("Failed to send initial message:", err)
-				return
-			}
-
-			var accumulatedText string
-			for word := range wordChannel {
-				accumulatedText += word + " "
-
-				aiResponseMutex.Lock()
-				aiLastResponse[chatID] = accumulatedText
-				aiResponseMutex.Unlock()
-
-				editedMsg := tgbotapi.NewEditMessageText(chatID, initialMessage.MessageID, strings.TrimSpace(accumulatedText))
-				_, err := bot.Send(editedMsg)
-				if err != nil {
-					log.Println("Failed to update message:", err)
-				}
-			}
-
-			if err := <-errChannel; err != nil {
-				errorMsg := tgbotapi.NewMessage(chatID, err.Error())
-				_, err := bot.Send(errorMsg)
-				if err != nil {
-					log.Println("Failed to send error message:", err)
-				}
-				return
-			}
-			return
-		}
-
-		chatState.RLock()
-		var hint string
-		if lastHintType == 0 {
-			hint = model.GenerateMeaningHint(chatState.Word)
-		} else {
-			hint = model.GenerateMeaningHint(chatState.Word)
-			hint = hint + "\n" + model.GenerateHint(chatState.Word)
-			hint = hint + "\n" + model.GenerateAuroraHint(chatState.Word)
-		}
-		chatState.RUnlock()
-
-		// Send chat action "typing" before sending hint
-		// chatAction := tgbotapi.NewChatAction(message.Chat.ID, tgbotapi.ChatTyping)
-		// bot.Send(chatAction)
-
-		// Send chat action "typing" before sending hint
-		chatAction := tgbotapi.NewChatAction(chatID, tgbotapi.ChatTyping)
-		bot.Send(chatAction)
-
-		// Escape MarkdownV2 special characters in hint text
-		escapedHint := escapeMarkdownV2(hint)
-
-		// Wrap escaped hint text in spoiler formatting for Telegram MarkdownV2
-		spoilerHint := "||" + escapedHint + "||"
-		msg := tgbotapi.NewMessage(chatID, spoilerHint)
-		msg.ParseMode = "MarkdownV2"
-		_, err := bot.Send(msg)
-		if err != nil {
-			log.Printf("Failed to send hint message with spoiler formatting: %v", err)
-		}
-
-		chatState.Lock()
-		chatState.LastHintTimestamp = time.Now()
-		chatState.LastHintTypeSent = 1 - lastHintType
-		chatState.Unlock()
-		saveCategoryChatStateAsync(chatID, chatState)
-	case "reveal":
-		chatState.RLock()
-		word := chatState.Word
-		leadTime := chatState.LeadTimestamp
-		chatState.RUnlock()
-
-		if time.Since(leadTime) >= 600*time.Second {
-			buttons := createSingleButtonKeyboard(" 🗣️ Explain ", "explain")
-			view.SendMessageWithButtons(bot, message.Chat.ID, fmt.Sprintf("The word was: %s", word), buttons)
-
-			chatState.reset(chatID)
-		} else {
-			sentMsg, err := view.SendMessage(bot, message.Chat.ID, "Please wait for 10 minutes before revealing the word.")
-			deleteWarningMessage(bot, message, sentMsg, err)
-		}
-	default:
-		// Check if Wordle is active for group chat
-		if wordlebot.IsWordleActive(chatID) {
-			wordlebot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if scramybot.IsScramyActive(chatID) {
-			scramybot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if geographybot.IsGeographyActive(chatID) {
-			geographybot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if wordgridbot.IsWordGridActive(chatID) {
-			wordgridbot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		if animebot.IsAnimeActive(chatID) {
-			animebot.HandleGuess(bot, message, client, chatID, message.Text)
-		}
-
-		chatState.RLock()
-		word := chatState.Word
-		user := chatState.User
-		leader := chatState.Leader
-		chatState.RUnlock()
-
-		if user != 0 && service.NormalizeAndComparePlural(message.Text, word) && message.From.ID != user {
-			chatState.reset(chatID)
-			buttons := createSingleButtonKeyboard("🌟 Claim Leadership 🙋", "explain")
-			view.SendMessageWithButtons(bot, message.Chat.ID, fmt.Sprintf("%s! %s guessed the word %s.\n /word", telegramReactions[7], message.From.FirstName, word), buttons)
-			go view.ReactToMessage(bot.Token, chatID, message.MessageID, telegramReactions[rand.Intn(8)+13], true)
-			go view.ReactToMessage(bot.Token, chatID, message.MessageID, telegramReactions[rand.Intn(8)+13], true)
-			go repository.InsertDoc(message.From.ID, message.From.FirstName, message.Chat.ID, client, "CrocEn")
-			go repository.InsertDoc(user, leader, message.Chat.ID, client, "CrocEnLeader")
-
-		}
-		aiModeMutex.Lock()
-		aiOn := aiModeUsers[chatID]
-		aiModeMutex.Unlock()
-		if aiOn && strings.Contains(text, "Jarvis") {
-			// AI processing here
-			wordChannel, errChannel := installOllama.RunOllama(text)
-
-			// Send the initial message (could be an empty string or placeholder)
-			initialMsg := tgbotapi.NewMessage(chatID, "Thinking...")
-			initialMessage, err := bot.Send(initialMsg)
-			if err != nil {
-				log.Println("Failed to send initial message:", err)
-				return
-			}
-
-			// Start a variable to accumulate the text as we receive each word
-			var accumulatedText string
-
-			// Process words as they arrive
-			for word := range wordChannel {
-				// Accumulate the word and append it to the message content
-				accumulatedText += word + " "
-
-				// Update the same message with the accumulated text
-				editedMsg := tgbotapi.NewEditMessageText(chatID, initialMessage.MessageID, strings.TrimSpace(accumulatedText))
-				_, err := bot.Send(editedMsg)
-				if err != nil {
-					log.Println("Failed to update message:", err)
-				}
-			}
-
-			// If an error occurs during execution, send it to the user
-			if err := <-errChannel; err != nil {
-				// Send an error message if something goes wrong
-				errorMsg := tgbotapi.NewMessage(chatID, err.Error())
-				_, err := bot.Send(errorMsg)
-				if err != nil {
-					log.Println("Failed to send error message:", err)
-				}
-				return
-			}
-			//
-		}
-		fmt.Print(text, "escaped jarvis-----------------------------------------")
-		return
-	}
-}
-
-// handleCallbackQuery processes incoming callback queries and handles the "explain" action.
-func handleCallbackQuery(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client *mongo.Client) {
-	if strings.HasPrefix(callback.Data, "geo_ans_") {
-		geographybot.HandleGeographyCallback(bot, callback.Message.Chat.ID, callback.From.ID, callback.From.FirstName, callback.Data, callback.ID, callback.Message.MessageID, client)
-		return
-	}
-	if handleShopCallback(bot, callback, client) {
-		return
-	}
-	if collectibleController.HandleCallback(bot, callback, client) {
-		return
-	}
-
-	chatID := callback.Message.Chat.ID
-	chatState := getOrCreateChatState(chatID)
-
-	switch callback.Data {
-	case "statsglobal_wordguess":
-		markup := service.LeaderBoardListButtons(client, "CrocEn", 0, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsglobal_wordle":
-		markup := service.LeaderBoardListButtons(client, "WordleEn", 0, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsglobal_scramy":
-		markup := service.LeaderBoardListButtons(client, "ScramyEn", 0, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsglobal_geography":
-		markup := service.LeaderBoardListButtons(client, "GeographyPoints", 0, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsglobal_wordgrid":
-		markup := service.LeaderBoardListButtons(client, "WordGridPoints", 0, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsglobal_anime":
-		markup := service.LeaderBoardListButtons(client, "AnimePoints", 0, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsgroup_wordguess":
-		markup := service.LeaderBoardListButtons(client, "CrocEn", chatID, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsgroup_wordle":
-		markup := service.LeaderBoardListButtons(client, "WordleEn", chatID, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsgroup_scramy":
-		markup := service.LeaderBoardListButtons(client, "ScramyEn", chatID, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsgroup_geography":
-		markup := service.LeaderBoardListButtons(client, "GeographyPoints", chatID, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsgroup_wordgrid":
-		markup := service.LeaderBoardListButtons(client, "WordGridPoints", chatID, callback.Data)
-		err := view.EditMessageTextWithStyledButtons(bot.Token, chatID, callback.Message.MessageID, "🏆 <b>Top 10 Players Leaderboard</b> 🏆\n\n✨ <b>Keep it up and aim for the top!</b> ✨", markup)
-		if err != nil {
-			log.Printf("Failed to send styled buttons message: %v", err)
-			view.SendMessagehtml(bot, chatID, "Failed to load leaderboard.")
-		}
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "statsimg_global_wordguess":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		bot.Send(tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "Generating image... Please wait ⏳"))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "CrocEn", 0, "Word Guess Global Leaderboard")
-		if err == nil {
-			photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-			photo.ReplyMarkup = markup
-			bot.Send(photo)
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-		return
-	case "statsimg_global_wordle":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		bot.Send(tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "Generating image... Please wait ⏳"))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "WordleEn", 0, "Wordle Global Leaderboard")
-		if err == nil {
-			photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-			photo.ReplyMarkup = markup
-			bot.Send(photo)
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-		return
-	case "statsimg_global_scramy":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		bot.Send(tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "Generating image... Please wait ⏳"))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "ScramyEn", 0, "Scramy Global Leaderboard")
-		if err == nil {
-			photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-			photo.ReplyMarkup = markup
-			bot.Send(photo)
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-		return
-	case "statsimg_global_geography":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Anime Image Global 🎌", "statsimg_global_anime")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		bot.Send(tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "Generating image... Please wait ⏳"))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "GeographyPoints", 0, "Geography Global Leaderboard")
-		if err == nil {
-			photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-			photo.ReplyMarkup = markup
-			bot.Send(photo)
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-		return
-	case "statsimg_global_anime":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Global", "statsimg_global_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Global", "statsimg_global_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Global", "statsimg_global_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Global 🌍", "statsimg_global_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Anime Image Global 🎌", "statsimg_global_anime")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Global 🔠", "statsimg_global_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		bot.Send(tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "Generating image... Please wait ⏳"))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "AnimePoints", 0, "Anime Global Leaderboard")
-		if err == nil {
-			photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-			photo.ReplyMarkup = markup
-			bot.Send(photo)
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-		return
-	case "statsimg_group_wordguess":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Group", "statsimg_group_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Group", "statsimg_group_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Group", "statsimg_group_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Group 🌍", "statsimg_group_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Group 🔠", "statsimg_group_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		bot.Send(tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "Generating image... Please wait ⏳"))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "CrocEn", chatID, "Word Guess Group Leaderboard")
-		if err == nil {
-			photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-			photo.ReplyMarkup = markup
-			bot.Send(photo)
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-		return
-	case "statsimg_group_wordle":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Group", "statsimg_group_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Group", "statsimg_group_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Group", "statsimg_group_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Group 🌍", "statsimg_group_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Group 🔠", "statsimg_group_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		bot.Send(tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "Generating image... Please wait ⏳"))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "WordleEn", chatID, "Wordle Group Leaderboard")
-		if err == nil {
-			photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-			photo.ReplyMarkup = markup
-			bot.Send(photo)
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-		return
-	case "statsimg_group_scramy":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Group", "statsimg_group_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Group", "statsimg_group_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Group", "statsimg_group_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Group 🌍", "statsimg_group_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Group 🔠", "statsimg_group_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		bot.Send(tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "Generating image... Please wait ⏳"))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "ScramyEn", chatID, "Scramy Group Leaderboard")
-		if err == nil {
-			photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-			photo.ReplyMarkup = markup
-			bot.Send(photo)
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-		return
-	case "statsimg_group_geography":
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Guess Image Group", "statsimg_group_wordguess"), tgbotapi.NewInlineKeyboardButtonData("Wordle Image Group", "statsimg_group_wordle")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Scramy Image Group", "statsimg_group_scramy"), tgbotapi.NewInlineKeyboardButtonData("Geography Image Group 🌍", "statsimg_group_geography")), tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Word Grid Image Group 🔠", "statsimg_group_wordgrid")))
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Generating image..."))
-		bot.Send(tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "Generating image... Please wait ⏳"))
-		imgBytes, err := service.GenerateLeaderboardImage(client, "GeographyPoints", chatID, "Geography Group Leaderboard")
-		if err == nil {
-			photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
-			photo.ReplyMarkup = markup
-			bot.Send(photo)
-		} else {
-			view.SendMessage(bot, chatID, "Failed to generate image.")
-		}
-		bot.Send(tgbotapi.NewDeleteMessage(chatID, callback.Message.MessageID))
-		return
-	case "mystats_main":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Word Guess", "stats_wordguess"),
-				tgbotapi.NewInlineKeyboardButtonData("Wordle", "stats_wordle"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Scramy", "stats_scramy"),
-				tgbotapi.NewInlineKeyboardButtonData("Anime", "statsglobal_anime"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "🐊🇮🇳\n📊 Choose game stats to view:")
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_geography_main":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Mode (MCQ/Text) 🎮", "setting_geography_mode"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Question Types ❓", "setting_geo_questions"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "settings_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Geography Settings*\nChoose a setting to configure:")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_geography_mode":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("MCQ Mode (Multiple Choice)", "setting_geo_mode_mcq"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Text Guess Mode", "setting_geo_mode_text"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "setting_geography_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Geography Mode*\nChoose how you want to play Geography:\n- *MCQ Mode*: Buttons to select the answer.\n- *Text Guess Mode*: Type out your guess (5 attempts).")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_geo_questions":
-		settings := geographybot.GetChatSettings(chatID, client)
-		getBtn := func(key, label string) string {
-			if settings.QuestionTypes[key] {
-				return "✅ " + label
-			}
-			return "❌ " + label
-		}
-
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("capital", "Capital"), "toggle_geo_capital"),
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("flag", "Flag"), "toggle_geo_flag"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("region", "Region"), "toggle_geo_region"),
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("landmark", "Landmark (Image)"), "toggle_geo_landmark"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("country_from_capital", "Country from Capital"), "toggle_geo_country_from_capital"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("landmark_name", "Landmark Name (Image)"), "toggle_geo_landmark_name"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "setting_geography_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Geography Question Types*\nToggle which types of questions can appear:")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_geo_mode_mcq", "setting_geo_mode_text":
-		mode := "mcq"
-		if callback.Data == "setting_geo_mode_text" {
-			mode = "text"
-		}
-		err := geographybot.UpdateGeographyMode(chatID, mode, client)
-		if err != nil {
-			log.Printf("Failed to update geography mode: %v", err)
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Failed to update setting."))
-			return
-		}
-		modeStr := "MCQ Mode"
-		if mode == "text" {
-			modeStr = "Text Guess Mode"
-		}
-
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Geography Settings", "setting_geography_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, fmt.Sprintf("✅ *Geography mode updated to %s!*", modeStr))
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Settings saved!"))
-		return
-	case "toggle_geo_capital", "toggle_geo_flag", "toggle_geo_region", "toggle_geo_landmark", "toggle_geo_country_from_capital", "toggle_geo_landmark_name":
-		qType := strings.TrimPrefix(callback.Data, "toggle_geo_")
-		err := geographybot.ToggleGeographyQuestionType(chatID, qType, client)
-		if err != nil {
-			log.Printf("Failed to toggle geography question type: %v", err)
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Failed to update setting"))
-			return
-		}
-
-		// Re-render the menu
-		settings := geographybot.GetChatSettings(chatID, client)
-		getBtn := func(key, label string) string {
-			if settings.QuestionTypes[key] {
-				return "✅ " + label
-			}
-			return "❌ " + label
-		}
-
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("capital", "Capital"), "toggle_geo_capital"),
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("flag", "Flag"), "toggle_geo_flag"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("region", "Region"), "toggle_geo_region"),
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("landmark", "Landmark (Image)"), "toggle_geo_landmark"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("country_from_capital", "Country from Capital"), "toggle_geo_country_from_capital"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(getBtn("landmark_name", "Landmark Name (Image)"), "toggle_geo_landmark_name"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "setting_geography_main"),
-			),
-		)
-
-		// Use edit message reply markup to update the buttons in place
-		editMarkup := tgbotapi.NewEditMessageReplyMarkup(chatID, callback.Message.MessageID, buttons)
-		bot.Send(editMarkup)
-
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_scramy_letters":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Squared 🔠", "set_scramy_squared"),
-				tgbotapi.NewInlineKeyboardButtonData("Normal abc", "set_scramy_normal"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "settings_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Scramy Letters Setting*\nChoose the letter style for Scramy:")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_scramy_letters_new":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Squared 🔠", "set_scramy_squared_new"),
-				tgbotapi.NewInlineKeyboardButtonData("Normal abc", "set_scramy_normal_new"),
-				tgbotapi.NewInlineKeyboardButtonData("H1 📝", "set_scramy_h1_new"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "refresh_scramy_game"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageReplyMarkup(chatID, callback.Message.MessageID, buttons)
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "set_scramy_squared":
-		scramybot.UpdateScramyLetterView(chatID, "squared", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Scramy letters updated to **Squared**.")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy set to Squared"))
-		return
-	case "set_scramy_h1":
-		scramybot.UpdateScramyLetterView(chatID, "h1", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Scramy letters updated to **H1**.")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy letters updated!"))
-		return
-	case "set_scramy_normal":
-		scramybot.UpdateScramyLetterView(chatID, "normal", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Scramy letters updated to **Normal**.")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy set to Normal"))
-		return
-	case "stats_wordguess":
-		result := service.GetUserStatsByID(client, callback.From.ID)
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "mystats_main")))
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, result)
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeHTML
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "stats_scramy":
-		result := service.GetScramyUserStatsByID(client, callback.From.ID)
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "mystats_main")))
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, result)
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeHTML
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "stats_wordle":
-		result := service.GetWordleUserStatsByID(client, callback.From.ID)
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "mystats_main")))
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, result)
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeHTML
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "explain":
-		chatState.Lock()
-		if chatState.User != callback.From.ID && chatState.User != 0 && time.Since(chatState.LeadTimestamp) < 600*time.Second {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("%s is already explaining the word. Please wait for your turn, %s.", chatState.Leader, callback.From.UserName)))
-			chatState.Unlock()
-			saveCategoryChatStateAsync(chatID, chatState)
-			return
-		}
-		if chatState.User == callback.From.ID {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, chatState.Word))
-			chatState.Unlock()
-			saveCategoryChatStateAsync(chatID, chatState)
-			return
-		}
-		if chatState.User == 0 || (time.Since(chatState.LeadTimestamp) >= 600*time.Second && chatState.User != callback.From.ID) {
-			chatState.User = callback.From.ID
-			word, err := model.GetRandomWord()
-			if err != nil {
-				chatState.Unlock()
-				saveCategoryChatStateAsync(chatID, chatState)
-				return
-			}
-			buttons := createCategoryBotKeyboard(bot.Self.UserName, chatID)
-			chatState.Word = word
-			view.SendMessageWithButtons(bot, callback.Message.Chat.ID, fmt.Sprintf(" [%s](tg://user?id=%d) is explaining the word!", callback.From.FirstName, callback.From.ID), buttons)
-
-			// Remove the inline keyboard (buttons) from the "claim leadership" message when someone starts leading
-			editMarkup := tgbotapi.NewEditMessageReplyMarkup(callback.Message.Chat.ID, callback.Message.MessageID, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
-			_, err = bot.Send(editMarkup)
-			if err != nil {
-				log.Printf("Failed to remove inline keyboard: %v", err)
-			}
-		}
-		chatState.Leader = callback.From.FirstName
-		chatState.LeadTimestamp = time.Now()
-		chatState.Unlock()
-		saveCategoryChatStateAsync(chatID, chatState)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, chatState.Word))
-	case "settings_main":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Wordle View 🖼️", "setting_wordle_view"),
-				tgbotapi.NewInlineKeyboardButtonData("Wordle Color 🎨", "setting_wordle_color"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Scramy Letters 🔠", "setting_scramy_letters"),
-				tgbotapi.NewInlineKeyboardButtonData("Geography Settings 🌍", "setting_geography_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Settings*\nChoose a setting to configure:")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_wordle_color":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Classic (🟥)", "set_wordle_color_classic"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Dark Mode (⬛)", "set_wordle_color_dark"),
-				tgbotapi.NewInlineKeyboardButtonData("Light Mode (⬜)", "set_wordle_color_light"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "settings_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Wordle Color Setting*\nChoose the color used for missing letters:")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_wordle_color_new":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Classic (🟥)", "set_wordle_color_classic_new"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Dark Mode (⬛)", "set_wordle_color_dark_new"),
-				tgbotapi.NewInlineKeyboardButtonData("Light Mode (⬜)", "set_wordle_color_light_new"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "refresh_wordle_game"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageReplyMarkup(chatID, callback.Message.MessageID, buttons)
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_wordle_view":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Text View 📝", "set_wordle_view_text"),
-				tgbotapi.NewInlineKeyboardButtonData("Image View 🖼️", "set_wordle_view_image"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "settings_main"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "⚙️ *Wordle View Setting*\nChoose how you want Wordle results to be displayed:")
-		editMsg.ReplyMarkup = &buttons
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "refresh_wordle_game":
-		wordlebot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "refresh_scramy_game":
-		scramybot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "setting_wordle_view_new":
-		buttons := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Text View 📝", "set_wordle_view_text_new"),
-				tgbotapi.NewInlineKeyboardButtonData("Image View 🖼️", "set_wordle_view_image_new"),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "refresh_wordle_game"),
-			),
-		)
-		editMsg := tgbotapi.NewEditMessageReplyMarkup(chatID, callback.Message.MessageID, buttons)
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-		return
-	case "set_wordle_view_text":
-		wordlebot.UpdateWordleViewType(chatID, "text", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Wordle view updated to **Text**.")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "View set to Text"))
-		return
-	case "set_wordle_view_image":
-		wordlebot.UpdateWordleViewType(chatID, "image", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Wordle view updated to **Image**.")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "View set to Image"))
-		return
-	case "set_wordle_color_classic":
-		wordlebot.UpdateWordleColor(chatID, "classic", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Wordle color updated to **Classic** (🟥).")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Color set to Classic"))
-		return
-	case "set_wordle_color_dark":
-		wordlebot.UpdateWordleColor(chatID, "dark", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Wordle color updated to **Dark** (⬛).")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Color set to Dark"))
-		return
-	case "set_wordle_color_light":
-		wordlebot.UpdateWordleColor(chatID, "light", client)
-		editMsg := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, "✅ Wordle color updated to **Light** (⬜).")
-		editMsg.ParseMode = tgbotapi.ModeMarkdown
-		buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
-		editMsg.ReplyMarkup = &buttons
-		bot.Send(editMsg)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Color set to Light"))
-		return
-	case "set_scramy_squared_new":
-		scramybot.UpdateScramyLetterView(chatID, "squared", client)
-		scramybot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy set to Squared"))
-		return
-	case "set_scramy_normal_new":
-		scramybot.UpdateScramyLetterView(chatID, "normal", client)
-		scramybot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy set to Normal"))
-		return
-	case "set_scramy_h1_new":
-		scramybot.UpdateScramyLetterView(chatID, "h1", client)
-		scramybot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy set to H1"))
-		return
-	case "set_wordle_view_text_new":
-		wordlebot.UpdateWordleViewType(chatID, "text", client)
-		wordlebot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "View set to Text"))
-		return
-	case "set_wordle_view_image_new":
-		wordlebot.UpdateWordleViewType(chatID, "image", client)
-		wordlebot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "View set to Image"))
-		return
-	case "set_wordle_color_classic_new":
-		wordlebot.UpdateWordleColor(chatID, "classic", client)
-		wordlebot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Color set to Classic"))
-		return
-	case "set_wordle_color_dark_new":
-		wordlebot.UpdateWordleColor(chatID, "dark", client)
-		wordlebot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Color set to Dark"))
-		return
-	case "set_wordle_color_light_new":
-		wordlebot.UpdateWordleColor(chatID, "light", client)
-		wordlebot.RefreshActiveGameMessage(bot, chatID, callback.Message.MessageID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Color set to Light"))
-		return
-	case "wordle_start":
-		wordlebot.HandleWordleCommand(bot, chatID, callback.From.FirstName, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Wordle Started!"))
-		return
-	case "wordgrid_start":
-		wordgridbot.StartWordGridGame(bot, chatID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Word Grid Started!"))
-		return
-	case "wordgrid_start_easy":
-		wordgridbot.StartWordGridEasyGame(bot, chatID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Word Grid Easy Started!"))
-		return
-	case "cancel_new_wordle":
-		if wordlebot.CancelPendingGame(bot, chatID, callback.From.FirstName) {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Cancelled new game request."))
-		} else {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "No pending game request to cancel."))
-		}
-		return
-	case "scramy_start":
-		scramybot.HandleScramyCommand(bot, chatID, callback.From.FirstName)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Scramy Started!"))
-		return
-	case "geography_start":
-		geographybot.HandleGeographyCommand(bot, chatID, callback.From.FirstName, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Geography Started!"))
-		return
-	case "anime_start":
-		animebot.HandleAnimeCommand(bot, chatID, client)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Anime Started!"))
-		return
-	case "cancel_new_scramy":
-		if scramybot.CancelPendingGame(bot, chatID, callback.From.FirstName) {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Cancelled new Scramy game request."))
-		} else {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "No pending game request to cancel."))
-		}
-		return
-	case "ai_hint":
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "feature under maintenance."))
-		aiResponseMutex.RLock()
-		aiResponseMutex.RUnlock()
-		word := chatState.Word
-		if word == "" {
-			view.SendMessage(bot, chatID, "No active word to provide a hint for.")
-			return
-		}
-
-		wordChannel, errChannel := installOllama.RunOllama("Explain \"" + word + "\"")
-
-		initialMsg := tgbotapi.NewMessage(chatID, "Thinking...")
-		initialMessage, err := bot.Send(initialMsg)
-		if err != nil {
-			log.Println("Failed to send initial message:", err)
-			return
-		}
-
-		var accumulatedText string
-		for word := range wordChannel {
-			word = replaceWord(word, chatState.Word)
-			accumulatedText += word + " "
-
-			aiResponseMutex.Lock()
-			aiLastResponse[chatID] = accumulatedText
-			aiResponseMutex.Unlock()
-
-			editedMsg := tgbotapi.NewEditMessageText(chatID, initialMessage.MessageID, strings.TrimSpace(accumulatedText))
-			_, err := bot.Send(editedMsg)
-			if err != nil {
-				log.Println("Failed to update message:", err)
-			}
-		}
-
-		if err := <-errChannel; err != nil {
-			errorMsg := tgbotapi.NewMessage(chatID, err.Error())
-			_, err := bot.Send(errorMsg)
-			if err != nil {
-				log.Println("Failed to send error message:", err)
-			}
-			return
-		}
-
-		// Generate a simple follow-up hint by truncating or modifying the last AI response
-		hint := accumulatedText
-		if len(hint) > 100 {
-			hint = hint[:100] + "..."
-		}
-		view.SendMessage(bot, chatID, "💡 AI Hint: "+hint)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-	case "animal":
-		chatState.Lock()
-		if chatState.User != callback.From.ID && chatState.User != 0 {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("someone is already explaining the word. %s", callback.From.UserName)))
-			chatState.Unlock()
-			saveCategoryChatStateAsync(chatID, chatState)
-			return
-		}
-		if chatState.User == 0 {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("%s Please click on see word/claim Leadership", callback.From.FirstName)))
-			chatState.Unlock()
-			saveCategoryChatStateAsync(chatID, chatState)
-			return
-		}
-		chatState.User = callback.From.ID
-		word, err := model.GetRandomAnimal()
-		if err != nil {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, "Failed to get an animal word. Please try again later."))
-			chatState.Unlock()
-			saveCategoryChatStateAsync(chatID, chatState)
-			return
-		}
-		chatState.Word = word
-		chatState.Unlock()
-		saveCategoryChatStateAsync(chatID, chatState)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, chatState.Word))
-
-	case "next":
-		chatState.Lock()
-		if chatState.User != callback.From.ID && chatState.User != 0 {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("%s is already explaining the word. %s", chatState.Leader, callback.From.UserName)))
-			chatState.Unlock()
-			saveCategoryChatStateAsync(chatID, chatState)
-			return
-		}
-		if chatState.User == 0 {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("%s Please click on see word/claim Leadership", callback.From.FirstName)))
-			chatState.Unlock()
-			saveCategoryChatStateAsync(chatID, chatState)
-			return
-		}
-		chatState.User = callback.From.ID
-		chatState.Leader = callback.From.FirstName
-		chatState.Word, _ = model.GetRandomWord()
-		chatState.Unlock()
-		saveCategoryChatStateAsync(chatID, chatState)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, chatState.Word))
-
-	case "flower":
-		chatState.Lock()
-		if chatState.User != callback.From.ID && chatState.User != 0 {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("someone is already explaining the word. %s", callback.From.UserName)))
-			chatState.Unlock()
-			saveCategoryChatStateAsync(chatID, chatState)
-			return
-		}
-		if chatState.User == 0 {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("%s Please click on see word/claim Leadership", callback.From.FirstName)))
-			chatState.Unlock()
-			saveCategoryChatStateAsync(chatID, chatState)
-			return
-		}
-		chatState.User = callback.From.ID
-		chatState.Word, _ = model.GetRandomFlower()
-		chatState.Unlock()
-		saveCategoryChatStateAsync(chatID, chatState)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, chatState.Word))
-
-	case "car":
-		chatState.Lock()
-		if chatState.User != callback.From.ID && chatState.User != 0 {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("someone is already explaining the word. %s", callback.From.UserName)))
-			chatState.Unlock()
-			saveCategoryChatStateAsync(chatID, chatState)
-			return
-		}
-		if chatState.User == 0 {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("%s Please click on see word/claim Leadership", callback.From.FirstName)))
-			chatState.Unlock()
-			saveCategoryChatStateAsync(chatID, chatState)
-			return
-		}
-		chatState.User = callback.From.ID
-		chatState.Word, _ = model.GetRandomCar()
-		chatState.Unlock()
-		saveCategoryChatStateAsync(chatID, chatState)
-		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, chatState.Word))
-
-	case "droplead":
-		chatState.Lock()
-		if chatState.User != callback.From.ID {
-			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, "You are not the current leader, so you cannot drop the lead!"))
-			chatState.Unlock()
-			saveCategoryChatStateAsync(chatID, chatState)
-			return
-		}
-		// Delete the callback message when user selects "Changed my mind"
-		deleteMsg := tgbotapi.NewDeleteMessage(callback.Message.Chat.ID, callback.Message.MessageID)
-		_, err := bot.DeleteMessage(deleteMsg)
-		if err != nil {
-			log.Printf("Failed to delete message on droplead: %v", err)
-		}
-		chatState.Unlock()
-		saveCategoryChatStateAsync(chatID, chatState)
-		buttons := createSingleButtonKeyboard("🌟 Claim Leadership 🙋", "explain")
-		view.SendMessageWithButtons(bot, callback.Message.Chat.ID, fmt.Sprintf("%s refused to lead -> %s \n", callback.From.FirstName, chatState.Word), buttons)
-		chatState.reset(chatID)
-
-	case "hint":
-		chatState.RLock()
-		wordEmpty := chatState.Word == ""
-		lastHint := chatState.LastHintTimestamp
-		lastHintType := chatState.LastHintTypeSent
-		chatState.RUnlock()
-
-		if wordEmpty {
-			view.SendMessage(bot, callback.Message.Chat.ID, "No active game right now. Click below to start one! \n  __		/start		__")
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-			return
-		}
-
-		if !lastHint.IsZero() && time.Since(lastHint) < 1*time.Minute {
-			sentMsg, err := view.SendMessage(bot, chatID, "Please take a minute before requesting another hint.")
-			deleteWarningMessage(bot, callback.Message, sentMsg, err)
-			bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-
-			return
-		}
-
-		chatState.RLock()
-		var hint string
-		if lastHintType == 0 {
-			hint = model.GenerateMeaningHint(chatState.Word)
-		} else {
-			hint = model.GenerateMeaningHint(chatState.Word)
-			hint = hint + "\n" + model.GenerateHint(chatState.Word)
-			hint = hint + "\n" + model.GenerateAuroraHint(chatState.Word)
-		}
-		chatState.RUnlock()
-
-		chatAction := tgbotapi.NewChatAction(callback.Message.Chat.ID, tgbotapi.ChatTyping)
-		bot.Send(chatAction)
-
-		escapedHint := escapeMarkdownV2(hint)
-		spoilerHint := "||" + escapedHint + "||"
-		msg := tgbotapi.NewMessage(callback.Message.Chat.ID, spoilerHint)
-		msg.ParseMode = "MarkdownV2"
-		_, err := bot.Send(msg)
-		if err != nil {
-			log.Printf("Failed to send hint message with spoiler formatting: %v", err)
-		}
-
-		chatState.Lock()
-		chatState.LastHintTimestamp = time.Now()
-		chatState.LastHintTypeSent = 1 - lastHintType
-		chatState.Unlock()
-		saveCategoryChatStateAsync(chatID, chatState)
-
-		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-	default:
-		chatState.RLock()
-		word := chatState.Word
-		chatState.RUnlock()
-		if service.NormalizeAndComparePlural(callback.Message.Text, word) {
-			buttons := createSingleButtonKeyboard("🌟 Claim Leadership 🙋", "explain")
-			view.SendMessageWithButtons(bot, callback.Message.Chat.ID, fmt.Sprintf("%s! %s guessed the word correctly.", telegramReactions[0], callback.From.FirstName), buttons)
-			chatState.reset(chatID)
-		}
-	}
-	bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
-}
+	// Any other button is checked as a guess of the current word.
+	chatState.RLock()
+	word := chatState.Word
+	chatState.RUnlock()
+	if service.NormalizeAndComparePlural(callback.Message.Text, word) {
+		buttons := createSingleButtonKeyboard("🌟 Claim Leadership 🙋", "explain")
+		view.SendMessageWithButtons(bot, callback.Message.Chat.ID, fmt.Sprintf("%s! %s guessed the word correctly.", telegramReactions[0], callback.From.FirstName), buttons)
+		chatState.reset(chatID)
+	}
+	bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
+}
 
 // createMultiButtonKeyboard creates an inline keyboard markup with multiple buttons
 func createMultiButtonKeyboard(buttonsData [][]string) tgbotapi.InlineKeyboardMarkup {
@@ -2997,51 +1293,3 @@ func createMultiButtonKeyboard(buttonsData [][]string) tgbotapi.InlineKeyboardMa
 	}
 	return tgbotapi.NewInlineKeyboardMarkup(rows...)
 }
-
-func handleProfileCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client) {
-	username := message.From.UserName
-	if username == "" {
-		username = message.From.FirstName
-	}
-
-	profile, err := repository.GetUserProfile(client, int64(message.From.ID), username)
-	if err != nil {
-		view.SendMessage(bot, message.Chat.ID, "Error retrieving profile. Please try again later.")
-		return
-	}
-
-	html := service.FormatUserProfile(profile)
-	view.SendMessagehtml(bot, message.Chat.ID, html)
-}
-
-func handleDailyCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client) {
-	username := message.From.UserName
-	if username == "" {
-		username = message.From.FirstName
-	}
-
-	xp, coins, err := service.ClaimDailyReward(client, int64(message.From.ID), username)
-	if err != nil {
-		view.SendMessage(bot, message.Chat.ID, "❌ "+err.Error())
-		return
-	}
-
-	msg := fmt.Sprintf("🎁 *Daily Reward Claimed!*\n\nEarned:\n✨ +%d XP\n🪙 +%d Coins", xp, coins)
-	view.SendMessage(bot, message.Chat.ID, msg)
-}
-
-func handleWeeklyCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client) {
-	username := message.From.UserName
-	if username == "" {
-		username = message.From.FirstName
-	}
-
-	xp, coins, err := service.ClaimWeeklyReward(client, int64(message.From.ID), username)
-	if err != nil {
-		view.SendMessage(bot, message.Chat.ID, "❌ "+err.Error())
-		return
-	}
-
-	msg := fmt.Sprintf("🎉 *Weekly Reward Claimed!*\n\nEarned:\n✨ +%d XP\n🪙 +%d Coins", xp, coins)
-	view.SendMessage(bot, message.Chat.ID, msg)
-}
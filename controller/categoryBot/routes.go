@@ -0,0 +1,755 @@
+package categorybot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	collectibleController "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/collectible"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/commands"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/modbot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	installOllama "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/installOllama"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordguess"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapiv5Ovy "github.com/OvyFlash/telegram-bot-api"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// newRouter wires the shared commands and the Category Bot ones.
+func newRouter(games *game.Engine) *router.Router {
+	env := games.Env()
+	r := router.New(env.Bot, env.Client, env.Store,
+		router.Recover(),
+		router.Logging(),
+		router.BanCheck(modbot.IsGloballyBanned),
+		router.Cooldown(),
+	)
+	commands.Register(r, games)
+
+	r.Command(router.Command{Name: "ai_on", Handler: handleAIOn})
+	r.Command(router.Command{Name: "ai_off", Scope: router.PrivateOnly, Handler: handleAIOff})
+	r.Command(router.Command{Name: "start", Scope: router.PrivateOnly, Handler: handlePrivateStart})
+	r.Command(router.Command{Name: "start", Scope: router.GroupOnly, Handler: handleGroupStart})
+	r.Command(router.Command{Name: "setting", Description: "Configure the games for this chat", Scope: router.GroupOnly, Handler: handleSetting})
+	r.Command(router.Command{Name: "geosettings", Description: "Choose the Geography mode", Scope: router.GroupOnly, Handler: handleGeoSettings})
+	r.Command(router.Command{Name: "richmessage", Scope: router.GroupOnly, Handler: handleRichMessage})
+	r.Command(router.Command{Name: "scramyleaderboard", Description: "Show the Scramy top 10", Scope: router.GroupOnly, Handler: handleScramyLeaderboard})
+	r.Command(router.Command{Name: "word", Description: "Start a new word round", Scope: router.GroupOnly, Handler: handleWordCommand})
+	r.Command(router.Command{Name: "game", Description: "Pick a game to play", Scope: router.GroupOnly, Handler: handleGameCommand})
+	r.Command(router.Command{Name: "hint", Description: "Get a hint for the current word", Scope: router.GroupOnly, Handler: handleHintCommand})
+	r.Command(router.Command{Name: "reveal", Description: "Reveal the word once the round is over", Scope: router.GroupOnly, Handler: handleRevealCommand})
+
+	r.Callback(router.Callback{Data: "explain", Handler: handleExplainCallback})
+	r.Callback(router.Callback{Data: "ai_hint", Handler: handleAIHintCallback})
+	r.Callback(router.Callback{Data: "animal", Handler: categoryCallback(model.GetRandomAnimal)})
+	r.Callback(router.Callback{Data: "flower", Handler: categoryCallback(model.GetRandomFlower)})
+	r.Callback(router.Callback{Data: "car", Handler: categoryCallback(model.GetRandomCar)})
+	r.Callback(router.Callback{Data: "next", Handler: handleNextCallback})
+	r.Callback(router.Callback{Data: "droplead", Handler: handleDropLeadCallback})
+	r.Callback(router.Callback{Data: "hint", Handler: handleHintCallback})
+	return r
+}
+
+// handleAIOn turns on AI replies and AI hints for a chat.
+func handleAIOn(c *router.Context) {
+	bot, chatID := c.Bot, c.ChatID
+
+	aiModeMutex.Lock()
+	aiModeUsers[chatID] = true
+	aiModeMutex.Unlock()
+	view.SendMessage(bot, chatID, "AI mode is now enabled! Enjoy the smart responses.")
+}
+
+// handleAIOff turns AI replies back off.
+func handleAIOff(c *router.Context) {
+	bot, chatID := c.Bot, c.ChatID
+
+	aiModeMutex.Lock()
+	delete(aiModeUsers, chatID)
+	aiModeMutex.Unlock()
+	view.SendMessage(bot, chatID, "AI mode has been disabled.")
+}
+
+// handlePrivateStart handles /start in a DM, including the deep links
+// used by the collectibles, shop and custom word buttons.
+func handlePrivateStart(c *router.Context) {
+	bot, message, store, chatID := c.Bot, c.Message, c.Store, c.ChatID
+
+	args := message.CommandArguments()
+	if args == "collectibles" {
+		collectibleController.ShowHub(bot, message.Chat.ID, message.From.ID, store)
+		return
+	} else if args == "shop" {
+		commands.ShowShop(bot, message.Chat.ID, message.From.ID, store)
+		return
+	} else if strings.HasPrefix(args, "custom_word_") {
+		parts := strings.Split(args, "_")
+		if len(parts) == 3 {
+			groupChatIDStr := parts[2]
+			var groupChatID int64
+			if _, err := fmt.Sscanf(groupChatIDStr, "%d", &groupChatID); err == nil {
+				groupState := getOrCreateChatState(groupChatID)
+				groupState.RLock()
+				isLeader := groupState.User == message.From.ID
+				groupState.RUnlock()
+				if isLeader {
+					customWordMutex.Lock()
+					customWordState[int64(message.From.ID)] = groupChatID
+					customWordMutex.Unlock()
+					msg := tgbotapi.NewMessage(chatID, "Type the word you want the group to guess (must be a valid English word):")
+					msg.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
+					bot.Send(msg)
+				} else {
+					view.SendMessage(bot, chatID, "You are not the current leader in that group.")
+				}
+			}
+		}
+		return
+	} else {
+		buttons := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Get Hint"+telegramReactions[20], "hint")))
+		view.SendMessageWithButtons(bot, message.Chat.ID, "Heyyy! Got a word for ya 😏 Tap the button below if you need a lil hint 👇", buttons)
+		return
+	}
+}
+
+// handleGroupStart greets a group.
+func handleGroupStart(c *router.Context) {
+	bot, message, store := c.Bot, c.Message, c.Store
+
+	if message.CommandArguments() == "collectibles" {
+		collectibleController.ShowHub(bot, message.Chat.ID, message.From.ID, store)
+	} else if message.CommandArguments() == "shop" {
+		commands.ShowShop(bot, message.Chat.ID, message.From.ID, store)
+	} else {
+		welcomeMsg := "👋 *Welcome to Category Bot!*\n\n" +
+			"📚 I'm your friendly group category game host.\n\n" +
+			"🎮 *Quick Start:*\n" +
+			"📝 `/word` — Start a new game\n" +
+			"📖 `/rules` — Learn how to play\n" +
+			"📊 `/stats` — View your scores\n\n" +
+			"🎉 Have fun!"
+		view.SendMessage(bot, message.Chat.ID, welcomeMsg)
+	}
+}
+
+// handleSetting opens the per-chat game settings as an ephemeral message.
+func handleSetting(c *router.Context) {
+	view.SendMessageWithButtonsV2(c.ChatID, "⚙️ *Settings*\nChoose a setting to configure:", commands.SettingsMenu(), c.UserID, c.Message.EphemeralMessageID)
+}
+
+// handleGeoSettings opens the Geography mode picker.
+func handleGeoSettings(c *router.Context) {
+	view.SendMessageWithButtons(c.Bot, c.ChatID, commands.GeographyModeText, commands.GeographyModeMenu(false))
+}
+
+// handleRichMessage sends a demo of the rich message format.
+func handleRichMessage(c *router.Context) {
+	chatID := c.ChatID
+
+	// Dummy command to demonstrate SendRichMessage with table, image, and text
+	photoMedia := tgbotapiv5Ovy.NewInputMediaPhoto(tgbotapiv5Ovy.FileURL("https://wallpapers.com/images/hd/celebratory-congratulations-banner-qeo95d2enk0nay3r.jpg"))
+	richMessage := tgbotapiv5Ovy.NewInputRichMessageBlocks(
+		tgbotapiv5Ovy.InputRichBlockSectionHeading{
+			Type: "heading",
+			Text: "📋 Demo Rich Message",
+			Size: 2,
+		},
+		tgbotapiv5Ovy.InputRichBlockParagraph{
+			Type: "paragraph",
+			Text: "This is a demonstration of Telegram's rich message format using the OvyFlash library. It includes headings, paragraphs, tables, and images.",
+		},
+		tgbotapiv5Ovy.InputRichBlockDivider{
+			Type: "divider",
+		},
+		tgbotapiv5Ovy.InputRichBlockTable{
+			Type: "table",
+			Cells: [][]tgbotapiv5Ovy.RichBlockTableCell{
+				{
+					{Text: "Player", IsHeader: true, Align: "left", Valign: "middle"},
+					{Text: "Score", IsHeader: true, Align: "center", Valign: "middle"},
+					{Text: "Rank", IsHeader: true, Align: "center", Valign: "middle"},
+				},
+				{
+					{Text: "Alice", Align: "left", Valign: "middle"},
+					{Text: "1250", Align: "center", Valign: "middle"},
+					{Text: "🥇", Align: "center", Valign: "middle"},
+				},
+				{
+					{Text: "Bob", Align: "left", Valign: "middle"},
+					{Text: "980", Align: "center", Valign: "middle"},
+					{Text: "🥈", Align: "center", Valign: "middle"},
+				},
+				{
+					{Text: "Charlie", Align: "left", Valign: "middle"},
+					{Text: "720", Align: "center", Valign: "middle"},
+					{Text: "🥉", Align: "center", Valign: "middle"},
+				},
+			},
+			IsBordered: true,
+			IsStriped:  true,
+			Caption:    "🏆 Top Players Leaderboard",
+		},
+		tgbotapiv5Ovy.InputRichBlockParagraph{
+			Type: "paragraph",
+			Text: "Below is a random image fetched for demonstration:",
+		},
+		tgbotapiv5Ovy.InputRichBlockPhoto{
+			Type:  "photo",
+			Photo: photoMedia,
+			Caption: &tgbotapiv5Ovy.RichBlockCaption{
+				Text: "📸 Random Demo Image",
+			},
+		},
+	)
+	view.SendRichMessage(chatID, richMessage)
+}
+
+// handleScramyLeaderboard sends the global Scramy top 10 as a rich message.
+func handleScramyLeaderboard(c *router.Context) {
+	bot, store, chatID := c.Bot, c.Store, c.ChatID
+
+	// Fetch real scramy leaderboard data (global)
+	photoMedia := tgbotapiv5Ovy.NewInputMediaPhoto(tgbotapiv5Ovy.FileURL("https://wallpapers.com/images/hd/celebratory-congratulations-banner-qeo95d2enk0nay3r.jpg"))
+	idCounts, err := store.CountIDOccurrences("ScramyEn", 0, repository.WindowAll)
+	if err != nil {
+		log.Printf("Error fetching scramy leaderboard: %v", err)
+		view.SendMessage(bot, chatID, "Failed to fetch leaderboard data.")
+		return
+	}
+
+	limit := 10
+	if len(idCounts) < limit {
+		limit = len(idCounts)
+	}
+
+	// Generate leaderboard image
+	// imgBytes, imgErr := service.GenerateLeaderboardImage(client, "ScramyEn", 0, "🔀 Scramy Leaderboard")
+
+	// Build table cells for the rich message
+	rankEmojis := []string{"🥇", "🥈", "🥉"}
+	cells := make([][]tgbotapiv5Ovy.RichBlockTableCell, 0, limit+1)
+	// Header row
+	cells = append(cells, []tgbotapiv5Ovy.RichBlockTableCell{
+		{Text: "Rank", IsHeader: true, Align: "center", Valign: "middle"},
+		{Text: "Player", IsHeader: true, Align: "left", Valign: "middle"},
+		{Text: "Score", IsHeader: true, Align: "center", Valign: "middle"},
+	})
+
+	for i := 0; i < limit; i++ {
+		count := idCounts[i]
+		name := fmt.Sprintf("%v", count["Name"])
+		score := fmt.Sprintf("%v", count["count"]) + " 💎"
+
+		var userID int
+		if id, ok := count["_id"]; ok {
+			switch v := id.(type) {
+			case int32:
+				userID = int(v)
+			case int64:
+				userID = int(v)
+			case int:
+				userID = v
+			}
+		}
+
+		// Fetch and append equipped emojis to the user's name
+		equippedEmojis, eqErr := store.GetEquippedEmojis(userID)
+		if eqErr == nil && len(equippedEmojis) > 0 {
+			name += " " + strings.Join(equippedEmojis, "")
+		}
+
+		rankDisplay := fmt.Sprintf("%d", i+1)
+		if i < 3 {
+			rankDisplay = rankEmojis[i]
+		} else {
+			rankDisplay = "⭐ " + rankDisplay
+		}
+
+		cells = append(cells, []tgbotapiv5Ovy.RichBlockTableCell{
+			{Text: rankDisplay, Align: "center", Valign: "middle"},
+			{Text: name, Align: "left", Valign: "middle"},
+			{Text: score, Align: "center", Valign: "middle"},
+		})
+	}
+
+	// Build the rich message blocks
+	var blocks []tgbotapiv5Ovy.InputRichBlock
+
+	blocks = append(blocks,
+		tgbotapiv5Ovy.InputRichBlockPhoto{
+			Type:  "photo",
+			Photo: photoMedia,
+			Caption: &tgbotapiv5Ovy.RichBlockCaption{
+				Text: "📸",
+			},
+		},
+		tgbotapiv5Ovy.InputRichBlockSectionHeading{
+			Type: "heading",
+			Text: "🔀 Scramy Leaderboard",
+			Size: 2,
+		},
+		tgbotapiv5Ovy.InputRichBlockParagraph{
+			Type: "paragraph",
+			Text: "Top players ranked by their Scramy scores across all groups:",
+		},
+	)
+
+	// Add the leaderboard image if generation succeeded
+	// // if imgErr == nil && len(imgBytes) > 0 {
+	// 	photoMedia := tgbotapiv5Ovy.NewInputMediaPhoto(tgbotapiv5Ovy.FileBytes{
+	// 		Name:  "leaderboard.png",
+	// 		Bytes: imgBytes,
+	// 	})
+	// 	blocks = append(blocks, tgbotapiv5Ovy.InputRichBlockPhoto{
+	// 		Type:  "photo",
+	// 		Photo: photoMedia,
+	// 	})
+	// }
+
+	blocks = append(blocks,
+		tgbotapiv5Ovy.InputRichBlockDivider{Type: "divider"},
+		tgbotapiv5Ovy.InputRichBlockTable{
+			Type:       "table",
+			Cells:      cells,
+			IsBordered: true,
+			IsStriped:  true,
+			Caption:    "🏆 Top Players",
+		},
+		tgbotapiv5Ovy.InputRichBlockParagraph{
+			Type: "paragraph",
+			Text: "✨ Keep it up and aim for the top! Play /scramy to earn more points.",
+		},
+	)
+
+	richMessage := tgbotapiv5Ovy.NewInputRichMessageBlocks(blocks...)
+	view.SendRichMessage(chatID, richMessage)
+}
+
+// handleWordCommand starts a new round unless one is running.
+func handleWordCommand(c *router.Context) {
+	bot, message, chatID := c.Bot, c.Message, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+	settings := wordguess.GetChatSettings(chatID, c.Store)
+
+	chatState.RLock()
+	wordEmpty := chatState.Word == ""
+	leadExpired := chatState.roundOver(settings)
+	chatState.RUnlock()
+
+	if wordEmpty || leadExpired {
+		word, err := wordguess.NextWord(chatID, c.Store)
+		if err != nil {
+			view.SendMessage(bot, message.Chat.ID, "Failed to fetch a word.")
+			return
+		}
+
+		buttons := createSingleButtonKeyboard(" 🗣️ Explain ", "explain")
+
+		chatState.Lock()
+		chatState.Word = word
+		chatState.HintsGiven = 0
+		chatState.User = 0
+		chatState.Leader = ""
+		chatState.Unlock()
+		saveCategoryChatStateAsync(chatID, chatState)
+
+		view.SendSticker(bot, chatID, "CAACAgUAAxkBAAEwCnNnYW-OkgV7Odt9osVwoBSzLC6vsAACMhMAAj45CFdCstMoIYiPfjYE")
+		view.SendMessageWithButtons(bot, message.Chat.ID, "The word is ready! Click 'Explain' to start explaining it.", buttons)
+	} else {
+		sentMsg, err := view.SendMessage(bot, message.Chat.ID, "A game is currently in progress.")
+		deleteWarningMessage(bot, message, sentMsg, err)
+	}
+}
+
+// handleGameCommand shows the game picker.
+func handleGameCommand(c *router.Context) {
+	bot, chatID := c.Bot, c.ChatID
+
+	buttons := createMultiButtonKeyboard([][]string{
+		{"Lead Croc 🗣️  ", "explain"},
+		{"Wordle 🟩🟨", "wordle_start"},
+		{"Scramy 𒅒𒈔𒅒", "scramy_start"},
+	})
+	view.SendMessageWithButtons(bot, chatID, "🐊 *The Crocodile peeks from the reeds, waiting...*", buttons)
+}
+
+// handleHintCommand sends a hint for the current word, from the AI when
+// AI mode is on.
+func handleHintCommand(c *router.Context) {
+	bot, message, chatID := c.Bot, c.Message, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+
+	chatState.RLock()
+	wordEmpty := chatState.Word == ""
+	lastHint := chatState.LastHintTimestamp
+	hintsGiven := chatState.HintsGiven
+	chatState.RUnlock()
+
+	if wordEmpty {
+		buttons := createSingleButtonKeyboard(" 🗣️ Explain ", "explain")
+		view.SendMessageWithButtons(bot, message.Chat.ID, "No active game right now. Click below to start one!", buttons)
+		return
+	}
+
+	if !lastHint.IsZero() && time.Since(lastHint) < 1*time.Minute {
+		sentMsg, err := view.SendMessage(bot, message.Chat.ID, "Please wait a minute before requesting another hint.")
+		deleteWarningMessage(bot, message, sentMsg, err)
+		return
+	}
+	aiModeMutex.Lock()
+	aiOn := aiModeUsers[chatID]
+	aiModeMutex.Unlock()
+
+	if aiOn {
+		// Use AI to generate hint based on current word
+		word := chatState.Word
+		if word == "" {
+			view.SendMessage(bot, chatID, "No active word to provide a hint for.")
+			return
+		}
+
+		wordChannel, errChannel := installOllama.RunOllama("Give me a riddle about a " + word)
+
+		initialMsg := tgbotapi.NewMessage(chatID, "Thinking...")
+		initialMessage, err := bot.Send(initialMsg)
+		if err != nil {
+			log.Println("Failed to send initial message:", err)
+			return
+		}
+
+		var accumulatedText string
+		for word := range wordChannel {
+			accumulatedText += word + " "
+
+			aiResponseMutex.Lock()
+			aiLastResponse[chatID] = accumulatedText
+			aiResponseMutex.Unlock()
+
+			editedMsg := tgbotapi.NewEditMessageText(chatID, initialMessage.MessageID, strings.TrimSpace(accumulatedText))
+			_, err := bot.Send(editedMsg)
+			if err != nil {
+				log.Println("Failed to update message:", err)
+			}
+		}
+
+		if err := <-errChannel; err != nil {
+			errorMsg := tgbotapi.NewMessage(chatID, err.Error())
+			_, err := bot.Send(errorMsg)
+			if err != nil {
+				log.Println("Failed to send error message:", err)
+			}
+			return
+		}
+		return
+	}
+
+	chatState.RLock()
+	word := chatState.Word
+	chatState.RUnlock()
+	hint, ok := wordguess.NextHint(wordguess.GetChatSettings(chatID, c.Store), word, hintsGiven)
+	if !ok {
+		view.SendMessage(bot, chatID, hint)
+		return
+	}
+
+	// Send chat action "typing" before sending hint
+	// chatAction := tgbotapi.NewChatAction(message.Chat.ID, tgbotapi.ChatTyping)
+	// bot.Send(chatAction)
+
+	// Send chat action "typing" before sending hint
+	chatAction := tgbotapi.NewChatAction(chatID, tgbotapi.ChatTyping)
+	bot.Send(chatAction)
+
+	// Escape MarkdownV2 special characters in hint text
+	escapedHint := escapeMarkdownV2(hint)
+
+	// Wrap escaped hint text in spoiler formatting for Telegram MarkdownV2
+	spoilerHint := "||" + escapedHint + "||"
+	msg := tgbotapi.NewMessage(chatID, spoilerHint)
+	msg.ParseMode = "MarkdownV2"
+	_, err := bot.Send(msg)
+	if err != nil {
+		log.Printf("Failed to send hint message with spoiler formatting: %v", err)
+	}
+
+	chatState.Lock()
+	chatState.LastHintTimestamp = time.Now()
+	chatState.HintsGiven = hintsGiven + 1
+	chatState.Unlock()
+	saveCategoryChatStateAsync(chatID, chatState)
+}
+
+// handleRevealCommand reveals the word once the round is old enough.
+func handleRevealCommand(c *router.Context) {
+	bot, message, chatID := c.Bot, c.Message, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+	settings := wordguess.GetChatSettings(chatID, c.Store)
+
+	chatState.RLock()
+	word := chatState.Word
+	roundOver := chatState.roundOver(settings)
+	chatState.RUnlock()
+
+	if roundOver {
+		buttons := createSingleButtonKeyboard(" 🗣️ Explain ", "explain")
+		view.SendMessageWithButtons(bot, message.Chat.ID, fmt.Sprintf("The word was: %s", word), buttons)
+
+		chatState.reset(chatID)
+	} else {
+		sentMsg, err := view.SendMessage(bot, message.Chat.ID, "Please wait until the round is over before revealing the word.")
+		deleteWarningMessage(bot, message, sentMsg, err)
+	}
+}
+
+// handleExplainCallback lets a user claim leadership or see their word again.
+func handleExplainCallback(c *router.Context) {
+	bot, callback, chatID := c.Bot, c.Callback, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+	settings := wordguess.GetChatSettings(chatID, c.Store)
+
+	chatState.Lock()
+	if chatState.User != callback.From.ID && !chatState.roundOver(settings) {
+		c.AnswerAlert(fmt.Sprintf("%s is already explaining the word. Please wait for your turn, %s.", chatState.Leader, callback.From.UserName))
+		chatState.Unlock()
+		saveCategoryChatStateAsync(chatID, chatState)
+		return
+	}
+	if chatState.User == callback.From.ID {
+		c.AnswerAlert(chatState.Word)
+		chatState.Unlock()
+		saveCategoryChatStateAsync(chatID, chatState)
+		return
+	}
+	if chatState.roundOver(settings) {
+		if !commands.ClaimTurn(c) {
+			chatState.Unlock()
+			return
+		}
+		chatState.User = callback.From.ID
+		word, err := wordguess.NextWord(chatID, c.Store)
+		if err != nil {
+			chatState.Unlock()
+			saveCategoryChatStateAsync(chatID, chatState)
+			return
+		}
+		buttons := createCategoryBotKeyboard(bot.Self.UserName, chatID)
+		chatState.Word = word
+		chatState.HintsGiven = 0
+		commands.TurnTaken(chatID, callback.From.ID)
+		view.SendMessageWithButtons(bot, callback.Message.Chat.ID, fmt.Sprintf(" [%s](tg://user?id=%d) is explaining the word!", callback.From.FirstName, callback.From.ID), buttons)
+
+		// Remove the inline keyboard (buttons) from the "claim leadership" message when someone starts leading
+		editMarkup := tgbotapi.NewEditMessageReplyMarkup(callback.Message.Chat.ID, callback.Message.MessageID, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
+		_, err = bot.Send(editMarkup)
+		if err != nil {
+			log.Printf("Failed to remove inline keyboard: %v", err)
+		}
+	}
+	chatState.Leader = callback.From.FirstName
+	chatState.LeadTimestamp = time.Now()
+	chatState.Unlock()
+	saveCategoryChatStateAsync(chatID, chatState)
+	c.AnswerAlert(chatState.Word)
+	if !c.IsPrivate() {
+		roundTimers.Start(chatID)
+	}
+}
+
+// handleAIHintCallback asks the AI to explain the current word.
+func handleAIHintCallback(c *router.Context) {
+	bot, chatID := c.Bot, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+
+	c.Answer("feature under maintenance.")
+	aiResponseMutex.RLock()
+	aiResponseMutex.RUnlock()
+	word := chatState.Word
+	if word == "" {
+		view.SendMessage(bot, chatID, "No active word to provide a hint for.")
+		return
+	}
+
+	wordChannel, errChannel := installOllama.RunOllama("Explain \"" + word + "\"")
+
+	initialMsg := tgbotapi.NewMessage(chatID, "Thinking...")
+	initialMessage, err := bot.Send(initialMsg)
+	if err != nil {
+		log.Println("Failed to send initial message:", err)
+		return
+	}
+
+	var accumulatedText string
+	for word := range wordChannel {
+		word = replaceWord(word, chatState.Word)
+		accumulatedText += word + " "
+
+		aiResponseMutex.Lock()
+		aiLastResponse[chatID] = accumulatedText
+		aiResponseMutex.Unlock()
+
+		editedMsg := tgbotapi.NewEditMessageText(chatID, initialMessage.MessageID, strings.TrimSpace(accumulatedText))
+		_, err := bot.Send(editedMsg)
+		if err != nil {
+			log.Println("Failed to update message:", err)
+		}
+	}
+
+	if err := <-errChannel; err != nil {
+		errorMsg := tgbotapi.NewMessage(chatID, err.Error())
+		_, err := bot.Send(errorMsg)
+		if err != nil {
+			log.Println("Failed to send error message:", err)
+		}
+		return
+	}
+
+	// Generate a simple follow-up hint by truncating or modifying the last AI response
+	hint := accumulatedText
+	if len(hint) > 100 {
+		hint = hint[:100] + "..."
+	}
+	view.SendMessage(bot, chatID, "💡 AI Hint: "+hint)
+}
+
+// categoryCallback gives the leader a fresh word from a category.
+func categoryCallback(next func() (string, error)) router.HandlerFunc {
+	return func(c *router.Context) {
+		callback, chatID := c.Callback, c.ChatID
+		chatState := getOrCreateChatState(chatID)
+
+		chatState.Lock()
+		if chatState.User != callback.From.ID && chatState.User != 0 {
+			c.AnswerAlert(fmt.Sprintf("someone is already explaining the word. %s", callback.From.UserName))
+			chatState.Unlock()
+			saveCategoryChatStateAsync(chatID, chatState)
+			return
+		}
+		if chatState.User == 0 {
+			c.AnswerAlert(fmt.Sprintf("%s Please click on see word/claim Leadership", callback.From.FirstName))
+			chatState.Unlock()
+			saveCategoryChatStateAsync(chatID, chatState)
+			return
+		}
+		chatState.User = callback.From.ID
+		word, err := next()
+		if err != nil {
+			c.AnswerAlert("Failed to get a word. Please try again later.")
+			chatState.Unlock()
+			saveCategoryChatStateAsync(chatID, chatState)
+			return
+		}
+		chatState.Word = word
+		chatState.HintsGiven = 0
+		chatState.Unlock()
+		saveCategoryChatStateAsync(chatID, chatState)
+		c.AnswerAlert(chatState.Word)
+	}
+}
+
+// handleNextCallback gives the leader a fresh word.
+func handleNextCallback(c *router.Context) {
+	callback, chatID := c.Callback, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+
+	chatState.Lock()
+	if chatState.User != callback.From.ID && chatState.User != 0 {
+		c.AnswerAlert(fmt.Sprintf("%s is already explaining the word. %s", chatState.Leader, callback.From.UserName))
+		chatState.Unlock()
+		saveCategoryChatStateAsync(chatID, chatState)
+		return
+	}
+	if chatState.User == 0 {
+		c.AnswerAlert(fmt.Sprintf("%s Please click on see word/claim Leadership", callback.From.FirstName))
+		chatState.Unlock()
+		saveCategoryChatStateAsync(chatID, chatState)
+		return
+	}
+	chatState.User = callback.From.ID
+	chatState.Leader = callback.From.FirstName
+	chatState.Word, _ = wordguess.NextWord(chatID, c.Store)
+	chatState.HintsGiven = 0
+	chatState.Unlock()
+	saveCategoryChatStateAsync(chatID, chatState)
+	c.AnswerAlert(chatState.Word)
+}
+
+// handleDropLeadCallback lets the leader give up the round.
+func handleDropLeadCallback(c *router.Context) {
+	bot, callback, chatID := c.Bot, c.Callback, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+
+	chatState.Lock()
+	if chatState.User != callback.From.ID {
+		c.AnswerAlert("You are not the current leader, so you cannot drop the lead!")
+		chatState.Unlock()
+		saveCategoryChatStateAsync(chatID, chatState)
+		return
+	}
+	// Delete the callback message when user selects "Changed my mind"
+	deleteMsg := tgbotapi.NewDeleteMessage(callback.Message.Chat.ID, callback.Message.MessageID)
+	_, err := bot.DeleteMessage(deleteMsg)
+	if err != nil {
+		log.Printf("Failed to delete message on droplead: %v", err)
+	}
+	chatState.Unlock()
+	saveCategoryChatStateAsync(chatID, chatState)
+	buttons := createSingleButtonKeyboard("🌟 Claim Leadership 🙋", "explain")
+	view.SendMessageWithButtons(bot, callback.Message.Chat.ID, fmt.Sprintf("%s refused to lead -> %s \n", callback.From.FirstName, chatState.Word), buttons)
+	chatState.reset(chatID)
+}
+
+// handleHintCallback sends a hint from the hint button.
+func handleHintCallback(c *router.Context) {
+	bot, callback, chatID := c.Bot, c.Callback, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+
+	chatState.RLock()
+	wordEmpty := chatState.Word == ""
+	lastHint := chatState.LastHintTimestamp
+	hintsGiven := chatState.HintsGiven
+	chatState.RUnlock()
+
+	if wordEmpty {
+		view.SendMessage(bot, callback.Message.Chat.ID, "No active game right now. Click below to start one! \n  __		/start		__")
+		return
+	}
+
+	if !lastHint.IsZero() && time.Since(lastHint) < 1*time.Minute {
+		sentMsg, err := view.SendMessage(bot, chatID, "Please take a minute before requesting another hint.")
+		deleteWarningMessage(bot, callback.Message, sentMsg, err)
+		return
+	}
+
+	chatState.RLock()
+	word := chatState.Word
+	chatState.RUnlock()
+	hint, ok := wordguess.NextHint(wordguess.GetChatSettings(chatID, c.Store), word, hintsGiven)
+	if !ok {
+		view.SendMessage(bot, chatID, hint)
+		return
+	}
+
+	chatAction := tgbotapi.NewChatAction(callback.Message.Chat.ID, tgbotapi.ChatTyping)
+	bot.Send(chatAction)
+
+	escapedHint := escapeMarkdownV2(hint)
+	spoilerHint := "||" + escapedHint + "||"
+	msg := tgbotapi.NewMessage(callback.Message.Chat.ID, spoilerHint)
+	msg.ParseMode = "MarkdownV2"
+	_, err := bot.Send(msg)
+	if err != nil {
+		log.Printf("Failed to send hint message with spoiler formatting: %v", err)
+	}
+
+	chatState.Lock()
+	chatState.LastHintTimestamp = time.Now()
+	chatState.HintsGiven = hintsGiven + 1
+	chatState.Unlock()
+	saveCategoryChatStateAsync(chatID, chatState)
+}
@@ -9,18 +9,17 @@ import (
 
 	model "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model/collectible"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
-	collectibleRepo "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository/collectible"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/collectible"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/ledger"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 var pendingMarketplaceListing = make(map[int64]string) // map[userID]itemID
 var pendingMarketplaceMutex sync.Mutex
 
-func ShowHub(bot *tgbotapi.BotAPI, chatID int64, userID int, client *mongo.Client) {
+func ShowHub(bot *tgbotapi.BotAPI, chatID int64, userID int, store repository.Store) {
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("🎁 Buy Pack (201 🪙)", "collectible_buy_pack"),
@@ -31,37 +30,37 @@ func ShowHub(bot *tgbotapi.BotAPI, chatID int64, userID int, client *mongo.Clien
 		),
 	)
 
-	points := repository.GetCurrentPoints(client, userID)
+	points := store.GetCurrentPoints(userID)
 	text := fmt.Sprintf("🌟 *Welcome to the Collectibles Hub!* 🌟\n\nOpen packs to discover unique anime-themed items. Trade them on the marketplace or build the ultimate collection!\n\n💰 *Your Balance:* %d 🪙", points)
 
 	view.SendMessageWithButtons(bot, chatID, text, markup)
 }
 
-func HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client *mongo.Client) bool {
+func HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, store repository.Store) bool {
 	data := callback.Data
 
 	if data == "collectible_hub" {
-		ShowHub(bot, callback.Message.Chat.ID, int(callback.From.ID), client)
+		ShowHub(bot, callback.Message.Chat.ID, int(callback.From.ID), store)
 		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
 		return true
 	} else if data == "collectible_buy_pack" {
-		handleBuyPack(bot, callback, client)
+		handleBuyPack(bot, callback, store)
 		return true
 	} else if data == "collectible_inventory" {
-		showInventory(bot, callback, client, 0)
+		showInventory(bot, callback, store, 0)
 		return true
 	} else if strings.HasPrefix(data, "collectible_inventory_page_") {
 		pageStr := strings.TrimPrefix(data, "collectible_inventory_page_")
 		page, _ := strconv.Atoi(pageStr)
-		showInventory(bot, callback, client, page)
+		showInventory(bot, callback, store, page)
 		return true
 	} else if data == "collectible_market" {
-		showMarketplace(bot, callback, client, 0)
+		showMarketplace(bot, callback, store, 0)
 		return true
 	} else if strings.HasPrefix(data, "collectible_market_page_") {
 		pageStr := strings.TrimPrefix(data, "collectible_market_page_")
 		page, _ := strconv.Atoi(pageStr)
-		showMarketplace(bot, callback, client, page)
+		showMarketplace(bot, callback, store, page)
 		return true
 	} else if strings.HasPrefix(data, "collectible_sell_") {
 		itemID := strings.TrimPrefix(data, "collectible_sell_")
@@ -69,15 +68,15 @@ func HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, clie
 		return true
 	} else if strings.HasPrefix(data, "collectible_buy_listing_") {
 		listingID := strings.TrimPrefix(data, "collectible_buy_listing_")
-		handleBuyListing(bot, callback, client, listingID)
+		handleBuyListing(bot, callback, store, listingID)
 		return true
 	}
 
 	return false
 }
 
-func handleBuyPack(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client *mongo.Client) {
-	item, template, err := collectible.OpenPack(client, int(callback.From.ID), callback.From.FirstName, callback.Message.Chat.ID)
+func handleBuyPack(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, store repository.Store) {
+	item, template, err := collectible.OpenPack(store, ledger.Key("pack", callback.ID), int(callback.From.ID), callback.From.FirstName, callback.Message.Chat.ID)
 
 	if err != nil {
 		if err.Error() == "not enough points" {
@@ -128,15 +127,15 @@ func handleBuyPack(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, clien
 	bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, "Pack opened!"))
 }
 
-func showInventory(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client *mongo.Client, page int) {
-	items, templates, err := collectible.GetUserInventoryWithTemplates(client, int(callback.From.ID))
+func showInventory(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, store repository.Store, page int) {
+	items, templates, err := collectible.GetUserInventoryWithTemplates(store, int(callback.From.ID))
 	if err != nil {
 		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, "Error loading inventory!"))
 		return
 	}
 
 	// Pre-fetch listings to know which items are currently on the market
-	listings, err := collectibleRepo.GetListings(client)
+	listings, err := store.GetListings()
 	var listingsErr bool
 	if err != nil {
 		listingsErr = true
@@ -219,8 +218,8 @@ func showInventory(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, clien
 	bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
 }
 
-func showMarketplace(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client *mongo.Client, page int) {
-	listings, items, templates, err := collectible.GetMarketplaceListingsWithDetails(client)
+func showMarketplace(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, store repository.Store, page int) {
+	listings, items, templates, err := collectible.GetMarketplaceListingsWithDetails(store)
 	if err != nil {
 		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, "Error loading marketplace!"))
 		return
@@ -303,8 +302,8 @@ func promptSellPrice(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, ite
 	bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
 }
 
-func handleBuyListing(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client *mongo.Client, listingID string) {
-	err := collectible.BuyItemFromMarketplace(client, listingID, int(callback.From.ID), callback.From.FirstName, callback.Message.Chat.ID)
+func handleBuyListing(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, store repository.Store, listingID string) {
+	err := collectible.BuyItemFromMarketplace(store, listingID, int(callback.From.ID), callback.From.FirstName, callback.Message.Chat.ID)
 
 	if err != nil {
 		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, "Purchase failed: "+err.Error()))
@@ -312,11 +311,11 @@ func handleBuyListing(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, cl
 	}
 
 	bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, "Successfully purchased item!"))
-	showMarketplace(bot, callback, client, 0)
+	showMarketplace(bot, callback, store, 0)
 }
 
 // CheckAndHandlePendingMarketplaceListing checks if a user is trying to list an item and processes the text as a price
-func CheckAndHandlePendingMarketplaceListing(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client) bool {
+func CheckAndHandlePendingMarketplaceListing(bot *tgbotapi.BotAPI, message *tgbotapi.Message, store repository.Store) bool {
 	userID := int64(message.From.ID)
 
 	pendingMarketplaceMutex.Lock()
@@ -344,16 +343,17 @@ func CheckAndHandlePendingMarketplaceListing(bot *tgbotapi.BotAPI, message *tgbo
 	}
 
 	// Verify ownership before listing
-	item, err := collectibleRepo.GetItemByID(client, itemID)
+	item, err := store.GetItemByID(itemID)
 	if err != nil || item.OwnerID != int(message.From.ID) {
 		view.SendMessage(bot, message.Chat.ID, "Failed to list item: Item not found or you don't own it.")
 		return true
 	}
 
-	err = collectibleRepo.CreateListing(client, model.MarketListing{
-		ItemID:   itemID,
-		SellerID: int(message.From.ID),
-		Price:    price,
+	err = store.CreateListing(model.MarketListing{
+		ItemID:     itemID,
+		SellerID:   int(message.From.ID),
+		SellerName: message.From.FirstName,
+		Price:      price,
 	})
 
 	if err != nil {
@@ -0,0 +1,324 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+
+	collectibleController "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/collectible"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/geographybot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/scramybot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/wordlebot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SettingsMenu is the top-level /setting keyboard.
+func SettingsMenu() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Wordle View 🖼️", "setting_wordle_view"),
+			tgbotapi.NewInlineKeyboardButtonData("Wordle Color 🎨", "setting_wordle_color"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Scramy Letters 🔠", "setting_scramy_letters"),
+			tgbotapi.NewInlineKeyboardButtonData("Geography Settings 🌍", "setting_geography_main"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Word Game 🐊", "setting_wordguess_main"),
+			tgbotapi.NewInlineKeyboardButtonData("Close Guesses 🤏", "setting_closeguess"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Wordle Rules 📏", "setting_wordle_rules"),
+		),
+	)
+}
+
+// GeographyModeMenu lets a chat pick between multiple choice and typed answers.
+func GeographyModeMenu(back bool) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("MCQ Mode (Multiple Choice)", "setting_geo_mode_mcq")),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Text Guess Mode", "setting_geo_mode_text")),
+	}
+	if back {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "setting_geography_main")))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// GeographyModeText explains the two Geography modes.
+const GeographyModeText = "⚙️ *Geography Mode*\nChoose how you want to play Geography:\n- *MCQ Mode*: Buttons to select the answer.\n- *Text Guess Mode*: Type out your guess (5 attempts)."
+
+func geographyQuestionsMenu(chatID int64, client *mongo.Client) tgbotapi.InlineKeyboardMarkup {
+	settings := geographybot.GetChatSettings(chatID, client)
+	getBtn := func(key, label string) string {
+		if settings.QuestionTypes[key] {
+			return "✅ " + label
+		}
+		return "❌ " + label
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(getBtn("capital", "Capital"), "toggle_geo_capital"),
+			tgbotapi.NewInlineKeyboardButtonData(getBtn("flag", "Flag"), "toggle_geo_flag"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(getBtn("region", "Region"), "toggle_geo_region"),
+			tgbotapi.NewInlineKeyboardButtonData(getBtn("landmark", "Landmark (Image)"), "toggle_geo_landmark"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(getBtn("country_from_capital", "Country from Capital"), "toggle_geo_country_from_capital"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(getBtn("landmark_name", "Landmark Name (Image)"), "toggle_geo_landmark_name"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "setting_geography_main"),
+		),
+	)
+}
+
+// editMenu replaces the pressed message with a Markdown menu.
+func editMenu(c *router.Context, text string, buttons tgbotapi.InlineKeyboardMarkup) {
+	editMsg := tgbotapi.NewEditMessageText(c.ChatID, c.Message.MessageID, text)
+	editMsg.ReplyMarkup = &buttons
+	editMsg.ParseMode = tgbotapi.ModeMarkdown
+	c.Bot.Send(editMsg)
+}
+
+// editButtons swaps only the keyboard of the pressed message, leaving the game
+// board above it untouched.
+func editButtons(c *router.Context, buttons tgbotapi.InlineKeyboardMarkup) {
+	c.Bot.Send(tgbotapi.NewEditMessageReplyMarkup(c.ChatID, c.Message.MessageID, buttons))
+}
+
+func backToSettings() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Settings", "settings_main")))
+}
+
+// gameSetting is one choice in the Wordle and Scramy settings menus. It is
+// reachable from /setting (data) and from the live game board (data+"_new").
+type gameSetting struct {
+	data    string
+	apply   func(chatID int64, store repository.Store) error
+	refresh func(bot *tgbotapi.BotAPI, chatID int64, messageID int, store repository.Store)
+	updated string // confirmation shown in the settings menu
+	toast   string
+}
+
+var gameSettings = []gameSetting{
+	{"set_scramy_squared", scramyLetters("squared"), scramybot.RefreshActiveGameMessage, "✅ Scramy letters updated to *Squared*.", "Scramy set to Squared"},
+	{"set_scramy_normal", scramyLetters("normal"), scramybot.RefreshActiveGameMessage, "✅ Scramy letters updated to *Normal*.", "Scramy set to Normal"},
+	{"set_scramy_h1", scramyLetters("h1"), scramybot.RefreshActiveGameMessage, "✅ Scramy letters updated to *H1*.", "Scramy set to H1"},
+	{"set_wordle_view_text", wordleView("text"), wordlebot.RefreshActiveGameMessage, "✅ Wordle view updated to *Text*.", "View set to Text"},
+	{"set_wordle_view_image", wordleView("image"), wordlebot.RefreshActiveGameMessage, "✅ Wordle view updated to *Image*.", "View set to Image"},
+	{"set_wordle_color_classic", wordleColor("classic"), wordlebot.RefreshActiveGameMessage, "✅ Wordle color updated to *Classic* (🟥).", "Color set to Classic"},
+	{"set_wordle_color_dark", wordleColor("dark"), wordlebot.RefreshActiveGameMessage, "✅ Wordle color updated to *Dark* (⬛).", "Color set to Dark"},
+	{"set_wordle_color_light", wordleColor("light"), wordlebot.RefreshActiveGameMessage, "✅ Wordle color updated to *Light* (⬜).", "Color set to Light"},
+}
+
+func scramyLetters(v string) func(int64, repository.Store) error {
+	return func(chatID int64, store repository.Store) error {
+		return scramybot.UpdateScramyLetterView(chatID, v, store)
+	}
+}
+
+func wordleView(v string) func(int64, repository.Store) error {
+	return func(chatID int64, store repository.Store) error {
+		return wordlebot.UpdateWordleViewType(chatID, v, store)
+	}
+}
+
+func wordleColor(v string) func(int64, repository.Store) error {
+	return func(chatID int64, store repository.Store) error { return wordlebot.UpdateWordleColor(chatID, v, store) }
+}
+
+func registerCallbacks(r *router.Router, e *game.Engine) {
+	r.Callback(router.Callback{Prefix: "statsglobal_", Handler: showLeaderboard(true)})
+	r.Callback(router.Callback{Prefix: "statsgroup_", Handler: showLeaderboard(false)})
+	r.Callback(router.Callback{Prefix: "statsimg_global_", Handler: switchLeaderboardImage})
+	r.Callback(router.Callback{Prefix: "statsimg_group_", Handler: switchLeaderboardImage})
+
+	r.Callback(router.Callback{Data: "mystats_main", Handler: func(c *router.Context) {
+		buttons := myStatsMenu()
+		editMsg := tgbotapi.NewEditMessageText(c.ChatID, c.Message.MessageID, "🐊🇮🇳\n📊 Choose game stats to view:")
+		editMsg.ReplyMarkup = &buttons
+		c.Bot.Send(editMsg)
+	}})
+	userStats := map[string]func(*mongo.Client, int) string{
+		"stats_wordguess": service.GetUserStatsByID,
+		"stats_wordle":    service.GetWordleUserStatsByID,
+		"stats_scramy":    service.GetScramyUserStatsByID,
+	}
+	for data, stats := range userStats {
+		r.Callback(router.Callback{Data: data, Handler: func(c *router.Context) {
+			buttons := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "mystats_main")))
+			editMsg := tgbotapi.NewEditMessageText(c.ChatID, c.Message.MessageID, stats(c.Client, c.UserID))
+			editMsg.ReplyMarkup = &buttons
+			editMsg.ParseMode = tgbotapi.ModeHTML
+			c.Bot.Send(editMsg)
+		}})
+	}
+
+	registerSettingsCallbacks(r)
+
+	registerGameButtons(r, e)
+	r.Callback(router.Callback{Data: "cancel_new_wordle", Handler: func(c *router.Context) {
+		if wordlebot.CancelPendingGame(c.Bot, c.ChatID, c.FirstName(), c.Store) {
+			c.Answer("Cancelled new game request.")
+		} else {
+			c.Answer("No pending game request to cancel.")
+		}
+	}})
+	r.Callback(router.Callback{Data: "cancel_new_scramy", Handler: func(c *router.Context) {
+		if scramybot.CancelPendingGame(c.Bot, c.ChatID, c.FirstName(), c.Store) {
+			c.Answer("Cancelled new Scramy game request.")
+		} else {
+			c.Answer("No pending game request to cancel.")
+		}
+	}})
+	r.Callback(router.Callback{Data: "refresh_wordle_game", Handler: func(c *router.Context) {
+		wordlebot.RefreshActiveGameMessage(c.Bot, c.ChatID, c.Message.MessageID, c.Store)
+	}})
+	r.Callback(router.Callback{Data: "refresh_scramy_game", Handler: func(c *router.Context) {
+		scramybot.RefreshActiveGameMessage(c.Bot, c.ChatID, c.Message.MessageID, c.Store)
+	}})
+
+	r.Callback(router.Callback{Prefix: "geo_ans_", Handler: func(c *router.Context) {
+		cb := c.Callback
+		res := geographybot.HandleGeographyCallback(c.Bot, c.ChatID, cb.From.ID, cb.From.FirstName, cb.Data, cb.ID, c.Message.MessageID, c.Client)
+		e.Finish(geographybot.Game.Info().Name, res)
+	}})
+
+	// The shop and the collectibles hub answer their own callbacks.
+	r.Callback(router.Callback{Data: "inventory", Handler: handleShopCallback})
+	r.Callback(router.Callback{Data: "shop_main", Handler: handleShopCallback})
+	r.Callback(router.Callback{Prefix: "buy_emoji_", Handler: handleShopCallback})
+	r.Callback(router.Callback{Prefix: "equip_emoji_", Handler: handleShopCallback})
+	r.Callback(router.Callback{Prefix: "collectible_", Handler: func(c *router.Context) {
+		collectibleController.HandleCallback(c.Bot, c.Callback, c.Store)
+	}})
+}
+
+func registerSettingsCallbacks(r *router.Router) {
+	registerWordGuessSettings(r)
+	registerCloseGuessSettings(r)
+	registerWordleRulesSettings(r)
+
+	r.Callback(router.Callback{Data: "settings_main", Handler: func(c *router.Context) {
+		editMenu(c, "⚙️ *Settings*\nChoose a setting to configure:", SettingsMenu())
+	}})
+
+	r.Callback(router.Callback{Data: "setting_geography_main", Handler: func(c *router.Context) {
+		editMenu(c, "⚙️ *Geography Settings*\nChoose a setting to configure:", tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Mode (MCQ/Text) 🎮", "setting_geography_mode")),
+			tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Question Types ❓", "setting_geo_questions")),
+			tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "settings_main")),
+		))
+	}})
+	r.Callback(router.Callback{Data: "setting_geography_mode", Handler: func(c *router.Context) {
+		editMenu(c, GeographyModeText, GeographyModeMenu(true))
+	}})
+	r.Callback(router.Callback{Data: "setting_geo_questions", Handler: func(c *router.Context) {
+		editMenu(c, "⚙️ *Geography Question Types*\nToggle which types of questions can appear:", geographyQuestionsMenu(c.ChatID, c.Client))
+	}})
+	r.Callback(router.Callback{Prefix: "setting_geo_mode_", Handler: func(c *router.Context) {
+		mode, modeStr := "mcq", "MCQ Mode"
+		if c.Args() == "text" {
+			mode, modeStr = "text", "Text Guess Mode"
+		}
+		if err := geographybot.UpdateGeographyMode(c.ChatID, mode, c.Client); err != nil {
+			log.Printf("Failed to update geography mode: %v", err)
+			c.Answer("Failed to update setting.")
+			return
+		}
+		editMenu(c, fmt.Sprintf("✅ *Geography mode updated to %s!*", modeStr), tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back to Geography Settings", "setting_geography_main")),
+		))
+		c.Answer("Settings saved!")
+	}})
+	r.Callback(router.Callback{Prefix: "toggle_geo_", Handler: func(c *router.Context) {
+		if err := geographybot.ToggleGeographyQuestionType(c.ChatID, c.Args(), c.Client); err != nil {
+			log.Printf("Failed to toggle geography question type: %v", err)
+			c.Answer("Failed to update setting")
+			return
+		}
+		editButtons(c, geographyQuestionsMenu(c.ChatID, c.Client))
+	}})
+
+	r.Callback(router.Callback{Data: "setting_scramy_letters", Handler: func(c *router.Context) {
+		editMenu(c, "⚙️ *Scramy Letters Setting*\nChoose the letter style for Scramy:", tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("Squared 🔠", "set_scramy_squared"),
+				tgbotapi.NewInlineKeyboardButtonData("Normal abc", "set_scramy_normal"),
+			),
+			tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "settings_main")),
+		))
+	}})
+	r.Callback(router.Callback{Data: "setting_scramy_letters_new", Handler: func(c *router.Context) {
+		editButtons(c, tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("Squared 🔠", "set_scramy_squared_new"),
+				tgbotapi.NewInlineKeyboardButtonData("Normal abc", "set_scramy_normal_new"),
+				tgbotapi.NewInlineKeyboardButtonData("H1 📝", "set_scramy_h1_new"),
+			),
+			tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "refresh_scramy_game")),
+		))
+	}})
+	r.Callback(router.Callback{Data: "setting_wordle_color", Handler: func(c *router.Context) {
+		editMenu(c, "⚙️ *Wordle Color Setting*\nChoose the color used for missing letters:", wordleColorMenu("", "settings_main"))
+	}})
+	r.Callback(router.Callback{Data: "setting_wordle_color_new", Handler: func(c *router.Context) {
+		editButtons(c, wordleColorMenu("_new", "refresh_wordle_game"))
+	}})
+	r.Callback(router.Callback{Data: "setting_wordle_view", Handler: func(c *router.Context) {
+		editMenu(c, "⚙️ *Wordle View Setting*\nChoose how you want Wordle results to be displayed:", wordleViewMenu("", "settings_main"))
+	}})
+	r.Callback(router.Callback{Data: "setting_wordle_view_new", Handler: func(c *router.Context) {
+		editButtons(c, wordleViewMenu("_new", "refresh_wordle_game"))
+	}})
+
+	for _, s := range gameSettings {
+		r.Callback(router.Callback{Data: s.data, Handler: func(c *router.Context) {
+			s.apply(c.ChatID, c.Store)
+			editMenu(c, s.updated, backToSettings())
+			c.Answer(s.toast)
+		}})
+		r.Callback(router.Callback{Data: s.data + "_new", Handler: func(c *router.Context) {
+			s.apply(c.ChatID, c.Store)
+			s.refresh(c.Bot, c.ChatID, c.Message.MessageID, c.Store)
+			c.Answer(s.toast)
+		}})
+	}
+}
+
+func wordleColorMenu(suffix, back string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Classic (🟥)", "set_wordle_color_classic"+suffix),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Dark Mode (⬛)", "set_wordle_color_dark"+suffix),
+			tgbotapi.NewInlineKeyboardButtonData("Light Mode (⬜)", "set_wordle_color_light"+suffix),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔙 Back", back),
+		),
+	)
+}
+
+func wordleViewMenu(suffix, back string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Text View 📝", "set_wordle_view_text"+suffix),
+			tgbotapi.NewInlineKeyboardButtonData("Image View 🖼️", "set_wordle_view_image"+suffix),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔙 Back", back),
+		),
+	)
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"log"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/closeguess"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const closeGuessText = "⚙️ *Close Guesses*\nWhether a guess one or two letters off gets a 👀 reaction or a \"so close!\" reply in the word game, Anime and Geography text mode. Replies come at most every 30 seconds."
+
+func closeGuessMenu(on bool) tgbotapi.InlineKeyboardMarkup {
+	onLabel, offLabel := "On 🤏", "Off 🔇"
+	if on {
+		onLabel = "✅ " + onLabel
+	} else {
+		offLabel = "✅ " + offLabel
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(onLabel, "set_closeguess_on"),
+			tgbotapi.NewInlineKeyboardButtonData(offLabel, "set_closeguess_off"),
+		),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "settings_main")),
+	)
+}
+
+func registerCloseGuessSettings(r *router.Router) {
+	r.Callback(router.Callback{Data: "setting_closeguess", Handler: func(c *router.Context) {
+		editMenu(c, closeGuessText, closeGuessMenu(closeguess.Enabled(c.ChatID, c.Store)))
+	}})
+	r.Callback(router.Callback{Prefix: "set_closeguess_", Handler: func(c *router.Context) {
+		var on bool
+		switch c.Args() {
+		case "on":
+			on = true
+		case "off":
+		default:
+			return
+		}
+		if err := closeguess.SetEnabled(c.ChatID, on, c.Store); err != nil {
+			log.Printf("Failed to update the close-guess setting of chat %d: %v", c.ChatID, err)
+			c.Answer("Failed to update setting.")
+			return
+		}
+		editButtons(c, closeGuessMenu(on))
+		c.Answer("Settings saved!")
+	}})
+}
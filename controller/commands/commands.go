@@ -0,0 +1,302 @@
+// Package commands holds the commands and buttons the word game bots have in
+// common. Each bot registers these on its router and adds its own on top.
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	collectibleController "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/collectible"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+	installOllama "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/installOllama"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/ledger"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Register adds the shared commands and callbacks to r. The game commands
+// start, cancel and hint the games run by e.
+func Register(r *router.Router, e *game.Engine) {
+	registerGames(r, e)
+	registerStats(r)
+	registerRating(r, e)
+	registerAccount(r)
+	registerPrivacy(r)
+	registerWordPacks(r)
+	registerQueue(r)
+	registerTeams(r)
+	registerAdmin(r)
+	registerCallbacks(r, e)
+
+	r.Command(router.Command{Name: "help", Description: "List the available commands", Handler: func(c *router.Context) {
+		view.SendMessagehtml(c.Bot, c.ChatID, "<b>Commands</b>\n\n"+r.Help(c.IsPrivate()))
+	}})
+}
+
+func registerStats(r *router.Router) {
+	noGroupStats := func(c *router.Context) {
+		view.SendMessage(c.Bot, c.ChatID, "Group stats are not available in a DM. You can view global stats using /statsglobal or /leaderstatsglobal.")
+	}
+
+	r.Command(router.Command{Name: "stats", Description: "Group leaderboards", Scope: router.GroupOnly, Handler: func(c *router.Context) {
+		view.SendMessageWithButtons(c.Bot, c.ChatID, "🐊🇮🇳\n📊 Choose group stats to view:", leaderboardMenu("statsgroup_", "Group", false))
+	}})
+	r.Command(router.Command{Name: "stats", Scope: router.PrivateOnly, Handler: noGroupStats})
+	r.Command(router.Command{Name: "statsimage", Description: "Group leaderboards as an image", Scope: router.GroupOnly, Handler: func(c *router.Context) {
+		sendLeaderboardImage(c, "statsimg_group_", "wordguess")
+	}})
+	r.Command(router.Command{Name: "leaderstats", Description: "Best explainers in this group", Scope: router.GroupOnly, Handler: func(c *router.Context) {
+		view.SendMessagehtml(c.Bot, c.ChatID, service.LeaderBoardList(c.Client, "CrocEnLeader", c.ChatID))
+	}})
+	r.Command(router.Command{Name: "leaderstats", Scope: router.PrivateOnly, Handler: noGroupStats})
+	r.Command(router.Command{Name: "statsglobal", Description: "Global leaderboards", Handler: func(c *router.Context) {
+		view.SendMessageWithButtons(c.Bot, c.ChatID, "🐊🇮🇳\n📊 Choose global stats to view:", leaderboardMenu("statsglobal_", "Global", true))
+	}})
+	r.Command(router.Command{Name: "statsimageglobal", Description: "Global leaderboards as an image", Handler: func(c *router.Context) {
+		sendLeaderboardImage(c, "statsimg_global_", "wordguess")
+	}})
+	r.Command(router.Command{Name: "leaderstatsglobal", Description: "Best explainers everywhere", Handler: func(c *router.Context) {
+		view.SendMessagehtml(c.Bot, c.ChatID, service.LeaderBoardList(c.Client, "CrocEnLeader", 0))
+	}})
+	r.Command(router.Command{Name: "season", Description: "Current season, or /season <n> for a past one", Handler: showSeason})
+	r.Command(router.Command{Name: "mystats", Description: "Your own stats", Handler: func(c *router.Context) {
+		view.SendMessageWithButtons(c.Bot, c.ChatID, "🐊🇮🇳\n📊 Choose game stats to view:", myStatsMenu())
+	}})
+}
+
+// showSeason sends the final standings of the season named in the arguments:
+// the group's in a group, the global ones in private. Without a number it
+// describes the running season.
+func showSeason(c *router.Context) {
+	arg := strings.TrimSpace(c.Args())
+	first, err := service.FirstSeason(c.Store, time.Now())
+	if err != nil {
+		log.Printf("Failed to load the first season: %v", err)
+		view.SendMessage(c.Bot, c.ChatID, "Failed to load that season. Please try again later.")
+		return
+	}
+	if arg == "" {
+		view.SendMessagehtml(c.Bot, c.ChatID, service.FormatCurrentSeason(time.Now(), first))
+		return
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 {
+		view.SendMessage(c.Bot, c.ChatID, "Usage: /season <number>, e.g. /season 1")
+		return
+	}
+	season, err := c.Store.GetSeason(n)
+	if err == repository.ErrSeasonNotFound {
+		switch {
+		case n >= config.App.Seasons.Number(time.Now()):
+			view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("Season %d has not finished yet.", n))
+		case n < first:
+			view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("Season %d ended before seasons started here, so it has no standings.", n))
+		default:
+			view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("Season %d has finished but its standings are not archived yet. Try again in an hour.", n))
+		}
+		return
+	} else if err != nil {
+		log.Printf("Failed to load season %d: %v", n, err)
+		view.SendMessage(c.Bot, c.ChatID, "Failed to load that season. Please try again later.")
+		return
+	}
+	chatID := c.ChatID
+	if c.IsPrivate() {
+		chatID = 0
+	}
+	view.SendMessagehtml(c.Bot, c.ChatID, service.FormatSeason(season, chatID))
+}
+
+func registerAccount(r *router.Router) {
+	r.Command(router.Command{Name: "rules", Description: "How to play", Scope: router.PrivateOnly, Handler: func(c *router.Context) {
+		sendRules(c, "🏅 — Claim leadership via button or `/word` command.\n")
+	}})
+	r.Command(router.Command{Name: "rules", Description: "How to play", Scope: router.GroupOnly, Handler: func(c *router.Context) {
+		sendRules(c, "🏅 — Claim leadership by clicking 'Explain' or using the appropriate command.\n")
+	}})
+	r.Command(router.Command{Name: "profile", Description: "Your level, XP and coins", Scope: router.PrivateOnly, Handler: handleProfileCommand})
+	r.Command(router.Command{Name: "daily", Description: "Claim your daily reward", Scope: router.PrivateOnly, Handler: handleDailyCommand})
+	r.Command(router.Command{Name: "weekly", Description: "Claim your weekly reward", Scope: router.PrivateOnly, Handler: handleWeeklyCommand})
+	r.Command(router.Command{Name: "collectibles", Description: "Open the Collectibles Hub", Handler: func(c *router.Context) {
+		if c.IsPrivate() {
+			collectibleController.ShowHub(c.Bot, c.ChatID, c.UserID, c.Store)
+			return
+		}
+		sendDMLink(c, "collectibles", "🌟 Go to Collectibles Hub", "Click the button below to visit the Collectibles Hub!")
+	}})
+	r.Command(router.Command{Name: "shop", Description: "Buy emojis for your name", Handler: func(c *router.Context) {
+		if c.IsPrivate() {
+			ShowShop(c.Bot, c.ChatID, c.UserID, c.Store)
+			return
+		}
+		sendDMLink(c, "shop", "🛒 Go to Shop", "Click the button below to visit the Emoji Shop!")
+	}})
+	r.Command(router.Command{Name: "addBot", Description: "Add the bot to a group", Scope: router.PrivateOnly, Handler: func(c *router.Context) {
+		button := tgbotapi.NewInlineKeyboardButtonURL("add to group", fmt.Sprintf("https://t.me/%s?startgroup=true", c.Bot.Self.UserName))
+		view.SendMessageWithKeyboardButton(c.Bot, c.ChatID, "Unlock my full potential by adding me to a group chat!", button)
+	}})
+	r.Command(router.Command{Name: "report", Description: "Send a report to the bot admin", Cooldown: time.Minute, Handler: func(c *router.Context) {
+		msgstr, _ := MessageToJSONString(c.Message)
+		view.SendMessage(c.Bot, config.App.OwnerID(), msgstr)
+		view.SendMessage(c.Bot, c.ChatID, "Thank you! Your report has been successfully submitted.")
+	}})
+	// getButton posts an announcement with a link button:
+	// /getButton  <text>  <url> <button label>
+	r.Command(router.Command{Name: "getButton", Handler: func(c *router.Context) {
+		announcement := strings.Split(c.Message.Text, "  ")
+		if len(announcement) > 2 {
+			parts := strings.Split(announcement[2], " ")
+			if len(parts) > 2 {
+				button := tgbotapi.NewInlineKeyboardButtonURL(strings.Join(parts[1:], " "), parts[0])
+				view.SendMessageWithKeyboardButton(c.Bot, c.ChatID, announcement[1], button)
+			}
+		}
+	}})
+}
+
+func registerAdmin(r *router.Router) {
+	r.Command(router.Command{Name: "addwordlepoints", AdminOnly: true, Handler: handleAddWordlePoints})
+	r.Command(router.Command{Name: "reconcilepoints", Scope: router.PrivateOnly, AdminOnly: true, Handler: func(c *router.Context) {
+		fixed, err := c.Store.ReconcilePoints()
+		if err != nil {
+			view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("Reconciliation failed: %v", err))
+			return
+		}
+		view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("Rebuilt the points balances from the ledger. %d balances were corrected.", fixed))
+	}})
+	r.Command(router.Command{Name: "exportdata", Scope: router.PrivateOnly, AdminOnly: true, Handler: func(c *router.Context) {
+		exportAndSend := func(name string, filename string) error {
+			data, err := service.ExportAllData(c.Client, name)
+			if err != nil {
+				return fmt.Errorf("failed to export %s data: %w", name, err)
+			}
+			if err := view.SendFile(c.Bot, config.App.OwnerID(), filename, data); err != nil {
+				return fmt.Errorf("failed to send %s data file: %w", name, err)
+			}
+			return nil
+		}
+		if err := exportAndSend("CrocEnLeader", "croc_en_leader_data.json"); err != nil {
+			view.SendMessage(c.Bot, c.ChatID, err.Error())
+			return
+		}
+		if err := exportAndSend("CrocEn", "croc_en_data.json"); err != nil {
+			view.SendMessage(c.Bot, c.ChatID, err.Error())
+			return
+		}
+		view.SendMessage(c.Bot, c.ChatID, "Both datasets exported and sent to admin successfully.")
+	}})
+	r.Command(router.Command{Name: "getlogs", Scope: router.PrivateOnly, Handler: func(c *router.Context) {
+		data, err := os.ReadFile("output.log")
+		if err != nil {
+			view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("Failed to read log file: %v", err))
+			return
+		}
+		if err := view.SendFile(c.Bot, config.App.OwnerID(), "output.log", data); err != nil {
+			view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("Failed to send log file: %v", err))
+			return
+		}
+		view.SendMessage(c.Bot, c.ChatID, "Log file sent to admin successfully.")
+	}})
+	r.Command(router.Command{Name: "installAI", Scope: router.PrivateOnly, AdminOnly: true, Handler: func(c *router.Context) {
+		logs, err := installOllama.Install(true)
+		logsText := strings.Join(logs, "\n")
+		if err != nil {
+			view.SendMessage(c.Bot, c.ChatID, logsText+"\nLogs:\n"+err.Error())
+		}
+		view.SendMessage(c.Bot, c.ChatID, logsText+"\nLogs:\n")
+	}})
+	r.Command(router.Command{Name: "buildModel", Scope: router.PrivateOnly, AdminOnly: true, Handler: func(c *router.Context) {
+		output, err := installOllama.BuildOllamaModel()
+		if err != nil {
+			view.SendMessage(c.Bot, c.ChatID, "Build fail Error:"+err.Error())
+		}
+		view.SendMessage(c.Bot, c.ChatID, "\nLogs:\n"+output)
+	}})
+}
+
+func handleAddWordlePoints(c *router.Context) {
+	parts := strings.Fields(c.Message.Text)
+	if len(parts) < 3 {
+		view.SendMessage(c.Bot, c.ChatID, "Usage: /addwordlepoints <userID> <points> [name]")
+		return
+	}
+	var userID int
+	var points int
+	if _, err := fmt.Sscanf(parts[1], "%d", &userID); err != nil {
+		view.SendMessage(c.Bot, c.ChatID, "Invalid userID. Must be a number.")
+		return
+	}
+	if _, err := fmt.Sscanf(parts[2], "%d", &points); err != nil {
+		view.SendMessage(c.Bot, c.ChatID, "Invalid points. Must be a number.")
+		return
+	}
+	name := "Unknown"
+	if len(parts) > 3 {
+		name = strings.Join(parts[3:], " ")
+	}
+	balance, err := c.Store.ApplyPoints(repository.PointsEntry{
+		Key:          ledger.Key("admin", c.ChatID, c.Message.MessageID),
+		UserID:       userID,
+		Name:         name,
+		ChatID:       c.ChatID,
+		Amount:       points,
+		Reason:       "Admin adjustment",
+		Counterparty: c.UserID,
+	})
+	if err != nil {
+		view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("Could not add %d points for user %d: %v", points, userID, err))
+		return
+	}
+	go c.Store.InsertWordleBonusDoc(userID, name, c.ChatID, "WordleEn", points)
+	view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("Added %d Wordle points for user %d (%s). Balance: %d", points, userID, name, balance))
+}
+
+func sendRules(c *router.Context, leaderLine string) {
+	rulesText := "🎮 *Game Rules*\n\n" +
+		"👥 *Players*\n" +
+		"🧠 — Guess the word by typing your answer.\n" +
+		"🕵️ — Use `/hint` to get clues (wait 1 min between hints).\n" +
+		"🏳️ — Use `/reveal` if you give up (available after 10 mins).\n\n" +
+		"👑 *Leaders*\n" +
+		leaderLine +
+		"🗣️ — Explain the word without saying it directly.\n" +
+		"🔄 — Drop leadership or get a new word via buttons.\n\n" +
+		"🌍 *General*\n" +
+		"🤝 — Be respectful and fair.\n" +
+		"🎉 — Have fun and enjoy the game!\n\n" +
+		"💡 *Tip:* Type `/word` to start or `/rules` to view this menu."
+	msg := tgbotapi.NewMessage(c.ChatID, rulesText)
+	msg.ParseMode = "Markdown"
+	if _, err := c.Bot.Send(msg); err != nil {
+		log.Printf("Failed to send rules message: %v", err)
+	}
+}
+
+// sendDMLink points a group at a /start deep link so the feature opens in a DM.
+func sendDMLink(c *router.Context, start, label, text string) {
+	link := fmt.Sprintf("https://t.me/%s?start=%s", c.Bot.Self.UserName, start)
+	markup := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonURL(label, link),
+		),
+	)
+	view.SendMessageWithButtons(c.Bot, c.ChatID, text, markup)
+}
+
+// MessageToJSONString renders message as indented JSON, as sent with /report.
+func MessageToJSONString(message *tgbotapi.Message) (string, error) {
+	jsonBytes, err := json.MarshalIndent(message, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(jsonBytes), nil
+}
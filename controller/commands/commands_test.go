@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordguess"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+func TestRegisterHasNoDuplicates(t *testing.T) {
+	r := router.New(nil, nil, nil)
+	Register(r, NewEngine(game.Env{})) // panics on a duplicate command or callback
+
+	group := r.Help(false)
+	for _, cmd := range []string{"/wordle", "/stats", "/rules", "/help"} {
+		if !strings.Contains(group, cmd+" ") {
+			t.Errorf("group help is missing %s:\n%s", cmd, group)
+		}
+	}
+	if strings.Contains(group, "/profile") || strings.Contains(group, "/exportdata") {
+		t.Errorf("group help lists a DM or admin command:\n%s", group)
+	}
+}
+
+func TestDailyCommandsDoNotCollide(t *testing.T) {
+	// Record the route instead of running it: the handlers need a live bot.
+	var routes []string
+	r := router.New(nil, nil, nil, func(router.HandlerFunc) router.HandlerFunc {
+		return func(c *router.Context) { routes = append(routes, c.Route) }
+	})
+	Register(r, NewEngine(game.Env{}))
+
+	send := func(text, chatType string) bool {
+		return r.DispatchMessage(&tgbotapi.Message{
+			Text:     text,
+			From:     &tgbotapi.User{ID: 5, FirstName: "user"},
+			Chat:     &tgbotapi.Chat{ID: 5, Type: chatType},
+			Entities: &[]tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(text)}},
+		})
+	}
+	for _, tc := range []struct {
+		text, chatType string
+		want           bool
+	}{
+		{"/daily", "private", true},
+		{"/dailywordle", "private", true},
+		{"/daily", "group", false},
+		{"/dailywordle", "supergroup", true},
+	} {
+		if got := send(tc.text, tc.chatType); got != tc.want {
+			t.Errorf("%s in a %s chat dispatched = %v, want %v", tc.text, tc.chatType, got, tc.want)
+		}
+	}
+	if want := "daily,dailywordle,dailywordle"; strings.Join(routes, ",") != want {
+		t.Errorf("routes = %v, want %s", routes, want)
+	}
+
+	private := r.Help(true)
+	for _, line := range []string{"/daily — Claim your daily reward", "/dailywordle — "} {
+		if !strings.Contains(private, line) {
+			t.Errorf("private help is missing %q:\n%s", line, private)
+		}
+	}
+}
+
+func TestParseLeaderboardDefaultsToAllTime(t *testing.T) {
+	for args, want := range map[string]repository.Window{
+		"wordle":        repository.WindowAll,
+		"wordle_season": repository.WindowSeason,
+		"wordle_week":   repository.WindowWeek,
+	} {
+		lb, window, ok := parseLeaderboard(args)
+		if !ok || lb.key != "wordle" || window != want {
+			t.Errorf("parseLeaderboard(%q) = %s, %s, %v; want wordle, %s", args, lb.key, window, ok, want)
+		}
+	}
+}
+
+// stillRound is a round that never ends by itself.
+type stillRound struct{}
+
+func (stillRound) Round(int64) Round {
+	return Round{Leader: 1, Started: time.Unix(1, 0), Timer: wordguess.Timer{Ends: time.Now().Add(time.Hour)}}
+}
+func (stillRound) SetTimer(int64, time.Time, wordguess.Timer) bool { return true }
+func (stillRound) End(int64, time.Time) bool                       { return true }
+
+func TestRoundTimersStopWithTheBot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	timers := NewRoundTimers(ctx, nil, nil, stillRound{})
+	timers.Resume(1)
+	cancel()
+
+	for start := time.Now(); ; time.Sleep(time.Millisecond) {
+		timers.mu.Lock()
+		_, running := timers.running[1]
+		timers.mu.Unlock()
+		if !running {
+			break
+		}
+		if time.Since(start) > time.Second {
+			t.Fatal("the countdown kept running after the bot stopped")
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/animebot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/geographybot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/scramybot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/wordgridbot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/wordlebot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+)
+
+// NewEngine returns the engine running the minigames the word game bots
+// offer. A new game only has to be added here.
+func NewEngine(env game.Env) *game.Engine {
+	return game.NewEngine(env,
+		wordlebot.Game,
+		wordlebot.Daily,
+		scramybot.Game,
+		geographybot.Game,
+		wordgridbot.Game,
+		animebot.Game,
+	)
+}
+
+func player(c *router.Context) game.Player {
+	return game.Player{ID: c.UserID, Name: c.FirstName()}
+}
+
+// registerGames adds /<game> and /cancel<game> for every game run by e, plus
+// the older aliases users already know.
+func registerGames(r *router.Router, e *game.Engine) {
+	for _, g := range e.Games() {
+		info := g.Info()
+		r.Command(router.Command{Name: info.Name, Description: info.Description, Handler: func(c *router.Context) {
+			e.Start(info.Name, c.ChatID, player(c))
+		}})
+		r.Command(router.Command{Name: "cancel" + info.Name, Description: fmt.Sprintf("Cancel the %s game", info.Title), Handler: cancelGame(e, info)})
+	}
+
+	r.Command(router.Command{Name: "grideasy", Description: "Start an easy Word Grid game", Handler: func(c *router.Context) {
+		wordgridbot.StartWordGridEasyGame(c.Bot, c.ChatID, c.Client)
+	}})
+	r.Command(router.Command{Name: "geohint", Description: "Get a hint for the Geography question", Handler: gameHint(e, "geography")})
+	r.Command(router.Command{Name: "animehint", Description: "Get a hint for the Anime question", Handler: gameHint(e, "anime")})
+	if g, ok := e.Game("geography"); ok {
+		r.Command(router.Command{Name: "cancelgeo", Handler: cancelGame(e, g.Info())})
+	}
+}
+
+func cancelGame(e *game.Engine, info game.Info) router.HandlerFunc {
+	return func(c *router.Context) {
+		if e.Cancel(info.Name, c.ChatID) {
+			view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("%s game cancelled.", info.Title))
+		} else {
+			view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("No active %s game.", info.Title))
+		}
+	}
+}
+
+func gameHint(e *game.Engine, name string) router.HandlerFunc {
+	return func(c *router.Context) {
+		if !e.Hint(name, c.Message) {
+			view.SendMessage(c.Bot, c.ChatID, "This game has no hints.")
+		}
+	}
+}
+
+// gameButtons are the buttons that start a game in the current chat.
+var gameButtons = []struct {
+	data  string
+	game  string
+	toast string
+}{
+	{"wordle_start", "wordle", "Wordle Started!"},
+	{"scramy_start", "scramy", "Scramy Started!"},
+	{"geography_start", "geography", "Geography Started!"},
+	{"anime_start", "anime", "Anime Started!"},
+	{"wordgrid_start", "wordgrid", "Word Grid Started!"},
+}
+
+func registerGameButtons(r *router.Router, e *game.Engine) {
+	for _, b := range gameButtons {
+		r.Callback(router.Callback{Data: b.data, Handler: func(c *router.Context) {
+			e.Start(b.game, c.ChatID, player(c))
+			c.Answer(b.toast)
+		}})
+	}
+	r.Callback(router.Callback{Data: "wordgrid_start_easy", Handler: func(c *router.Context) {
+		wordgridbot.StartWordGridEasyGame(c.Bot, c.ChatID, c.Client)
+		c.Answer("Word Grid Easy Started!")
+	}})
+}
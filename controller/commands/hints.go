@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/ledger"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordguess"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// RewardGuess pays the guesser of the leader's word the points it was still
+// worth after the hints given for it, and counts the round on both of their
+// leaderboards.
+func RewardGuess(store repository.Store, message *tgbotapi.Message, leaderID int, leaderName string, hints int) {
+	chatID := message.Chat.ID
+	go func(userID int, name string) {
+		// Pay out before the leaderboard doc so a first-time guesser's
+		// opening balance does not count this round twice.
+		key := ledger.Key("wordguess", chatID, message.MessageID)
+		if _, err := ledger.Credit(store, key, userID, name, chatID, wordguess.PointsFor(hints), "Word guess"); err != nil {
+			log.Printf("Failed to credit word guess to %d: %v", userID, err)
+		}
+		store.InsertDoc(userID, name, chatID, "CrocEn")
+	}(message.From.ID, message.From.FirstName)
+	go store.InsertDoc(leaderID, leaderName, chatID, "CrocEnLeader")
+}
+
+const hintBudgetText = "⚙️ *Hints*\nHow many hints players can ask for per word. A correct guess is worth %d points, less %d for each hint given."
+
+func hintBudgetMenu(current int) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, n := range wordguess.HintBudgetChoices {
+		label := fmt.Sprint(n)
+		if n == 0 {
+			label = "Off"
+		}
+		if n == current {
+			label = "✅ " + label
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("set_wordguess_hints_%d", n)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row,
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "setting_wordguess_main")))
+}
+
+func registerHintSettings(r *router.Router) {
+	r.Callback(router.Callback{Data: "setting_wordguess_hints", Handler: func(c *router.Context) {
+		editMenu(c, fmt.Sprintf(hintBudgetText, wordguess.GuessPoints, wordguess.HintCost),
+			hintBudgetMenu(wordguess.GetChatSettings(c.ChatID, c.Store).HintBudget))
+	}})
+	r.Callback(router.Callback{Prefix: "set_wordguess_hints_", Handler: func(c *router.Context) {
+		n, err := strconv.Atoi(c.Args())
+		if err != nil || !slices.Contains(wordguess.HintBudgetChoices, n) {
+			return
+		}
+		if err := wordguess.UpdateHintBudget(c.ChatID, n, c.Store); err != nil {
+			log.Printf("Failed to update the hint budget of chat %d: %v", c.ChatID, err)
+			c.Answer("Failed to update setting.")
+			return
+		}
+		editButtons(c, hintBudgetMenu(n))
+		c.Answer("Settings saved!")
+	}})
+}
@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// leaderboard is a game whose scores can be listed with the stats buttons.
+type leaderboard struct {
+	key        string // callback data suffix
+	collection string
+	name       string
+	emoji      string
+}
+
+// leaderboards in menu order. The first four share rows of two, the rest get
+// a row each.
+var leaderboards = []leaderboard{
+	{"wordguess", "CrocEn", "Word Guess", ""},
+	{"wordle", "WordleEn", "Wordle", ""},
+	{"scramy", "ScramyEn", "Scramy", ""},
+	{"geography", "GeographyPoints", "Geography", " 🌍"},
+	{"anime", "AnimePoints", "Anime", " 🎌"},
+	{"wordgrid", "WordGridPoints", "Word Grid", " 🔠"},
+}
+
+func findLeaderboard(key string) (leaderboard, bool) {
+	for _, lb := range leaderboards {
+		if lb.key == key {
+			return lb, true
+		}
+	}
+	return leaderboard{}, false
+}
+
+// parseLeaderboard splits callback arguments such as "wordle_week" into the
+// game and the period. Buttons sent before periods existed carry only the
+// game and rank all time; the season is one of the periods to pick.
+func parseLeaderboard(args string) (leaderboard, repository.Window, bool) {
+	key, window, _ := strings.Cut(args, "_")
+	lb, ok := findLeaderboard(key)
+	return lb, repository.ParseWindow(window), ok
+}
+
+// leaderboardTitle is the heading of lb's board, e.g. "Wordle Group
+// Leaderboard - This Week".
+func leaderboardTitle(lb leaderboard, scope string, window repository.Window) string {
+	title := fmt.Sprintf("%s %s Leaderboard", lb.name, scope)
+	if window != repository.WindowAll {
+		title += " - " + window.Label()
+	}
+	return title
+}
+
+// leaderboardMenu builds the buttons that switch between game leaderboards.
+// Buttons carry prefix+key as callback data and are labelled with suffix,
+// e.g. "Wordle Image Global".
+func leaderboardMenu(prefix, suffix string, withAnime bool) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(leaderboardRows(prefix, suffix, withAnime, "")...)
+}
+
+// leaderboardWindowMenu is leaderboardMenu for a board being shown: the game
+// buttons keep window, and a last row switches the period of the game current.
+func leaderboardWindowMenu(prefix, suffix string, withAnime bool, current string, window repository.Window) tgbotapi.InlineKeyboardMarkup {
+	rows := leaderboardRows(prefix, suffix, withAnime, "_"+string(window))
+	var periods []tgbotapi.InlineKeyboardButton
+	for _, w := range repository.Windows {
+		label := w.Label()
+		if w == window {
+			label = "✅ " + label
+		}
+		periods = append(periods, tgbotapi.NewInlineKeyboardButtonData(label, prefix+current+"_"+string(w)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(append(rows, periods)...)
+}
+
+func leaderboardRows(prefix, suffix string, withAnime bool, dataSuffix string) [][]tgbotapi.InlineKeyboardButton {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var pair []tgbotapi.InlineKeyboardButton
+	for i, lb := range leaderboards {
+		if lb.key == "anime" && !withAnime {
+			continue
+		}
+		btn := tgbotapi.NewInlineKeyboardButtonData(lb.name+" "+suffix+lb.emoji, prefix+lb.key+dataSuffix)
+		if i < 4 {
+			pair = append(pair, btn)
+			if len(pair) == 2 {
+				rows = append(rows, tgbotapi.NewInlineKeyboardRow(pair...))
+				pair = nil
+			}
+			continue
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(btn))
+	}
+	return rows
+}
+
+func myStatsMenu() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Word Guess", "stats_wordguess"),
+			tgbotapi.NewInlineKeyboardButtonData("Wordle", "stats_wordle"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Scramy", "stats_scramy"),
+			tgbotapi.NewInlineKeyboardButtonData("Anime", "statsglobal_anime"),
+		),
+	)
+}
+
+// imageScope returns the chat to rank, the menu and the title wording for a
+// statsimg_global_ or statsimg_group_ prefix, showing key within window.
+func imageScope(c *router.Context, prefix, key string, window repository.Window) (int64, tgbotapi.InlineKeyboardMarkup, string) {
+	if strings.Contains(prefix, "global") {
+		return 0, leaderboardWindowMenu(prefix, "Image Global", true, key, window), "Global"
+	}
+	return c.ChatID, leaderboardWindowMenu(prefix, "Image Group", false, key, window), "Group"
+}
+
+// sendLeaderboardImage posts a new all-time leaderboard image for key.
+func sendLeaderboardImage(c *router.Context, prefix, key string) {
+	lb, _ := findLeaderboard(key)
+	chatID, markup, scope := imageScope(c, prefix, key, repository.WindowAll)
+	imgBytes, err := service.GenerateLeaderboardImage(c.Client, lb.collection, chatID, repository.WindowAll, leaderboardTitle(lb, scope, repository.WindowAll))
+	if err != nil {
+		return
+	}
+	photo := tgbotapi.NewPhotoUpload(c.ChatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
+	photo.ReplyMarkup = markup
+	c.Bot.Send(photo)
+}
+
+// switchLeaderboardImage replaces the image on the pressed message with the
+// leaderboard and period chosen by the callback.
+func switchLeaderboardImage(c *router.Context) {
+	lb, window, ok := parseLeaderboard(c.Args())
+	if !ok {
+		return
+	}
+	c.Answer("Generating image...")
+	chatID, markup, scope := imageScope(c, c.Route, lb.key, window)
+	imgBytes, err := service.GenerateLeaderboardImage(c.Client, lb.collection, chatID, window, leaderboardTitle(lb, scope, window))
+	if err != nil {
+		view.SendMessage(c.Bot, c.ChatID, "Failed to generate image.")
+		return
+	}
+	err = view.EditMessageMediaWithStyledButtons(c.Bot.Token, c.ChatID, c.Message.MessageID, imgBytes, "leaderboard.png", &markup)
+	if err != nil {
+		// Fallback if message wasn't a photo previously
+		photo := tgbotapi.NewPhotoUpload(c.ChatID, tgbotapi.FileBytes{Name: "leaderboard.png", Bytes: imgBytes})
+		photo.ReplyMarkup = markup
+		c.Bot.Send(photo)
+		c.Bot.Send(tgbotapi.NewDeleteMessage(c.ChatID, c.Message.MessageID))
+	}
+}
+
+// showLeaderboard edits the pressed message into the styled top 10 for the
+// game and period named by the callback, either globally or for this chat.
+func showLeaderboard(global bool) router.HandlerFunc {
+	return func(c *router.Context) {
+		lb, window, ok := parseLeaderboard(c.Args())
+		if !ok {
+			return
+		}
+		chatID := c.ChatID
+		if global {
+			chatID = 0
+		}
+		markup := service.LeaderBoardListButtons(c.Client, lb.collection, chatID, c.Route+lb.key, window)
+		text := fmt.Sprintf("🏆 <b>Top 10 Players Leaderboard</b> 🏆\n📅 <b>%s</b>\n\n✨ <b>Keep it up and aim for the top!</b> ✨", window.Label())
+		err := view.EditMessageTextWithStyledButtons(c.Bot.Token, c.ChatID, c.Message.MessageID, text, markup)
+		if err != nil {
+			log.Printf("Failed to send styled buttons message: %v", err)
+			view.SendMessagehtml(c.Bot, c.ChatID, "Failed to load leaderboard.")
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"fmt"
+	"html"
+	"log"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordguess"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// CheckLeaderMessage looks for the word in a group message from the round's
+// leader. If they gave it away the message is deleted and they are warned;
+// under the chat's end-round policy the word is revealed too, and the result
+// is true so the caller ends the round. The next leader draws a new word.
+func CheckLeaderMessage(bot *tgbotapi.BotAPI, store repository.Store, message *tgbotapi.Message, word string) bool {
+	policy := wordguess.GetChatSettings(message.Chat.ID, store).LeakPolicy
+	if policy == wordguess.LeakOff || word == "" {
+		return false
+	}
+	text := message.Text
+	if text == "" {
+		text = message.Caption
+	}
+	leak := wordguess.DetectLeak(text, word)
+	if leak == wordguess.NoLeak {
+		return false
+	}
+
+	chatID := message.Chat.ID
+	if _, err := bot.DeleteMessage(tgbotapi.NewDeleteMessage(chatID, message.MessageID)); err != nil {
+		log.Printf("Failed to delete the leader's message in chat %d: %v", chatID, err)
+	}
+	name := fmt.Sprintf("<a href=\"tg://user?id=%d\">%s</a>", message.From.ID, html.EscapeString(message.From.FirstName))
+	if policy != wordguess.LeakEndRound {
+		view.SendMessagehtml(bot, chatID, fmt.Sprintf("🤐 %s, that message had %s in it, so I deleted it. Explain without giving it away!", name, leak))
+		return false
+	}
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🌟 Claim Leadership 🙋", "explain"),
+	))
+	view.SendMessagehtmlWithButtons(bot, chatID, fmt.Sprintf("🚫 %s gave away %s, so they lose the lead. The word was <b>%s</b>.\nWho explains the next one?",
+		name, leak, html.EscapeString(word)), markup)
+	return true
+}
+
+// leakPolicies are the choices of the Leader Leaks setting.
+var leakPolicies = []struct {
+	policy wordguess.LeakPolicy
+	label  string
+}{
+	{wordguess.LeakOff, "Allow 😶"},
+	{wordguess.LeakWarn, "Delete & warn ⚠️"},
+	{wordguess.LeakEndRound, "Delete & end round 🚫"},
+}
+
+const leakPolicyText = "⚙️ *Leader Leaks*\nWhat happens when the leader types the word, a form of it, spells it out or translates it:\n" +
+	"- *Allow*: nothing.\n- *Delete & warn*: the message is deleted and the leader warned.\n- *Delete & end round*: the leader also loses the lead and the word is revealed."
+
+func leakPolicyMenu(current wordguess.LeakPolicy) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, p := range leakPolicies {
+		label := p.label
+		if p.policy == current {
+			label = "✅ " + label
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(label, "set_wordguess_leak_"+string(p.policy))))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "setting_wordguess_main")))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func registerWordGuessSettings(r *router.Router) {
+	r.Callback(router.Callback{Data: "setting_wordguess_main", Handler: func(c *router.Context) {
+		editMenu(c, "⚙️ *Word Game Settings*\nChoose a setting to configure:", wordGuessSettingsMenu())
+	}})
+	r.Callback(router.Callback{Data: "setting_wordguess_leak", Handler: func(c *router.Context) {
+		editMenu(c, leakPolicyText, leakPolicyMenu(wordguess.GetChatSettings(c.ChatID, c.Store).LeakPolicy))
+	}})
+	r.Callback(router.Callback{Prefix: "set_wordguess_leak_", Handler: func(c *router.Context) {
+		policy := wordguess.LeakPolicy(c.Args())
+		switch policy {
+		case wordguess.LeakOff, wordguess.LeakWarn, wordguess.LeakEndRound:
+		default:
+			return
+		}
+		if err := wordguess.UpdateLeakPolicy(c.ChatID, policy, c.Store); err != nil {
+			log.Printf("Failed to update the leak policy of chat %d: %v", c.ChatID, err)
+			c.Answer("Failed to update setting.")
+			return
+		}
+		editButtons(c, leakPolicyMenu(policy))
+		c.Answer("Settings saved!")
+	}})
+	registerTimerSettings(r)
+	registerLanguageSettings(r)
+	registerHintSettings(r)
+}
+
+// wordGuessSettingsMenu lists the word-guess settings.
+func wordGuessSettingsMenu() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Round Time ⏳", "setting_wordguess_round"),
+			tgbotapi.NewInlineKeyboardButtonData("Quiet Leader 💤", "setting_wordguess_idle"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Leader Leaks 🤐", "setting_wordguess_leak"),
+			tgbotapi.NewInlineKeyboardButtonData("Language 🌐", "setting_wordguess_lang"),
+		),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Hints 💡", "setting_wordguess_hints")),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "settings_main")),
+	)
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/modbot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/wordlebot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/rotation"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/teams"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const forgetMeText = "🗑 <b>Delete your data?</b>\n\n" +
+	"This permanently removes everything stored under your account:\n" +
+	"<blockquote>points and their history, game scores, ratings, profile, emojis, marketplace listings, whispers, moderation warnings, Daily Wordle streaks and results, your place in leader queues and on teams</blockquote>\n" +
+	"Your collectibles stay in circulation without an owner, your place in past seasons is shown as \"Deleted user\", and scheduled messages you added to a group stay in it.\n\n" +
+	"Bans and admin rights are kept.\n\n" +
+	"Send /mydata first if you want a copy. This cannot be undone."
+
+// registerPrivacy adds /mydata, which sends the user everything stored about
+// them, and /forgetme, which deletes it after a confirmation.
+func registerPrivacy(r *router.Router) {
+	r.Command(router.Command{Name: "mydata", Description: "Get a copy of your stored data", Cooldown: time.Minute, Handler: sendUserData})
+	r.Command(router.Command{Name: "forgetme", Description: "Delete your stored data", Scope: router.PrivateOnly, Handler: func(c *router.Context) {
+		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🗑 Delete everything", "forgetme_confirm"),
+			tgbotapi.NewInlineKeyboardButtonData("Cancel", "forgetme_cancel"),
+		))
+		view.SendMessagehtmlWithButtons(c.Bot, c.ChatID, forgetMeText, markup)
+	}})
+	r.Callback(router.Callback{Data: "forgetme_cancel", Handler: func(c *router.Context) {
+		c.Answer("Cancelled")
+		c.Bot.Send(tgbotapi.NewEditMessageText(c.ChatID, c.Message.MessageID, "Nothing was deleted."))
+	}})
+	r.Callback(router.Callback{Data: "forgetme_confirm", Handler: forgetUser})
+}
+
+func sendUserData(c *router.Context) {
+	data, err := service.ExportUserData(c.Store, c.UserID, time.Now())
+	if err != nil {
+		log.Printf("Failed to export data of user %d: %v", c.UserID, err)
+		view.SendMessage(c.Bot, c.ChatID, "Failed to collect your data. Please try again later.")
+		return
+	}
+	filename := fmt.Sprintf("mydata-%d.json", c.UserID)
+	if err := view.SendFile(c.Bot, int64(c.UserID), filename, data); err != nil {
+		view.SendMessage(c.Bot, c.ChatID, "I couldn't message you. Start a private chat with me and send /mydata again.")
+		return
+	}
+	if !c.IsPrivate() {
+		view.SendMessage(c.Bot, c.ChatID, "Sent you your data in a private message.")
+	}
+}
+
+func forgetUser(c *router.Context) {
+	// The confirmation is only sent in the user's own chat.
+	if c.ChatID != int64(c.UserID) {
+		c.Answer("This is not your request.")
+		return
+	}
+	c.Answer("Deleting...")
+	report, err := c.Store.ForgetUser(c.UserID)
+	modbot.ForgetUserViolations(c.UserID)
+	wordlebot.ForgetDailyPlayer(c.UserID)
+	rotation.Default.Forget(c.UserID)
+	teams.Default.Forget(c.UserID)
+
+	var sb strings.Builder
+	if err != nil {
+		log.Printf("Forgetting user %d failed: %v", c.UserID, err)
+		view.SendMessage(c.Bot, config.App.OwnerID(), fmt.Sprintf("/forgetme for user %d failed: %v", c.UserID, err))
+		sb.WriteString("Some of your data could not be deleted. The bot admin has been told; try /forgetme again later.")
+	} else {
+		sb.WriteString("Your data has been deleted.")
+	}
+	if len(report) > 0 {
+		names := make([]string, 0, len(report))
+		for name := range report {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		sb.WriteString("\n")
+		for _, name := range names {
+			fmt.Fprintf(&sb, "\n%s: %d", name, report[name])
+		}
+	}
+	c.Bot.Send(tgbotapi.NewEditMessageText(c.ChatID, c.Message.MessageID, sb.String()))
+}
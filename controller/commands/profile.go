@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+func displayName(from *tgbotapi.User) string {
+	if from.UserName != "" {
+		return from.UserName
+	}
+	return from.FirstName
+}
+
+func handleProfileCommand(c *router.Context) {
+	profile, err := c.Store.GetUserProfile(int64(c.UserID), displayName(c.Message.From))
+	if err != nil {
+		view.SendMessage(c.Bot, c.ChatID, "Error retrieving profile. Please try again later.")
+		return
+	}
+
+	html := service.FormatUserProfile(profile)
+	view.SendMessagehtml(c.Bot, c.ChatID, html)
+}
+
+func handleDailyCommand(c *router.Context) {
+	xp, coins, err := service.ClaimDailyReward(c.Store, int64(c.UserID), displayName(c.Message.From))
+	if err != nil {
+		view.SendMessage(c.Bot, c.ChatID, "❌ "+err.Error())
+		return
+	}
+
+	msg := fmt.Sprintf("🎁 *Daily Reward Claimed!*\n\nEarned:\n✨ +%d XP\n🪙 +%d Coins", xp, coins)
+	view.SendMessage(c.Bot, c.ChatID, msg)
+}
+
+func handleWeeklyCommand(c *router.Context) {
+	xp, coins, err := service.ClaimWeeklyReward(c.Store, int64(c.UserID), displayName(c.Message.From))
+	if err != nil {
+		view.SendMessage(c.Bot, c.ChatID, "❌ "+err.Error())
+		return
+	}
+
+	msg := fmt.Sprintf("🎉 *Weekly Reward Claimed!*\n\nEarned:\n✨ +%d XP\n🪙 +%d Coins", xp, coins)
+	view.SendMessage(c.Bot, c.ChatID, msg)
+}
@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/modbot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/rotation"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/teams"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// registerQueue adds /queue, the leader rotation of the word-guess round:
+// players join a queue and take turns leading instead of racing for the
+// Explain button.
+func registerQueue(r *router.Router) {
+	r.Command(router.Command{Name: "queue", Description: "Take turns leading the word game", Scope: router.GroupOnly, Handler: handleQueue})
+	r.Callback(router.Callback{Prefix: "queue_", Handler: handleQueueCallback})
+}
+
+func handleQueue(c *router.Context) {
+	switch arg := strings.ToLower(strings.TrimSpace(c.Args())); arg {
+	case "":
+		text, markup := queueMenu(c.ChatID)
+		view.SendMessagehtmlWithButtons(c.Bot, c.ChatID, text, markup)
+	case "on", "off":
+		if !modbot.IsChatAdmin(c.Bot, c.ChatID, c.UserID) {
+			view.SendMessage(c.Bot, c.ChatID, "Only group admins can turn the leader rotation on or off.")
+			return
+		}
+		if err := rotation.Default.SetEnabled(c.ChatID, arg == "on"); err != nil {
+			log.Printf("Failed to save the leader rotation of chat %d: %v", c.ChatID, err)
+			view.SendMessage(c.Bot, c.ChatID, "Failed to save the setting. Please try again later.")
+			return
+		}
+		text, markup := queueMenu(c.ChatID)
+		view.SendMessagehtmlWithButtons(c.Bot, c.ChatID, text, markup)
+	default:
+		view.SendMessage(c.Bot, c.ChatID, "Usage:\n/queue — show the leader queue\n/queue on|off — take turns leading, or let the fastest tap lead")
+	}
+}
+
+func handleQueueCallback(c *router.Context) {
+	var err error
+	switch c.Args() {
+	case "join":
+		var joined bool
+		joined, err = rotation.Default.Join(c.ChatID, c.UserID, c.FirstName())
+		if err == nil && !joined {
+			c.Answer("You are already in the queue.")
+			return
+		}
+	case "leave":
+		var left bool
+		left, err = rotation.Default.Leave(c.ChatID, c.UserID)
+		if err == nil && !left {
+			c.Answer("You are not in the queue.")
+			return
+		}
+	case "pass":
+		declined, next, ok := rotation.Default.Decline(c.ChatID, c.UserID)
+		if !declined {
+			c.AnswerAlert("This turn is not yours to pass.")
+			return
+		}
+		c.Bot.Send(tgbotapi.NewEditMessageText(c.ChatID, c.Message.MessageID, fmt.Sprintf("%s passed on their turn.", c.FirstName())))
+		passTurn(c.Bot, c.ChatID, next, ok)
+		return
+	default:
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to save the leader queue of chat %d: %v", c.ChatID, err)
+		c.AnswerAlert("Failed to save the queue. Please try again.")
+		return
+	}
+	text, markup := queueMenu(c.ChatID)
+	edit := tgbotapi.NewEditMessageText(c.ChatID, c.Message.MessageID, text)
+	edit.ParseMode = tgbotapi.ModeHTML
+	edit.ReplyMarkup = &markup
+	c.Bot.Send(edit)
+}
+
+// queueMenu lists the chat's queue in turn order, with buttons to join and
+// leave it.
+func queueMenu(chatID int64) (string, tgbotapi.InlineKeyboardMarkup) {
+	var sb strings.Builder
+	sb.WriteString("🔁 <b>Leader queue</b>\n")
+	if rotation.Default.Enabled(chatID) {
+		sb.WriteString("Rotation is on: whoever led least recently is offered the next round.\n\n")
+	} else {
+		sb.WriteString("Rotation is off: the fastest tap on Explain leads. Admins can turn it on with /queue on.\n\n")
+	}
+
+	offer, offered := rotation.Default.Current(chatID)
+	players := rotation.Default.Order(chatID)
+	if len(players) == 0 {
+		sb.WriteString("Nobody has joined yet.")
+	}
+	for i, p := range players {
+		fmt.Fprintf(&sb, "%d. %s", i+1, html.EscapeString(p.Name))
+		if offered && offer.UserID == p.ID {
+			sb.WriteString(" 🎯")
+		}
+		sb.WriteString("\n")
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🙋 Join", "queue_join"),
+		tgbotapi.NewInlineKeyboardButtonData("🚪 Leave", "queue_leave"),
+	))
+	return sb.String(), markup
+}
+
+// ClaimTurn is called before the user takes the lead of a round nobody is
+// leading. It reports whether they may; if it is someone else's turn it tells
+// the user so, offering the turn first if nobody has been offered it yet.
+// While a team match runs, the team whose turn it is leads instead. Private
+// chats have no rotation.
+func ClaimTurn(c *router.Context) bool {
+	if c.IsPrivate() {
+		return true
+	}
+	if teams.Default.Active(c.ChatID) {
+		ok, turn := teams.Default.CanLead(c.ChatID, c.UserID)
+		if !ok {
+			c.AnswerAlert(fmt.Sprintf("It's team %s's turn to lead. Players pick a team with /teams.", turn.Label()))
+		}
+		return ok
+	}
+	ok, offer, created := rotation.Default.Claim(c.ChatID, c.UserID)
+	if ok {
+		return true
+	}
+	if created {
+		offerTurn(c.Bot, c.ChatID, offer)
+	}
+	c.AnswerAlert(fmt.Sprintf("It's %s's turn to lead. If they pass, the turn moves on.", offer.Name))
+	return false
+}
+
+// TurnTaken records that the user took the lead, which sends them to the back
+// of the queue and, in a team match, hands the next turn to the other team.
+func TurnTaken(chatID int64, userID int) {
+	if err := rotation.Default.Led(chatID, userID); err != nil {
+		log.Printf("Failed to save the leader queue of chat %d: %v", chatID, err)
+	}
+	if err := teams.Default.Led(chatID, userID); err != nil {
+		log.Printf("Failed to save the team match of chat %d: %v", chatID, err)
+	}
+}
+
+// offerTurn announces the offer and passes it on if it is not taken in time.
+// Taking it is the usual Explain button.
+func offerTurn(bot *tgbotapi.BotAPI, chatID int64, offer rotation.Offer) {
+	text := fmt.Sprintf("🎯 It's <a href=\"tg://user?id=%d\">%s</a>'s turn to lead! Tap Lead within %d seconds, or pass it on.",
+		offer.UserID, html.EscapeString(offer.Name), int(rotation.OfferTimeout/time.Second))
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🗣️ Lead", "explain"),
+		tgbotapi.NewInlineKeyboardButtonData("⏭️ Pass", "queue_pass"),
+	))
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = markup
+	sent, err := bot.Send(msg)
+	if err != nil {
+		log.Printf("Failed to offer the lead in chat %d: %v", chatID, err)
+	}
+
+	time.AfterFunc(time.Until(offer.Expires), func() {
+		expired, next, ok := rotation.Default.Expire(chatID, offer)
+		if !expired {
+			return
+		}
+		if err == nil {
+			bot.Send(tgbotapi.NewEditMessageText(chatID, sent.MessageID, fmt.Sprintf("%s didn't take their turn in time.", offer.Name)))
+		}
+		passTurn(bot, chatID, next, ok)
+	})
+}
+
+// passTurn offers the turn to the next player, or opens it to everyone when
+// the whole queue has passed.
+func passTurn(bot *tgbotapi.BotAPI, chatID int64, next rotation.Offer, ok bool) {
+	if ok {
+		offerTurn(bot, chatID, next)
+		return
+	}
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🌟 Claim Leadership 🙋", "explain"),
+	))
+	view.SendMessageWithButtons(bot, chatID, "Everyone in the queue passed. The first to tap leads this round!", markup)
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strings"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/rating"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// registerRating adds /rating, which shows the user's skill rating in each of
+// the games run by e, and the buttons that send each game's rating board.
+func registerRating(r *router.Router, e *game.Engine) {
+	r.Command(router.Command{Name: "rating", Description: "Your skill rating in each game", Handler: func(c *router.Context) {
+		showRatings(c, e)
+	}})
+	r.Callback(router.Callback{Prefix: "ratingimg_", Handler: func(c *router.Context) {
+		g, ok := e.Game(c.Args())
+		if !ok {
+			return
+		}
+		c.Answer("Generating image...")
+		info := g.Info()
+		imgBytes, err := service.GenerateRatingLeaderboardImage(c.Client, info.Name, info.Title+" Ratings")
+		if err != nil {
+			view.SendMessage(c.Bot, c.ChatID, "Failed to generate image.")
+			return
+		}
+		c.Bot.Send(tgbotapi.NewPhotoUpload(c.ChatID, tgbotapi.FileBytes{Name: "ratings.png", Bytes: imgBytes}))
+	}})
+}
+
+// showRatings lists the user's rating in every game, with a button per game
+// for its top rated players.
+func showRatings(c *router.Context, e *game.Engine) {
+	ratings, err := c.Store.UserRatings(c.UserID)
+	if err != nil {
+		log.Printf("Failed to load ratings of user %d: %v", c.UserID, err)
+		view.SendMessage(c.Bot, c.ChatID, "Failed to load your ratings. Please try again later.")
+		return
+	}
+	byGame := make(map[string]float64)
+	rounds := make(map[string]int)
+	for _, r := range ratings {
+		byGame[r.Game] = r.Rating
+		rounds[r.Game] = r.Rounds
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📈 <b>%s's Ratings</b>\n<blockquote>", html.EscapeString(c.FirstName()))
+	var buttons []tgbotapi.InlineKeyboardButton
+	for i, g := range e.Games() {
+		info := g.Info()
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if r, ok := byGame[info.Name]; ok {
+			fmt.Fprintf(&sb, "%s — <b>%d</b> (%d rounds)", html.EscapeString(info.Title), rating.Display(r), rounds[info.Name])
+		} else {
+			fmt.Fprintf(&sb, "%s — unrated", html.EscapeString(info.Title))
+		}
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(info.Title, "ratingimg_"+info.Name))
+	}
+	fmt.Fprintf(&sb, "</blockquote>\nEveryone starts at %d. Ratings move after rounds played against others.", rating.Display(rating.Initial))
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i := 0; i < len(buttons); i += 2 {
+		rows = append(rows, buttons[i:min(i+2, len(buttons))])
+	}
+	view.SendMessagehtmlWithButtons(c.Bot, c.ChatID, sb.String(), tgbotapi.NewInlineKeyboardMarkup(rows...))
+}
@@ -0,0 +1,266 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordguess"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// countdownTick is how often a running round is checked.
+const countdownTick = 5 * time.Second
+
+// Round is what the round timer needs to know of a word-guess round.
+type Round struct {
+	Word       string
+	Leader     int
+	LeaderName string
+	Started    time.Time // when the leader took the lead; tells rounds apart
+	Timer      wordguess.Timer
+}
+
+// Rounds is how the round timer reads and ends the rounds of a bot, each of
+// which keeps its own chat states.
+type Rounds interface {
+	// Round returns the chat's current round.
+	Round(chatID int64) Round
+	// SetTimer stores the timer of the round started at started, reporting
+	// false if that round is over.
+	SetTimer(chatID int64, started time.Time, t wordguess.Timer) bool
+	// End resets the round started at started, reporting false if that round
+	// is over already.
+	End(chatID int64, started time.Time) bool
+}
+
+// RoundTimers run the countdowns of a bot's rounds: they keep a countdown
+// message up to date, and reveal the word when the time is up or the leader
+// has gone quiet.
+type RoundTimers struct {
+	ctx     context.Context
+	bot     *tgbotapi.BotAPI
+	store   repository.Store
+	rounds  Rounds
+	mu      sync.Mutex
+	running map[int64]time.Time // chat -> start of the round being timed
+}
+
+// NewRoundTimers returns the round timers of a bot. The countdowns stop when
+// ctx is cancelled, leaving the rounds as they are for Resume after a
+// restart.
+func NewRoundTimers(ctx context.Context, bot *tgbotapi.BotAPI, store repository.Store, rounds Rounds) *RoundTimers {
+	return &RoundTimers{ctx: ctx, bot: bot, store: store, rounds: rounds, running: make(map[int64]time.Time)}
+}
+
+// Start starts the clock of the chat's round, which a leader just took, and
+// posts its countdown.
+func (t *RoundTimers) Start(chatID int64) {
+	r := t.rounds.Round(chatID)
+	if r.Leader == 0 {
+		return
+	}
+	settings := wordguess.GetChatSettings(chatID, t.store)
+	timer := wordguess.NewTimer(settings, time.Now())
+	msg := tgbotapi.NewMessage(chatID, timer.Countdown(time.Now(), settings.RoundTime(), r.LeaderName))
+	msg.ParseMode = tgbotapi.ModeHTML
+	if sent, err := t.bot.Send(msg); err != nil {
+		log.Printf("Failed to send the countdown in chat %d: %v", chatID, err)
+	} else {
+		timer.MessageID = sent.MessageID
+	}
+	if !t.rounds.SetTimer(chatID, r.Started, timer) {
+		t.deleteCountdown(chatID, timer.MessageID)
+		return
+	}
+	t.watch(chatID, r.Started, timer.MessageID)
+}
+
+// Resume carries on the countdown of a round that was running before a
+// restart.
+func (t *RoundTimers) Resume(chatID int64) {
+	if r := t.rounds.Round(chatID); r.Leader != 0 && !r.Timer.Ends.IsZero() {
+		t.watch(chatID, r.Started, r.Timer.MessageID)
+	}
+}
+
+// LeaderActive notes that the leader is still explaining.
+func (t *RoundTimers) LeaderActive(chatID int64) {
+	r := t.rounds.Round(chatID)
+	if r.Leader == 0 || r.Timer.Ends.IsZero() || time.Since(r.Timer.LastActive) < countdownTick {
+		return
+	}
+	r.Timer.LastActive = time.Now()
+	t.rounds.SetTimer(chatID, r.Started, r.Timer)
+}
+
+// watch checks the round every tick until it is over or the bot stops. A
+// chat has one watcher at a time; a new round takes over from the last one's.
+func (t *RoundTimers) watch(chatID int64, started time.Time, countdownID int) {
+	t.mu.Lock()
+	t.running[chatID] = started
+	t.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(countdownTick)
+		defer ticker.Stop()
+		shown := ""
+	loop:
+		for {
+			select {
+			case <-t.ctx.Done():
+				break loop
+			case <-ticker.C:
+			}
+			t.mu.Lock()
+			current := t.running[chatID].Equal(started)
+			t.mu.Unlock()
+			if !current {
+				// A new round took over before this one's end was seen.
+				t.deleteCountdown(chatID, countdownID)
+				break loop
+			}
+			if !t.tick(chatID, started, countdownID, &shown) {
+				break loop
+			}
+		}
+		t.mu.Lock()
+		if t.running[chatID].Equal(started) {
+			delete(t.running, chatID)
+		}
+		t.mu.Unlock()
+	}()
+}
+
+// tick brings the countdown up to date, or ends the round if it ran out. It
+// reports false once the round is over.
+func (t *RoundTimers) tick(chatID int64, started time.Time, countdownID int, shown *string) bool {
+	r := t.rounds.Round(chatID)
+	if r.Leader == 0 || !r.Started.Equal(started) {
+		// Guessed, dropped or taken over; the countdown has nothing left to show.
+		t.deleteCountdown(chatID, countdownID)
+		return false
+	}
+	settings := wordguess.GetChatSettings(chatID, t.store)
+	now := time.Now()
+	expiry := r.Timer.Expired(now, settings.IdleTime())
+	if expiry == wordguess.NotExpired {
+		if text := r.Timer.Countdown(now, settings.RoundTime(), r.LeaderName); text != *shown && countdownID != 0 {
+			edit := tgbotapi.NewEditMessageText(chatID, countdownID, text)
+			edit.ParseMode = tgbotapi.ModeHTML
+			if _, err := t.bot.Send(edit); err == nil {
+				*shown = text
+			}
+		}
+		return true
+	}
+	if !t.rounds.End(chatID, started) {
+		return false
+	}
+	t.deleteCountdown(chatID, countdownID)
+
+	var text string
+	if expiry == wordguess.LeaderIdle {
+		text = fmt.Sprintf("💤 %s went quiet for %s, so the round is over.", html.EscapeString(r.LeaderName), formatDuration(settings.IdleTime()))
+	} else {
+		text = "⌛ Time's up! Nobody guessed it."
+	}
+	text += fmt.Sprintf("\nThe word was <b>%s</b>.", html.EscapeString(r.Word))
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🌟 Claim Leadership 🙋", "explain"),
+	))
+	view.SendMessagehtmlWithButtons(t.bot, chatID, text, markup)
+	return false
+}
+
+func (t *RoundTimers) deleteCountdown(chatID int64, messageID int) {
+	if messageID == 0 {
+		return
+	}
+	if _, err := t.bot.DeleteMessage(tgbotapi.NewDeleteMessage(chatID, messageID)); err != nil {
+		log.Printf("Failed to delete the countdown in chat %d: %v", chatID, err)
+	}
+}
+
+// formatDuration writes d as "90 seconds" or "5 minutes".
+func formatDuration(d time.Duration) string {
+	if d%time.Minute != 0 || d < time.Minute {
+		return fmt.Sprintf("%d seconds", int(d/time.Second))
+	}
+	if d == time.Minute {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", int(d/time.Minute))
+}
+
+// timerSetting is one of the round timer settings, chosen from a list of
+// durations.
+type timerSetting struct {
+	name    string // in the button data
+	text    string
+	choices []int
+	current func(wordguess.ChatSettings) int
+	update  func(chatID int64, seconds int, store repository.Store) error
+}
+
+var timerSettings = []timerSetting{
+	{
+		name:    "round",
+		text:    "⚙️ *Round Time*\nHow long a leader has for a word before it is revealed:",
+		choices: wordguess.RoundChoices,
+		current: func(s wordguess.ChatSettings) int { return s.RoundSeconds },
+		update:  wordguess.UpdateRoundSeconds,
+	},
+	{
+		name:    "idle",
+		text:    "⚙️ *Quiet Leader*\nHow long a leader may go without a message before the round ends, and before /word can start a new one:",
+		choices: wordguess.IdleChoices,
+		current: func(s wordguess.ChatSettings) int { return s.IdleSeconds },
+		update:  wordguess.UpdateIdleSeconds,
+	},
+}
+
+func (s timerSetting) menu(current int) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, seconds := range s.choices {
+		label := "No limit"
+		if seconds > 0 {
+			label = formatDuration(time.Duration(seconds) * time.Second)
+		}
+		if seconds == current {
+			label = "✅ " + label
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("set_wordguess_%s_%d", s.name, seconds)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row[:2], row[2:],
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "setting_wordguess_main")))
+}
+
+func registerTimerSettings(r *router.Router) {
+	for _, s := range timerSettings {
+		r.Callback(router.Callback{Data: "setting_wordguess_" + s.name, Handler: func(c *router.Context) {
+			editMenu(c, s.text, s.menu(s.current(wordguess.GetChatSettings(c.ChatID, c.Store))))
+		}})
+		r.Callback(router.Callback{Prefix: "set_wordguess_" + s.name + "_", Handler: func(c *router.Context) {
+			seconds, err := strconv.Atoi(c.Args())
+			if err != nil || !slices.Contains(s.choices, seconds) {
+				return
+			}
+			if err := s.update(c.ChatID, seconds, c.Store); err != nil {
+				log.Printf("Failed to update the %s timer of chat %d: %v", s.name, c.ChatID, err)
+				c.Answer("Failed to update setting.")
+				return
+			}
+			editButtons(c, s.menu(seconds))
+			c.Answer("Settings saved!")
+		}})
+	}
+}
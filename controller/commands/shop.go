@@ -1,4 +1,4 @@
-package controller
+package commands
 
 import (
 	"fmt"
@@ -6,10 +6,10 @@ import (
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func showShop(bot *tgbotapi.BotAPI, chatID int64, userID int, client *mongo.Client) {
+// ShowShop sends the emoji shop with userID's current balance to chatID.
+func ShowShop(bot *tgbotapi.BotAPI, chatID int64, userID int, store repository.Store) {
 	var rows [][]tgbotapi.InlineKeyboardButton
 
 	// Add inventory button
@@ -34,7 +34,7 @@ func showShop(bot *tgbotapi.BotAPI, chatID int64, userID int, client *mongo.Clie
 
 	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
 
-	points := repository.GetCurrentPoints(client, userID)
+	points := store.GetCurrentPoints(userID)
 	text := fmt.Sprintf("🛒 *Welcome to the Emoji Shop!*\n\nSpend your Wordle Points here to buy custom emojis that will appear next to your name on leaderboards.\n\n💰 *Your Balance:* %d 🪙", points)
 
 	view.SendMessageWithButtons(bot, chatID, text, markup)
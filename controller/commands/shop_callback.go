@@ -1,38 +1,35 @@
-package categorybot
+package commands
 
 import (
 	"fmt"
 	"strings"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/ledger"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func handleShopCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client *mongo.Client) bool {
+// handleShopCallback routes the shop's inventory, buy and equip buttons.
+func handleShopCallback(c *router.Context) {
+	bot, callback, store := c.Bot, c.Callback, c.Store
 	data := callback.Data
 
 	if data == "inventory" {
-		showInventory(bot, callback, client)
-		return true
+		showInventory(bot, callback, store)
 	} else if data == "shop_main" {
-		editShopMain(bot, callback, client)
-		return true
+		editShopMain(bot, callback, store)
 	} else if strings.HasPrefix(data, "buy_emoji_") {
 		emoji := strings.TrimPrefix(data, "buy_emoji_")
-		handleBuyEmoji(bot, callback, client, emoji)
-		return true
+		handleBuyEmoji(bot, callback, store, emoji)
 	} else if strings.HasPrefix(data, "equip_emoji_") {
 		emoji := strings.TrimPrefix(data, "equip_emoji_")
-		handleEquipEmoji(bot, callback, client, emoji)
-		return true
+		handleEquipEmoji(bot, callback, store, emoji)
 	}
-
-	return false
 }
 
-func editShopMain(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client *mongo.Client) {
+func editShopMain(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, store repository.Store) {
 	var rows [][]tgbotapi.InlineKeyboardButton
 
 	// Add inventory button
@@ -57,7 +54,7 @@ func editShopMain(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client
 
 	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
 
-	points := repository.GetCurrentPoints(client, int(callback.From.ID))
+	points := store.GetCurrentPoints(int(callback.From.ID))
 	text := fmt.Sprintf("🛒 *Welcome to the Emoji Shop!*\n\nSpend your Wordle Points here to buy custom emojis that will appear next to your name on leaderboards.\n\n💰 *Your Balance:* %d 🪙", points)
 
 	editMsg := tgbotapi.NewEditMessageText(callback.Message.Chat.ID, callback.Message.MessageID, text)
@@ -68,8 +65,8 @@ func editShopMain(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client
 	bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
 }
 
-func showInventory(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client *mongo.Client) {
-	purchased, err := repository.GetPurchasedEmojis(client, int(callback.From.ID))
+func showInventory(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, store repository.Store) {
+	purchased, err := store.GetPurchasedEmojis(int(callback.From.ID))
 	if err != nil {
 		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, "Error loading inventory!"))
 		return
@@ -80,7 +77,7 @@ func showInventory(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, clien
 		return
 	}
 
-	equipped, err := repository.GetEquippedEmojis(client, int(callback.From.ID))
+	equipped, err := store.GetEquippedEmojis(int(callback.From.ID))
 	if err != nil {
 		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, "Error loading equipped emojis!"))
 		return
@@ -122,7 +119,7 @@ func showInventory(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, clien
 	bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, ""))
 }
 
-func handleBuyEmoji(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client *mongo.Client, emoji string) {
+func handleBuyEmoji(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, store repository.Store, emoji string) {
 	var price int
 	for _, item := range service.ShopItems {
 		if item.Emoji == emoji {
@@ -136,7 +133,7 @@ func handleBuyEmoji(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, clie
 		return
 	}
 
-	purchased, _ := repository.GetPurchasedEmojis(client, int(callback.From.ID))
+	purchased, _ := store.GetPurchasedEmojis(int(callback.From.ID))
 	for _, e := range purchased {
 		if e == emoji {
 			bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, "You already own this emoji!"))
@@ -144,15 +141,19 @@ func handleBuyEmoji(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, clie
 		}
 	}
 
-	points := repository.GetCurrentPoints(client, int(callback.From.ID))
-	if points < price {
+	// One key per user and emoji: if delivery failed last time, the retry
+	// finds the payment already made and only delivers.
+	key := ledger.Key("emoji", callback.From.ID, emoji)
+	_, err := ledger.Debit(store, key, int(callback.From.ID), callback.From.FirstName, callback.Message.Chat.ID, price, "Emoji "+emoji)
+	if err == ledger.ErrInsufficientPoints {
 		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("Not enough points! You need %d points.", price)))
 		return
+	} else if err != nil && err != ledger.ErrDuplicate {
+		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, "Error purchasing emoji! Please try again."))
+		return
 	}
 
-	repository.DeductWordlePoints(client, int(callback.From.ID), callback.From.FirstName, callback.Message.Chat.ID, price)
-
-	err := repository.PurchaseEmoji(client, int(callback.From.ID), emoji)
+	err = store.PurchaseEmoji(int(callback.From.ID), emoji)
 	if err != nil {
 		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, "Error purchasing emoji! Please try again."))
 		return
@@ -161,11 +162,11 @@ func handleBuyEmoji(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, clie
 	bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, fmt.Sprintf("Successfully bought %s for %d points!", emoji, price)))
 
 	// Show inventory so the user can immediately equip their new emoji
-	showInventory(bot, callback, client)
+	showInventory(bot, callback, store)
 }
 
-func handleEquipEmoji(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, client *mongo.Client, emoji string) {
-	isEquipped, err := repository.ToggleEquipEmoji(client, int(callback.From.ID), emoji)
+func handleEquipEmoji(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, store repository.Store, emoji string) {
+	isEquipped, err := store.ToggleEquipEmoji(int(callback.From.ID), emoji)
 	if err != nil {
 		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callback.ID, "Error toggling emoji!"))
 		return
@@ -177,5 +178,5 @@ func handleEquipEmoji(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, cl
 	}
 
 	bot.AnswerCallbackQuery(tgbotapi.NewCallback(callback.ID, fmt.Sprintf("%s has been %s!", emoji, status)))
-	showInventory(bot, callback, client)
+	showInventory(bot, callback, store)
 }
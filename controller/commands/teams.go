@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/modbot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/teams"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// registerTeams adds /teams, Crocodile-style team play of the word-guess
+// round: players pick Red or Blue, the lead alternates between the teams and
+// a match runs to a target score.
+func registerTeams(r *router.Router) {
+	r.Command(router.Command{Name: "teams", Description: "Play the word game Red against Blue", Scope: router.GroupOnly, Handler: handleTeams})
+	r.Callback(router.Callback{Prefix: "teams_", Handler: handleTeamsCallback})
+}
+
+const teamsUsage = "Usage:\n/teams — show the teams\n/teams start [score] — start a match, first to the score wins (default %d)\n/teams stop — end the match (admins)"
+
+func handleTeams(c *router.Context) {
+	args := strings.Fields(strings.ToLower(c.Args()))
+	if len(args) == 0 {
+		text, markup := teamsMenu(c.ChatID)
+		view.SendMessagehtmlWithButtons(c.Bot, c.ChatID, text, markup)
+		return
+	}
+	switch args[0] {
+	case "start":
+		target := teams.DefaultTarget
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil {
+				view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf(teamsUsage, teams.DefaultTarget))
+				return
+			}
+			target = n
+		}
+		switch err := teams.Default.Start(c.ChatID, target); {
+		case errors.Is(err, teams.ErrBadTarget):
+			view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("The target score must be between 1 and %d.", teams.MaxTarget))
+		case errors.Is(err, teams.ErrMatchRunning):
+			view.SendMessage(c.Bot, c.ChatID, "A match is already running. Admins can end it with /teams stop.")
+		case errors.Is(err, teams.ErrTeamsShort):
+			view.SendMessage(c.Bot, c.ChatID, "Both teams need at least one player. Join one with /teams.")
+		case err != nil:
+			log.Printf("Failed to save the team match of chat %d: %v", c.ChatID, err)
+			view.SendMessage(c.Bot, c.ChatID, "Failed to start the match. Please try again later.")
+		default:
+			markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("🌟 Claim Leadership 🙋", "explain"),
+			))
+			view.SendMessagehtmlWithButtons(c.Bot, c.ChatID, fmt.Sprintf("⚔️ <b>The match is on!</b> First team to %d wins.\n"+
+				"Only the other team's guesses score, so explain for them. %s leads first.", target, teams.Red.Label()), markup)
+		}
+	case "stop":
+		if !modbot.IsChatAdmin(c.Bot, c.ChatID, c.UserID) {
+			view.SendMessage(c.Bot, c.ChatID, "Only group admins can end a match.")
+			return
+		}
+		m, stopped, err := teams.Default.Stop(c.ChatID)
+		if err != nil {
+			log.Printf("Failed to save the team match of chat %d: %v", c.ChatID, err)
+		}
+		if !stopped {
+			view.SendMessage(c.Bot, c.ChatID, "No match is running.")
+			return
+		}
+		view.SendMessagehtml(c.Bot, c.ChatID, "🛑 The match was ended early.\n\n"+scoreboard(m))
+	default:
+		view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf(teamsUsage, teams.DefaultTarget))
+	}
+}
+
+func handleTeamsCallback(c *router.Context) {
+	var err error
+	switch arg := c.Args(); arg {
+	case "red", "blue":
+		var joined bool
+		joined, err = teams.Default.Join(c.ChatID, c.UserID, c.FirstName(), teams.Team(arg))
+		if errors.Is(err, teams.ErrMatchRunning) {
+			c.AnswerAlert("You cannot change teams during a match.")
+			return
+		}
+		if err == nil && !joined {
+			c.Answer("You are already on that team.")
+			return
+		}
+	case "leave":
+		var left bool
+		left, err = teams.Default.Leave(c.ChatID, c.UserID)
+		if err == nil && !left {
+			c.Answer("You are not on a team.")
+			return
+		}
+	default:
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to save the teams of chat %d: %v", c.ChatID, err)
+		c.AnswerAlert("Failed to save the teams. Please try again.")
+		return
+	}
+	text, markup := teamsMenu(c.ChatID)
+	edit := tgbotapi.NewEditMessageText(c.ChatID, c.Message.MessageID, text)
+	edit.ParseMode = tgbotapi.ModeHTML
+	edit.ReplyMarkup = &markup
+	c.Bot.Send(edit)
+}
+
+// teamsMenu shows the chat's rosters and the score of the match, with
+// buttons to pick a side.
+func teamsMenu(chatID int64) (string, tgbotapi.InlineKeyboardMarkup) {
+	m := teams.Default.Match(chatID)
+	var sb strings.Builder
+	sb.WriteString("⚔️ <b>Team play</b>\n")
+	if m.Active {
+		fmt.Fprintf(&sb, "Match to %d: %s %d – %d %s. %s leads next.\n\n",
+			m.Target, teams.Red.Label(), m.RedScore, m.BlueScore, teams.Blue.Label(), m.Turn.Label())
+	} else {
+		sb.WriteString("Pick a side, then start a match with /teams start.\n\n")
+	}
+	for _, t := range []teams.Team{teams.Red, teams.Blue} {
+		fmt.Fprintf(&sb, "<b>%s</b>\n", t.Label())
+		roster := m.Roster(t)
+		if len(roster) == 0 {
+			sb.WriteString("Nobody yet.\n")
+		}
+		for _, p := range roster {
+			fmt.Fprintf(&sb, "• %s\n", html.EscapeString(p.Name))
+		}
+		sb.WriteString("\n")
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔴 Join Red", "teams_red"),
+			tgbotapi.NewInlineKeyboardButtonData("🔵 Join Blue", "teams_blue"),
+		),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🚪 Leave", "teams_leave")),
+	)
+	return strings.TrimSpace(sb.String()), markup
+}
+
+// scoreboard writes the final standings of a match: the score, and what each
+// player guessed and explained.
+func scoreboard(m teams.Match) string {
+	var sb strings.Builder
+	if winner, ok := m.Winner(); ok {
+		fmt.Fprintf(&sb, "🏆 <b>%s wins %d – %d!</b>\n\n", winner.Label(), m.Score(winner), m.Score(winner.Other()))
+	} else {
+		fmt.Fprintf(&sb, "🤝 <b>It's a draw, %d – %d.</b>\n\n", m.RedScore, m.BlueScore)
+	}
+	for _, t := range []teams.Team{teams.Red, teams.Blue} {
+		fmt.Fprintf(&sb, "<b>%s — %d</b>\n", t.Label(), m.Score(t))
+		for _, p := range m.Roster(t) {
+			fmt.Fprintf(&sb, "• %s: 🎯 %d guessed, 🗣️ %d explained\n", html.EscapeString(p.Name), p.Guessed, p.Explained)
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// TeamGuess settles a correct group guess of the leader's word while a team
+// match is running, and reports whether it did; if not, the guess scores as
+// usual. A guess from the team not leading wins that team a point, and the
+// guesser and leader their usual points, less the hints given. Any other guess ends the round
+// without score.
+func TeamGuess(bot *tgbotapi.BotAPI, store repository.Store, message *tgbotapi.Message, leaderID int, leaderName, word string, hints int) bool {
+	chatID := message.Chat.ID
+	result, err := teams.Default.Guessed(chatID, message.From.ID, leaderID)
+	if err != nil {
+		log.Printf("Failed to save the team match of chat %d: %v", chatID, err)
+	}
+	if result.Outcome == teams.NoMatch {
+		return false
+	}
+
+	name := fmt.Sprintf("<a href=\"tg://user?id=%d\">%s</a>", message.From.ID, html.EscapeString(message.From.FirstName))
+	var text string
+	switch result.Outcome {
+	case teams.Scored:
+		RewardGuess(store, message, leaderID, leaderName, hints)
+		if result.Won {
+			view.SendMessagehtml(bot, chatID, fmt.Sprintf("🎉 %s guessed <b>%s</b> and wins the match for %s!\n\n%s",
+				name, html.EscapeString(word), result.Team.Label(), scoreboard(result.Match)))
+			return true
+		}
+		text = fmt.Sprintf("🎉 %s guessed <b>%s</b>! +1 for %s.", name, html.EscapeString(word), result.Team.Label())
+	case teams.OwnTeam:
+		text = fmt.Sprintf("🙅 %s guessed <b>%s</b>, but it was their own team's word, so it doesn't count.", name, html.EscapeString(word))
+	case teams.NotPlaying:
+		text = fmt.Sprintf("🙅 %s guessed <b>%s</b>, but they are not on a team, so it doesn't count. Join one with /teams.", name, html.EscapeString(word))
+	}
+	m := result.Match
+	text += fmt.Sprintf("\n\n%s %d – %d %s (first to %d). %s leads next.",
+		teams.Red.Label(), m.RedScore, m.BlueScore, teams.Blue.Label(), m.Target, m.Turn.Label())
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🌟 Claim Leadership 🙋", "explain"),
+	))
+	view.SendMessagehtmlWithButtons(bot, chatID, text, markup)
+	return true
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"log"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordguess"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const languageText = "⚙️ *Language*\nThe language of the words, the hints and the guesses. A round that is running keeps its word."
+
+func languageMenu(current wordguess.Language) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, lang := range wordguess.Languages {
+		label := lang.Label()
+		if lang == current {
+			label = "✅ " + label
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, "set_wordguess_lang_"+string(lang)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row[:2], row[2:],
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "setting_wordguess_main")))
+}
+
+func registerLanguageSettings(r *router.Router) {
+	r.Callback(router.Callback{Data: "setting_wordguess_lang", Handler: func(c *router.Context) {
+		editMenu(c, languageText, languageMenu(wordguess.GetChatSettings(c.ChatID, c.Store).Language))
+	}})
+	r.Callback(router.Callback{Prefix: "set_wordguess_lang_", Handler: func(c *router.Context) {
+		lang := wordguess.Language(c.Args())
+		if !lang.Valid() {
+			return
+		}
+		if err := wordguess.UpdateLanguage(c.ChatID, lang, c.Store); err != nil {
+			log.Printf("Failed to update the language of chat %d: %v", c.ChatID, err)
+			c.Answer("Failed to update setting.")
+			return
+		}
+		editButtons(c, languageMenu(lang))
+		c.Answer("Settings saved!")
+	}})
+}
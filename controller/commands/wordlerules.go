@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/wordlebot"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const wordleRulesText = "⚙️ *Wordle Rules*\nPick the word length, how many guesses a game allows and whether hard mode is on. In hard mode every letter found must be used in later guesses, greens in place.\n\nDordle and Quordle hide 2 or 4 words; every guess is played on all boards, with an extra attempt per board past the first. Hard mode is for single boards only.\n\nChanges apply from the next game."
+
+func wordleRulesMenu(rules wordlebot.ChatSettings) tgbotapi.InlineKeyboardMarkup {
+	check := func(on bool, label string) string {
+		if on {
+			return "✅ " + label
+		}
+		return label
+	}
+	var lengths, tries, boards []tgbotapi.InlineKeyboardButton
+	for n := wordlebot.MinWordLength; n <= wordlebot.MaxWordLength; n++ {
+		lengths = append(lengths, tgbotapi.NewInlineKeyboardButtonData(check(n == rules.WordLength, fmt.Sprintf("%d 🔡", n)), fmt.Sprintf("set_wordle_len_%d", n)))
+	}
+	for _, n := range wordlebot.AttemptChoices {
+		tries = append(tries, tgbotapi.NewInlineKeyboardButtonData(check(n == rules.MaxAttempts, fmt.Sprintf("%d 🎯", n)), fmt.Sprintf("set_wordle_tries_%d", n)))
+	}
+	for _, n := range wordlebot.BoardChoices {
+		boards = append(boards, tgbotapi.NewInlineKeyboardButtonData(check(n == rules.Boards, wordlebot.ModeName(n)), fmt.Sprintf("set_wordle_boards_%d", n)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(lengths, tries, boards,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(check(rules.HardMode, "Hard 💪"), "set_wordle_hard_on"),
+			tgbotapi.NewInlineKeyboardButtonData(check(!rules.HardMode, "Normal"), "set_wordle_hard_off"),
+		),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔙 Back", "settings_main")),
+	)
+}
+
+// setWordleRules saves rules for the chat and shows them on the menu.
+func setWordleRules(c *router.Context, rules wordlebot.ChatSettings) {
+	if err := wordlebot.UpdateWordleRules(c.ChatID, rules.WordLength, rules.MaxAttempts, rules.HardMode, c.Store); err != nil {
+		log.Printf("Failed to update the Wordle rules of chat %d: %v", c.ChatID, err)
+		c.Answer("Failed to update setting.")
+		return
+	}
+	editButtons(c, wordleRulesMenu(rules))
+	c.Answer("Settings saved!")
+}
+
+func registerWordleRulesSettings(r *router.Router) {
+	r.Callback(router.Callback{Data: "setting_wordle_rules", Handler: func(c *router.Context) {
+		editMenu(c, wordleRulesText, wordleRulesMenu(wordlebot.Rules(c.ChatID, c.Store)))
+	}})
+	r.Callback(router.Callback{Prefix: "set_wordle_len_", Handler: func(c *router.Context) {
+		n, err := strconv.Atoi(c.Args())
+		if err != nil || n < wordlebot.MinWordLength || n > wordlebot.MaxWordLength {
+			return
+		}
+		rules := wordlebot.Rules(c.ChatID, c.Store)
+		rules.WordLength = n
+		setWordleRules(c, rules)
+	}})
+	r.Callback(router.Callback{Prefix: "set_wordle_tries_", Handler: func(c *router.Context) {
+		n, err := strconv.Atoi(c.Args())
+		if err != nil || !slices.Contains(wordlebot.AttemptChoices, n) {
+			return
+		}
+		rules := wordlebot.Rules(c.ChatID, c.Store)
+		rules.MaxAttempts = n
+		setWordleRules(c, rules)
+	}})
+	r.Callback(router.Callback{Prefix: "set_wordle_boards_", Handler: func(c *router.Context) {
+		n, err := strconv.Atoi(c.Args())
+		if err != nil || !slices.Contains(wordlebot.BoardChoices, n) {
+			return
+		}
+		if err := wordlebot.UpdateWordleBoards(c.ChatID, n, c.Store); err != nil {
+			log.Printf("Failed to update the Wordle boards of chat %d: %v", c.ChatID, err)
+			c.Answer("Failed to update setting.")
+			return
+		}
+		rules := wordlebot.Rules(c.ChatID, c.Store)
+		editButtons(c, wordleRulesMenu(rules))
+		c.Answer("Settings saved!")
+	}})
+	r.Callback(router.Callback{Prefix: "set_wordle_hard_", Handler: func(c *router.Context) {
+		rules := wordlebot.Rules(c.ChatID, c.Store)
+		switch c.Args() {
+		case "on":
+			rules.HardMode = true
+		case "off":
+			rules.HardMode = false
+		default:
+			return
+		}
+		setWordleRules(c, rules)
+	}})
+}
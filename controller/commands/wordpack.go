@@ -0,0 +1,193 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/modbot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordbank"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const wordPackUsage = "Usage:\n" +
+	"/wordpack — choose the packs the words come from\n" +
+	"/wordpack add <name> [title] — as a reply to a .txt or .csv word list\n" +
+	"/wordpack delete <name>"
+
+var downloadClient = &http.Client{Timeout: 30 * time.Second}
+
+// registerWordPacks adds /wordpack, with which group admins choose the packs
+// the word-guess words come from and upload their own.
+func registerWordPacks(r *router.Router) {
+	r.Command(router.Command{Name: "wordpack", Description: "Choose or upload word packs for the word game", Handler: handleWordPack})
+	r.Callback(router.Callback{Prefix: "wordpack_", Handler: handleWordPackCallback})
+}
+
+func handleWordPack(c *router.Context) {
+	args := strings.Fields(c.Args())
+	if len(args) == 0 {
+		text, markup := wordPackMenu(c.ChatID)
+		view.SendMessagehtmlWithButtons(c.Bot, c.ChatID, text, markup)
+		return
+	}
+	if !modbot.IsChatAdmin(c.Bot, c.ChatID, c.UserID) {
+		view.SendMessage(c.Bot, c.ChatID, "Only group admins can change the word packs.")
+		return
+	}
+	switch {
+	case args[0] == "add" && len(args) >= 2:
+		addWordPack(c, strings.ToLower(args[1]), strings.Join(args[2:], " "))
+	case args[0] == "delete" && len(args) == 2:
+		err := wordbank.DeleteChatPack(wordbank.Default, c.Store, c.ChatID, strings.ToLower(args[1]))
+		switch {
+		case errors.Is(err, repository.ErrWordPackNotFound):
+			view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("This chat has no pack called %q.", args[1]))
+		case err != nil:
+			log.Printf("Failed to delete word pack %s of chat %d: %v", args[1], c.ChatID, err)
+			view.SendMessage(c.Bot, c.ChatID, "Failed to delete the pack. Please try again later.")
+		default:
+			view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("Deleted the %s pack.", args[1]))
+		}
+	default:
+		view.SendMessage(c.Bot, c.ChatID, wordPackUsage)
+	}
+}
+
+// addWordPack reads the word list the command replies to into the chat's
+// pack called name.
+func addWordPack(c *router.Context, name, title string) {
+	reply := c.Message.ReplyToMessage
+	if reply == nil || reply.Document == nil {
+		view.SendMessage(c.Bot, c.ChatID, "Reply to a .txt or .csv file with /wordpack add <name> [title].")
+		return
+	}
+	doc := reply.Document
+	if doc.FileSize > wordbank.MaxUploadBytes {
+		view.SendMessage(c.Bot, c.ChatID, fmt.Sprintf("Word lists can be at most %d KB.", wordbank.MaxUploadBytes>>10))
+		return
+	}
+	data, err := downloadFile(c.Bot, doc.FileID)
+	if err != nil {
+		log.Printf("Failed to download word list %s: %v", doc.FileID, err)
+		view.SendMessage(c.Bot, c.ChatID, "Failed to download the file. Please try again.")
+		return
+	}
+	upload, err := wordbank.ParseUpload(name, title, doc.FileName, data)
+	if err != nil {
+		view.SendMessage(c.Bot, c.ChatID, "Could not use this word list: "+err.Error())
+		return
+	}
+	if err := wordbank.SaveChatPack(wordbank.Default, c.Store, c.ChatID, c.UserID, upload.Pack); err != nil {
+		log.Printf("Failed to save word pack %s of chat %d: %v", name, c.ChatID, err)
+		view.SendMessage(c.Bot, c.ChatID, "Could not save the pack: "+err.Error())
+		return
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📚 Added <b>%s</b> with %d words. New rounds use it now; /wordpack changes the packs in play.",
+		html.EscapeString(upload.Pack.Title), len(upload.Pack.Words))
+	if n := len(upload.Rejected); n > 0 {
+		shown := upload.Rejected[:min(n, 5)]
+		fmt.Fprintf(&sb, "\n\nSkipped %d lines that are not words, such as:\n<code>%s</code>", n, html.EscapeString(strings.Join(shown, "\n")))
+	}
+	view.SendMessagehtml(c.Bot, c.ChatID, sb.String())
+}
+
+func downloadFile(bot *tgbotapi.BotAPI, fileID string) ([]byte, error) {
+	url, err := bot.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := downloadClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download: %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, wordbank.MaxUploadBytes+1))
+}
+
+func handleWordPackCallback(c *router.Context) {
+	if !modbot.IsChatAdmin(c.Bot, c.ChatID, c.UserID) {
+		c.AnswerAlert("Only group admins can change the word packs.")
+		return
+	}
+	var err error
+	switch arg := c.Args(); {
+	case arg == "all":
+		err = wordbank.SetActivePacks(wordbank.Default, c.Store, c.ChatID, nil)
+	case strings.HasPrefix(arg, "t_"):
+		err = wordbank.TogglePack(wordbank.Default, c.Store, c.ChatID, strings.TrimPrefix(arg, "t_"))
+	default:
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to save the word packs of chat %d: %v", c.ChatID, err)
+		c.AnswerAlert("Failed to save the word packs. Please try again.")
+		return
+	}
+	text, markup := wordPackMenu(c.ChatID)
+	edit := tgbotapi.NewEditMessageText(c.ChatID, c.Message.MessageID, text)
+	edit.ParseMode = tgbotapi.ModeHTML
+	edit.ReplyMarkup = &markup
+	c.Bot.Send(edit)
+}
+
+// wordPackMenu lists the built-in and the chat's own packs, with a button to
+// tick or untick each.
+func wordPackMenu(chatID int64) (string, tgbotapi.InlineKeyboardMarkup) {
+	chat := wordbank.Default.Chat(chatID)
+	active := make(map[string]bool)
+	for _, name := range chat.Active {
+		active[name] = true
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	add := func(p wordbank.Pack, own bool) {
+		mark := "▫️"
+		if active[p.Name] {
+			mark = "✅"
+		}
+		label := fmt.Sprintf("%s %s (%d)", mark, p.Title, len(p.Words))
+		if own {
+			label += " ⭐"
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, "wordpack_t_"+p.Name))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	for _, p := range wordbank.Default.Packs() {
+		add(p, false)
+	}
+	for _, p := range chat.Custom {
+		add(p, true)
+	}
+	if row != nil {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("🔄 All built-in packs", "wordpack_all")))
+
+	var sb strings.Builder
+	sb.WriteString("📚 <b>Word packs</b>\n")
+	if len(chat.Active) == 0 {
+		sb.WriteString("Words come from every built-in pack.")
+	} else {
+		sb.WriteString("Words come from the ticked packs.")
+	}
+	sb.WriteString(" ⭐ marks this chat's own packs.\n\nAdmins can tick packs below, add a pack by replying to a .txt or .csv word list with <code>/wordpack add name [title]</code>, and remove one with <code>/wordpack delete name</code>.")
+	return sb.String(), tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
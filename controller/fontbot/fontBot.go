@@ -1,10 +1,13 @@
 package fontbot
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/webhook"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
@@ -15,31 +18,34 @@ type FormatBot struct {
 }
 
 // StartFormatBot initializes and starts the format bot for inline queries
-func StartFormatBot(botToken string) error {
-	bot, err := tgbotapi.NewBotAPI(botToken)
+func StartFormatBot(ctx context.Context, cfg config.Config) error {
+	bot, err := tgbotapi.NewBotAPI(cfg.Bots.Font.Token)
 	if err != nil {
 		return err
 	}
 
 	formatBot := &FormatBot{bot: bot}
 
-	bot.Debug = true
+	bot.Debug = cfg.Features.Debug
 	log.Printf("Format Bot authorized on account %s", bot.Self.UserName)
 
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
-	updates, err := bot.GetUpdatesChan(u)
+	updates, err := webhook.Updates(cfg, "font", cfg.Bots.Font.Token, func() (<-chan tgbotapi.Update, error) {
+		return bot.GetUpdatesChan(u)
+	})
 	if err != nil {
 		return err
 	}
 
 	// Handle both inline queries and regular messages
-	for update := range updates {
+	webhook.Serve(ctx, updates, func(update tgbotapi.Update) {
 		if update.InlineQuery != nil {
-			go formatBot.handleInlineQuery(update.InlineQuery)
+			formatBot.handleInlineQuery(update.InlineQuery)
 		}
-	}
+	})
+	bot.StopReceivingUpdates()
 
 	return nil
 }
@@ -0,0 +1,20 @@
+package game
+
+import (
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/closeguess"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// CloseGuess nudges a player whose wrong guess of answer was nearly right,
+// with a reaction or, now and then, a "so close!" reply. allowed is how many
+// edits the game already accepts as a right answer.
+func CloseGuess(bot *tgbotapi.BotAPI, store repository.Store, msg *tgbotapi.Message, guess, answer string, allowed int) {
+	switch closeguess.Check(store, msg.Chat.ID, msg.From.ID, guess, answer, allowed) {
+	case closeguess.React:
+		go view.ReactToMessage(bot.Token, msg.Chat.ID, msg.MessageID, "👀", false)
+	case closeguess.Reply:
+		view.ReplyToMessage(bot, msg.MessageID, msg.Chat.ID, "🤏 So close!")
+	}
+}
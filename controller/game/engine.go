@@ -0,0 +1,154 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/rating"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Engine runs a set of games for one bot: it dispatches commands and guesses
+// to them, rates finished rounds and saves and restores the sessions the
+// games keep.
+type Engine struct {
+	env    Env
+	games  []Game
+	byName map[string]Game
+}
+
+// NewEngine returns an engine running games, in the order given. It panics if
+// two games share a name, which is a programming error.
+func NewEngine(env Env, games ...Game) *Engine {
+	e := &Engine{env: env, byName: make(map[string]Game)}
+	for _, g := range games {
+		name := g.Info().Name
+		if _, dup := e.byName[name]; dup {
+			panic(fmt.Sprintf("game: %q registered twice", name))
+		}
+		e.byName[name] = g
+		e.games = append(e.games, g)
+	}
+	return e
+}
+
+// Env returns the environment the games run in.
+func (e *Engine) Env() Env {
+	return e.env
+}
+
+// Games returns the games in the order they were registered.
+func (e *Engine) Games() []Game {
+	return e.games
+}
+
+// Game returns the game called name.
+func (e *Engine) Game(name string) (Game, bool) {
+	g, ok := e.byName[name]
+	return g, ok
+}
+
+// Start starts the game called name in the chat. It returns false if there is
+// no such game.
+func (e *Engine) Start(name string, chatID int64, p Player) bool {
+	g, ok := e.byName[name]
+	if !ok {
+		return false
+	}
+	g.Start(e.env, chatID, p)
+	return true
+}
+
+// Active reports whether any game has a round in the chat that takes guesses.
+func (e *Engine) Active(chatID int64) bool {
+	for _, g := range e.games {
+		if g.Active(chatID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Guess passes msg to every game that is active in its chat, and reports
+// whether there was one. Rounds the guess ended are rated.
+func (e *Engine) Guess(msg *tgbotapi.Message) bool {
+	handled := false
+	for _, g := range e.games {
+		if g.Active(msg.Chat.ID) {
+			if res := g.Guess(e.env, msg); res != nil {
+				e.rate(g, res)
+			}
+			handled = true
+		}
+	}
+	return handled
+}
+
+// Finish rates a round of the game called name that ended outside Guess,
+// such as on a button press.
+func (e *Engine) Finish(name string, res *Result) {
+	if g, ok := e.byName[name]; ok && res != nil {
+		e.rate(g, res)
+	}
+}
+
+// rate updates the ratings of the players of a finished round of g in the
+// background.
+func (e *Engine) rate(g Game, res *Result) {
+	if e.env.Store == nil || len(res.Scores) < 2 {
+		return
+	}
+	results := make([]rating.Result, len(res.Scores))
+	for i, s := range res.Scores {
+		results[i] = rating.Result{UserID: s.ID, Name: s.Name, Score: s.Points}
+	}
+	name := g.Info().Name
+	repository.SaveAsync(func() {
+		if _, err := rating.Update(e.env.Store, name, results); err != nil {
+			log.Printf("Failed to update %s ratings: %v", name, err)
+		}
+	})
+}
+
+// Hint asks the game called name for a hint. It returns false if there is no
+// such game or it has no hints.
+func (e *Engine) Hint(name string, msg *tgbotapi.Message) bool {
+	g, ok := e.byName[name]
+	return ok && g.Hint(e.env, msg)
+}
+
+// Cancel ends the round of the game called name in the chat. It returns false
+// if there was none.
+func (e *Engine) Cancel(name string, chatID int64) bool {
+	g, ok := e.byName[name]
+	return ok && g.Cancel(e.env, chatID)
+}
+
+// Save writes the chat's session of g to the store in the background.
+func (e *Engine) Save(g Game, chatID int64) {
+	doc, ok := g.Snapshot(chatID)
+	if !ok {
+		return
+	}
+	Save(e.env.Store, g.Info().States, chatID, doc)
+}
+
+// Restore loads the saved sessions of every persisted game. A game that fails
+// to load is logged and starts empty; the others are still restored.
+func (e *Engine) Restore() {
+	for _, g := range e.games {
+		info := g.Info()
+		if info.States == "" || e.env.Store == nil {
+			continue
+		}
+		n, err := g.Restore(func(target any) error {
+			return e.env.Store.LoadAllGameStates(info.States, target)
+		})
+		if err != nil {
+			log.Printf("Failed to load saved %s states: %v", info.Title, err)
+			continue
+		}
+		log.Printf("Loaded %d %s states", n, info.Title)
+	}
+}
@@ -0,0 +1,210 @@
+package game
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+type fakeDoc struct {
+	ChatID int64  `bson:"_id"`
+	Word   string `bson:"word"`
+}
+
+// fakeGame keeps one word per chat; a chat with a word is active.
+type fakeGame struct {
+	name    string
+	mu      sync.Mutex
+	words   map[int64]string
+	guesses []string
+	result  *Result // returned by the guess that finds the word
+}
+
+func newFakeGame(name string) *fakeGame {
+	return &fakeGame{name: name, words: make(map[int64]string)}
+}
+
+func (g *fakeGame) Info() Info {
+	return Info{Name: g.name, Title: g.name, States: g.name + "States"}
+}
+
+func (g *fakeGame) Scoreboard() string {
+	return g.name + "Points"
+}
+
+func (g *fakeGame) Start(env Env, chatID int64, p Player) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.words[chatID] = p.Name
+}
+
+func (g *fakeGame) Active(chatID int64) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.words[chatID] != ""
+}
+
+func (g *fakeGame) Guess(env Env, msg *tgbotapi.Message) *Result {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.guesses = append(g.guesses, msg.Text)
+	if msg.Text != g.words[msg.Chat.ID] {
+		return nil
+	}
+	delete(g.words, msg.Chat.ID)
+	return g.result
+}
+
+func (g *fakeGame) Hint(Env, *tgbotapi.Message) bool { return false }
+
+func (g *fakeGame) Cancel(env Env, chatID int64) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	_, ok := g.words[chatID]
+	delete(g.words, chatID)
+	return ok
+}
+
+func (g *fakeGame) Snapshot(chatID int64) (any, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	word, ok := g.words[chatID]
+	return fakeDoc{ChatID: chatID, Word: word}, ok
+}
+
+func (g *fakeGame) Restore(load func(target any) error) (int, error) {
+	var docs []fakeDoc
+	if err := load(&docs); err != nil {
+		return 0, err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, doc := range docs {
+		g.words[doc.ChatID] = doc.Word
+	}
+	return len(docs), nil
+}
+
+func guess(chatID int64, text string) *tgbotapi.Message {
+	return &tgbotapi.Message{Text: text, Chat: &tgbotapi.Chat{ID: chatID}}
+}
+
+func TestGuessGoesToActiveGamesOnly(t *testing.T) {
+	a, b := newFakeGame("a"), newFakeGame("b")
+	e := NewEngine(Env{}, a, b)
+
+	if e.Guess(guess(1, "early")) || e.Active(1) {
+		t.Fatal("Guess reported a game with none started")
+	}
+	e.Start("b", 1, Player{Name: "word"})
+	if !e.Active(1) || e.Active(2) {
+		t.Error("Active does not follow the started game")
+	}
+	if !e.Guess(guess(1, "hello")) {
+		t.Fatal("Guess did not reach the started game")
+	}
+	e.Guess(guess(2, "other chat"))
+
+	if len(a.guesses) != 0 {
+		t.Errorf("inactive game got guesses %v", a.guesses)
+	}
+	if len(b.guesses) != 1 || b.guesses[0] != "hello" {
+		t.Errorf("active game got guesses %v, want [hello]", b.guesses)
+	}
+}
+
+func TestCancelAndHint(t *testing.T) {
+	g := newFakeGame("a")
+	e := NewEngine(Env{}, g)
+
+	if e.Cancel("a", 1) {
+		t.Error("Cancel succeeded with no game running")
+	}
+	e.Start("a", 1, Player{Name: "word"})
+	if !e.Cancel("a", 1) || g.Active(1) {
+		t.Error("Cancel did not end the game")
+	}
+	if e.Cancel("missing", 1) || e.Hint("missing", guess(1, "")) {
+		t.Error("unknown game was handled")
+	}
+	if e.Start("missing", 1, Player{}) {
+		t.Error("Start succeeded for an unknown game")
+	}
+}
+
+func TestSaveAndRestore(t *testing.T) {
+	store := repository.NewMemoryStore()
+	before := newFakeGame("a")
+	e := NewEngine(Env{Store: store}, before)
+	e.Start("a", 7, Player{Name: "apple"})
+	e.Save(before, 7)
+	e.Save(before, 8) // no session, nothing saved
+	if err := repository.FlushSaves(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	after := newFakeGame("a")
+	NewEngine(Env{Store: store}, after).Restore()
+	if len(after.words) != 1 || after.words[7] != "apple" {
+		t.Errorf("restored %v, want map[7:apple]", after.words)
+	}
+}
+
+func TestFinishedRoundsAreRated(t *testing.T) {
+	store := repository.NewMemoryStore()
+	g := newFakeGame("a")
+	g.result = &Result{Scores: []Score{
+		{Player: Player{ID: 1, Name: "Ann"}, Points: 1},
+		{Player: Player{ID: 2, Name: "Bob"}},
+	}}
+	e := NewEngine(Env{Store: store}, g)
+	e.Start("a", 1, Player{Name: "apple"})
+
+	e.Guess(guess(1, "pear"))
+	e.Guess(guess(1, "apple"))
+	if err := repository.FlushSaves(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	top, err := store.TopRatings("a", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 2 || top[0].UserID != 1 || top[0].Rounds != 1 || top[0].Rating <= top[1].Rating {
+		t.Errorf("ratings %+v, want Ann above Bob after one round", top)
+	}
+}
+
+func TestFinishRatesRoundsEndedElsewhere(t *testing.T) {
+	store := repository.NewMemoryStore()
+	e := NewEngine(Env{Store: store}, newFakeGame("a"))
+	e.Finish("a", &Result{Scores: []Score{
+		{Player: Player{ID: 1, Name: "Ann"}},
+		{Player: Player{ID: 2, Name: "Bob"}, Points: 1},
+	}})
+	e.Finish("nosuchgame", &Result{})
+	e.Finish("a", nil)
+	if err := repository.FlushSaves(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	top, err := store.TopRatings("a", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 2 || top[0].UserID != 2 {
+		t.Errorf("ratings %+v, want Bob above Ann", top)
+	}
+}
+
+func TestDuplicateGamePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a game twice did not panic")
+		}
+	}()
+	NewEngine(Env{}, newFakeGame("a"), newFakeGame("a"))
+}
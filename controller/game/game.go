@@ -0,0 +1,84 @@
+// Package game defines what every minigame (Wordle, Scramy, Geography, ...)
+// looks like to the bots, and the Engine that starts games, routes guesses to
+// them and saves their per-chat state. Each Game is a facade over its
+// package: the sessions stay in the package, and the engine reaches them
+// only through the interface.
+package game
+
+import (
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Env is what a game gets to talk to the chat and the database with.
+type Env struct {
+	Bot    *tgbotapi.BotAPI
+	Client *mongo.Client
+	Store  repository.Store
+}
+
+// Player is the user who started, guessed in or cancelled a game.
+type Player struct {
+	ID   int
+	Name string
+}
+
+// Score is a player's result in a finished round.
+type Score struct {
+	Player
+	Points int
+}
+
+// Result is how a round ended: the score of everyone who took part, including
+// those who scored nothing.
+type Result struct {
+	Scores []Score
+}
+
+// Info describes a game.
+type Info struct {
+	Name        string // command that starts the game, e.g. "wordle"
+	Title       string // name shown to users, e.g. "Word Grid"
+	Description string // shown in /help
+	States      string // collection holding the per-chat state, empty if not persisted
+}
+
+// Game is a minigame that can run in any chat. The engine holds no sessions:
+// implementations keep their own per chat, hand them out through Snapshot
+// and Restore for saving, and must be safe for concurrent use.
+type Game interface {
+	Info() Info
+	// Scoreboard returns the collection the game's points are written to,
+	// empty if it keeps no leaderboard.
+	Scoreboard() string
+	// Start starts a round in the chat, or asks to replace the running one.
+	Start(env Env, chatID int64, p Player)
+	// Active reports whether the chat has a round that takes guesses.
+	Active(chatID int64) bool
+	// Guess checks msg against the chat's round. It returns the result if the
+	// guess ended the round, nil otherwise.
+	Guess(env Env, msg *tgbotapi.Message) *Result
+	// Hint sends a hint for the chat's round. It returns false if the game
+	// has no hints.
+	Hint(env Env, msg *tgbotapi.Message) bool
+	// Cancel ends the chat's round. It returns false if there was none.
+	Cancel(env Env, chatID int64) bool
+	// Snapshot returns the chat's session in the form it is saved in.
+	Snapshot(chatID int64) (any, bool)
+	// Restore replaces the sessions with the saved ones. load decodes every
+	// saved snapshot into target, a pointer to a slice.
+	Restore(load func(target any) error) (int, error)
+}
+
+// Save writes doc as the saved state of chatID in collection. The write runs
+// in the background and is flushed on shutdown. Nothing is written when store
+// is nil.
+func Save(store repository.Store, collection string, chatID int64, doc any) {
+	if store == nil || collection == "" {
+		return
+	}
+	repository.SaveAsync(func() {
+		store.SaveGameState(collection, chatID, doc)
+	})
+}
@@ -0,0 +1,63 @@
+package geographybot
+
+import (
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/translator"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// statesCollection holds one saved GeographyStateDoc per chat.
+const statesCollection = "GeographyStates"
+
+// Game is the Geography quiz for the game engine.
+var Game game.Game = geography{}
+
+type geography struct{}
+
+func (geography) Info() game.Info {
+	return game.Info{
+		Name:        "geography",
+		Title:       "Geography",
+		Description: "Start a Geography quiz",
+		States:      statesCollection,
+	}
+}
+
+func (geography) Scoreboard() string {
+	return "GeographyPoints"
+}
+
+func (geography) Start(env game.Env, chatID int64, p game.Player) {
+	HandleGeographyCommand(env.Bot, chatID, p.Name, env.Client)
+}
+
+func (geography) Active(chatID int64) bool {
+	return IsGeographyActive(chatID)
+}
+
+func (geography) Guess(env game.Env, msg *tgbotapi.Message) *game.Result {
+	return HandleGuess(env.Bot, msg, env.Client, env.Store, msg.Chat.ID, msg.Text)
+}
+
+func (geography) Hint(env game.Env, msg *tgbotapi.Message) bool {
+	HandleGeographyHint(env.Bot, msg, env.Client, msg.Chat.ID, translator.NewTextTranslator())
+	return true
+}
+
+func (geography) Cancel(env game.Env, chatID int64) bool {
+	return CancelGeography(chatID)
+}
+
+func (geography) Snapshot(chatID int64) (any, bool) {
+	geographyMutex.RLock()
+	state, ok := geographyStates[chatID]
+	geographyMutex.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return state.snapshot(chatID), true
+}
+
+func (geography) Restore(load func(target any) error) (int, error) {
+	return restoreStates(load)
+}
@@ -15,8 +15,10 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/closeguess"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -61,15 +63,18 @@ var (
 	geographyMutex  = &sync.RWMutex{}
 )
 
-// saveGeographyStateAsync asynchronously saves the Geography state to MongoDB
-func saveGeographyStateAsync(chatID int64, state *GeographyState) {
+// snapshot returns state in the form it is saved in. The caller must not hold
+// the state's lock.
+func (state *GeographyState) snapshot(chatID int64) GeographyStateDoc {
 	state.RLock()
+	defer state.RUnlock()
+
 	userAttemptsDoc := make(map[string]int)
 	for userID, attempts := range state.UserAttempts {
 		userAttemptsDoc[strconv.FormatInt(userID, 10)] = attempts
 	}
 
-	doc := GeographyStateDoc{
+	return GeographyStateDoc{
 		ChatID:            chatID,
 		Active:            state.Active,
 		QuestionType:      state.QuestionType,
@@ -82,23 +87,24 @@ func saveGeographyStateAsync(chatID int64, state *GeographyState) {
 		LastHintTimestamp: state.LastHintTimestamp,
 		LastHintTypeSent:  state.LastHintTypeSent,
 	}
-	state.RUnlock()
+}
 
-	go func() {
+// saveGeographyStateAsync saves the Geography state to MongoDB in the background
+func saveGeographyStateAsync(chatID int64, state *GeographyState) {
+	doc := state.snapshot(chatID)
+	repository.SaveAsync(func() {
 		client := repository.DbManager()
 		if client != nil {
-			repository.SaveGameState(client, "GeographyStates", chatID, doc)
+			repository.SaveGameState(client, statesCollection, chatID, doc)
 		}
-	}()
+	})
 }
 
-// LoadSavedStates loads the persisted Geography states from MongoDB into the memory map
-func LoadSavedStates(client *mongo.Client) {
+// restoreStates replaces the in-memory states with the saved ones.
+func restoreStates(load func(target any) error) (int, error) {
 	var results []GeographyStateDoc
-	err := repository.LoadAllGameStates(client, "GeographyStates", &results)
-	if err != nil {
-		log.Printf("Failed to load saved Geography states: %v", err)
-		return
+	if err := load(&results); err != nil {
+		return 0, err
 	}
 
 	geographyMutex.Lock()
@@ -127,7 +133,7 @@ func LoadSavedStates(client *mongo.Client) {
 		}
 		geographyStates[doc.ChatID] = gs
 	}
-	log.Printf("Loaded %d active Geography games from MongoDB", len(results))
+	return len(results), nil
 }
 
 // Country represents a country's data
@@ -461,26 +467,44 @@ func startNewRound(bot *tgbotapi.BotAPI, chatID int64, client *mongo.Client) {
 	}
 }
 
-// HandleGeographyCallback handles the inline button callbacks for MCQ
-func HandleGeographyCallback(bot *tgbotapi.BotAPI, chatID int64, userID int, userName string, data string, callbackQueryID string, messageID int, client *mongo.Client) {
+// HandleGeographyCallback handles a player's pick in MCQ mode. Each player
+// gets one pick a question: a wrong one only rules them out, and the first
+// right one ends the round, whose result it returns.
+func HandleGeographyCallback(bot *tgbotapi.BotAPI, chatID int64, userID int, userName string, data string, callbackQueryID string, messageID int, client *mongo.Client) *game.Result {
 	geographyMutex.RLock()
 	state, exists := geographyStates[chatID]
 	geographyMutex.RUnlock()
 
 	if !exists {
 		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callbackQueryID, "Game not found."))
-		return
+		return nil
 	}
 
 	state.Lock()
 	if !state.Active {
 		state.Unlock()
 		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callbackQueryID, "Game is not active."))
-		return
+		return nil
 	}
 
-	userAnswer := strings.TrimPrefix(data, "geo_ans_")
 	correctAnswer := state.TargetAnswer
+	pick, res := state.answerMCQ(userID, userName, strings.TrimPrefix(data, "geo_ans_"))
+	state.Unlock()
+
+	switch pick {
+	case pickRepeated:
+		bot.AnswerCallbackQuery(tgbotapi.NewCallback(callbackQueryID, "You already answered this question."))
+		return nil
+	case pickWrong:
+		bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callbackQueryID, "❌ Incorrect! You are out for this question."))
+		saveGeographyStateAsync(chatID, state)
+		return nil
+	}
+
+	select {
+	case state.CancelChan <- true:
+	default:
+	}
 
 	// Update UI to remove buttons by updating only the reply markup
 	editMarkup := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: make([][]tgbotapi.InlineKeyboardButton, 0)})
@@ -489,41 +513,52 @@ func HandleGeographyCallback(bot *tgbotapi.BotAPI, chatID int64, userID int, use
 		log.Printf("Failed to edit message reply markup: %v", err)
 	}
 
-	if strings.EqualFold(userAnswer, correctAnswer) {
-		// Correct
-		state.Active = false
-		state.PendingNewGame = true
-		state.Unlock()
-		select {
-		case state.CancelChan <- true:
-		default:
-		}
+	points := 5 // standard geography points
+	if client != nil {
+		repository.InsertWordleBonusDoc(userID, userName, chatID, client, "GeographyPoints", points)
+	}
 
-		points := 5 // standard geography points
-		if client != nil {
-			repository.InsertWordleBonusDoc(userID, userName, chatID, client, "GeographyPoints", points)
-		}
+	successMsg := fmt.Sprintf("✅ *Correct, %s!*\n\nThe answer was *%s*.\nYou earned %d Geography points! 🌍", userName, correctAnswer, points)
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Play Again 🌍", "geography_start")))
+	view.SendMessageWithButtons(bot, chatID, successMsg, markup)
 
-		successMsg := fmt.Sprintf("✅ *Correct, %s!*\n\nThe answer was *%s*.\nYou earned %d Geography points! 🌍", userName, correctAnswer, points)
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Play Again 🌍", "geography_start")))
-		view.SendMessageWithButtons(bot, chatID, successMsg, markup)
+	saveGeographyStateAsync(chatID, state)
+	return res
+}
 
-	} else {
-		// Wrong
-		state.Active = false
-		state.PendingNewGame = false
-		state.Unlock()
-		select {
-		case state.CancelChan <- true:
-		default:
-		}
+// How a player's pick in MCQ mode went.
+type mcqPick int
 
-		failMsg := fmt.Sprintf("❌ *Incorrect, %s!*\n\nThe correct answer was *%s*.", userName, correctAnswer)
-		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Play Again 🌍", "geography_start")))
-		view.SendMessageWithButtons(bot, chatID, failMsg, markup)
+const (
+	pickRepeated mcqPick = iota // they had already picked
+	pickWrong
+	pickRight
+)
+
+// answerMCQ records the player's pick. A right one ends the round and comes
+// with its result: the player against everyone who picked wrong. The caller
+// holds the state's lock.
+func (state *GeographyState) answerMCQ(userID int, userName, answer string) (mcqPick, *game.Result) {
+	if state.UserAttempts == nil {
+		state.UserAttempts = make(map[int64]int)
+	}
+	if state.UserAttempts[int64(userID)] > 0 {
+		return pickRepeated, nil
+	}
+	state.UserAttempts[int64(userID)]++
+	if !strings.EqualFold(answer, state.TargetAnswer) {
+		return pickWrong, nil
 	}
 
-	saveGeographyStateAsync(chatID, state)
+	state.Active = false
+	state.PendingNewGame = true
+	res := &game.Result{Scores: []game.Score{{Player: game.Player{ID: userID, Name: userName}, Points: 1}}}
+	for id := range state.UserAttempts {
+		if int(id) != userID {
+			res.Scores = append(res.Scores, game.Score{Player: game.Player{ID: int(id)}})
+		}
+	}
+	return pickRight, res
 }
 
 func normalizeAnswer(s string) string {
@@ -559,20 +594,21 @@ func normalizeAnswer(s string) string {
 	return strings.Join(finalWords, "")
 }
 
-// HandleGuess handles exact text match guesses
-func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client, chatID int64, text string) {
+// HandleGuess handles exact text match guesses. A correct guess returns the
+// round's result: the winner against everyone who had guessed wrong.
+func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client, store repository.Store, chatID int64, text string) *game.Result {
 	geographyMutex.RLock()
 	state, exists := geographyStates[chatID]
 	geographyMutex.RUnlock()
 
 	if !exists {
-		return
+		return nil
 	}
 
 	state.Lock()
 	if !state.Active {
 		state.Unlock()
-		return
+		return nil
 	}
 
 	correctAnswer := state.TargetAnswer
@@ -589,12 +625,12 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 
 		if !isReplyToBot && !mentionsBot {
 			state.Unlock()
-			return
+			return nil
 		}
 
 		if state.UserAttempts[userID] >= state.MaxAttempts {
 			state.Unlock()
-			return // User already reached max attempts, ignore
+			return nil // User already reached max attempts, ignore
 		}
 
 		if mentionsBot {
@@ -602,6 +638,10 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 			text = strings.ReplaceAll(strings.ToLower(text), strings.ToLower(botMention), " ")
 		}
 	}
+	if !isTextMode && state.UserAttempts[userID] > 0 {
+		state.Unlock()
+		return nil // Already picked a wrong option
+	}
 
 	normGuess := normalizeAnswer(text)
 	normAns := normalizeAnswer(correctAnswer)
@@ -609,6 +649,12 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 	if normGuess == normAns {
 		state.Active = false
 		state.PendingNewGame = true
+		res := &game.Result{Scores: []game.Score{{Player: game.Player{ID: message.From.ID, Name: message.From.FirstName}, Points: 1}}}
+		for id := range state.UserAttempts {
+			if int(id) != message.From.ID {
+				res.Scores = append(res.Scores, game.Score{Player: game.Player{ID: int(id)}})
+			}
+		}
 		state.Unlock()
 		select {
 		case state.CancelChan <- true:
@@ -616,10 +662,10 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 		}
 
 		points := 5
-		if client != nil {
-			repository.InsertWordleBonusDoc(message.From.ID, message.From.FirstName, chatID, client, "GeographyPoints", points)
+		if store != nil {
+			store.InsertWordleBonusDoc(message.From.ID, message.From.FirstName, chatID, "GeographyPoints", points)
 			go func(uID int64, username string) {
-				service.AwardGameResult(client, uID, username, true) // Winner
+				service.AwardGameResult(store, uID, username, true) // Winner
 			}(int64(message.From.ID), message.From.FirstName)
 		}
 
@@ -628,6 +674,7 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 		view.SendMessageWithButtons(bot, chatID, successMsg, markup)
 
 		saveGeographyStateAsync(chatID, state)
+		return res
 	} else {
 		if isTextMode {
 			attempts := state.UserAttempts[userID]
@@ -638,7 +685,7 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 				failMsg := fmt.Sprintf("❌ *Incorrect, %s!*\n\nYou've used all %d attempts.", message.From.FirstName, state.MaxAttempts)
 				view.SendMessage(bot, chatID, failMsg)
 				saveGeographyStateAsync(chatID, state)
-				return
+				return nil
 			}
 
 			attemptsLeft := state.MaxAttempts - state.UserAttempts[userID]
@@ -646,11 +693,16 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 			saveGeographyStateAsync(chatID, state)
 
 			failMsg := fmt.Sprintf("❌ *Incorrect, %s!*\n\nAttempts left: %d", message.From.FirstName, attemptsLeft)
+			if closeguess.Enabled(chatID, store) && closeguess.Near(normGuess, normAns, 0) {
+				// The reply already comes every time, so it need not be throttled.
+				failMsg = fmt.Sprintf("🤏 *So close, %s!*\n\nAttempts left: %d", message.From.FirstName, attemptsLeft)
+			}
 			view.SendMessage(bot, chatID, failMsg)
 		} else {
 			state.Unlock()
 		}
 	}
+	return nil
 }
 func CancelGeography(chatID int64) bool {
 	geographyMutex.RLock()
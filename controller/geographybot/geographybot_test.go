@@ -0,0 +1,25 @@
+package geographybot
+
+import "testing"
+
+func TestAnswerMCQ(t *testing.T) {
+	state := &GeographyState{Active: true, TargetAnswer: "Paris", UserAttempts: make(map[int64]int)}
+
+	if pick, res := state.answerMCQ(2, "Bob", "Lyon"); pick != pickWrong || res != nil {
+		t.Fatalf("wrong pick = %v, %v", pick, res)
+	}
+	if pick, _ := state.answerMCQ(2, "Bob", "paris"); pick != pickRepeated {
+		t.Errorf("second pick = %v, want pickRepeated", pick)
+	}
+	if !state.Active {
+		t.Fatal("a wrong pick ended the round")
+	}
+
+	pick, res := state.answerMCQ(3, "Cy", "paris")
+	if pick != pickRight || state.Active {
+		t.Fatalf("right pick = %v, active %v", pick, state.Active)
+	}
+	if len(res.Scores) != 2 || res.Scores[0].ID != 3 || res.Scores[0].Points != 1 || res.Scores[1].ID != 2 || res.Scores[1].Points != 0 {
+		t.Errorf("result = %+v, want Cy winning against Bob", res.Scores)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -47,7 +48,7 @@ func GetChatSettings(chatID int64, client *mongo.Client) *ChatSettings {
 	}
 
 	if client != nil {
-		collection := client.Database("TelegramBot").Collection("GeographySettings")
+		collection := client.Database(config.App.Mongo.SettingsDatabase).Collection("GeographySettings")
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
@@ -103,7 +104,7 @@ func ToggleGeographyQuestionType(chatID int64, qType string, client *mongo.Clien
 	settingsMutex.Unlock()
 
 	if client != nil {
-		collection := client.Database("TelegramBot").Collection("GeographySettings")
+		collection := client.Database(config.App.Mongo.SettingsDatabase).Collection("GeographySettings")
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
@@ -126,7 +127,7 @@ func UpdateGeographyMode(chatID int64, mode string, client *mongo.Client) error
 	settingsMutex.Unlock()
 
 	if client != nil {
-		collection := client.Database("TelegramBot").Collection("GeographySettings")
+		collection := client.Database(config.App.Mongo.SettingsDatabase).Collection("GeographySettings")
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
@@ -1,19 +1,22 @@
 package instagrambot
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/webhook"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
-func StartBot(token string) error {
-	// Create a new instance of the bot using the provided token.
-	bot, err := tgbotapi.NewBotAPI(token)
+func StartBot(ctx context.Context, cfg config.Config) error {
+	// Create a new instance of the bot using the configured token.
+	bot, err := tgbotapi.NewBotAPI(cfg.Bots.Instagram.Token)
 	if err != nil {
 		return err
 	}
@@ -23,16 +26,23 @@ func StartBot(token string) error {
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
-	updates, err := bot.GetUpdatesChan(u)
+	updates, err := webhook.Updates(cfg, "instagram", cfg.Bots.Instagram.Token, func() (<-chan tgbotapi.Update, error) {
+		return bot.GetUpdatesChan(u)
+	})
 	if err != nil {
 		return err
 	}
 
-	for update := range updates {
+	for {
+		update, ok := webhook.Next(ctx, updates)
+		if !ok {
+			break
+		}
 		if update.Message != nil {
 			handleMessage(bot, update.Message)
 		}
 	}
+	bot.StopReceivingUpdates()
 
 	return nil
 }
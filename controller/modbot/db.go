@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -132,7 +133,7 @@ func SaveChatSettings(client *mongo.Client, settings *ModChatSettings) {
 	}
 
 	go func() {
-		collection := client.Database("Telegram").Collection("ModSettings")
+		collection := client.Database(config.App.Mongo.Database).Collection("ModSettings")
 		filter := bson.M{"_id": settings.ChatID}
 		update := bson.M{"$set": settings}
 		opts := options.Update().SetUpsert(true)
@@ -150,7 +151,7 @@ func loadSettings(client *mongo.Client) {
 	}
 
 	// Load Settings
-	collection := client.Database("Telegram").Collection("ModSettings")
+	collection := client.Database(config.App.Mongo.Database).Collection("ModSettings")
 	cursor, err := collection.Find(context.TODO(), bson.M{})
 	if err != nil {
 		log.Printf("Failed to load mod settings: %v", err)
@@ -184,7 +185,7 @@ func loadSettings(client *mongo.Client) {
 	log.Printf("Loaded %d ModBot chat settings from MongoDB", len(results))
 
 	// Load Violations
-	vCollection := client.Database("Telegram").Collection("ModViolations")
+	vCollection := client.Database(config.App.Mongo.Database).Collection("ModViolations")
 	vCursor, err := vCollection.Find(context.TODO(), bson.M{})
 	if err != nil {
 		log.Printf("Failed to load mod violations: %v", err)
@@ -207,7 +208,7 @@ func loadSettings(client *mongo.Client) {
 	log.Printf("Loaded %d ModBot user violations from MongoDB", len(vResults))
 
 	// Load Global Bans
-	gbCollection := client.Database("Telegram").Collection("ModGlobalBans")
+	gbCollection := client.Database(config.App.Mongo.Database).Collection("ModGlobalBans")
 	gbCursor, err := gbCollection.Find(context.TODO(), bson.M{})
 	if err != nil {
 		log.Printf("Failed to load global bans: %v", err)
@@ -230,7 +231,7 @@ func loadSettings(client *mongo.Client) {
 	log.Printf("Loaded %d globally banned users from MongoDB", len(gbResults))
 
 	// Load Global Admins
-	gaCollection := client.Database("Telegram").Collection("ModGlobalAdmins")
+	gaCollection := client.Database(config.App.Mongo.Database).Collection("ModGlobalAdmins")
 	gaCursor, err := gaCollection.Find(context.TODO(), bson.M{})
 	if err != nil {
 		log.Printf("Failed to load global admins: %v", err)
@@ -252,7 +253,7 @@ func loadSettings(client *mongo.Client) {
 	log.Printf("Loaded %d global admins from MongoDB", len(gaResults))
 
 	// Load global keyboard menu configuration
-	gkCollection := client.Database("Telegram").Collection("ModGlobalKeyboardMenu")
+	gkCollection := client.Database(config.App.Mongo.Database).Collection("ModGlobalKeyboardMenu")
 	gkCursor, err := gkCollection.Find(context.TODO(), bson.M{})
 	if err != nil {
 		log.Printf("Failed to load global keyboard menu settings: %v", err)
@@ -287,6 +288,19 @@ func GetUserViolations(chatID int64, userID int) int {
 	return 0
 }
 
+// ForgetUserViolations drops the user's cached violation counts in every chat,
+// after their documents were deleted, so the next violation does not write
+// the old count back.
+func ForgetUserViolations(userID int) {
+	violationsMutex.Lock()
+	defer violationsMutex.Unlock()
+	for id, v := range violationsCache {
+		if v.UserID == userID {
+			delete(violationsCache, id)
+		}
+	}
+}
+
 // IncrementUserViolations adds 1 to a user's violation count and saves to DB
 func IncrementUserViolations(client *mongo.Client, chatID int64, userID int) int {
 	id := fmt.Sprintf("%d_%d", chatID, userID)
@@ -313,7 +327,7 @@ func IncrementUserViolations(client *mongo.Client, chatID int64, userID int) int
 
 	if client != nil {
 		go func() {
-			collection := client.Database("Telegram").Collection("ModViolations")
+			collection := client.Database(config.App.Mongo.Database).Collection("ModViolations")
 			filter := bson.M{"_id": id}
 			update := bson.M{"$set": copyV}
 			opts := options.Update().SetUpsert(true)
@@ -356,7 +370,7 @@ func GlobalBanUser(client *mongo.Client, userID int, reason string, bannedBy int
 
 	if client != nil {
 		go func() {
-			collection := client.Database("Telegram").Collection("ModGlobalBans")
+			collection := client.Database(config.App.Mongo.Database).Collection("ModGlobalBans")
 			filter := bson.M{"_id": userID}
 			update := bson.M{"$set": ban}
 			opts := options.Update().SetUpsert(true)
@@ -379,7 +393,7 @@ func GlobalUnbanUser(client *mongo.Client, userID int) error {
 
 	if client != nil {
 		go func() {
-			collection := client.Database("Telegram").Collection("ModGlobalBans")
+			collection := client.Database(config.App.Mongo.Database).Collection("ModGlobalBans")
 			_, err := collection.DeleteOne(context.TODO(), bson.M{"_id": userID})
 			if err != nil {
 				log.Printf("Failed to remove global ban for user %d: %v", userID, err)
@@ -403,17 +417,12 @@ func GetGloballyBannedUsers() map[int]*GlobalBanDoc {
 	return result
 }
 
-// hardcodedAdminID is the bot owner's ID, used as a built-in global admin fallback.
-// This matches the adminID used in controller/bot.go, controller/categoryBot/categoryBot.go,
-// and controller/translator/bot.go.
-const hardcodedAdminID = 1006461736
-
 // IsGlobalAdmin checks if a user is a global admin.
-// The hardcoded bot owner ID (1006461736) is always treated as a global admin,
+// The admins listed in the configuration are always treated as global admins,
 // even if not in the MongoDB global admins list.
 func IsGlobalAdmin(userID int) bool {
-	// Built-in fallback: the bot owner is always a global admin
-	if userID == hardcodedAdminID {
+	// Built-in fallback: configured admins are always global admins
+	if config.App.IsAdmin(int64(userID)) {
 		return true
 	}
 	globalAdminsMutex.RLock()
@@ -439,7 +448,7 @@ func AddGlobalAdmin(client *mongo.Client, userID int, addedBy int) error {
 			AddedBy: addedBy,
 		}
 		go func() {
-			collection := client.Database("Telegram").Collection("ModGlobalAdmins")
+			collection := client.Database(config.App.Mongo.Database).Collection("ModGlobalAdmins")
 			filter := bson.M{"_id": userID}
 			update := bson.M{"$set": doc}
 			opts := options.Update().SetUpsert(true)
@@ -455,10 +464,10 @@ func AddGlobalAdmin(client *mongo.Client, userID int, addedBy int) error {
 }
 
 // RemoveGlobalAdmin removes a user from the global admin list.
-// The hardcoded bot owner (1006461736) cannot be removed.
+// Admins listed in the configuration cannot be removed.
 func RemoveGlobalAdmin(client *mongo.Client, userID int) error {
-	if userID == hardcodedAdminID {
-		return fmt.Errorf("the built-in global admin %d cannot be removed", hardcodedAdminID)
+	if config.App.IsAdmin(int64(userID)) {
+		return fmt.Errorf("the built-in global admin %d cannot be removed", userID)
 	}
 	globalAdminsMutex.Lock()
 	delete(globalAdminsCache, userID)
@@ -466,7 +475,7 @@ func RemoveGlobalAdmin(client *mongo.Client, userID int) error {
 
 	if client != nil {
 		go func() {
-			collection := client.Database("Telegram").Collection("ModGlobalAdmins")
+			collection := client.Database(config.App.Mongo.Database).Collection("ModGlobalAdmins")
 			_, err := collection.DeleteOne(context.TODO(), bson.M{"_id": userID})
 			if err != nil {
 				log.Printf("Failed to remove global admin %d: %v", userID, err)
@@ -477,18 +486,20 @@ func RemoveGlobalAdmin(client *mongo.Client, userID int) error {
 	return nil
 }
 
-// GetGlobalAdmins returns a copy of the global admin list, always including the hardcoded admin
+// GetGlobalAdmins returns a copy of the global admin list, always including the configured admins
 func GetGlobalAdmins() []int {
 	globalAdminsMutex.RLock()
 	defer globalAdminsMutex.RUnlock()
 
 	seen := make(map[int]bool)
-	result := make([]int, 0, len(globalAdminsCache)+1)
+	result := make([]int, 0, len(globalAdminsCache)+len(config.App.AdminIDs))
 
-	// Always include the hardcoded admin
-	if !globalAdminsCache[hardcodedAdminID] {
-		result = append(result, hardcodedAdminID)
-		seen[hardcodedAdminID] = true
+	// Always include the configured admins
+	for _, id := range config.App.AdminIDs {
+		if !seen[int(id)] {
+			result = append(result, int(id))
+			seen[int(id)] = true
+		}
 	}
 
 	for id := range globalAdminsCache {
@@ -539,7 +550,7 @@ func SetGlobalKeyboardMenuState(client *mongo.Client, trigger string, enabled bo
 	}
 
 	go func() {
-		collection := client.Database("Telegram").Collection("ModGlobalKeyboardMenu")
+		collection := client.Database(config.App.Mongo.Database).Collection("ModGlobalKeyboardMenu")
 		filter := bson.M{"_id": trigger}
 		update := bson.M{"$set": GlobalKeyboardMenuDoc{Trigger: trigger, Enabled: enabled, UpdatedAt: time.Now()}}
 		opts := options.Update().SetUpsert(true)
@@ -23,6 +23,12 @@ var (
 	adminMutex sync.RWMutex
 )
 
+// IsChatAdmin reports whether the user administers the group. Everyone
+// administers their private chat with the bot.
+func IsChatAdmin(bot *tgbotapi.BotAPI, chatID int64, userID int) bool {
+	return isAdmin(bot, chatID, userID)
+}
+
 // isAdmin checks if the user is an administrator in the group.
 // It uses a temporary in-memory cache to prevent exhausting API rate limits.
 func isAdmin(bot *tgbotapi.BotAPI, chatID int64, userID int) bool {
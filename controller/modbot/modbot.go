@@ -1,19 +1,22 @@
 package modbot
 
 import (
+	"context"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/webhook"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"log"
 )
 
 // StartModBot initializes and starts the moderator bot
-func StartModBot(token string) error {
-	bot, err := tgbotapi.NewBotAPI(token)
+func StartModBot(ctx context.Context, cfg config.Config) error {
+	bot, err := tgbotapi.NewBotAPI(cfg.Bots.Mod.Token)
 	if err != nil {
 		return err
 	}
 
-	bot.Debug = true
+	bot.Debug = cfg.Features.Debug
 	log.Printf("ModBot authorized on account %s", bot.Self.UserName)
 
 	client := repository.DbManager()
@@ -27,21 +30,24 @@ func StartModBot(token string) error {
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
-	updates, err := bot.GetUpdatesChan(u)
+	updates, err := webhook.Updates(cfg, "mod", cfg.Bots.Mod.Token, func() (<-chan tgbotapi.Update, error) {
+		return bot.GetUpdatesChan(u)
+	})
 	if err != nil {
 		return err
 	}
 
-	for update := range updates {
+	webhook.Serve(ctx, updates, func(update tgbotapi.Update) {
 		if update.Message != nil {
-			go handleMessage(bot, update.Message, client)
+			handleMessage(bot, update.Message, client)
 		} else if update.EditedMessage != nil {
 			// Apply filters to edited messages to prevent filter bypassing
-			go handleFilters(bot, update.EditedMessage, client)
+			handleFilters(bot, update.EditedMessage, client)
 		} else if update.CallbackQuery != nil {
-			go handleCallbackQuery(bot, update.CallbackQuery, client)
+			handleCallbackQuery(bot, update.CallbackQuery, client)
 		}
-	}
+	})
+	bot.StopReceivingUpdates()
 
 	return nil
 }
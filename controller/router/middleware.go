@@ -0,0 +1,88 @@
+package router
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Recover stops a panicking handler from taking the whole bot down.
+func Recover() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Recovered from panic in %s handler: %v\n%s", c.Route, r, debug.Stack())
+				}
+			}()
+			next(c)
+		}
+	}
+}
+
+// Logging logs every dispatched command and callback with how long it took.
+func Logging() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) {
+			start := time.Now()
+			next(c)
+			kind := "command /"
+			if c.IsCallback() {
+				kind = "callback "
+			}
+			log.Printf("%s%s from user %d in chat %d handled in %s", kind, c.Route, c.UserID, c.ChatID, time.Since(start).Round(time.Millisecond))
+		}
+	}
+}
+
+// BanCheck drops updates from users isBanned reports as banned.
+func BanCheck(isBanned func(userID int) bool) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) {
+			if isBanned(c.UserID) {
+				c.Answer("You are banned from using this bot.")
+				return
+			}
+			next(c)
+		}
+	}
+}
+
+// Cooldown enforces each route's Cooldown per user. Routes without one are
+// not limited.
+func Cooldown() Middleware {
+	return cooldown(time.Now)
+}
+
+func cooldown(now func() time.Time) Middleware {
+	var mu sync.Mutex
+	last := make(map[string]time.Time)
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) {
+			if c.Cooldown <= 0 {
+				next(c)
+				return
+			}
+			key := fmt.Sprintf("%d:%s", c.UserID, c.Route)
+			t := now()
+
+			mu.Lock()
+			prev, seen := last[key]
+			if seen && t.Sub(prev) < c.Cooldown {
+				mu.Unlock()
+				wait := (c.Cooldown - t.Sub(prev)).Round(time.Second)
+				if c.IsCallback() {
+					c.Answer(fmt.Sprintf("Please wait %s before trying again.", wait))
+				}
+				return
+			}
+			last[key] = t
+			mu.Unlock()
+
+			next(c)
+		}
+	}
+}
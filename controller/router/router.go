@@ -0,0 +1,332 @@
+// Package router dispatches Telegram commands and callback queries to handlers
+// registered up front with their metadata, instead of through one large switch
+// per bot. Every dispatch runs through the router's middleware chain.
+package router
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Scope limits the chats a command is available in.
+type Scope int
+
+const (
+	// Anywhere commands work in private chats and groups.
+	Anywhere Scope = iota
+	// PrivateOnly commands only work in a DM with the bot.
+	PrivateOnly
+	// GroupOnly commands only work in groups and supergroups.
+	GroupOnly
+)
+
+func (s Scope) String() string {
+	switch s {
+	case PrivateOnly:
+		return "private"
+	case GroupOnly:
+		return "group"
+	default:
+		return "anywhere"
+	}
+}
+
+// HandlerFunc handles a single command or callback.
+type HandlerFunc func(c *Context)
+
+// Middleware wraps a handler with behaviour shared by every route.
+type Middleware func(HandlerFunc) HandlerFunc
+
+// Command describes a slash command.
+type Command struct {
+	Name        string
+	Description string // shown by /help; commands without one are hidden
+	Scope       Scope
+	AdminOnly   bool
+	Cooldown    time.Duration // per user, enforced by the Cooldown middleware
+	Handler     HandlerFunc
+}
+
+// Callback describes an inline button handler. Data matches the callback data
+// exactly; Prefix matches any data starting with it. Exact matches win, then
+// the longest prefix.
+type Callback struct {
+	Data      string
+	Prefix    string
+	AdminOnly bool
+	Cooldown  time.Duration
+	Handler   HandlerFunc
+}
+
+// Context carries everything a handler needs about the update being handled.
+type Context struct {
+	Bot    *tgbotapi.BotAPI
+	Client *mongo.Client
+	Store  repository.Store
+
+	// Message is the command message, or for callbacks the message the
+	// button is attached to.
+	Message  *tgbotapi.Message
+	Callback *tgbotapi.CallbackQuery
+
+	ChatID int64
+	UserID int
+
+	// Route is the name of the matched command, or the callback data/prefix
+	// it was registered under.
+	Route     string
+	Cooldown  time.Duration
+	AdminOnly bool
+
+	router   *Router
+	answered bool
+}
+
+// IsCallback reports whether the context was created for a callback query.
+func (c *Context) IsCallback() bool {
+	return c.Callback != nil
+}
+
+// IsPrivate reports whether the update came from a private chat.
+func (c *Context) IsPrivate() bool {
+	return c.Message != nil && c.Message.Chat != nil && c.Message.Chat.IsPrivate()
+}
+
+// Args returns the text after the command, or the callback data after the
+// registered prefix.
+func (c *Context) Args() string {
+	if c.Callback != nil {
+		return strings.TrimPrefix(c.Callback.Data, c.Route)
+	}
+	return c.Message.CommandArguments()
+}
+
+// FirstName is the first name of the user who triggered the update.
+func (c *Context) FirstName() string {
+	if c.Callback != nil {
+		return c.Callback.From.FirstName
+	}
+	return c.Message.From.FirstName
+}
+
+// Answer acknowledges a callback query with an optional toast text. Later
+// calls are ignored, as Telegram only accepts the first answer.
+func (c *Context) Answer(text string) {
+	c.answer(text, false)
+}
+
+// AnswerAlert acknowledges a callback query with a modal alert.
+func (c *Context) AnswerAlert(text string) {
+	c.answer(text, true)
+}
+
+func (c *Context) answer(text string, alert bool) {
+	if c.Callback == nil || c.answered {
+		return
+	}
+	c.answered = true
+	c.router.answerCallback(c.Callback.ID, text, alert)
+}
+
+// Router holds the registered commands and callbacks for one bot.
+type Router struct {
+	bot        *tgbotapi.BotAPI
+	client     *mongo.Client
+	store      repository.Store
+	middleware []Middleware
+
+	commands map[string]map[Scope]*Command
+	exact    map[string]*Callback
+	prefixes []*Callback
+
+	// answerCallback is swapped out in tests, which run without a bot.
+	answerCallback func(id, text string, alert bool)
+	isAdmin        func(userID int) bool
+}
+
+// New returns a router for bot. The middleware runs in the order given, the
+// first one outermost.
+func New(bot *tgbotapi.BotAPI, client *mongo.Client, store repository.Store, mw ...Middleware) *Router {
+	r := &Router{
+		bot:        bot,
+		client:     client,
+		store:      store,
+		middleware: mw,
+		commands:   make(map[string]map[Scope]*Command),
+		exact:      make(map[string]*Callback),
+		isAdmin:    func(userID int) bool { return config.App.IsAdmin(int64(userID)) },
+	}
+	r.answerCallback = func(id, text string, alert bool) {
+		if alert {
+			r.bot.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(id, text))
+			return
+		}
+		r.bot.AnswerCallbackQuery(tgbotapi.NewCallback(id, text))
+	}
+	return r
+}
+
+// Command registers cmd. A name may be registered once per scope, so a
+// command can have separate private and group handlers. Registering the same
+// name and scope twice panics, as it is always a programming error.
+func (r *Router) Command(cmd Command) {
+	if cmd.Name == "" || cmd.Handler == nil {
+		panic("router: command needs a name and a handler")
+	}
+	byScope := r.commands[cmd.Name]
+	if byScope == nil {
+		byScope = make(map[Scope]*Command)
+		r.commands[cmd.Name] = byScope
+	}
+	if _, dup := byScope[cmd.Scope]; dup {
+		panic(fmt.Sprintf("router: command /%s registered twice for scope %s", cmd.Name, cmd.Scope))
+	}
+	byScope[cmd.Scope] = &cmd
+}
+
+// Callback registers an inline button handler.
+func (r *Router) Callback(cb Callback) {
+	if (cb.Data == "") == (cb.Prefix == "") || cb.Handler == nil {
+		panic("router: callback needs a handler and exactly one of Data or Prefix")
+	}
+	if cb.Data != "" {
+		if _, dup := r.exact[cb.Data]; dup {
+			panic(fmt.Sprintf("router: callback %q registered twice", cb.Data))
+		}
+		r.exact[cb.Data] = &cb
+		return
+	}
+	for _, p := range r.prefixes {
+		if p.Prefix == cb.Prefix {
+			panic(fmt.Sprintf("router: callback prefix %q registered twice", cb.Prefix))
+		}
+	}
+	r.prefixes = append(r.prefixes, &cb)
+	sort.SliceStable(r.prefixes, func(i, j int) bool {
+		return len(r.prefixes[i].Prefix) > len(r.prefixes[j].Prefix)
+	})
+}
+
+// lookup finds the command for name in a private or group chat, preferring a
+// scope-specific registration over an Anywhere one.
+func (r *Router) lookup(name string, private bool) *Command {
+	byScope := r.commands[name]
+	if byScope == nil {
+		return nil
+	}
+	specific := GroupOnly
+	if private {
+		specific = PrivateOnly
+	}
+	if cmd := byScope[specific]; cmd != nil {
+		return cmd
+	}
+	return byScope[Anywhere]
+}
+
+// DispatchMessage runs the handler for message's command. It reports whether
+// a registered command matched, in which case the caller should stop
+// processing the message.
+func (r *Router) DispatchMessage(message *tgbotapi.Message) bool {
+	if message == nil || !message.IsCommand() {
+		return false
+	}
+	cmd := r.lookup(message.Command(), message.Chat.IsPrivate())
+	if cmd == nil {
+		return false
+	}
+	// Admin commands stay invisible to everyone else, as they always have.
+	if cmd.AdminOnly && !r.isAdmin(message.From.ID) {
+		return true
+	}
+	c := &Context{
+		Bot:       r.bot,
+		Client:    r.client,
+		Store:     r.store,
+		Message:   message,
+		ChatID:    message.Chat.ID,
+		UserID:    message.From.ID,
+		Route:     cmd.Name,
+		Cooldown:  cmd.Cooldown,
+		AdminOnly: cmd.AdminOnly,
+		router:    r,
+	}
+	r.chain(cmd.Handler)(c)
+	return true
+}
+
+// DispatchCallback runs the handler registered for the callback's data and
+// answers the query if the handler did not. It reports whether a handler
+// matched.
+func (r *Router) DispatchCallback(callback *tgbotapi.CallbackQuery) bool {
+	if callback == nil || callback.Message == nil {
+		return false
+	}
+	cb, route := r.exact[callback.Data], callback.Data
+	if cb == nil {
+		for _, p := range r.prefixes {
+			if strings.HasPrefix(callback.Data, p.Prefix) {
+				cb, route = p, p.Prefix
+				break
+			}
+		}
+	}
+	if cb == nil {
+		return false
+	}
+
+	c := &Context{
+		Bot:       r.bot,
+		Client:    r.client,
+		Store:     r.store,
+		Message:   callback.Message,
+		Callback:  callback,
+		ChatID:    callback.Message.Chat.ID,
+		UserID:    callback.From.ID,
+		Route:     route,
+		Cooldown:  cb.Cooldown,
+		AdminOnly: cb.AdminOnly,
+		router:    r,
+	}
+	if cb.AdminOnly && !r.isAdmin(callback.From.ID) {
+		c.Answer("")
+		return true
+	}
+	r.chain(cb.Handler)(c)
+	c.Answer("")
+	return true
+}
+
+func (r *Router) chain(h HandlerFunc) HandlerFunc {
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		h = r.middleware[i](h)
+	}
+	return h
+}
+
+// Help lists the described, non-admin commands available in a private chat or
+// a group, one per line, sorted by name.
+func (r *Router) Help(private bool) string {
+	names := make([]string, 0, len(r.commands))
+	for name := range r.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		cmd := r.lookup(name, private)
+		if cmd == nil || cmd.AdminOnly || cmd.Description == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "/%s — %s\n", cmd.Name, cmd.Description)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
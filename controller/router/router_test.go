@@ -0,0 +1,213 @@
+package router
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+func newTestRouter(mw ...Middleware) (*Router, *[]string) {
+	r := New(nil, nil, nil, mw...)
+	answers := &[]string{}
+	r.answerCallback = func(id, text string, alert bool) {
+		*answers = append(*answers, text)
+	}
+	r.isAdmin = func(userID int) bool { return userID == 1 }
+	return r, answers
+}
+
+func command(text string, chatType string, userID int) *tgbotapi.Message {
+	name := strings.Fields(text)[0]
+	return &tgbotapi.Message{
+		Text:     text,
+		From:     &tgbotapi.User{ID: userID, FirstName: "user"},
+		Chat:     &tgbotapi.Chat{ID: 42, Type: chatType},
+		Entities: &[]tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(name)}},
+	}
+}
+
+func callback(data string, userID int) *tgbotapi.CallbackQuery {
+	return &tgbotapi.CallbackQuery{
+		ID:      "cb",
+		Data:    data,
+		From:    &tgbotapi.User{ID: userID},
+		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 42, Type: "group"}},
+	}
+}
+
+func TestDispatchMessageScopes(t *testing.T) {
+	r, _ := newTestRouter()
+	var got []string
+	record := func(tag string) HandlerFunc {
+		return func(c *Context) { got = append(got, tag+":"+c.Args()) }
+	}
+	r.Command(Command{Name: "rules", Scope: PrivateOnly, Handler: record("dm")})
+	r.Command(Command{Name: "rules", Scope: GroupOnly, Handler: record("group")})
+	r.Command(Command{Name: "stats", Handler: record("any")})
+	r.Command(Command{Name: "word", Scope: GroupOnly, Handler: record("word")})
+
+	cases := []struct {
+		msg  *tgbotapi.Message
+		want bool
+	}{
+		{command("/rules", "private", 5), true},
+		{command("/rules now", "supergroup", 5), true},
+		{command("/stats", "private", 5), true},
+		{command("/word", "private", 5), false},
+		{command("/unknown", "group", 5), false},
+		{&tgbotapi.Message{Text: "plain guess", From: &tgbotapi.User{ID: 5}, Chat: &tgbotapi.Chat{Type: "group"}}, false},
+	}
+	for _, tc := range cases {
+		if ok := r.DispatchMessage(tc.msg); ok != tc.want {
+			t.Errorf("DispatchMessage(%q) = %v, want %v", tc.msg.Text, ok, tc.want)
+		}
+	}
+	want := []string{"dm:", "group:now", "any:"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("handlers ran %v, want %v", got, want)
+	}
+}
+
+func TestAdminOnlyIsSilentForOthers(t *testing.T) {
+	r, _ := newTestRouter()
+	ran := 0
+	r.Command(Command{Name: "getlogs", AdminOnly: true, Handler: func(c *Context) { ran++ }})
+
+	if !r.DispatchMessage(command("/getlogs", "private", 7)) {
+		t.Error("admin command should still count as handled")
+	}
+	if ran != 0 {
+		t.Fatal("non-admin ran an admin command")
+	}
+	r.DispatchMessage(command("/getlogs", "private", 1))
+	if ran != 1 {
+		t.Error("admin could not run the command")
+	}
+}
+
+func TestDispatchCallbackMatchingAndAnswer(t *testing.T) {
+	r, answers := newTestRouter()
+	var routes []string
+	r.Callback(Callback{Data: "stats_wordle", Handler: func(c *Context) { routes = append(routes, c.Route) }})
+	r.Callback(Callback{Prefix: "stats_", Handler: func(c *Context) {
+		routes = append(routes, c.Route+"|"+c.Args())
+		c.Answer("loading")
+		c.Answer("ignored")
+	}})
+	r.Callback(Callback{Prefix: "stats_group_", Handler: func(c *Context) { routes = append(routes, c.Route+"|"+c.Args()) }})
+
+	for _, data := range []string{"stats_wordle", "stats_scramy", "stats_group_anime"} {
+		if !r.DispatchCallback(callback(data, 5)) {
+			t.Errorf("%s did not match", data)
+		}
+	}
+	if r.DispatchCallback(callback("explain", 5)) {
+		t.Error("unregistered callback matched")
+	}
+
+	wantRoutes := "stats_wordle,stats_|scramy,stats_group_|anime"
+	if strings.Join(routes, ",") != wantRoutes {
+		t.Errorf("routes = %v, want %s", routes, wantRoutes)
+	}
+	// Each callback is answered exactly once, with the handler's text if it set one.
+	if strings.Join(*answers, ",") != ",loading," {
+		t.Errorf("answers = %q", *answers)
+	}
+}
+
+func TestDuplicateRegistrationPanics(t *testing.T) {
+	r, _ := newTestRouter()
+	h := func(c *Context) {}
+	r.Command(Command{Name: "hint", Scope: GroupOnly, Handler: h})
+	r.Command(Command{Name: "hint", Scope: PrivateOnly, Handler: h})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a duplicate command")
+		}
+	}()
+	r.Command(Command{Name: "hint", Scope: GroupOnly, Handler: h})
+}
+
+func TestMiddlewareOrderAndRecover(t *testing.T) {
+	var trace []string
+	tag := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(c *Context) {
+				trace = append(trace, name)
+				next(c)
+			}
+		}
+	}
+	r, _ := newTestRouter(Recover(), tag("outer"), tag("inner"))
+	r.Command(Command{Name: "boom", Handler: func(c *Context) {
+		trace = append(trace, "handler")
+		panic("boom")
+	}})
+
+	r.DispatchMessage(command("/boom", "group", 5))
+	if strings.Join(trace, ",") != "outer,inner,handler" {
+		t.Errorf("trace = %v", trace)
+	}
+}
+
+func TestBanCheck(t *testing.T) {
+	r, answers := newTestRouter(BanCheck(func(userID int) bool { return userID == 66 }))
+	ran := 0
+	r.Callback(Callback{Data: "hint", Handler: func(c *Context) { ran++ }})
+
+	r.DispatchCallback(callback("hint", 66))
+	r.DispatchCallback(callback("hint", 5))
+	if ran != 1 {
+		t.Errorf("handler ran %d times, want 1", ran)
+	}
+	if len(*answers) != 2 || !strings.Contains((*answers)[0], "banned") {
+		t.Errorf("answers = %q", *answers)
+	}
+}
+
+func TestCooldownPerUserAndRoute(t *testing.T) {
+	now := time.Unix(0, 0)
+	r, answers := newTestRouter(cooldown(func() time.Time { return now }))
+	ran := map[string]int{}
+	r.Command(Command{Name: "hint", Cooldown: time.Minute, Handler: func(c *Context) { ran["hint"]++ }})
+	r.Command(Command{Name: "stats", Handler: func(c *Context) { ran["stats"]++ }})
+	r.Callback(Callback{Data: "next", Cooldown: 10 * time.Second, Handler: func(c *Context) { ran["next"]++ }})
+
+	r.DispatchMessage(command("/hint", "group", 5))
+	r.DispatchMessage(command("/hint", "group", 5))
+	r.DispatchMessage(command("/hint", "group", 6))
+	r.DispatchMessage(command("/stats", "group", 5))
+	r.DispatchMessage(command("/stats", "group", 5))
+	r.DispatchCallback(callback("next", 5))
+	r.DispatchCallback(callback("next", 5))
+
+	now = now.Add(time.Minute)
+	r.DispatchMessage(command("/hint", "group", 5))
+
+	if ran["hint"] != 3 || ran["stats"] != 2 || ran["next"] != 1 {
+		t.Errorf("ran = %v", ran)
+	}
+	if len(*answers) != 2 || !strings.Contains((*answers)[1], "wait 10s") {
+		t.Errorf("answers = %q", *answers)
+	}
+}
+
+func TestHelpListsDescribedCommands(t *testing.T) {
+	r, _ := newTestRouter()
+	h := func(c *Context) {}
+	r.Command(Command{Name: "word", Description: "Start a new round", Scope: GroupOnly, Handler: h})
+	r.Command(Command{Name: "profile", Description: "Show your profile", Scope: PrivateOnly, Handler: h})
+	r.Command(Command{Name: "stats", Description: "Leaderboards", Handler: h})
+	r.Command(Command{Name: "getlogs", Description: "Send logs", AdminOnly: true, Handler: h})
+	r.Command(Command{Name: "getButton", Handler: h})
+
+	if got, want := r.Help(false), "/stats — Leaderboards\n/word — Start a new round"; got != want {
+		t.Errorf("group help = %q, want %q", got, want)
+	}
+	if got, want := r.Help(true), "/profile — Show your profile\n/stats — Leaderboards"; got != want {
+		t.Errorf("private help = %q, want %q", got, want)
+	}
+}
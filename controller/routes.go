@@ -0,0 +1,489 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	collectibleController "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/collectible"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/commands"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/modbot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/router"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordguess"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// newRouter wires the shared commands and the Croco-specific ones.
+func newRouter(games *game.Engine) *router.Router {
+	env := games.Env()
+	r := router.New(env.Bot, env.Client, env.Store,
+		router.Recover(),
+		router.Logging(),
+		router.BanCheck(modbot.IsGloballyBanned),
+		router.Cooldown(),
+	)
+	commands.Register(r, games)
+
+	r.Command(router.Command{Name: "ai_on", Scope: router.PrivateOnly, Handler: handleAIOn})
+	r.Command(router.Command{Name: "ai_off", Scope: router.PrivateOnly, Handler: handleAIOff})
+	r.Command(router.Command{Name: "start", Scope: router.PrivateOnly, Handler: handlePrivateStart})
+	r.Command(router.Command{Name: "start", Scope: router.GroupOnly, Handler: handleGroupStart})
+	r.Command(router.Command{Name: "setting", Description: "Configure the games for this chat", Scope: router.GroupOnly, Handler: handleSetting})
+	r.Command(router.Command{Name: "geosettings", Description: "Choose the Geography mode", Scope: router.GroupOnly, Handler: handleGeoSettings})
+	r.Command(router.Command{Name: "word", Description: "Start a new word round", Scope: router.GroupOnly, Handler: handleWordCommand})
+	r.Command(router.Command{Name: "game", Description: "Pick a game to play", Scope: router.GroupOnly, Handler: handleGameCommand})
+	r.Command(router.Command{Name: "hint", Description: "Get a hint for the current word", Scope: router.GroupOnly, Handler: handleHintCommand})
+	r.Command(router.Command{Name: "reveal", Description: "Reveal the word once the round is over", Scope: router.GroupOnly, Handler: handleRevealCommand})
+
+	r.Callback(router.Callback{Data: "explain", Handler: handleExplainCallback})
+	r.Callback(router.Callback{Data: "next", Handler: handleNextCallback})
+	r.Callback(router.Callback{Data: "droplead", Handler: handleDropLeadCallback})
+	r.Callback(router.Callback{Data: "hint", Handler: handleHintCallback})
+	return r
+}
+
+// handleAIOn turns on AI replies for a DM.
+func handleAIOn(c *router.Context) {
+	bot, chatID := c.Bot, c.ChatID
+
+	aiModeMutex.Lock()
+	aiModeUsers[chatID] = true
+	aiModeMutex.Unlock()
+	view.SendMessage(bot, chatID, "AI mode is now enabled! Enjoy the smart responses.")
+}
+
+// handleAIOff turns AI replies back off.
+func handleAIOff(c *router.Context) {
+	bot, chatID := c.Bot, c.ChatID
+
+	aiModeMutex.Lock()
+	delete(aiModeUsers, chatID)
+	aiModeMutex.Unlock()
+	view.SendMessage(bot, chatID, "AI mode has been disabled.")
+}
+
+// handlePrivateStart handles /start in a DM, including the deep links
+// used by the collectibles, shop and custom word buttons.
+func handlePrivateStart(c *router.Context) {
+	bot, message, store, chatID := c.Bot, c.Message, c.Store, c.ChatID
+
+	args := message.CommandArguments()
+	if args == "collectibles" {
+		collectibleController.ShowHub(bot, message.Chat.ID, message.From.ID, store)
+		return
+	} else if args == "shop" {
+		commands.ShowShop(bot, message.Chat.ID, message.From.ID, store)
+		return
+	} else if strings.HasPrefix(args, "custom_word_") {
+		parts := strings.Split(args, "_")
+		if len(parts) == 3 {
+			groupChatIDStr := parts[2]
+			var groupChatID int64
+			if _, err := fmt.Sscanf(groupChatIDStr, "%d", &groupChatID); err == nil {
+				groupState := getOrCreateChatState(groupChatID)
+				groupState.RLock()
+				isLeader := groupState.User == message.From.ID
+				groupState.RUnlock()
+				if isLeader {
+					customWordMutex.Lock()
+					customWordState[int64(message.From.ID)] = groupChatID
+					customWordMutex.Unlock()
+					msg := tgbotapi.NewMessage(chatID, "Type the word you want the group to guess (must be a valid English word):")
+					msg.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
+					bot.Send(msg)
+				} else {
+					view.SendMessage(bot, chatID, "You are not the current leader in that group.")
+				}
+			}
+		}
+		return
+	} else {
+		buttons := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Get Hint"+telegramReactions[20], "hint")))
+		view.SendMessageWithButtons(bot, message.Chat.ID, "Heyyy! Got a word for ya 😏 Tap the button below if you need a lil hint 👇", buttons)
+		return
+	}
+}
+
+// handleGroupStart greets a group.
+func handleGroupStart(c *router.Context) {
+	bot, message, store := c.Bot, c.Message, c.Store
+
+	if message.CommandArguments() == "collectibles" {
+		collectibleController.ShowHub(bot, message.Chat.ID, message.From.ID, store)
+	} else if message.CommandArguments() == "shop" {
+		commands.ShowShop(bot, message.Chat.ID, message.From.ID, store)
+	} else {
+		welcomeMsg := "👋 *Welcome to Croco Bot!*\n\n" +
+			"🐊 I'm your friendly group game host.\n\n" +
+			"🎮 *Quick Start:*\n" +
+			"📝 `/word` — Start a new game\n" +
+			"📖 `/rules` — Learn how to play\n" +
+			"📊 `/stats` — View your scores\n\n" +
+			"🎉 Have fun!"
+		view.SendMessage(bot, message.Chat.ID, welcomeMsg)
+	}
+}
+
+// handleSetting opens the per-chat game settings.
+func handleSetting(c *router.Context) {
+	view.SendMessageWithButtons(c.Bot, c.ChatID, "⚙️ *Settings*\nChoose a setting to configure:", commands.SettingsMenu())
+}
+
+// handleGeoSettings opens the Geography mode picker.
+func handleGeoSettings(c *router.Context) {
+	view.SendMessageWithButtons(c.Bot, c.ChatID, commands.GeographyModeText, commands.GeographyModeMenu(false))
+}
+
+// handleWordCommand starts a new round unless one is running.
+func handleWordCommand(c *router.Context) {
+	bot, message, chatID := c.Bot, c.Message, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+	settings := wordguess.GetChatSettings(chatID, c.Store)
+
+	chatState.RLock()
+	wordEmpty := chatState.Word == ""
+	leadExpired := chatState.roundOver(settings)
+	chatState.RUnlock()
+
+	if wordEmpty || leadExpired {
+		word, err := wordguess.NextWord(chatID, c.Store)
+		if err != nil {
+			view.SendMessage(bot, message.Chat.ID, "Failed to fetch a word.")
+			return
+		}
+
+		buttons := createMultiButtonKeyboard([][]string{
+			{" 🗣️ Explain ", "explain"},
+			{"Wordle 🟩🟨", "wordle_start"},
+			{"Scramy 𒅒𒈔𒅒", "scramy_start"},
+		})
+
+		chatState.Lock()
+		chatState.Word = word
+		chatState.HintsGiven = 0
+		chatState.User = 0
+		chatState.Leader = ""
+		chatState.Unlock()
+		saveChatStateAsync(chatID, chatState)
+
+		// THE CHARACTER UPGRADE:
+		sendCharacterAction(bot, chatID, StickerOwlWatching,
+			"🦉 *The Owl ruffles its feathers in the moonlight.*\n\"A new secret has been placed in the forest. Who will step forward to explain?\"")
+
+		view.SendMessageWithButtons(bot, chatID, "🐊 *The Crocodile peeks from the reeds, waiting...*", buttons)
+	} else {
+		sentMsg, err := view.SendMessage(bot, message.Chat.ID, "A game is currently in progress.")
+		deleteWarningMessage(bot, message, sentMsg, err)
+	}
+}
+
+// handleGameCommand shows the game picker.
+func handleGameCommand(c *router.Context) {
+	bot, chatID := c.Bot, c.ChatID
+
+	buttons := createMultiButtonKeyboard([][]string{
+		{" 🗣️ Explain ", "explain"},
+		{"Wordle 🟩🟨", "wordle_start"},
+		{"Scramy 𒅒𒈔𒅒", "scramy_start"},
+	})
+	view.SendMessageWithButtons(bot, chatID, "🐊 *The Crocodile peeks from the reeds, waiting...*", buttons)
+}
+
+// handleHintCommand sends a spoilered hint for the current word.
+func handleHintCommand(c *router.Context) {
+	bot, message, chatID := c.Bot, c.Message, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+
+	chatState.RLock()
+	wordEmpty := chatState.Word == ""
+	lastHint := chatState.LastHintTimestamp
+	hintsGiven := chatState.HintsGiven
+	chatState.RUnlock()
+
+	if wordEmpty {
+		buttons := createSingleButtonKeyboard(" 🗣️ Explain ", "explain")
+		view.SendMessageWithButtons(bot, message.Chat.ID, "No active game right now. Click below to start one!", buttons)
+		return
+	}
+
+	if !lastHint.IsZero() && time.Since(lastHint) < 1*time.Minute {
+		sentMsg, err := view.SendMessage(bot, message.Chat.ID, "Please wait a minute before requesting another hint.")
+		deleteWarningMessage(bot, message, sentMsg, err)
+		return
+	}
+
+	chatState.RLock()
+	word := chatState.Word
+	chatState.RUnlock()
+	hint, ok := wordguess.NextHint(wordguess.GetChatSettings(chatID, c.Store), word, hintsGiven)
+	if !ok {
+		view.SendMessage(bot, chatID, hint)
+		return
+	}
+
+	// THE CHARACTER UPGRADE:
+	sendCharacterAction(bot, chatID, StickerCrocCurious, "🐊 *The Crocodile tilts his head.*\n\"Stuck already? I thought humans were supposed to be the smart ones!\"")
+
+	time.Sleep(1 * time.Second) // Let the Croc's joke land
+
+	// Send chat action "typing" before sending hint
+	chatAction := tgbotapi.NewChatAction(chatID, tgbotapi.ChatTyping)
+	bot.Send(chatAction)
+
+	// Escape MarkdownV2 special characters in hint text
+	escapedHint := escapeMarkdownV2(hint)
+
+	// Wrap escaped hint text in spoiler formatting for Telegram MarkdownV2
+	spoilerHint := "||" + escapedHint + "||"
+	msg := tgbotapi.NewMessage(chatID, spoilerHint)
+	msg.ParseMode = "MarkdownV2"
+	_, err := bot.Send(msg)
+	if err != nil {
+		log.Printf("Failed to send hint message with spoiler formatting: %v", err)
+	}
+
+	chatState.Lock()
+	chatState.LastHintTimestamp = time.Now()
+	chatState.HintsGiven = hintsGiven + 1
+	chatState.Unlock()
+	saveChatStateAsync(chatID, chatState)
+}
+
+// handleRevealCommand reveals the word once the round is old enough.
+func handleRevealCommand(c *router.Context) {
+	bot, message, chatID := c.Bot, c.Message, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+	settings := wordguess.GetChatSettings(chatID, c.Store)
+
+	chatState.RLock()
+	word := chatState.Word
+	roundOver := chatState.roundOver(settings)
+	chatState.RUnlock()
+
+	if roundOver {
+		buttons := createMultiButtonKeyboard([][]string{
+			{" 🗣️ Explain ", "explain"},
+			{"Wordle 🟩🟨", "wordle_start"},
+		})
+		view.SendMessageWithButtons(bot, message.Chat.ID, fmt.Sprintf("The word was: %s", word), buttons)
+
+		chatState.reset(chatID)
+	} else {
+		sentMsg, err := view.SendMessage(bot, message.Chat.ID, "Please wait until the round is over before revealing the word.")
+		deleteWarningMessage(bot, message, sentMsg, err)
+	}
+}
+
+// handleExplainCallback lets a user claim leadership or see their word again.
+func handleExplainCallback(c *router.Context) {
+	bot, callback, chatID := c.Bot, c.Callback, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+	settings := wordguess.GetChatSettings(chatID, c.Store)
+
+	chatState.Lock()
+	if chatState.User != callback.From.ID && !chatState.roundOver(settings) {
+		c.AnswerAlert(fmt.Sprintf("%s is already explaining the word. Please wait for your turn, %s.", chatState.Leader, callback.From.UserName))
+		chatState.Unlock()
+		saveChatStateAsync(chatID, chatState)
+		return
+	}
+	if chatState.User == callback.From.ID {
+		c.AnswerAlert(chatState.Word)
+		chatState.Unlock()
+		saveChatStateAsync(chatID, chatState)
+		return
+	}
+	if chatState.roundOver(settings) {
+		if !commands.ClaimTurn(c) {
+			chatState.Unlock()
+			return
+		}
+		chatState.User = callback.From.ID
+
+		// THE CHARACTER UPGRADE: Owl nods in approval
+		if StickerOwlNodding != "" {
+			view.SendSticker(bot, chatID, StickerOwlNodding)
+		}
+
+		word, err := wordguess.NextWord(chatID, c.Store)
+		if err != nil {
+			chatState.Unlock()
+			saveChatStateAsync(chatID, chatState)
+			return
+		}
+		customWordLink := fmt.Sprintf("https://t.me/%s?start=custom_word_%d", bot.Self.UserName, chatID)
+		buttons := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("See word 👀", "explain"),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonURL("Custom Word ✍️", customWordLink),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("Next ⏭️", "next"),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("Wordle 🟩🟨", "wordle_start"),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("Changed my mind ❌", "droplead"),
+			),
+		)
+		chatState.Word = word
+		chatState.HintsGiven = 0
+		commands.TurnTaken(chatID, callback.From.ID)
+
+		// THE CHARACTER UPGRADE:
+		text := fmt.Sprintf("🐊 *The Crocodile splashes happily!*\n\"Look! [%s](tg://user?id=%d) is going to tell us a story! I'm all ears... and teeth!\"",
+			callback.From.FirstName, callback.From.ID)
+
+		view.SendMessageWithButtons(bot, chatID, text, buttons)
+
+		// The Owl gives a stern warning
+		time.Sleep(800 * time.Millisecond)
+		// view.SendMessage(bot, chatID, "🦉 *The Owl blinks slowly.*\n\"Be precise, traveler. Do not speak the word itself.\"")
+
+		// Remove the inline keyboard (buttons) from the "claim leadership" message when someone starts leading
+		editMarkup := tgbotapi.NewEditMessageReplyMarkup(callback.Message.Chat.ID, callback.Message.MessageID, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
+		_, err = bot.Send(editMarkup)
+		if err != nil {
+			log.Printf("Failed to remove inline keyboard: %v", err)
+		}
+	}
+	chatState.Leader = callback.From.FirstName
+	chatState.LeadTimestamp = time.Now()
+	chatState.Unlock()
+	saveChatStateAsync(chatID, chatState)
+	c.AnswerAlert(chatState.Word)
+	if !c.IsPrivate() {
+		roundTimers.Start(chatID)
+	}
+}
+
+// handleNextCallback gives the leader a fresh word.
+func handleNextCallback(c *router.Context) {
+	callback, chatID := c.Callback, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+
+	chatState.Lock()
+	if chatState.User != callback.From.ID && chatState.User != 0 {
+		c.AnswerAlert(fmt.Sprintf("%s is already explaining the word. %s", chatState.Leader, callback.From.UserName))
+		chatState.Unlock()
+		saveChatStateAsync(chatID, chatState)
+		return
+	}
+	if chatState.User == 0 {
+		c.AnswerAlert(fmt.Sprintf("%s Please click on see word/claim Leadership", callback.From.FirstName))
+		chatState.Unlock()
+		saveChatStateAsync(chatID, chatState)
+		return
+	}
+	chatState.User = callback.From.ID
+	chatState.Leader = callback.From.FirstName
+	chatState.Word, _ = wordguess.NextWord(chatID, c.Store)
+	chatState.HintsGiven = 0
+	chatState.Unlock()
+	saveChatStateAsync(chatID, chatState)
+	c.AnswerAlert(chatState.Word)
+}
+
+// handleDropLeadCallback lets the leader give up the round.
+func handleDropLeadCallback(c *router.Context) {
+	bot, callback, chatID := c.Bot, c.Callback, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+
+	chatState.Lock()
+	if chatState.User != callback.From.ID {
+		c.AnswerAlert("You are not the current leader, so you cannot drop the lead!")
+		chatState.Unlock()
+		saveChatStateAsync(chatID, chatState)
+		return
+	}
+	// Delete the callback message when user selects "Changed my mind"
+	deleteMsg := tgbotapi.NewDeleteMessage(callback.Message.Chat.ID, callback.Message.MessageID)
+	_, err := bot.DeleteMessage(deleteMsg)
+	if err != nil {
+		log.Printf("Failed to delete message on droplead: %v", err)
+	}
+	chatState.Unlock()
+	saveChatStateAsync(chatID, chatState)
+	buttons := createSingleButtonKeyboard("🌟 Claim Leadership 🙋", "explain")
+	view.SendMessageWithButtons(bot, callback.Message.Chat.ID, fmt.Sprintf("%s refused to lead -> %s \n", callback.From.FirstName, chatState.Word), buttons)
+	chatState.reset(chatID)
+}
+
+// handleHintCallback sends a hint from the hint button.
+func handleHintCallback(c *router.Context) {
+	bot, callback, chatID := c.Bot, c.Callback, c.ChatID
+	chatState := getOrCreateChatState(chatID)
+
+	chatState.RLock()
+	wordEmpty := chatState.Word == ""
+	lastHint := chatState.LastHintTimestamp
+	hintsGiven := chatState.HintsGiven
+	chatState.RUnlock()
+
+	if wordEmpty {
+		if callback.Message.Chat.IsPrivate() {
+			word, err := wordguess.NextWord(chatID, c.Store)
+			if err != nil {
+				view.SendMessage(bot, chatID, "Failed to load next word.")
+				chatState.reset(chatID)
+				return
+			}
+
+			chatState.Lock()
+			chatState.Word = word
+			chatState.HintsGiven = 0
+			chatState.User = callback.From.ID
+			chatState.LeadTimestamp = time.Now()
+			chatState.LastHintTimestamp = time.Time{}
+			chatState.Unlock()
+			saveChatStateAsync(chatID, chatState)
+		} else {
+			buttons := createMultiButtonKeyboard([][]string{
+				{" 🗣️ Explain ", "explain"},
+				{"Wordle 🟩🟨", "wordle_start"},
+			})
+			view.SendMessageWithButtons(bot, callback.Message.Chat.ID, "No active game right now. Click below to start one!", buttons)
+			return
+		}
+	}
+
+	if !lastHint.IsZero() && time.Since(lastHint) < 1*time.Minute {
+		sentMsg, err := view.SendMessage(bot, chatID, "Please take a minute before requesting another hint.")
+		deleteWarningMessage(bot, callback.Message, sentMsg, err)
+		return
+	}
+
+	chatState.RLock()
+	word := chatState.Word
+	chatState.RUnlock()
+	hint, ok := wordguess.NextHint(wordguess.GetChatSettings(chatID, c.Store), word, hintsGiven)
+	if !ok {
+		view.SendMessage(bot, chatID, hint)
+		return
+	}
+
+	chatAction := tgbotapi.NewChatAction(callback.Message.Chat.ID, tgbotapi.ChatTyping)
+	bot.Send(chatAction)
+
+	escapedHint := escapeMarkdownV2(hint)
+	spoilerHint := "||" + escapedHint + "||"
+	msg := tgbotapi.NewMessage(callback.Message.Chat.ID, spoilerHint)
+	msg.ParseMode = "MarkdownV2"
+	_, err := bot.Send(msg)
+	if err != nil {
+		log.Printf("Failed to send hint message with spoiler formatting: %v", err)
+	}
+
+	chatState.Lock()
+	chatState.LastHintTimestamp = time.Now()
+	chatState.HintsGiven = hintsGiven + 1
+	chatState.Unlock()
+	saveChatStateAsync(chatID, chatState)
+}
@@ -3,6 +3,7 @@ package schedulebot
 import (
 	"context"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -22,7 +23,7 @@ type ScheduledMessage struct {
 }
 
 func GetCollection(client *mongo.Client) *mongo.Collection {
-	return client.Database("Telegram").Collection("ScheduledMessages")
+	return client.Database(config.App.Mongo.Database).Collection("ScheduledMessages")
 }
 
 func saveSchedule(client *mongo.Client, msg ScheduledMessage) (*mongo.InsertOneResult, error) {
@@ -1,8 +1,11 @@
 package schedulebot
 
 import (
+	"context"
 	"log"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/webhook"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/robfig/cron/v3"
@@ -14,14 +17,14 @@ var (
 )
 
 // StartScheduleBot initializes and starts the schedule bot
-func StartScheduleBot(token string) error {
-	bot, err := tgbotapi.NewBotAPI(token)
+func StartScheduleBot(ctx context.Context, cfg config.Config) error {
+	bot, err := tgbotapi.NewBotAPI(cfg.Bots.Schedule.Token)
 	if err != nil {
 		return err
 	}
 
 	botInstance = bot
-	bot.Debug = true
+	bot.Debug = cfg.Features.Debug
 	log.Printf("ScheduleBot authorized on account %s", bot.Self.UserName)
 
 	client := repository.DbManager()
@@ -40,19 +43,25 @@ func StartScheduleBot(token string) error {
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
-	updates, err := bot.GetUpdatesChan(u)
+	updates, err := webhook.Updates(cfg, "schedule", cfg.Bots.Schedule.Token, func() (<-chan tgbotapi.Update, error) {
+		return bot.GetUpdatesChan(u)
+	})
 	if err != nil {
 		return err
 	}
 
-	for update := range updates {
+	webhook.Serve(ctx, updates, func(update tgbotapi.Update) {
 		if update.Message != nil {
-			go handleMessage(bot, update.Message, client)
+			handleMessage(bot, update.Message, client)
 		}
 		if update.ChannelPost != nil {
-			go handleMessage(bot, update.ChannelPost, client)
+			handleMessage(bot, update.ChannelPost, client)
 		}
-	}
+	})
+	bot.StopReceivingUpdates()
+
+	// Let reminders that are being sent right now finish.
+	<-cronParser.Stop().Done()
 
 	return nil
 }
@@ -0,0 +1,61 @@
+package scramybot
+
+import (
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// statesCollection holds one saved ScramyStateDoc per chat.
+const statesCollection = "ScramyStates"
+
+// Game is Scramy for the game engine.
+var Game game.Game = scramy{}
+
+type scramy struct{}
+
+func (scramy) Info() game.Info {
+	return game.Info{
+		Name:        "scramy",
+		Title:       "Scramy",
+		Description: "Start a Scramy game",
+		States:      statesCollection,
+	}
+}
+
+func (scramy) Scoreboard() string {
+	return "ScramyEn"
+}
+
+func (scramy) Start(env game.Env, chatID int64, p game.Player) {
+	HandleScramyCommand(env.Bot, chatID, p.Name, env.Store)
+}
+
+func (scramy) Active(chatID int64) bool {
+	return IsScramyActive(chatID)
+}
+
+func (scramy) Guess(env game.Env, msg *tgbotapi.Message) *game.Result {
+	return HandleGuess(env.Bot, msg, env.Store, msg.Chat.ID, msg.Text)
+}
+
+func (scramy) Hint(game.Env, *tgbotapi.Message) bool {
+	return false
+}
+
+func (scramy) Cancel(env game.Env, chatID int64) bool {
+	return CancelScramy(chatID, env.Store)
+}
+
+func (scramy) Snapshot(chatID int64) (any, bool) {
+	scramyMutex.RLock()
+	ss, ok := scramyStates[chatID]
+	scramyMutex.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return ss.snapshot(chatID), true
+}
+
+func (scramy) Restore(load func(target any) error) (int, error) {
+	return restoreStates(load)
+}
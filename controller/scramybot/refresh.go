@@ -2,14 +2,14 @@ package scramybot
 
 import (
 	"fmt"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
 	tgbotapiv5Ovy "github.com/OvyFlash/telegram-bot-api"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // RefreshActiveGameMessage updates the active scramy game message
-func RefreshActiveGameMessage(bot *tgbotapi.BotAPI, chatID int64, messageID int, client *mongo.Client) {
+func RefreshActiveGameMessage(bot *tgbotapi.BotAPI, chatID int64, messageID int, store repository.Store) {
 	ss := GetOrCreateScramyState(chatID)
 	ss.RLock()
 	defer ss.RUnlock()
@@ -18,7 +18,7 @@ func RefreshActiveGameMessage(bot *tgbotapi.BotAPI, chatID int64, messageID int,
 		return
 	}
 
-	settings := GetChatSettings(chatID, client)
+	settings := GetChatSettings(chatID, store)
 	isSquared := settings.ScramyLetterView == "squared"
 	isH1 := settings.ScramyLetterView == "h1"
 
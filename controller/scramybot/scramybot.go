@@ -13,11 +13,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // ScramyStateDoc is the MongoDB-serializable version of ScramyState
@@ -33,9 +33,11 @@ type ScramyStateDoc struct {
 	PendingNewGame bool                `bson:"pending_new_game"`
 }
 
-// saveScramyStateAsync asynchronously saves the Scramy state to MongoDB
-func saveScramyStateAsync(chatID int64, state *ScramyState) {
+// snapshot returns state in the form it is saved in. The caller must not hold
+// the state's lock.
+func (state *ScramyState) snapshot(chatID int64) ScramyStateDoc {
 	state.RLock()
+	defer state.RUnlock()
 
 	// Convert int keys to string keys for MongoDB BSON compatibility
 	userWordsStr := make(map[string][]string)
@@ -53,7 +55,7 @@ func saveScramyStateAsync(chatID int64, state *ScramyState) {
 		userNamesStr[strconv.FormatInt(int64(k), 10)] = v
 	}
 
-	doc := ScramyStateDoc{
+	return ScramyStateDoc{
 		ChatID:         chatID,
 		Active:         state.Active,
 		Letters:        state.Letters,
@@ -64,23 +66,18 @@ func saveScramyStateAsync(chatID int64, state *ScramyState) {
 		MaxWords:       state.MaxWords,
 		PendingNewGame: state.PendingNewGame,
 	}
-	state.RUnlock()
+}
 
-	go func() {
-		client := repository.DbManager()
-		if client != nil {
-			repository.SaveGameState(client, "ScramyStates", chatID, doc)
-		}
-	}()
+// saveScramyStateAsync saves the Scramy state to the store in the background
+func saveScramyStateAsync(store repository.Store, chatID int64, state *ScramyState) {
+	game.Save(store, statesCollection, chatID, state.snapshot(chatID))
 }
 
-// LoadSavedStates loads the persisted Scramy states from MongoDB into the memory map
-func LoadSavedStates(client *mongo.Client) {
+// restoreStates replaces the in-memory states with the saved ones.
+func restoreStates(load func(target any) error) (int, error) {
 	var results []ScramyStateDoc
-	err := repository.LoadAllGameStates(client, "ScramyStates", &results)
-	if err != nil {
-		log.Printf("Failed to load saved Scramy states: %v", err)
-		return
+	if err := load(&results); err != nil {
+		return 0, err
 	}
 
 	scramyMutex.Lock()
@@ -119,7 +116,7 @@ func LoadSavedStates(client *mongo.Client) {
 
 		scramyStates[doc.ChatID] = ss
 	}
-	log.Printf("Loaded %d active Scramy games from MongoDB", len(results))
+	return len(results), nil
 }
 
 // ScramyState holds the state for a Scramy game in a specific chat.
@@ -393,20 +390,20 @@ func IsScramyActive(chatID int64) bool {
 }
 
 // HandleScramyCommand starts a new Scramy game
-func HandleScramyCommand(bot *tgbotapi.BotAPI, chatID int64, username string) {
+func HandleScramyCommand(bot *tgbotapi.BotAPI, chatID int64, username string, store repository.Store) {
 	ss := GetOrCreateScramyState(chatID)
 
 	ss.Lock()
 	if ss.Active {
 		if ss.PendingNewGame {
 			ss.Unlock()
-			saveScramyStateAsync(chatID, ss)
+			saveScramyStateAsync(store, chatID, ss)
 			return
 		}
 		ss.PendingNewGame = true
 		ss.CancelChan = make(chan bool, 1)
 		ss.Unlock()
-		saveScramyStateAsync(chatID, ss)
+		saveScramyStateAsync(store, chatID, ss)
 
 		markup := tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(
@@ -426,7 +423,7 @@ func HandleScramyCommand(bot *tgbotapi.BotAPI, chatID int64, username string) {
 				ss.Lock()
 				if !ss.PendingNewGame {
 					ss.Unlock()
-					saveScramyStateAsync(chatID, ss)
+					saveScramyStateAsync(store, chatID, ss)
 					return
 				}
 				ss.PendingNewGame = false
@@ -437,7 +434,7 @@ func HandleScramyCommand(bot *tgbotapi.BotAPI, chatID int64, username string) {
 				ss.UserScores = make(map[int]int)
 				ss.UserNames = make(map[int]string)
 				ss.Unlock()
-				saveScramyStateAsync(chatID, ss)
+				saveScramyStateAsync(store, chatID, ss)
 
 				if err == nil {
 					deleteMsg := tgbotapi.NewDeleteMessage(chatID, sentMsg.MessageID)
@@ -479,7 +476,7 @@ func HandleScramyCommand(bot *tgbotapi.BotAPI, chatID int64, username string) {
 	ss.UserScores = make(map[int]int)
 	ss.UserNames = make(map[int]string)
 	ss.Unlock()
-	saveScramyStateAsync(chatID, ss)
+	saveScramyStateAsync(store, chatID, ss)
 
 	settings := GetChatSettings(chatID, nil)
 	isSquared := settings.ScramyLetterView == "squared"
@@ -502,12 +499,12 @@ func HandleScramyCommand(bot *tgbotapi.BotAPI, chatID int64, username string) {
 }
 
 // CancelPendingGame cancels an ongoing new game request
-func CancelPendingGame(bot *tgbotapi.BotAPI, chatID int64, username string) bool {
+func CancelPendingGame(bot *tgbotapi.BotAPI, chatID int64, username string, store repository.Store) bool {
 	ss := GetOrCreateScramyState(chatID)
 	ss.Lock()
 	defer func() {
 		ss.Unlock()
-		saveScramyStateAsync(chatID, ss)
+		saveScramyStateAsync(store, chatID, ss)
 	}()
 
 	if ss.PendingNewGame {
@@ -522,6 +519,27 @@ func CancelPendingGame(bot *tgbotapi.BotAPI, chatID int64, username string) bool
 	return false
 }
 
+// CancelScramy ends the Scramy game in the chat along with any pending new
+// game request. It returns false if no game was running.
+func CancelScramy(chatID int64, store repository.Store) bool {
+	ss := GetOrCreateScramyState(chatID)
+	ss.Lock()
+	if !ss.Active {
+		ss.Unlock()
+		return false
+	}
+	ss.Active = false
+	ss.PendingNewGame = false
+	select {
+	case ss.CancelChan <- true:
+	default:
+	}
+	ss.Unlock()
+
+	saveScramyStateAsync(store, chatID, ss)
+	return true
+}
+
 func isValidWordFromLetters(word string, letters string) bool {
 	// Bolt Optimization: Use a 256 boolean array instead of map for O(1) ASCII lookup,
 	// and single loop mapping over raw characters avoiding string allocation overheads.
@@ -562,33 +580,34 @@ func capitalizeWord(word string) string {
 	return string(b)
 }
 
-// HandleGuess processes a guess for a Scramy game
-func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client, chatID int64, text string) {
+// HandleGuess processes a guess for a Scramy game. It returns everyone's final
+// score when the guess ended the game.
+func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, store repository.Store, chatID int64, text string) *game.Result {
 	ss := GetOrCreateScramyState(chatID)
 	ss.Lock()
 	defer func() {
 		ss.Unlock()
-		saveScramyStateAsync(chatID, ss)
+		saveScramyStateAsync(store, chatID, ss)
 	}()
 
 	if !ss.Active {
-		return
+		return nil
 	}
 
 	guess := strings.ToLower(strings.TrimSpace(text))
 
 	if len(guess) < 4 {
-		return // Not a valid guess format, ignore
+		return nil // Not a valid guess format, ignore
 	}
 
 	for i := 0; i < len(guess); i++ {
 		if guess[i] < 'a' || guess[i] > 'z' {
-			return // Ignore non-alphabetical inputs completely
+			return nil // Ignore non-alphabetical inputs completely
 		}
 	}
 
 	if !isValidWordFromLetters(guess, ss.Letters) {
-		return
+		return nil
 	}
 
 	wordsMutex.RLock()
@@ -596,12 +615,12 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 	wordsMutex.RUnlock()
 
 	if !isValid {
-		return
+		return nil
 	}
 
 	for _, g := range ss.FoundWords {
 		if g == guess {
-			return // Already guessed
+			return nil // Already guessed
 		}
 	}
 
@@ -612,7 +631,7 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 	ss.UserScores[message.From.ID] += points
 	ss.UserNames[message.From.ID] = message.From.FirstName
 
-	settings := GetChatSettings(chatID, client)
+	settings := GetChatSettings(chatID, store)
 	isSquared := settings.ScramyLetterView == "squared"
 	isH1 := settings.ScramyLetterView == "h1"
 	letterStr := getLetterString(ss.Letters, isSquared)
@@ -665,12 +684,12 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 				name = fmt.Sprintf("User %d", userID) // Fallback if somehow not found
 			}
 			scores = append(scores, userScoreEntry{ID: userID, Name: name, Score: score})
-			go repository.InsertWordleBonusDoc(userID, name, chatID, client, "ScramyEn", score) // reusing logic since it just inserts Score/Points
+			go store.InsertWordleBonusDoc(userID, name, chatID, "ScramyEn", score) // reusing logic since it just inserts Score/Points
 
 			// ⚡ Add Progression Integration
 			go func(uID int64, username string) {
-				if client != nil {
-					service.AwardGameResult(client, uID, username, true) // Treating finding a word as a win/participation in Scramy
+				if store != nil {
+					service.AwardGameResult(store, uID, username, true) // Treating finding a word as a win/participation in Scramy
 				}
 			}(int64(userID), name)
 		}
@@ -708,6 +727,12 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 		// } else {
 		view.ReplyToMessageWithButtonsHTML(bot, message.MessageID, chatID, msg, buttons)
 		// }
+
+		res := &game.Result{}
+		for _, s := range scores {
+			res.Scores = append(res.Scores, game.Score{Player: game.Player{ID: s.ID, Name: s.Name}, Points: s.Score})
+		}
+		return res
 	} else {
 		if isH1 || isSquared {
 			topText := fmt.Sprintf("%s found \"%s\"\n+%d 💎\n\n", message.From.FirstName, capitalizeWord(guess), points)
@@ -721,4 +746,5 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 			view.ReplyToMessage(bot, message.MessageID, chatID, msg)
 		}
 	}
+	return nil
 }
@@ -1,13 +1,11 @@
 package scramybot
 
 import (
-	"context"
+	"log"
 	"sync"
-	"time"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type ChatSettings struct {
@@ -15,12 +13,14 @@ type ChatSettings struct {
 	ScramyLetterView string `bson:"scramy_letter_view"` // "squared" or "normal"
 }
 
+const settingsCollection = "ScramySettings"
+
 var (
 	settingsCache = make(map[int64]*ChatSettings)
 	settingsMutex sync.RWMutex
 )
 
-func GetChatSettings(chatID int64, client *mongo.Client) *ChatSettings {
+func GetChatSettings(chatID int64, store repository.Store) *ChatSettings {
 	settingsMutex.RLock()
 	settings, ok := settingsCache[chatID]
 	settingsMutex.RUnlock()
@@ -35,14 +35,9 @@ func GetChatSettings(chatID int64, client *mongo.Client) *ChatSettings {
 		ScramyLetterView: "squared", // Default to squared
 	}
 
-	if client != nil {
-		collection := client.Database("TelegramBot").Collection("ScramySettings")
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		err := collection.FindOne(ctx, bson.M{"_id": chatID}).Decode(settings)
-		if err != nil && err != mongo.ErrNoDocuments {
-			// Log error if needed
+	if store != nil {
+		if err := store.LoadChatSettings(settingsCollection, chatID, settings); err != nil {
+			log.Printf("Failed to load Scramy settings for chat %d: %v", chatID, err)
 		}
 	}
 
@@ -53,23 +48,16 @@ func GetChatSettings(chatID int64, client *mongo.Client) *ChatSettings {
 	return settings
 }
 
-func UpdateScramyLetterView(chatID int64, viewType string, client *mongo.Client) error {
-	settings := GetChatSettings(chatID, client)
+func UpdateScramyLetterView(chatID int64, viewType string, store repository.Store) error {
+	settings := GetChatSettings(chatID, store)
 
 	settingsMutex.Lock()
 	settings.ScramyLetterView = viewType
 	settingsCache[chatID] = settings
 	settingsMutex.Unlock()
 
-	if client != nil {
-		collection := client.Database("TelegramBot").Collection("ScramySettings")
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		opts := options.Update().SetUpsert(true)
-		update := bson.M{"$set": bson.M{"scramy_letter_view": viewType}}
-		_, err := collection.UpdateOne(ctx, bson.M{"_id": chatID}, update, opts)
-		return err
+	if store != nil {
+		return store.SaveChatSettings(settingsCollection, chatID, bson.M{"scramy_letter_view": viewType})
 	}
 	return nil
 }
@@ -4,6 +4,7 @@ package translator
 //Add synonyms
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -13,7 +14,9 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/translator/utilities"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/webhook"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -22,7 +25,6 @@ var (
 	bannedUsers     = make(map[int64]bool)
 	bannedUsersMu   = &sync.RWMutex{}
 	bannedUsersFile = "translator_banned_users.json"
-	adminID         = int64(1006461736)
 )
 
 func loadBannedUsers() error {
@@ -106,9 +108,10 @@ func getTargetUserID(message *tgbotapi.Message) (int64, error) {
 	return 0, fmt.Errorf("Usage: reply with /ban or /unban, or provide a numeric user ID")
 }
 
-func Bot() {
+func Bot(ctx context.Context, cfg config.Config) {
+	applyConfig(cfg)
 	if BotToken == "" {
-		log.Fatal("TELEGRAM_BOT_TOKEN environment variable is not set")
+		log.Fatal("TRANSLATOR_BOT_TOKEN environment variable is not set")
 	}
 
 	bot, err := tgbotapi.NewBotAPI(BotToken)
@@ -116,20 +119,29 @@ func Bot() {
 		log.Fatalf("Failed to create bot: %v", err)
 	}
 
-	bot.Debug = true
+	bot.Debug = cfg.Features.Debug
 
 	log.Printf("Authorized on account %s", bot.Self.UserName)
 
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
-	updates := bot.GetUpdatesChan(u)
+	updates, err := webhook.Updates(cfg, "translator", BotToken, func() (<-chan tgbotapi.Update, error) {
+		return bot.GetUpdatesChan(u), nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to receive updates: %v", err)
+	}
 
 	if err := loadBannedUsers(); err != nil {
 		log.Printf("Failed to load banned users: %v", err)
 	}
 
-	for update := range updates {
+	for {
+		update, ok := webhook.Next(ctx, updates)
+		if !ok {
+			break
+		}
 		if update.Message != nil {
 			message := update.Message
 			chatID := message.Chat.ID
@@ -149,7 +161,7 @@ func Bot() {
 			if cmd == "banZoro" || cmd == "unban" || cmd == "bannedlist" {
 				switch cmd {
 				case "banZoro":
-					if message.From.ID != adminID {
+					if !config.App.IsAdmin(message.From.ID) {
 						break
 					}
 					targetID, err := getTargetUserID(message)
@@ -163,7 +175,7 @@ func Bot() {
 					}
 					bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("User %d has been banned.", targetID)))
 				case "unban":
-					if message.From.ID != adminID {
+					if !config.App.IsAdmin(message.From.ID) {
 						break
 					}
 					targetID, err := getTargetUserID(message)
@@ -177,7 +189,7 @@ func Bot() {
 					}
 					bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("User %d has been unbanned.", targetID)))
 				case "bannedlist":
-					if message.From.ID != adminID {
+					if !config.App.IsAdmin(message.From.ID) {
 						break
 					}
 					list := getBannedUserList()
@@ -251,7 +263,7 @@ func Bot() {
 				case "start":
 					startHandler(bot, update)
 				case "banZoro":
-					if message.From.ID != adminID {
+					if !config.App.IsAdmin(message.From.ID) {
 						break
 					}
 					args := strings.Fields(commandArguments(message.Text))
@@ -270,7 +282,7 @@ func Bot() {
 					}
 					bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("User %d has been banned.", userID)))
 				case "unban":
-					if message.From.ID != adminID {
+					if !config.App.IsAdmin(message.From.ID) {
 						break
 					}
 					args := strings.Fields(commandArguments(message.Text))
@@ -289,7 +301,7 @@ func Bot() {
 					}
 					bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("User %d has been unbanned.", userID)))
 				case "bannedlist":
-					if message.From.ID != adminID {
+					if !config.App.IsAdmin(message.From.ID) {
 						break
 					}
 					list := getBannedUserList()
@@ -863,14 +875,23 @@ func Bot() {
 					if repository.HasFreeEordle(dbClient, userID) {
 						repository.UseFreeEordle(dbClient, userID)
 					} else {
-						points := repository.GetCurrentPoints(dbClient, userID)
-						if points < 5 {
+						_, err := repository.ApplyPoints(dbClient, repository.PointsEntry{
+							Key:    fmt.Sprintf("%s:%d:%d", message.Command(), chatID, message.MessageID),
+							UserID: userID,
+							Name:   userName,
+							ChatID: chatID,
+							Amount: -5,
+							Reason: "Eordle",
+						})
+						if err == repository.ErrInsufficientPoints {
 							msg := tgbotapi.NewMessage(chatID, "You don't have enough points. Every Eordle costs 5 points. Play Wordle to earn more points.")
 							msg.ReplyToMessageID = message.MessageID
 							bot.Send(msg)
 							break
+						} else if err != nil && err != repository.ErrDuplicateEntry {
+							log.Printf("Error charging for %s: %v", message.Command(), err)
+							break
 						}
-						repository.DeductWordlePoints(dbClient, userID, userName, chatID, 5)
 					}
 
 					suggestion := translator.SolveWordle(text)
@@ -904,14 +925,23 @@ func Bot() {
 					if repository.HasFreeEordle(dbClient, userID) {
 						repository.UseFreeEordle(dbClient, userID)
 					} else {
-						points := repository.GetCurrentPoints(dbClient, userID)
-						if points < 5 {
+						_, err := repository.ApplyPoints(dbClient, repository.PointsEntry{
+							Key:    fmt.Sprintf("%s:%d:%d", message.Command(), chatID, message.MessageID),
+							UserID: userID,
+							Name:   userName,
+							ChatID: chatID,
+							Amount: -5,
+							Reason: "Eordle",
+						})
+						if err == repository.ErrInsufficientPoints {
 							msg := tgbotapi.NewMessage(chatID, "You don't have enough points. Every Eordle costs 5 points. Play Wordle to earn more points.")
 							msg.ReplyToMessageID = message.MessageID
 							bot.Send(msg)
 							break
+						} else if err != nil && err != repository.ErrDuplicateEntry {
+							log.Printf("Error charging for %s: %v", message.Command(), err)
+							break
 						}
-						repository.DeductWordlePoints(dbClient, userID, userName, chatID, 5)
 					}
 
 					analysis := translator.AnalyzeEordle(text)
@@ -982,6 +1012,7 @@ func Bot() {
 			}
 		}
 	}
+	bot.StopReceivingUpdates()
 }
 
 func normalizeCommand(text, botUserName string) string {
@@ -1,10 +1,32 @@
 package translator
 
+import "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+
 var (
-	BotToken      = "dhjsdjjshdjsdhjs"
+	BotToken      = ""
 	OpenAIKey     = "dhjsdjjshdjsdhjs"
 	OPenAI        = "dhjsdjjshdjsdhjs"
 	AssemblyAIKey = "dhjsdjjshdjsdhjs"
 	APINinjas     = "dhjsdjjshdjsdhjs"
 	ElevenLabs    = "dhjsdjjshdjsdhjs"
 )
+
+// applyConfig copies the configured token, endpoint and API keys into the
+// package variables used by the handlers. Keys left empty in the configuration
+// keep their built-in values.
+func applyConfig(cfg config.Config) {
+	BotToken = cfg.Bots.Translator.Token
+	if cfg.LLM.BaseURL != "" {
+		NvidiaBaseURL = cfg.LLM.BaseURL
+	}
+	setIfNotEmpty(&OpenAIKey, cfg.LLM.APIKey)
+	setIfNotEmpty(&AssemblyAIKey, cfg.LLM.AssemblyAIKey)
+	setIfNotEmpty(&APINinjas, cfg.LLM.APINinjasKey)
+	setIfNotEmpty(&ElevenLabs, cfg.LLM.ElevenLabsKey)
+}
+
+func setIfNotEmpty(dst *string, v string) {
+	if v != "" {
+		*dst = v
+	}
+}
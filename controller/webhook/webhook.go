@@ -0,0 +1,196 @@
+// Package webhook runs the shared HTTP server and lets each bot receive its
+// updates either by long polling or by a Telegram webhook, depending on config.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+)
+
+// SecretHeader is the header Telegram fills with the secret_token given to setWebhook.
+const SecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// updateBuffer is how many decoded updates may wait for a bot before the
+// webhook handler blocks Telegram's request.
+const updateBuffer = 100
+
+var (
+	mux        = http.NewServeMux()
+	httpClient = &http.Client{Timeout: 15 * time.Second}
+	apiBaseURL = "https://api.telegram.org"
+
+	serverMu sync.Mutex
+	server   *http.Server
+)
+
+// ListenAndServe serves the health check and every registered webhook on addr.
+// It returns nil once Shutdown has been called.
+func ListenAndServe(addr string) error {
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "Bot is running!")
+	})
+	serverMu.Lock()
+	server = &http.Server{Addr: addr, Handler: mux}
+	srv := server
+	serverMu.Unlock()
+
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops the shared server from accepting requests and waits for the
+// webhook deliveries already in progress. It is a no-op if the server was
+// never started.
+func Shutdown(ctx context.Context) error {
+	serverMu.Lock()
+	srv := server
+	serverMu.Unlock()
+	if srv == nil {
+		return nil
+	}
+	return srv.Shutdown(ctx)
+}
+
+// Next returns the next update, or false once updates is closed or ctx is
+// cancelled with no update left queued. Queued updates were acknowledged to
+// Telegram, which will not send them again, so they are still handed out
+// after ctx is cancelled; Shutdown runs first so the queue only shrinks. Bots
+// that handle updates one at a time loop on it.
+func Next[T any](ctx context.Context, updates <-chan T) (T, bool) {
+	select {
+	case <-ctx.Done():
+	case update, ok := <-updates:
+		return update, ok
+	}
+	select {
+	case update, ok := <-updates:
+		return update, ok
+	default:
+		var zero T
+		return zero, false
+	}
+}
+
+// Serve runs handle in its own goroutine for every update until updates is
+// closed, or ctx is cancelled and the queued updates are handled, then waits
+// for the handlers still running.
+func Serve[T any](ctx context.Context, updates <-chan T, handle func(T)) {
+	var inFlight sync.WaitGroup
+	for {
+		update, ok := Next(ctx, updates)
+		if !ok {
+			break
+		}
+		inFlight.Add(1)
+		go func() {
+			defer inFlight.Done()
+			handle(update)
+		}()
+	}
+	inFlight.Wait()
+}
+
+// Updates returns the channel a bot should read updates from. In polling mode
+// any webhook left over from a previous deploy is removed and poll is used. In
+// webhook mode a handler is mounted on the shared server under a path derived
+// from the bot token, and Telegram is told to deliver there with the
+// configured secret.
+func Updates[T any](cfg config.Config, name, token string, poll func() (<-chan T, error)) (<-chan T, error) {
+	if !cfg.Webhook.Enabled() {
+		if err := callAPI(token, "deleteWebhook", url.Values{}); err != nil {
+			log.Printf("%s bot: failed to remove webhook: %v", name, err)
+		}
+		return poll()
+	}
+
+	path := Path(name, token, cfg.Webhook.Secret)
+	updates := make(chan T, updateBuffer)
+	mux.Handle(path, Handler(cfg.Webhook.Secret, updates))
+
+	params := url.Values{}
+	params.Set("url", strings.TrimRight(cfg.Webhook.PublicURL, "/")+path)
+	params.Set("secret_token", cfg.Webhook.Secret)
+	if err := callAPI(token, "setWebhook", params); err != nil {
+		return nil, fmt.Errorf("registering %s webhook: %w", name, err)
+	}
+	log.Printf("%s bot: receiving updates by webhook", name)
+	return updates, nil
+}
+
+// Path is the URL path a bot's webhook is served on. It includes a hash of the
+// token and secret so the endpoint cannot be guessed from the bot name alone.
+func Path(name, token, secret string) string {
+	sum := sha256.Sum256([]byte(secret + ":" + token))
+	return "/webhook/" + name + "/" + hex.EncodeToString(sum[:16])
+}
+
+// Handler decodes Telegram updates posted to it into out. Requests without the
+// matching secret header are rejected before the body is read.
+func Handler[T any](secret string, out chan<- T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		got := r.Header.Get(SecretHeader)
+		if subtle.ConstantTimeCompare([]byte(got), []byte(secret)) != 1 {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		var update T
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			http.Error(w, "bad update", http.StatusBadRequest)
+			return
+		}
+		select {
+		case out <- update:
+			w.WriteHeader(http.StatusOK)
+		case <-r.Context().Done():
+			// Telegram retries anything that was not acknowledged.
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	})
+}
+
+// callAPI makes a Bot API request directly so the same code serves both
+// telegram-bot-api versions used in this repo.
+func callAPI(token, method string, params url.Values) error {
+	endpoint := apiBaseURL + "/bot" + token + "/" + method
+	resp, err := httpClient.Post(endpoint, "application/x-www-form-urlencoded", bytes.NewBufferString(params.Encode()))
+	if err != nil {
+		// The error text contains the URL, and with it the token.
+		return fmt.Errorf("%s request failed", method)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("%s: unexpected response (status %d)", method, resp.StatusCode)
+	}
+	if !result.OK {
+		return fmt.Errorf("%s: %s", method, result.Description)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+)
+
+type testUpdate struct {
+	UpdateID int `json:"update_id"`
+}
+
+func TestHandlerChecksSecret(t *testing.T) {
+	out := make(chan testUpdate, 1)
+	h := Handler("s3cret", out)
+
+	cases := []struct {
+		name   string
+		method string
+		secret string
+		body   string
+		want   int
+	}{
+		{"missing secret", http.MethodPost, "", `{"update_id":1}`, http.StatusForbidden},
+		{"wrong secret", http.MethodPost, "nope", `{"update_id":1}`, http.StatusForbidden},
+		{"get", http.MethodGet, "s3cret", "", http.StatusMethodNotAllowed},
+		{"bad body", http.MethodPost, "s3cret", "{", http.StatusBadRequest},
+		{"ok", http.MethodPost, "s3cret", `{"update_id":7}`, http.StatusOK},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, "/hook", strings.NewReader(tc.body))
+		if tc.secret != "" {
+			req.Header.Set(SecretHeader, tc.secret)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: status %d, want %d", tc.name, rec.Code, tc.want)
+		}
+	}
+
+	select {
+	case u := <-out:
+		if u.UpdateID != 7 {
+			t.Errorf("update id = %d", u.UpdateID)
+		}
+	default:
+		t.Fatal("accepted update was not delivered")
+	}
+	if len(out) != 0 {
+		t.Error("rejected requests must not produce updates")
+	}
+}
+
+func TestPathIsStableAndSecret(t *testing.T) {
+	a := Path("word", "token", "secret")
+	if a != Path("word", "token", "secret") {
+		t.Fatal("path must be deterministic")
+	}
+	if a == Path("word", "other", "secret") || a == Path("word", "token", "other") {
+		t.Error("path must depend on token and secret")
+	}
+	if strings.Contains(a, "token") || !strings.HasPrefix(a, "/webhook/word/") {
+		t.Errorf("unexpected path %q", a)
+	}
+}
+
+func TestUpdatesSelectsMode(t *testing.T) {
+	var calls []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		calls = append(calls, r.URL.Path+"?"+r.Form.Encode())
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer api.Close()
+	apiBaseURL = api.URL
+
+	polled := make(chan testUpdate)
+	poll := func() (<-chan testUpdate, error) { return polled, nil }
+
+	cfg := config.Default()
+	ch, err := Updates(cfg, "poller", "T1", poll)
+	if err != nil || ch != (<-chan testUpdate)(polled) {
+		t.Fatalf("polling mode should use poll: %v", err)
+	}
+	if len(calls) != 1 || !strings.HasPrefix(calls[0], "/botT1/deleteWebhook") {
+		t.Fatalf("calls = %v", calls)
+	}
+
+	cfg.Webhook = config.Webhook{Mode: config.ModeWebhook, PublicURL: "https://example.com/", Secret: "abc"}
+	ch, err = Updates(cfg, "hooked", "T2", poll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch == (<-chan testUpdate)(polled) {
+		t.Fatal("webhook mode must not poll")
+	}
+	last := calls[len(calls)-1]
+	if !strings.HasPrefix(last, "/botT2/setWebhook") || !strings.Contains(last, "secret_token=abc") {
+		t.Fatalf("setWebhook call = %q", last)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, Path("hooked", "T2", "abc"), strings.NewReader(`{"update_id":3}`))
+	req.Header.Set(SecretHeader, "abc")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d", rec.Code)
+	}
+	if u := <-ch; u.UpdateID != 3 {
+		t.Errorf("update id = %d", u.UpdateID)
+	}
+}
+
+func TestServeDrainsHandlersOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan testUpdate, 3)
+	updates <- testUpdate{1}
+	updates <- testUpdate{2}
+
+	var started, finished atomic.Int32
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		Serve(ctx, updates, func(u testUpdate) {
+			started.Add(1)
+			<-release
+			finished.Add(1)
+		})
+		close(done)
+	}()
+
+	for started.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	updates <- testUpdate{3}
+
+	select {
+	case <-done:
+		t.Fatal("Serve returned before its handlers finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	<-done
+	// The update queued at cancellation was acknowledged and is handled too.
+	if finished.Load() != 3 {
+		t.Errorf("%d handlers finished, want 3", finished.Load())
+	}
+	if len(updates) != 0 {
+		t.Error("an update queued at cancellation was dropped")
+	}
+}
+
+func TestHandlerGivesUpWhenRequestEnds(t *testing.T) {
+	h := Handler("s3cret", make(chan testUpdate))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(`{"update_id":1}`)).WithContext(ctx)
+	req.Header.Set(SecretHeader, "s3cret")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestShutdownWithoutServer(t *testing.T) {
+	if err := Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() = %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package wordgridbot
+
+import (
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// statesCollection holds one saved WordGridStateDoc per chat.
+const statesCollection = "WordGridStates"
+
+// Game is Word Grid for the game engine. Start begins a normal grid; the easy
+// grid is started with StartWordGridEasyGame.
+var Game game.Game = wordGrid{}
+
+type wordGrid struct{}
+
+func (wordGrid) Info() game.Info {
+	return game.Info{
+		Name:        "wordgrid",
+		Title:       "Word Grid",
+		Description: "Start a Word Grid game",
+		States:      statesCollection,
+	}
+}
+
+func (wordGrid) Scoreboard() string {
+	return "WordGridPoints"
+}
+
+func (wordGrid) Start(env game.Env, chatID int64, p game.Player) {
+	StartWordGridGame(env.Bot, chatID, env.Client)
+}
+
+func (wordGrid) Active(chatID int64) bool {
+	return IsWordGridActive(chatID)
+}
+
+func (wordGrid) Guess(env game.Env, msg *tgbotapi.Message) *game.Result {
+	return HandleGuess(env.Bot, msg, env.Client, msg.Chat.ID, msg.Text)
+}
+
+func (wordGrid) Hint(game.Env, *tgbotapi.Message) bool {
+	return false
+}
+
+func (wordGrid) Cancel(env game.Env, chatID int64) bool {
+	return CancelWordGrid(chatID)
+}
+
+func (wordGrid) Snapshot(chatID int64) (any, bool) {
+	wordGridMutex.RLock()
+	state, ok := wordGridStates[chatID]
+	wordGridMutex.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return state.snapshot(chatID), true
+}
+
+func (wordGrid) Restore(load func(target any) error) (int, error) {
+	return restoreStates(load)
+}
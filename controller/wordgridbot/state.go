@@ -2,11 +2,9 @@ package wordgridbot
 
 import (
 	"fmt"
-	"log"
 	"sync"
 
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type WordPosition struct {
@@ -50,9 +48,11 @@ var (
 	wordGridMutex  sync.RWMutex
 )
 
-// saveWordGridStateAsync asynchronously saves the Word Grid state to MongoDB
-func saveWordGridStateAsync(chatID int64, state *WordGridState) {
+// snapshot returns state in the form it is saved in. The caller must not hold
+// the state's lock.
+func (state *WordGridState) snapshot(chatID int64) WordGridStateDoc {
 	state.RLock()
+	defer state.RUnlock()
 
 	userScoresStr := make(map[string]int)
 	for k, v := range state.UserScores {
@@ -64,7 +64,7 @@ func saveWordGridStateAsync(chatID int64, state *WordGridState) {
 		userNamesStr[fmt.Sprintf("%d", k)] = v
 	}
 
-	doc := WordGridStateDoc{
+	return WordGridStateDoc{
 		ChatID:        chatID,
 		Active:        state.Active,
 		Grid:          state.Grid,
@@ -76,23 +76,24 @@ func saveWordGridStateAsync(chatID int64, state *WordGridState) {
 		MessageID:     state.MessageID,
 		Mode:          state.Mode,
 	}
-	state.RUnlock()
+}
 
-	go func() {
+// saveWordGridStateAsync saves the Word Grid state to MongoDB in the background
+func saveWordGridStateAsync(chatID int64, state *WordGridState) {
+	doc := state.snapshot(chatID)
+	repository.SaveAsync(func() {
 		client := repository.DbManager()
 		if client != nil {
-			repository.SaveGameState(client, "WordGridStates", chatID, doc)
+			repository.SaveGameState(client, statesCollection, chatID, doc)
 		}
-	}()
+	})
 }
 
-// LoadSavedStates loads the persisted Word Grid states from MongoDB into the memory map
-func LoadSavedStates(client *mongo.Client) {
+// restoreStates replaces the in-memory states with the saved ones.
+func restoreStates(load func(target any) error) (int, error) {
 	var results []WordGridStateDoc
-	err := repository.LoadAllGameStates(client, "WordGridStates", &results)
-	if err != nil {
-		log.Printf("Failed to load saved Word Grid states: %v", err)
-		return
+	if err := load(&results); err != nil {
+		return 0, err
 	}
 
 	wordGridMutex.Lock()
@@ -125,5 +126,5 @@ func LoadSavedStates(client *mongo.Client) {
 
 		wordGridStates[doc.ChatID] = ws
 	}
-	log.Printf("Loaded %d Word Grid states", len(results))
+	return len(results), nil
 }
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
@@ -288,20 +289,22 @@ func IsWordGridActive(chatID int64) bool {
 	return false
 }
 
-func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client, chatID int64, text string) {
+// HandleGuess checks a guess against the grid's words. It returns everyone's
+// score when the guess found the last word.
+func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client, chatID int64, text string) *game.Result {
 	wordGridMutex.RLock()
 	state, exists := wordGridStates[chatID]
 	wordGridMutex.RUnlock()
 
 	if !exists {
-		return
+		return nil
 	}
 
 	state.Lock()
 	defer state.Unlock()
 
 	if !state.Active {
-		return
+		return nil
 	}
 
 	guess := strings.ToUpper(strings.TrimSpace(text))
@@ -315,12 +318,12 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 	}
 
 	if !isWordInList {
-		return
+		return nil
 	}
 
 	if state.FoundWords[guess] {
 		// Word already found
-		return
+		return nil
 	}
 
 	// Word found!
@@ -351,7 +354,7 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 	// ⚡ Add Progression Integration
 	go func(uID int64, username string) {
 		if client != nil {
-			service.AwardGameResult(client, uID, username, true) // Treating finding a word as a win/participation in WordGrid
+			service.AwardGameResult(repository.NewMongoStore(client), uID, username, true) // Treating finding a word as a win/participation in WordGrid
 		}
 	}(int64(message.From.ID), message.From.FirstName)
 
@@ -417,32 +420,35 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 
 		saveWordGridStateAsync(chat, s)
 	}(chatID, state.MessageID, imgBytes, caption, state.Active, state, allFound, newMsgText, newMsgMarkup)
+	if !allFound {
+		return nil
+	}
+	res := &game.Result{}
+	for id, score := range state.UserScores {
+		res.Scores = append(res.Scores, game.Score{Player: game.Player{ID: int(id), Name: state.UserNames[id]}, Points: score})
+	}
+	return res
 }
 
-func HandleCancelWordGrid(bot *tgbotapi.BotAPI, chatID int64) {
+// CancelWordGrid ends the Word Grid game in the chat. It returns false if no
+// game was running.
+func CancelWordGrid(chatID int64) bool {
 	wordGridMutex.RLock()
 	state, exists := wordGridStates[chatID]
 	wordGridMutex.RUnlock()
 
 	if !exists {
-		msg := tgbotapi.NewMessage(chatID, "No active Word Grid game to cancel.")
-		bot.Send(msg)
-		return
+		return false
 	}
 
 	state.Lock()
 	if !state.Active {
 		state.Unlock()
-		msg := tgbotapi.NewMessage(chatID, "No active Word Grid game to cancel.")
-		bot.Send(msg)
-		return
+		return false
 	}
-
 	state.Active = false
 	state.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "🛑 Word Grid game has been cancelled.")
-	bot.Send(msg)
-
 	saveWordGridStateAsync(chatID, state)
+	return true
 }
@@ -0,0 +1,191 @@
+package wordlebot
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/wordlebot/image_generator"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/ledger"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// boardWords picks the words of n boards, all different unless the list is
+// too short to allow it.
+func boardWords(length, n int) []string {
+	words := make([]string, 0, n)
+	for tries := 0; len(words) < n; tries++ {
+		w := getRandomWordleWord(length)
+		if tries < 100 && slices.Contains(words, w) {
+			continue
+		}
+		words = append(words, w)
+	}
+	return words
+}
+
+// boardGuesses returns the guesses played on each board: all of them, up to
+// the one that solved it.
+func boardGuesses(ws *WordleState) [][]string {
+	guesses := make([][]string, len(ws.Words))
+	for i := range ws.Words {
+		guesses[i] = ws.Guesses
+		if at := ws.SolvedAt[i]; at > 0 {
+			guesses[i] = ws.Guesses[:at]
+		}
+	}
+	return guesses
+}
+
+// buildBoards renders the boards as text, two side by side, each guess once
+// per row. A solved board's rows after the solving guess are left blank.
+func buildBoards(ws *WordleState, colorConfig string) string {
+	blank := strings.Repeat("➖", len(ws.Word))
+	var sb strings.Builder
+	for first := 0; first < len(ws.Words); first += 2 {
+		if first > 0 {
+			sb.WriteString("\n")
+		}
+		last := min(first+2, len(ws.Words))
+		for row, guess := range ws.Guesses {
+			for b := first; b < last; b++ {
+				if b > first {
+					sb.WriteString("  ")
+				}
+				if at := ws.SolvedAt[b]; at > 0 && row >= at {
+					sb.WriteString(blank)
+				} else {
+					sb.WriteString(strings.ReplaceAll(validateWordleGuess(guess, ws.Words[b], colorConfig), " ", ""))
+				}
+			}
+			sb.WriteString("  ")
+			sb.WriteString(strings.ToUpper(guess))
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// boardsLeft returns how many boards are still unsolved.
+func boardsLeft(ws *WordleState) int {
+	left := 0
+	for _, at := range ws.SolvedAt {
+		if at == 0 {
+			left++
+		}
+	}
+	return left
+}
+
+// handleBoardsGuess plays a checked guess on every board of a multi-board
+// game. Each board solved pays its solver like a Wordle win; the round ends
+// when all are solved or the attempts run out.
+func handleBoardsGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, store repository.Store, chatID int64, ws *WordleState, guess string, settings *ChatSettings) *game.Result {
+	solved := -1
+	for i, w := range ws.Words {
+		if ws.SolvedAt[i] == 0 && guess == w {
+			ws.SolvedBy[i], ws.SolvedAt[i], solved = message.From.ID, ws.Attempts, i
+		}
+	}
+	left := boardsLeft(ws)
+	over := left == 0 || ws.Attempts >= ws.MaxAttempts
+	if over {
+		ws.Active = false
+	}
+
+	isImage := settings.WordleViewType == "image"
+	var board string
+	var imgData []byte
+	if isImage {
+		var err error
+		imgData, err = image_generator.GenerateBoardsImage(boardGuesses(ws), ws.Words, settings.WordleColor)
+		if err != nil {
+			log.Printf("Failed to generate wordle image: %v", err)
+			isImage = false
+		}
+	}
+	if !isImage {
+		board = buildBoards(ws, settings.WordleColor)
+	}
+
+	var lines []string
+	if solved >= 0 {
+		points := max(25-ws.Attempts+1, 1)
+		lines = append(lines, fmt.Sprintf("%s  %s   [+%d💎]\n✅ [%s](tg://user?id=%d) solved board %d of %d!",
+			allGreen(len(guess)), strings.ToUpper(guess), points, message.From.FirstName, message.From.ID, solved+1, len(ws.Words)))
+
+		go func(userID int, name string, attempts int) {
+			// Pay out before the leaderboard doc so a first-time winner's
+			// opening balance does not count this board twice.
+			if _, err := ledger.Credit(store, ledger.Key("wordle", chatID, message.MessageID), userID, name, chatID, points, "Wordle board"); err != nil {
+				log.Printf("Failed to credit Wordle board to %d: %v", userID, err)
+			}
+			store.InsertWordleDoc(userID, name, chatID, "WordleEn", attempts)
+		}(message.From.ID, message.From.FirstName, ws.Attempts)
+	}
+	switch {
+	case left == 0:
+		lines = append(lines, fmt.Sprintf("🎉 All %d boards solved in %d attempts!", len(ws.Words), ws.Attempts))
+	case over:
+		var missed []string
+		for i, w := range ws.Words {
+			if ws.SolvedAt[i] == 0 {
+				missed = append(missed, strings.ToUpper(w))
+			}
+		}
+		lines = append(lines, fmt.Sprintf("❌ Out of attempts! %d of %d boards solved. Missed: %s.", len(ws.Words)-left, len(ws.Words), strings.Join(missed, ", ")))
+	case solved >= 0:
+		lines = append(lines, fmt.Sprintf("🧩 %d boards to go.", left))
+	}
+	text := strings.Join(lines, "\n")
+
+	var buttons tgbotapi.InlineKeyboardMarkup
+	if over {
+		buttons = tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("Start new Wordle! 🟩🟨", "wordle_start"),
+				tgbotapi.NewInlineKeyboardButtonData("Start Croc Game 🐊", "explain"),
+			),
+		)
+		go func(uID int64, username string, won bool) {
+			if store != nil {
+				service.AwardGameResult(store, uID, username, won)
+			}
+		}(int64(message.From.ID), message.From.FirstName, left == 0)
+	}
+
+	switch {
+	case isImage:
+		view.ReplyToMessageWithPhotoAndButtons(bot, message.MessageID, chatID, imgData, text, buttons)
+	case text == "":
+		view.ReplyToMessage(bot, message.MessageID, chatID, board)
+	default:
+		view.ReplyToMessageWithButtons(bot, message.MessageID, chatID, board+"\n"+text, buttons)
+	}
+
+	if over {
+		return boardsResult(ws)
+	}
+	return nil
+}
+
+// boardsResult scores a finished multi-board round: a point for every board a
+// player solved.
+func boardsResult(ws *WordleState) *game.Result {
+	res := &game.Result{}
+	for id, name := range ws.Players {
+		points := 0
+		for _, by := range ws.SolvedBy {
+			if by == id {
+				points++
+			}
+		}
+		res.Scores = append(res.Scores, game.Score{Player: game.Player{ID: id, Name: name}, Points: points})
+	}
+	return res
+}
@@ -0,0 +1,57 @@
+package wordlebot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildBoards(t *testing.T) {
+	ws := &WordleState{
+		Word:     "cargo",
+		Words:    []string{"cargo", "crane"},
+		Guesses:  []string{"cargo", "crate"},
+		SolvedBy: []int{7, 0},
+		SolvedAt: []int{1, 0},
+	}
+	want := "🟩🟩🟩🟩🟩  🟩🟨🟨⬛⬛  CARGO\n" +
+		"➖➖➖➖➖  🟩🟩🟩⬛🟩  CRATE\n"
+	if got := buildBoards(ws, "dark"); got != want {
+		t.Errorf("buildBoards =\n%s\nwant\n%s", got, want)
+	}
+
+	ws.Words = []string{"cargo", "crane", "stone", "pride"}
+	ws.SolvedBy, ws.SolvedAt = make([]int, 4), make([]int, 4)
+	if got := strings.Count(buildBoards(ws, "dark"), "\n"); got != 5 {
+		t.Errorf("four boards span %d lines, want 5", got)
+	}
+}
+
+func TestBoardsResult(t *testing.T) {
+	ws := &WordleState{
+		Players:  map[int]string{7: "Ann", 8: "Bo", 9: "Cy"},
+		SolvedBy: []int{7, 8, 7, 0},
+	}
+	got := map[int]int{}
+	for _, s := range boardsResult(ws).Scores {
+		got[s.Player.ID] = s.Points
+	}
+	if got[7] != 2 || got[8] != 1 || got[9] != 0 || len(got) != 3 {
+		t.Errorf("scores = %v", got)
+	}
+}
+
+func TestNewRoundBoards(t *testing.T) {
+	ws := &WordleState{}
+	ws.newRound(ChatSettings{WordLength: 5, MaxAttempts: 6, Boards: 4, HardMode: true})
+	if len(ws.Words) != 4 || ws.Word != ws.Words[0] || len(ws.SolvedAt) != 4 {
+		t.Fatalf("quordle round = %+v", ws)
+	}
+	if ws.MaxAttempts != 9 || ws.HardMode {
+		t.Errorf("quordle allows %d attempts, hard mode %v; want 9, false", ws.MaxAttempts, ws.HardMode)
+	}
+
+	ws.newRound(ChatSettings{WordLength: 5, MaxAttempts: 6, Boards: 1})
+	if ws.Words != nil || ws.MaxAttempts != 6 {
+		t.Errorf("single board round = %+v", ws)
+	}
+}
@@ -0,0 +1,409 @@
+package wordlebot
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/daily"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Collections of the Daily Wordle: each player's puzzle of the day, their
+// streaks and each group's board of the day. The last two hold user data, so
+// the repository owns their names.
+const (
+	dailyStatesCollection  = "DailyWordleStates"
+	dailyStreaksCollection = repository.DailyWordleStreaksCollection
+	dailyBoardsCollection  = repository.DailyWordleBoardsCollection
+)
+
+// Daily is the Daily Wordle for the game engine. Everyone plays the day's word
+// in private with the bot; /dailywordle in a group shares the player's card there
+// and shows the group's board.
+var Daily game.Game = dailyWordle{}
+
+type dailyWordle struct{}
+
+func (dailyWordle) Info() game.Info {
+	return game.Info{
+		Name:        "dailywordle",
+		Title:       "Daily Wordle",
+		Description: "Play today's Wordle, the same word for everyone",
+		States:      dailyStatesCollection,
+	}
+}
+
+// Scoreboard is empty: the Daily Wordle keeps streaks, not points.
+func (dailyWordle) Scoreboard() string {
+	return ""
+}
+
+func (dailyWordle) Start(env game.Env, chatID int64, p game.Player) {
+	if chatID != int64(p.ID) {
+		ShareDaily(env.Bot, chatID, p.ID, p.Name, env.Store)
+		return
+	}
+	StartDaily(env.Bot, chatID, env.Store)
+}
+
+func (dailyWordle) Active(chatID int64) bool {
+	return DailyActive(chatID)
+}
+
+func (dailyWordle) Guess(env game.Env, msg *tgbotapi.Message) *game.Result {
+	handleDailyGuess(env.Bot, msg, env.Store)
+	return nil
+}
+
+func (dailyWordle) Hint(game.Env, *tgbotapi.Message) bool {
+	return false
+}
+
+// Cancel gives up today's puzzle, which ends the player's streak.
+func (dailyWordle) Cancel(env game.Env, chatID int64) bool {
+	play, ok := lookupDailyPlay(chatID)
+	if !ok {
+		return false
+	}
+	play.Lock()
+	if play.Day != daily.Today() || play.Done {
+		play.Unlock()
+		return false
+	}
+	play.Done = true
+	doc := play.snapshot(chatID)
+	play.Unlock()
+	game.Save(env.Store, dailyStatesCollection, chatID, doc)
+	recordDaily(env.Store, int(chatID), doc.Day, false)
+	return true
+}
+
+func (dailyWordle) Snapshot(chatID int64) (any, bool) {
+	play, ok := lookupDailyPlay(chatID)
+	if !ok {
+		return nil, false
+	}
+	play.Lock()
+	defer play.Unlock()
+	return play.snapshot(chatID), true
+}
+
+func (dailyWordle) Restore(load func(target any) error) (int, error) {
+	var docs []DailyPlayDoc
+	if err := load(&docs); err != nil {
+		return 0, err
+	}
+	dailyMutex.Lock()
+	defer dailyMutex.Unlock()
+	for _, doc := range docs {
+		dailyPlays[doc.ChatID] = &DailyPlay{Day: doc.Day, Guesses: doc.Guesses, Done: doc.Done, Won: doc.Won}
+	}
+	return len(docs), nil
+}
+
+// DailyPlay is a player's go at a day's puzzle, kept by their private chat
+// with the bot.
+type DailyPlay struct {
+	sync.Mutex
+	Day     int
+	Guesses []string
+	Done    bool
+	Won     bool
+}
+
+// DailyPlayDoc is the saved form of a DailyPlay.
+type DailyPlayDoc struct {
+	ChatID  int64    `bson:"_id"`
+	Day     int      `bson:"day"`
+	Guesses []string `bson:"guesses"`
+	Done    bool     `bson:"done"`
+	Won     bool     `bson:"won"`
+}
+
+// snapshot returns the play in the form it is saved in. The caller holds the
+// play's lock.
+func (play *DailyPlay) snapshot(chatID int64) DailyPlayDoc {
+	return DailyPlayDoc{ChatID: chatID, Day: play.Day, Guesses: play.Guesses, Done: play.Done, Won: play.Won}
+}
+
+var (
+	dailyPlays   = make(map[int64]*DailyPlay)
+	dailyStreaks = make(map[int]*daily.Streak)
+	dailyBoards  = make(map[int64]*daily.Board)
+	dailyMutex   sync.Mutex
+)
+
+func getDailyPlay(chatID int64) *DailyPlay {
+	dailyMutex.Lock()
+	defer dailyMutex.Unlock()
+	play, ok := dailyPlays[chatID]
+	if !ok {
+		play = &DailyPlay{}
+		dailyPlays[chatID] = play
+	}
+	return play
+}
+
+func lookupDailyPlay(chatID int64) (*DailyPlay, bool) {
+	dailyMutex.Lock()
+	defer dailyMutex.Unlock()
+	play, ok := dailyPlays[chatID]
+	return play, ok
+}
+
+// DailyActive reports whether the private chat has today's puzzle under way.
+func DailyActive(chatID int64) bool {
+	play, ok := lookupDailyPlay(chatID)
+	if !ok {
+		return false
+	}
+	play.Lock()
+	defer play.Unlock()
+	return play.Day == daily.Today() && !play.Done
+}
+
+// dailyWord returns the word of the day's puzzle.
+func dailyWord(day int) string {
+	wordsMutex.RLock()
+	defer wordsMutex.RUnlock()
+	if w := daily.Word(wordleTargets(DefaultWordLength), day); w != "" {
+		return w
+	}
+	return "apple" // fallback
+}
+
+// dailyStreak returns the player's streak, loading it on first use. The
+// caller holds dailyMutex.
+func dailyStreak(store repository.Store, userID int) *daily.Streak {
+	s, ok := dailyStreaks[userID]
+	if ok {
+		return s
+	}
+	s = &daily.Streak{}
+	if store != nil {
+		if err := store.LoadChatSettings(dailyStreaksCollection, int64(userID), s); err != nil {
+			log.Printf("Failed to load the Daily Wordle streak of %d: %v", userID, err)
+		}
+	}
+	dailyStreaks[userID] = s
+	return s
+}
+
+// ForgetDailyPlayer drops the player's cached streak and board entries after
+// their data was deleted, so they are not saved back.
+func ForgetDailyPlayer(userID int) {
+	dailyMutex.Lock()
+	defer dailyMutex.Unlock()
+	delete(dailyStreaks, userID)
+	for _, b := range dailyBoards {
+		b.Entries = slices.DeleteFunc(b.Entries, func(e daily.Entry) bool { return e.ID == userID })
+	}
+}
+
+// streakOf returns the player's streak.
+func streakOf(store repository.Store, userID int) daily.Streak {
+	dailyMutex.Lock()
+	defer dailyMutex.Unlock()
+	return *dailyStreak(store, userID)
+}
+
+// recordDaily counts the player's finished puzzle towards their streak and
+// returns it.
+func recordDaily(store repository.Store, userID, day int, won bool) daily.Streak {
+	dailyMutex.Lock()
+	s := dailyStreak(store, userID)
+	recorded := s.Record(day, won)
+	streak := *s
+	dailyMutex.Unlock()
+
+	if recorded && store != nil {
+		repository.SaveAsync(func() {
+			if err := store.SaveChatSettings(dailyStreaksCollection, int64(userID), bson.M{
+				"current":  streak.Current,
+				"max":      streak.Max,
+				"last_day": streak.LastDay,
+				"played":   streak.Played,
+				"won":      streak.Won,
+			}); err != nil {
+				log.Printf("Failed to save the Daily Wordle streak of %d: %v", userID, err)
+			}
+		})
+	}
+	return streak
+}
+
+// StartDaily starts or resumes today's puzzle in a private chat.
+func StartDaily(bot *tgbotapi.BotAPI, chatID int64, store repository.Store) {
+	if IsWordleActive(chatID) {
+		view.SendMessage(bot, chatID, "Finish your Wordle game first, or end it with /cancelwordle.")
+		return
+	}
+	today := daily.Today()
+	play := getDailyPlay(chatID)
+	play.Lock()
+	defer play.Unlock()
+
+	color := GetChatSettings(chatID, store).WordleColor
+	if play.Day == today {
+		if play.Done {
+			view.SendMessage(bot, chatID, fmt.Sprintf("You've played today's Daily Wordle already. A new word comes at midnight UTC.\n\n%s\n\n%s",
+				dailyCard(play, color, streakOf(store, int(chatID))), dailyShareHint))
+			return
+		}
+		board := buildWordleBoard(&WordleState{Word: dailyWord(today), Guesses: play.Guesses}, color)
+		view.SendMessage(bot, chatID, fmt.Sprintf("📅 *Daily Wordle #%d* is under way: %d/%d guesses.\n\n%s", today, len(play.Guesses), daily.MaxGuesses, board))
+		return
+	}
+
+	play.Day, play.Guesses, play.Done, play.Won = today, nil, false, false
+	game.Save(store, dailyStatesCollection, chatID, play.snapshot(chatID))
+	view.SendMessage(bot, chatID, fmt.Sprintf("📅 *Daily Wordle #%d*\n\nEveryone gets the same word today.\n🔡 — The word consists of 5 letters.\n🎯 — You have %d attempts.\n\n"+
+		"💡 Hints:\n🟩 Correct letter in the right spot\n🟨 Correct letter but in the wrong spot\n%s Letter is not in the word\n\nSend a 5-letter word to guess.",
+		today, daily.MaxGuesses, missColor(color)))
+}
+
+const dailyShareHint = "Send /dailywordle in a group to share your card there and see who solved it in the fewest guesses."
+
+func handleDailyGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, store repository.Store) {
+	chatID := message.Chat.ID
+	play := getDailyPlay(chatID)
+	play.Lock()
+	defer play.Unlock()
+	if play.Day != daily.Today() || play.Done {
+		return
+	}
+	guess, ok := checkGuess(bot, chatID, message.Text, DefaultWordLength, play.Guesses)
+	if !ok {
+		return
+	}
+	play.Guesses = append(play.Guesses, guess)
+	word := dailyWord(play.Day)
+	color := GetChatSettings(chatID, store).WordleColor
+	board := buildWordleBoard(&WordleState{Word: word, Guesses: play.Guesses}, color)
+
+	switch {
+	case guess == word:
+		play.Done, play.Won = true, true
+	case len(play.Guesses) >= daily.MaxGuesses:
+		play.Done = true
+	default:
+		game.Save(store, dailyStatesCollection, chatID, play.snapshot(chatID))
+		view.ReplyToMessage(bot, message.MessageID, chatID, fmt.Sprintf("%s\n%d/%d", board, len(play.Guesses), daily.MaxGuesses))
+		return
+	}
+	game.Save(store, dailyStatesCollection, chatID, play.snapshot(chatID))
+
+	streak := recordDaily(store, message.From.ID, play.Day, play.Won)
+	result := fmt.Sprintf("🎉 Solved in %d/%d!", len(play.Guesses), daily.MaxGuesses)
+	if !play.Won {
+		result = fmt.Sprintf("❌ Out of attempts! The word was %s.", strings.ToUpper(word))
+	}
+	view.ReplyToMessage(bot, message.MessageID, chatID, fmt.Sprintf("%s\n%s\n🔥 Streak: %d (best %d) · Won %d of %d\n\n%s\n\n%s",
+		board, result, streak.Current, streak.Max, streak.Won, streak.Played, dailyCard(play, color, streak), dailyShareHint))
+}
+
+// dailyCard is the spoiler-free card of a finished play: the squares of each
+// guess without its letters. The caller holds the play's lock.
+func dailyCard(play *DailyPlay, colorConfig string, streak daily.Streak) string {
+	score := "X"
+	if play.Won {
+		score = fmt.Sprint(len(play.Guesses))
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Daily Wordle #%d %s/%d", play.Day, score, daily.MaxGuesses)
+	if s := streak.On(play.Day); s > 1 {
+		fmt.Fprintf(&sb, " 🔥%d", s)
+	}
+	word := dailyWord(play.Day)
+	for _, g := range play.Guesses {
+		sb.WriteString("\n")
+		sb.WriteString(strings.ReplaceAll(validateWordleGuess(g, word, colorConfig), " ", ""))
+	}
+	return sb.String()
+}
+
+// ShareDaily posts the player's card of today's puzzle in a group, puts them
+// on the group's board and shows it. A player who has not finished the puzzle
+// is only shown the board and sent off to play.
+func ShareDaily(bot *tgbotapi.BotAPI, chatID int64, userID int, name string, store repository.Store) {
+	today := daily.Today()
+	var card string
+	var entry daily.Entry
+	var finished bool
+	if play, ok := lookupDailyPlay(int64(userID)); ok {
+		play.Lock()
+		entry = daily.Entry{ID: userID, Name: name, Guesses: len(play.Guesses), Won: play.Won}
+		finished = play.Day == today && play.Done
+		if finished {
+			card = dailyCard(play, GetChatSettings(int64(userID), store).WordleColor, streakOf(store, userID))
+		}
+		play.Unlock()
+	}
+
+	dailyMutex.Lock()
+	b, ok := dailyBoards[chatID]
+	if !ok {
+		b = &daily.Board{}
+		if store != nil {
+			if err := store.LoadChatSettings(dailyBoardsCollection, chatID, b); err != nil {
+				log.Printf("Failed to load the Daily Wordle board of chat %d: %v", chatID, err)
+			}
+		}
+		dailyBoards[chatID] = b
+	}
+	added := finished && b.Add(today, entry)
+	if b.Day != today {
+		b.Day, b.Entries = today, nil
+	}
+	board := daily.Board{Day: b.Day, Entries: append([]daily.Entry(nil), b.Entries...)}
+	dailyMutex.Unlock()
+
+	if added && store != nil {
+		repository.SaveAsync(func() {
+			if err := store.SaveChatSettings(dailyBoardsCollection, chatID, bson.M{"day": board.Day, "entries": board.Entries}); err != nil {
+				log.Printf("Failed to save the Daily Wordle board of chat %d: %v", chatID, err)
+			}
+		})
+	}
+
+	var sb strings.Builder
+	if finished {
+		fmt.Fprintf(&sb, "<a href=\"tg://user?id=%d\">%s</a>'s\n%s\n\n", userID, html.EscapeString(name), card)
+	}
+	sb.WriteString(dailyBoardText(board))
+	if finished {
+		view.SendMessagehtml(bot, chatID, sb.String())
+		return
+	}
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonURL("Play in private 📅", "https://t.me/"+bot.Self.UserName),
+	))
+	sb.WriteString("\n\nPlay today's puzzle with me in private, then send /dailywordle here to join the board.")
+	view.SendMessagehtmlWithButtons(bot, chatID, sb.String(), markup)
+}
+
+// dailyBoardText writes the group's board of the day.
+func dailyBoardText(b daily.Board) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "🏆 <b>Daily Wordle #%d in this group</b>", b.Day)
+	ranked := b.Ranked()
+	if len(ranked) == 0 {
+		sb.WriteString("\nNobody has shared a result yet.")
+	}
+	for i, e := range ranked {
+		if e.Won {
+			fmt.Fprintf(&sb, "\n%d. %s — %d/%d", i+1, html.EscapeString(e.Name), e.Guesses, daily.MaxGuesses)
+		} else {
+			fmt.Fprintf(&sb, "\n✖️ %s — X/%d", html.EscapeString(e.Name), daily.MaxGuesses)
+		}
+	}
+	return sb.String()
+}
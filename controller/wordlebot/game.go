@@ -0,0 +1,61 @@
+package wordlebot
+
+import (
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// statesCollection holds one saved WordleStateDoc per chat.
+const statesCollection = "WordleStates"
+
+// Game is Wordle for the game engine.
+var Game game.Game = wordle{}
+
+type wordle struct{}
+
+func (wordle) Info() game.Info {
+	return game.Info{
+		Name:        "wordle",
+		Title:       "Wordle",
+		Description: "Start a Wordle game",
+		States:      statesCollection,
+	}
+}
+
+func (wordle) Scoreboard() string {
+	return "WordleEn"
+}
+
+func (wordle) Start(env game.Env, chatID int64, p game.Player) {
+	HandleWordleCommand(env.Bot, chatID, p.Name, env.Store)
+}
+
+func (wordle) Active(chatID int64) bool {
+	return IsWordleActive(chatID)
+}
+
+func (wordle) Guess(env game.Env, msg *tgbotapi.Message) *game.Result {
+	return HandleGuess(env.Bot, msg, env.Store, msg.Chat.ID, msg.Text)
+}
+
+func (wordle) Hint(game.Env, *tgbotapi.Message) bool {
+	return false
+}
+
+func (wordle) Cancel(env game.Env, chatID int64) bool {
+	return CancelWordle(chatID, env.Store)
+}
+
+func (wordle) Snapshot(chatID int64) (any, bool) {
+	wordleMutex.RLock()
+	ws, ok := wordleStates[chatID]
+	wordleMutex.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return ws.snapshot(chatID), true
+}
+
+func (wordle) Restore(load func(target any) error) (int, error) {
+	return restoreStates(load)
+}
@@ -14,46 +14,63 @@ import (
 	"golang.org/x/image/math/fixed"
 )
 
+// Sizes of the cells and the space between and around them, in pixels.
+const (
+	cellSize = 60
+	margin   = 10
+	padding  = 20
+)
+
 // GenerateWordleImage creates an image from Wordle guesses and their feedback
 func GenerateWordleImage(guesses []string, targetWord string, colorConfig string) ([]byte, error) {
-	// Constants
-	cellSize := 60
-	margin := 10
-	padding := 20
+	return GenerateBoardsImage([][]string{guesses}, []string{targetWord}, colorConfig)
+}
+
+// GenerateBoardsImage draws one board per target word, two to a row. guesses
+// holds each board's guesses; a solved board stops at the guess that solved
+// it. Every board is as tall as the longest.
+func GenerateBoardsImage(guesses [][]string, targets []string, colorConfig string) ([]byte, error) {
+	if len(targets) == 0 {
+		targets = []string{""}
+	}
 	rows := 6
 	cols := 5
+	if len(targets[0]) > 0 {
+		cols = len(targets[0])
+	}
 
 	// If more than 6 guesses, expand rows
-	if len(guesses) > 6 {
-		rows = len(guesses)
-	}
-	if len(guesses) > 0 && len(guesses) < 6 && len(guesses) == 6 {
-		rows = 6
+	for _, g := range guesses {
+		rows = max(rows, len(g))
 	}
 
-	width := cols*cellSize + (cols-1)*margin + 2*padding
-	height := rows*cellSize + (rows-1)*margin + 2*padding
+	perRow := min(len(targets), 2)
+	boardWidth := cols*cellSize + (cols-1)*margin
+	boardHeight := rows*cellSize + (rows-1)*margin
+	boardRows := (len(targets) + perRow - 1) / perRow
+	width := perRow*boardWidth + (perRow-1)*2*padding + 2*padding
+	height := boardRows*boardHeight + (boardRows-1)*2*padding + 2*padding
 
 	img := image.NewRGBA(image.Rect(0, 0, width, height))
 
 	// Colors
-	bgColor := color.RGBA{18, 18, 19, 255} // Dark background
-	emptyCellColor := color.RGBA{58, 58, 60, 255}
-	greenColor := color.RGBA{83, 141, 78, 255}
-	yellowColor := color.RGBA{181, 159, 59, 255}
-	redColor := color.RGBA{220, 53, 69, 255}
-
-	missColor := redColor
+	p := palette{
+		bg:     color.RGBA{18, 18, 19, 255}, // Dark background
+		empty:  color.RGBA{58, 58, 60, 255},
+		green:  color.RGBA{83, 141, 78, 255},
+		yellow: color.RGBA{181, 159, 59, 255},
+		miss:   color.RGBA{220, 53, 69, 255},
+		text:   color.RGBA{255, 255, 255, 255},
+	}
 	if colorConfig == "dark" {
-		missColor = color.RGBA{58, 58, 60, 255} // Dark gray (same as empty cell in classic)
+		p.miss = color.RGBA{58, 58, 60, 255} // Dark gray (same as empty cell in classic)
 	} else if colorConfig == "light" {
-		missColor = color.RGBA{240, 240, 240, 255} // Light gray/white
+		p.miss = color.RGBA{240, 240, 240, 255} // Light gray/white
+		p.missText = color.RGBA{0, 0, 0, 255}   // Black text for light mode miss cells
 	}
 
-	textColor := color.RGBA{255, 255, 255, 255}
-
 	// Fill background
-	draw.Draw(img, img.Bounds(), &image.Uniform{bgColor}, image.Point{}, draw.Src)
+	draw.Draw(img, img.Bounds(), &image.Uniform{p.bg}, image.Point{}, draw.Src)
 
 	// Load font
 	f, err := opentype.Parse(goregular.TTF)
@@ -69,6 +86,34 @@ func GenerateWordleImage(guesses []string, targetWord string, colorConfig string
 		return nil, err
 	}
 
+	for i, target := range targets {
+		var boardGuesses []string
+		if i < len(guesses) {
+			boardGuesses = guesses[i]
+		}
+		left := padding + (i%perRow)*(boardWidth+2*padding)
+		top := padding + (i/perRow)*(boardHeight+2*padding)
+		drawBoard(img, face, p, left, top, rows, cols, boardGuesses, target)
+	}
+
+	var buf bytes.Buffer
+	err = png.Encode(&buf, img)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// palette holds the colors a board is drawn in.
+type palette struct {
+	bg, empty, green, yellow, miss, text color.Color
+	missText                             color.Color // text on a miss, if not text
+}
+
+// drawBoard draws a board of rows guesses at target with its top left corner
+// at left, top.
+func drawBoard(img *image.RGBA, face font.Face, p palette, left, top, rows, cols int, guesses []string, targetWord string) {
 	for r := 0; r < rows; r++ {
 		var guess string
 		if r < len(guesses) {
@@ -83,11 +128,11 @@ func GenerateWordleImage(guesses []string, targetWord string, colorConfig string
 		// First pass: Find exact matches (Green)
 		colors := make([]color.Color, cols)
 		for c := 0; c < cols; c++ {
-			colors[c] = emptyCellColor
+			colors[c] = p.empty
 			if r < len(guesses) && c < len(guess) {
 				char := guess[c]
 				if char == targetUpper[c] {
-					colors[c] = greenColor
+					colors[c] = p.green
 					matched[c] = true
 				}
 			}
@@ -97,7 +142,7 @@ func GenerateWordleImage(guesses []string, targetWord string, colorConfig string
 		for c := 0; c < cols; c++ {
 			if r < len(guesses) && c < len(guess) {
 				char := guess[c]
-				if colors[c] == greenColor {
+				if colors[c] == p.green {
 					continue // Already green
 				}
 
@@ -105,7 +150,7 @@ func GenerateWordleImage(guesses []string, targetWord string, colorConfig string
 				foundYellow := false
 				for i, targetChar := range targetUpper {
 					if char == byte(targetChar) && !matched[i] {
-						colors[c] = yellowColor
+						colors[c] = p.yellow
 						matched[i] = true
 						foundYellow = true
 						break
@@ -113,14 +158,14 @@ func GenerateWordleImage(guesses []string, targetWord string, colorConfig string
 				}
 
 				if !foundYellow {
-					colors[c] = missColor
+					colors[c] = p.miss
 				}
 			}
 		}
 
 		for c := 0; c < cols; c++ {
-			x0 := padding + c*(cellSize+margin)
-			y0 := padding + r*(cellSize+margin)
+			x0 := left + c*(cellSize+margin)
+			y0 := top + r*(cellSize+margin)
 			x1 := x0 + cellSize
 			y1 := y0 + cellSize
 
@@ -137,14 +182,14 @@ func GenerateWordleImage(guesses []string, targetWord string, colorConfig string
 			// Draw border for empty cells
 			if r >= len(guesses) || c >= len(guess) {
 				borderRect := image.Rect(x0+2, y0+2, x1-2, y1-2)
-				draw.Draw(img, borderRect, &image.Uniform{bgColor}, image.Point{}, draw.Src)
+				draw.Draw(img, borderRect, &image.Uniform{p.bg}, image.Point{}, draw.Src)
 			}
 
 			// Draw text
 			if char != 0 {
-				cellTextColor := textColor
-				if colors[c] == missColor && colorConfig == "light" {
-					cellTextColor = color.RGBA{0, 0, 0, 255} // Black text for light mode miss cells
+				var cellTextColor color.Color = p.text
+				if colors[c] == p.miss && p.missText != nil {
+					cellTextColor = p.missText
 				}
 
 				d := &font.Drawer{
@@ -166,12 +211,4 @@ func GenerateWordleImage(guesses []string, targetWord string, colorConfig string
 			}
 		}
 	}
-
-	var buf bytes.Buffer
-	err = png.Encode(&buf, img)
-	if err != nil {
-		return nil, err
-	}
-
-	return buf.Bytes(), nil
 }
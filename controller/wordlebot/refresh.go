@@ -2,14 +2,16 @@ package wordlebot
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/wordlebot/image_generator"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // RefreshActiveGameMessage updates the active wordle game message
-func RefreshActiveGameMessage(bot *tgbotapi.BotAPI, chatID int64, messageID int, client *mongo.Client) {
+func RefreshActiveGameMessage(bot *tgbotapi.BotAPI, chatID int64, messageID int, store repository.Store) {
 	ws := GetOrCreateWordleState(chatID)
 	ws.RLock()
 	defer ws.RUnlock()
@@ -18,7 +20,7 @@ func RefreshActiveGameMessage(bot *tgbotapi.BotAPI, chatID int64, messageID int,
 		return
 	}
 
-	settings := GetChatSettings(chatID, client)
+	settings := GetChatSettings(chatID, store)
 	isImage := settings.WordleViewType == "image"
 
 	buttons := tgbotapi.NewInlineKeyboardMarkup(
@@ -28,7 +30,13 @@ func RefreshActiveGameMessage(bot *tgbotapi.BotAPI, chatID int64, messageID int,
 	)
 
 	if isImage {
-		imageData, err := image_generator.GenerateWordleImage(ws.Guesses, ws.Word, settings.WordleColor)
+		var imageData []byte
+		var err error
+		if len(ws.Words) > 0 {
+			imageData, err = image_generator.GenerateBoardsImage(boardGuesses(ws), ws.Words, settings.WordleColor)
+		} else {
+			imageData, err = image_generator.GenerateWordleImage(ws.Guesses, ws.Word, settings.WordleColor)
+		}
 		if err == nil {
 			err = view.EditMessageMediaWithStyledButtons(bot.Token, chatID, messageID, imageData, "wordle.png", &buttons)
 			if err != nil {
@@ -50,7 +58,10 @@ func RefreshActiveGameMessage(bot *tgbotapi.BotAPI, chatID int64, messageID int,
 	bot.Send(deleteMsg)
 
 	boardStr := buildWordleBoard(ws, settings.WordleColor)
-	msgText := fmt.Sprintf("📝 *WORDLE*\n\n%s\n\nTotal: %d/6", boardStr, len(ws.Guesses))
+	if len(ws.Words) > 0 {
+		boardStr = buildBoards(ws, settings.WordleColor)
+	}
+	msgText := fmt.Sprintf("📝 *%s*\n\n%s\n\nTotal: %d/%d", strings.ToUpper(ModeName(max(len(ws.Words), 1))), boardStr, len(ws.Guesses), ws.MaxAttempts)
 	msg := tgbotapi.NewMessage(chatID, msgText)
 	msg.ReplyMarkup = buttons
 	msg.ParseMode = tgbotapi.ModeMarkdown
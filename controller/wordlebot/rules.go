@@ -0,0 +1,75 @@
+package wordlebot
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mark is how a letter of a guess matched the word.
+type mark int
+
+const (
+	miss    mark = iota // not in the word
+	present             // in the word, elsewhere
+	correct             // in the word, in this spot
+)
+
+// scoreGuess marks each letter of guess against target. A letter that shows up
+// more often in the guess than in the target is only marked as often as the
+// target has it, greens first.
+func scoreGuess(guess, target string) []mark {
+	marks := make([]mark, len(guess))
+	var left [256]int
+	for i := 0; i < len(target); i++ {
+		if i < len(guess) && guess[i] == target[i] {
+			marks[i] = correct
+		} else {
+			left[target[i]]++
+		}
+	}
+	for i := 0; i < len(guess); i++ {
+		if marks[i] != correct && left[guess[i]] > 0 {
+			marks[i] = present
+			left[guess[i]]--
+		}
+	}
+	return marks
+}
+
+// hardModeViolation returns why guess breaks hard mode after the earlier
+// guesses at target, or "" if it does not. Every green has to stay in its
+// spot, and every letter found has to be used again, as many times as it was
+// found in a single guess.
+func hardModeViolation(guess string, guesses []string, target string) string {
+	var need [256]int
+	for _, g := range guesses {
+		var found [256]int
+		for i, m := range scoreGuess(g, target) {
+			if m == correct && (i >= len(guess) || guess[i] != g[i]) {
+				return fmt.Sprintf("letter %d must be %s", i+1, strings.ToUpper(g[i:i+1]))
+			}
+			if m != miss {
+				found[g[i]]++
+			}
+		}
+		for c, n := range found {
+			need[c] = max(need[c], n)
+		}
+	}
+
+	var have [256]int
+	for i := 0; i < len(guess); i++ {
+		have[guess[i]]++
+	}
+	for c, n := range need {
+		if have[c] >= n {
+			continue
+		}
+		letter := strings.ToUpper(string(rune(c)))
+		if n == 1 {
+			return fmt.Sprintf("the word must contain %s", letter)
+		}
+		return fmt.Sprintf("the word must contain %s %d times", letter, n)
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package wordlebot
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestScoreGuess(t *testing.T) {
+	for _, tc := range []struct {
+		guess, target string
+		want          []mark
+	}{
+		{"crane", "crane", []mark{correct, correct, correct, correct, correct}},
+		{"eerie", "there", []mark{present, miss, present, miss, correct}},
+		{"soap", "oops", []mark{present, correct, miss, present}},
+		{"stone", "onset", []mark{present, present, present, present, present}},
+		{"planets", "plaster", []mark{correct, correct, correct, miss, present, present, present}},
+	} {
+		if got := scoreGuess(tc.guess, tc.target); !slices.Equal(got, tc.want) {
+			t.Errorf("scoreGuess(%q, %q) = %v, want %v", tc.guess, tc.target, got, tc.want)
+		}
+	}
+}
+
+func TestValidateWordleGuessLengths(t *testing.T) {
+	if got, want := validateWordleGuess("eerie", "there", "dark"), "🟨 ⬛ 🟨 ⬛ 🟩"; got != want {
+		t.Errorf("five letters = %q, want %q", got, want)
+	}
+	if got, want := validateWordleGuess("soap", "oops", "classic"), "🟨 🟩 🟥 🟨"; got != want {
+		t.Errorf("four letters = %q, want %q", got, want)
+	}
+}
+
+func TestHardModeViolation(t *testing.T) {
+	guesses := []string{"crane"} // against "cargo": C green, R and A yellow
+	for _, tc := range []struct {
+		guess string
+		want  string
+	}{
+		{"cigar", ""},
+		{"bread", "letter 1 must be C"},
+		{"comet", "the word must contain A"},
+		{"coast", "the word must contain R"},
+		{"cobra", ""},
+	} {
+		if got := hardModeViolation(tc.guess, guesses, "cargo"); got != tc.want {
+			t.Errorf("hardModeViolation(%q) = %q, want %q", tc.guess, got, tc.want)
+		}
+	}
+	if got := hardModeViolation("reuse", []string{"eerie"}, "there"); got != "" {
+		t.Errorf("both Es reused: %q", got)
+	}
+	if got := hardModeViolation("rinse", []string{"eerie"}, "there"); got != "the word must contain E 2 times" {
+		t.Errorf("one E after two were found: %q", got)
+	}
+}
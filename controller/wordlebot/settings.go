@@ -1,27 +1,43 @@
 package wordlebot
 
 import (
-	"context"
+	"log"
+	"slices"
 	"sync"
-	"time"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type ChatSettings struct {
 	ChatID         int64  `bson:"_id"`
 	WordleViewType string `bson:"wordle_view_type"` // "text" or "image"
 	WordleColor    string `bson:"wordle_color"`     // "classic", "dark", or "light"
+	WordLength     int    `bson:"wordle_length"`    // letters in the word, MinWordLength to MaxWordLength
+	MaxAttempts    int    `bson:"wordle_attempts"`  // guesses a game allows
+	HardMode       bool   `bson:"wordle_hard_mode"` // revealed letters must be used in later guesses
+	Boards         int    `bson:"wordle_boards"`    // hidden words each guess is played against
 }
 
+// DefaultMaxAttempts is how many guesses a game allows unless the chat picks
+// another of AttemptChoices.
+const DefaultMaxAttempts = 15
+
+// AttemptChoices are the attempt limits a chat can pick.
+var AttemptChoices = []int{6, 10, 15, 20}
+
+// BoardChoices are the numbers of boards a chat can play at once: Wordle,
+// Dordle and Quordle.
+var BoardChoices = []int{1, 2, 4}
+
+const settingsCollection = "ChatSettings"
+
 var (
 	settingsCache = make(map[int64]*ChatSettings)
 	settingsMutex sync.RWMutex
 )
 
-func GetChatSettings(chatID int64, client *mongo.Client) *ChatSettings {
+func GetChatSettings(chatID int64, store repository.Store) *ChatSettings {
 	settingsMutex.RLock()
 	settings, ok := settingsCache[chatID]
 	settingsMutex.RUnlock()
@@ -35,16 +51,14 @@ func GetChatSettings(chatID int64, client *mongo.Client) *ChatSettings {
 		ChatID:         chatID,
 		WordleViewType: "text",    // Default to text
 		WordleColor:    "classic", // Default to classic
+		WordLength:     DefaultWordLength,
+		MaxAttempts:    DefaultMaxAttempts,
+		Boards:         1,
 	}
 
-	if client != nil {
-		collection := client.Database("TelegramBot").Collection("ChatSettings")
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		err := collection.FindOne(ctx, bson.M{"_id": chatID}).Decode(settings)
-		if err != nil && err != mongo.ErrNoDocuments {
-			// Log error if needed
+	if store != nil {
+		if err := store.LoadChatSettings(settingsCollection, chatID, settings); err != nil {
+			log.Printf("Failed to load Wordle settings for chat %d: %v", chatID, err)
 		}
 	}
 
@@ -55,44 +69,102 @@ func GetChatSettings(chatID int64, client *mongo.Client) *ChatSettings {
 	return settings
 }
 
-func UpdateWordleViewType(chatID int64, viewType string, client *mongo.Client) error {
-	settings := GetChatSettings(chatID, client)
+// Rules returns a copy of the chat's settings with a word length, attempt
+// limit and number of boards a game can be played with.
+func Rules(chatID int64, store repository.Store) ChatSettings {
+	settings := GetChatSettings(chatID, store)
+	settingsMutex.RLock()
+	rules := *settings
+	settingsMutex.RUnlock()
+
+	if rules.WordLength < MinWordLength || rules.WordLength > MaxWordLength {
+		rules.WordLength = DefaultWordLength
+	}
+	if rules.MaxAttempts <= 0 {
+		rules.MaxAttempts = DefaultMaxAttempts
+	}
+	if !slices.Contains(BoardChoices, rules.Boards) {
+		rules.Boards = 1
+	}
+	return rules
+}
+
+func UpdateWordleViewType(chatID int64, viewType string, store repository.Store) error {
+	settings := GetChatSettings(chatID, store)
 
 	settingsMutex.Lock()
 	settings.WordleViewType = viewType
 	settingsCache[chatID] = settings
 	settingsMutex.Unlock()
 
-	if client != nil {
-		collection := client.Database("TelegramBot").Collection("ChatSettings")
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		opts := options.Update().SetUpsert(true)
-		update := bson.M{"$set": bson.M{"wordle_view_type": viewType}}
-		_, err := collection.UpdateOne(ctx, bson.M{"_id": chatID}, update, opts)
-		return err
+	if store != nil {
+		return store.SaveChatSettings(settingsCollection, chatID, bson.M{"wordle_view_type": viewType})
 	}
 	return nil
 }
 
-func UpdateWordleColor(chatID int64, color string, client *mongo.Client) error {
-	settings := GetChatSettings(chatID, client)
+func UpdateWordleColor(chatID int64, color string, store repository.Store) error {
+	settings := GetChatSettings(chatID, store)
 
 	settingsMutex.Lock()
 	settings.WordleColor = color
 	settingsCache[chatID] = settings
 	settingsMutex.Unlock()
 
-	if client != nil {
-		collection := client.Database("TelegramBot").Collection("ChatSettings")
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+	if store != nil {
+		return store.SaveChatSettings(settingsCollection, chatID, bson.M{"wordle_color": color})
+	}
+	return nil
+}
+
+// UpdateWordleRules sets the word length, attempt limit and hard mode of the
+// chat's next games.
+func UpdateWordleRules(chatID int64, length, attempts int, hard bool, store repository.Store) error {
+	settings := GetChatSettings(chatID, store)
+
+	settingsMutex.Lock()
+	settings.WordLength = length
+	settings.MaxAttempts = attempts
+	settings.HardMode = hard
+	settingsMutex.Unlock()
+
+	if store != nil {
+		return store.SaveChatSettings(settingsCollection, chatID, bson.M{
+			"wordle_length":    length,
+			"wordle_attempts":  attempts,
+			"wordle_hard_mode": hard,
+		})
+	}
+	return nil
+}
+
+// UpdateWordleBoards sets how many boards the chat's next games are played on.
+func UpdateWordleBoards(chatID int64, boards int, store repository.Store) error {
+	settings := GetChatSettings(chatID, store)
 
-		opts := options.Update().SetUpsert(true)
-		update := bson.M{"$set": bson.M{"wordle_color": color}}
-		_, err := collection.UpdateOne(ctx, bson.M{"_id": chatID}, update, opts)
-		return err
+	settingsMutex.Lock()
+	settings.Boards = boards
+	settingsMutex.Unlock()
+
+	if store != nil {
+		return store.SaveChatSettings(settingsCollection, chatID, bson.M{"wordle_boards": boards})
 	}
 	return nil
 }
+
+// roundAttempts is how many guesses a game under the rules allows: the chat's
+// limit plus one for every board past the first.
+func (rules ChatSettings) roundAttempts() int {
+	return rules.MaxAttempts + max(rules.Boards, 1) - 1
+}
+
+// ModeName names the game played on the given number of boards.
+func ModeName(boards int) string {
+	switch boards {
+	case 2:
+		return "Dordle"
+	case 4:
+		return "Quordle"
+	}
+	return "Wordle"
+}
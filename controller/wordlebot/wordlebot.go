@@ -11,84 +11,118 @@ import (
 	"sync"
 	"time"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/game"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/wordlebot/image_generator"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/ledger"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/view"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // WordleStateDoc is the MongoDB-serializable version of WordleState
 type WordleStateDoc struct {
-	ChatID         int64    `bson:"_id"`
-	Active         bool     `bson:"active"`
-	Word           string   `bson:"word"`
-	Guesses        []string `bson:"guesses"`
-	Attempts       int      `bson:"attempts"`
-	MaxAttempts    int      `bson:"max_attempts"`
-	PendingNewGame bool     `bson:"pending_new_game"`
+	ChatID         int64             `bson:"_id"`
+	Active         bool              `bson:"active"`
+	Word           string            `bson:"word"`
+	Guesses        []string          `bson:"guesses"`
+	Attempts       int               `bson:"attempts"`
+	MaxAttempts    int               `bson:"max_attempts"`
+	HardMode       bool              `bson:"hard_mode"`
+	Words          []string          `bson:"words,omitempty"`
+	SolvedBy       []int             `bson:"solved_by,omitempty"`
+	SolvedAt       []int             `bson:"solved_at,omitempty"`
+	PendingNewGame bool              `bson:"pending_new_game"`
+	Players        map[string]string `bson:"players"`
 }
 
-// saveWordleStateAsync asynchronously saves the Wordle state to MongoDB
-func saveWordleStateAsync(chatID int64, state *WordleState) {
+// snapshot returns state in the form it is saved in. The caller must not hold
+// the state's lock.
+func (state *WordleState) snapshot(chatID int64) WordleStateDoc {
 	state.RLock()
-	doc := WordleStateDoc{
+	defer state.RUnlock()
+
+	// Convert int keys to string keys for MongoDB BSON compatibility
+	players := make(map[string]string)
+	for id, name := range state.Players {
+		players[strconv.Itoa(id)] = name
+	}
+
+	return WordleStateDoc{
 		ChatID:         chatID,
 		Active:         state.Active,
 		Word:           state.Word,
 		Guesses:        state.Guesses,
 		Attempts:       state.Attempts,
 		MaxAttempts:    state.MaxAttempts,
+		HardMode:       state.HardMode,
+		Words:          state.Words,
+		SolvedBy:       state.SolvedBy,
+		SolvedAt:       state.SolvedAt,
 		PendingNewGame: state.PendingNewGame,
+		Players:        players,
 	}
-	state.RUnlock()
+}
 
-	go func() {
-		client := repository.DbManager()
-		if client != nil {
-			repository.SaveGameState(client, "WordleStates", chatID, doc)
-		}
-	}()
+// saveWordleStateAsync saves the Wordle state to the store in the background
+func saveWordleStateAsync(store repository.Store, chatID int64, state *WordleState) {
+	game.Save(store, statesCollection, chatID, state.snapshot(chatID))
 }
 
-// LoadSavedStates loads the persisted Wordle states from MongoDB into the memory map
-func LoadSavedStates(client *mongo.Client) {
+// restoreStates replaces the in-memory states with the saved ones.
+func restoreStates(load func(target any) error) (int, error) {
 	var results []WordleStateDoc
-	err := repository.LoadAllGameStates(client, "WordleStates", &results)
-	if err != nil {
-		log.Printf("Failed to load saved Wordle states: %v", err)
-		return
+	if err := load(&results); err != nil {
+		return 0, err
 	}
 
 	wordleMutex.Lock()
 	defer wordleMutex.Unlock()
 
 	for _, doc := range results {
+		players := make(map[int]string)
+		for k, name := range doc.Players {
+			if id, err := strconv.Atoi(k); err == nil {
+				players[id] = name
+			}
+		}
 		ws := &WordleState{
 			Active:         doc.Active,
 			Word:           doc.Word,
 			Guesses:        doc.Guesses,
 			Attempts:       doc.Attempts,
 			MaxAttempts:    doc.MaxAttempts,
+			HardMode:       doc.HardMode,
+			Words:          doc.Words,
+			SolvedBy:       doc.SolvedBy,
+			SolvedAt:       doc.SolvedAt,
 			PendingNewGame: doc.PendingNewGame,
+			Players:        players,
 			CancelChan:     make(chan bool, 1),
 		}
 		wordleStates[doc.ChatID] = ws
 	}
-	log.Printf("Loaded %d active Wordle games from MongoDB", len(results))
+	return len(results), nil
 }
 
 // WordleState holds the state for a Wordle game in a specific chat.
 type WordleState struct {
 	sync.RWMutex
-	Active         bool
-	Word           string
-	Guesses        []string
-	Attempts       int
-	MaxAttempts    int
+	Active      bool
+	Word        string
+	Guesses     []string
+	Attempts    int
+	MaxAttempts int
+	HardMode    bool // revealed letters must be used in later guesses
+	// Words holds every board's word in a game of several boards, Word
+	// being the first; it is nil on a single board. SolvedBy and SolvedAt
+	// hold, per board, who solved it and with which guess, or 0.
+	Words          []string
+	SolvedBy       []int
+	SolvedAt       []int
 	PendingNewGame bool
+	Players        map[int]string // everyone who made a valid guess this round
 	CancelChan     chan bool
 }
 
@@ -97,10 +131,9 @@ var (
 	wordleStates = make(map[int64]*WordleState)
 	wordleMutex  = &sync.RWMutex{}
 
-	validWordleWords = make(map[string]bool)
-	wordleWordList   = make([]string, 0)
-	wordsLoaded      bool
-	wordsMutex       sync.RWMutex
+	wordleDictionaries = make(map[int]*wordleDictionary)
+	wordsLoaded        bool
+	wordsMutex         sync.RWMutex
 )
 
 // GetOrCreateWordleState safely retrieves or creates a WordleState for a chatID.
@@ -110,100 +143,146 @@ func GetOrCreateWordleState(chatID int64) *WordleState {
 	if _, exists := wordleStates[chatID]; !exists {
 		wordleStates[chatID] = &WordleState{
 			Guesses:     make([]string, 0),
-			MaxAttempts: 15,
+			MaxAttempts: DefaultMaxAttempts,
 		}
 	}
 	return wordleStates[chatID]
 }
 
-// LoadWordleWords loads the 5-letter words from words.txt
-func LoadWordleWords() error {
-	wordsMutex.Lock()
-	defer wordsMutex.Unlock()
-
-	if wordsLoaded {
-		return nil
-	}
+// Word lengths a chat can play Wordle with.
+const (
+	MinWordLength     = 4
+	MaxWordLength     = 8
+	DefaultWordLength = 5
+)
 
-	// Try to find words.txt starting from current dir or going up
-	paths := []string{
-		"controller/translator/words.txt",
-		"../translator/words.txt",
-		"../../controller/translator/words.txt",
-	}
+// wordleDictionary holds the words of one length: those that can come up as
+// the word, and every word accepted as a guess.
+type wordleDictionary struct {
+	targets []string
+	valid   map[string]bool
+}
 
+// scanWordFile calls fn with every lowercase word of the file at rel, a path
+// under controller/, found from the module root or a package directory.
+func scanWordFile(rel string, fn func(word string)) error {
 	var file *os.File
 	var err error
-	for _, p := range paths {
+	for _, p := range []string{"controller/" + rel, "../" + rel, "../../controller/" + rel} {
 		file, err = os.Open(p)
 		if err == nil {
 			break
 		}
 	}
-
 	if err != nil {
-		return fmt.Errorf("could not find words.txt: %v", err)
+		return err
 	}
 	defer file.Close()
 
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		word := strings.TrimSpace(strings.ToLower(scanner.Text()))
-		if len(word) == 5 {
-			validWordleWords[word] = true
-			wordleWordList = append(wordleWordList, word)
+		if isLetters(word) {
+			fn(word)
 		}
 	}
+	return scanner.Err()
+}
 
-	if err := scanner.Err(); err != nil {
-		return err
+// isLetters reports whether s is a non-empty run of the letters a to z.
+func isLetters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
 	}
+	return s != ""
+}
 
-	// Load allowed_words.txt for validation
-	allowedPaths := []string{
-		"controller/translator/allowed_words.txt",
-		"../translator/allowed_words.txt",
-		"../../controller/translator/allowed_words.txt",
-	}
+// LoadWordleWords loads the dictionaries of every word length. Five-letter
+// words come from words.txt, with allowed_words.txt for guesses; the other
+// lengths take their words from the Word Grid list and their guesses from the
+// Scramy lists.
+func LoadWordleWords() error {
+	wordsMutex.Lock()
+	defer wordsMutex.Unlock()
 
-	var allowedFile *os.File
-	var allowedErr error
-	for _, p := range allowedPaths {
-		allowedFile, allowedErr = os.Open(p)
-		if allowedErr == nil {
-			break
-		}
+	if wordsLoaded {
+		return nil
 	}
 
-	if allowedErr == nil {
-		defer allowedFile.Close()
-		allowedScanner := bufio.NewScanner(allowedFile)
-		for allowedScanner.Scan() {
-			word := strings.TrimSpace(strings.ToLower(allowedScanner.Text()))
-			if len(word) == 5 {
-				validWordleWords[word] = true
+	for n := MinWordLength; n <= MaxWordLength; n++ {
+		wordleDictionaries[n] = &wordleDictionary{valid: make(map[string]bool)}
+	}
+	add := func(target bool, five bool) func(string) {
+		return func(word string) {
+			d, ok := wordleDictionaries[len(word)]
+			if !ok || (len(word) == DefaultWordLength) != five {
+				return
+			}
+			if target && !d.valid[word] {
+				d.targets = append(d.targets, word)
 			}
+			d.valid[word] = true
 		}
-		if err := allowedScanner.Err(); err != nil {
-			log.Printf("Error scanning allowed_words.txt: %v", err)
+	}
+
+	if err := scanWordFile("translator/words.txt", add(true, true)); err != nil {
+		return fmt.Errorf("could not find words.txt: %v", err)
+	}
+	if err := scanWordFile("translator/allowed_words.txt", add(false, true)); err != nil {
+		log.Printf("Could not find allowed_words.txt, fallback to words.txt only: %v", err)
+	}
+	if err := scanWordFile("wordgridbot/lib/english_words_gt_10164946_family_safe_v3.txt", add(true, false)); err != nil {
+		log.Printf("Could not load the Wordle words of other lengths: %v", err)
+	}
+	for _, f := range []string{"translator/scramy_words.txt", "translator/scramy_allowed_words.txt"} {
+		if err := scanWordFile(f, add(false, false)); err != nil {
+			log.Printf("Could not load Wordle guesses from %s: %v", f, err)
 		}
-	} else {
-		log.Printf("Could not find allowed_words.txt, fallback to words.txt only: %v", allowedErr)
 	}
 
 	wordsLoaded = true
-	log.Printf("Loaded %d Wordle target words and %d valid guesses", len(wordleWordList), len(validWordleWords))
+	for n := MinWordLength; n <= MaxWordLength; n++ {
+		d := wordleDictionaries[n]
+		log.Printf("Loaded %d Wordle target words and %d valid guesses of %d letters", len(d.targets), len(d.valid), n)
+	}
+	return nil
+}
+
+// wordleTargets returns the words of length n that can come up. The caller
+// holds wordsMutex.
+func wordleTargets(n int) []string {
+	if d, ok := wordleDictionaries[n]; ok {
+		return d.targets
+	}
 	return nil
 }
 
-// getRandomWordleWord returns a random 5-letter word
-func getRandomWordleWord() string {
+// getRandomWordleWord returns a random word of length n, or of five letters
+// if there are none of that length.
+func getRandomWordleWord(n int) string {
 	wordsMutex.RLock()
 	defer wordsMutex.RUnlock()
-	if len(wordleWordList) == 0 {
+	words := wordleTargets(n)
+	if len(words) == 0 {
+		words = wordleTargets(DefaultWordLength)
+	}
+	if len(words) == 0 {
 		return "apple" // fallback
 	}
-	return wordleWordList[rand.Intn(len(wordleWordList))]
+	return words[rand.Intn(len(words))]
+}
+
+// missColor is the square of a letter that is not in the word.
+func missColor(colorConfig string) string {
+	switch colorConfig {
+	case "dark":
+		return "⬛"
+	case "light":
+		return "⬜"
+	}
+	return "🟥"
 }
 
 // validateWordleGuess compares a guess against the target word and returns colored emojis
@@ -220,17 +299,12 @@ func validateWordleGuess(guess, target string, colorConfig string) string {
 		var result [5]string
 		var targetCounts [256]int
 
-		missColor := "🟥"
-		if colorConfig == "dark" {
-			missColor = "⬛"
-		} else if colorConfig == "light" {
-			missColor = "⬜"
-		}
+		miss := missColor(colorConfig)
 
 		// First pass: count characters in target and default to miss
 		for i := 0; i < 5; i++ {
 			targetCounts[target[i]]++
-			result[i] = missColor
+			result[i] = miss
 		}
 
 		// Mark Green
@@ -252,48 +326,27 @@ func validateWordleGuess(guess, target string, colorConfig string) string {
 		return strings.Join(result[:], " ")
 	}
 
-	// Fallback for non 5-letter inputs
-	result := make([]string, len(target))
-	targetCounts := make(map[rune]int)
-
-	missColor := "🟥"
-	if colorConfig == "dark" {
-		missColor = "⬛"
-	} else if colorConfig == "light" {
-		missColor = "⬜"
-	}
-
-	// First pass: count characters in target and check for exact matches (Green)
-	for i, ch := range target {
-		targetCounts[ch]++
-		if i < len(result) {
-			result[i] = missColor // Default to selected miss color
-		}
-	}
-
-	// Mark Green
-	limit := len(guess)
-	if len(target) < limit {
-		limit = len(target)
-	}
-	for i := 0; i < limit; i++ {
-		if guess[i] == target[i] {
+	// Other lengths
+	miss := missColor(colorConfig)
+	result := make([]string, len(guess))
+	for i, m := range scoreGuess(guess, target) {
+		switch m {
+		case correct:
 			result[i] = "🟩"
-			targetCounts[rune(guess[i])]--
-		}
-	}
-
-	// Second pass: check for correct letter in wrong place (Yellow)
-	for i := 0; i < limit; i++ {
-		if guess[i] != target[i] && targetCounts[rune(guess[i])] > 0 {
+		case present:
 			result[i] = "🟨"
-			targetCounts[rune(guess[i])]--
+		default:
+			result[i] = miss
 		}
 	}
-
 	return strings.Join(result, " ")
 }
 
+// allGreen returns the feedback of a solved n-letter word.
+func allGreen(n int) string {
+	return strings.TrimSuffix(strings.Repeat("🟩 ", n), " ")
+}
+
 // getSuperscript returns the given number as a string of superscript characters
 func getSuperscript(num int) string {
 	// ⚡ Bolt Optimization: Replace map lookup and fmt.Sprintf with fixed array and strconv.Itoa
@@ -320,8 +373,8 @@ func getSuperscript(num int) string {
 // buildWordleBoard generates the string representation of the current Wordle board
 func buildWordleBoard(ws *WordleState, colorConfig string) string {
 	var sb strings.Builder
-	// Rough pre-allocation: guesses * (~30 bytes emojis + 7 bytes space/word + 2 bytes newline)
-	sb.Grow(len(ws.Guesses) * 45)
+	// Rough pre-allocation: guesses * (~5 bytes emoji and space + 1 byte word per letter + ~10 bytes spacing, number and newline)
+	sb.Grow(len(ws.Guesses) * (6*len(ws.Word) + 10))
 	for i, guess := range ws.Guesses {
 		feedback := validateWordleGuess(guess, ws.Word, colorConfig)
 
@@ -362,20 +415,25 @@ func IsWordleActive(chatID int64) bool {
 }
 
 // HandleWordleCommand starts a new Wordle game
-func HandleWordleCommand(bot *tgbotapi.BotAPI, chatID int64, username string, client *mongo.Client) {
+func HandleWordleCommand(bot *tgbotapi.BotAPI, chatID int64, username string, store repository.Store) {
+	if DailyActive(chatID) {
+		view.SendMessage(bot, chatID, "Finish today's Daily Wordle first, or give it up with /canceldailywordle.")
+		return
+	}
 	ws := GetOrCreateWordleState(chatID)
+	settings := Rules(chatID, store)
 
 	ws.Lock()
 	if ws.Active {
 		if ws.PendingNewGame {
 			ws.Unlock()
-			saveWordleStateAsync(chatID, ws)
+			saveWordleStateAsync(store, chatID, ws)
 			return // Already a pending request
 		}
 		ws.PendingNewGame = true
 		ws.CancelChan = make(chan bool, 1)
 		ws.Unlock()
-		saveWordleStateAsync(chatID, ws)
+		saveWordleStateAsync(store, chatID, ws)
 
 		markup := tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(
@@ -396,39 +454,19 @@ func HandleWordleCommand(bot *tgbotapi.BotAPI, chatID int64, username string, cl
 				if !ws.PendingNewGame {
 					// It was cancelled
 					ws.Unlock()
-					saveWordleStateAsync(chatID, ws)
+					saveWordleStateAsync(store, chatID, ws)
 					return
 				}
 				ws.PendingNewGame = false
-				ws.Active = true
-				ws.Word = getRandomWordleWord()
-				ws.Guesses = make([]string, 0)
-				ws.Attempts = 0
+				ws.newRound(settings)
 				ws.Unlock()
-				saveWordleStateAsync(chatID, ws)
+				saveWordleStateAsync(store, chatID, ws)
 
 				if err == nil {
 					deleteMsg := tgbotapi.NewDeleteMessage(chatID, sentMsg.MessageID)
 					bot.Send(deleteMsg)
 				}
-
-				buttons := tgbotapi.NewInlineKeyboardMarkup(
-					tgbotapi.NewInlineKeyboardRow(
-						tgbotapi.NewInlineKeyboardButtonData("Change Layout ⚙️", "setting_wordle_view_new"),
-						tgbotapi.NewInlineKeyboardButtonData("Wordle Color 🎨", "setting_wordle_color_new"),
-					),
-				)
-
-				settings := GetChatSettings(chatID, client)
-				missEmoji := "🟥"
-				if settings.WordleColor == "dark" {
-					missEmoji = "⬛"
-				} else if settings.WordleColor == "light" {
-					missEmoji = "⬜"
-				}
-
-				msg := fmt.Sprintf("🐊 🖼 *Wordle started!* ✨\n\n🔡 — The word consists of 5 letters.\n🎯 — You have %d attempts.\n\n💡 Hints:\n🟩 Correct letter in the right spot\n🟨 Correct letter but in the wrong spot\n%s Letter is not in the word\n\nSend a 5-letter word to guess.", ws.MaxAttempts, missEmoji)
-				view.SendMessageWithButtons(bot, chatID, msg, buttons)
+				sendWordleStarted(bot, chatID, settings)
 			case <-ws.CancelChan:
 				// Cancelled by a user
 				if err == nil {
@@ -440,13 +478,33 @@ func HandleWordleCommand(bot *tgbotapi.BotAPI, chatID int64, username string, cl
 		return
 	}
 
+	ws.newRound(settings)
+	ws.Unlock()
+	saveWordleStateAsync(store, chatID, ws)
+	sendWordleStarted(bot, chatID, settings)
+}
+
+// newRound starts a game under the chat's rules. The caller holds the state's
+// lock.
+func (ws *WordleState) newRound(settings ChatSettings) {
 	ws.Active = true
-	ws.Word = getRandomWordleWord()
+	ws.Word = getRandomWordleWord(settings.WordLength)
 	ws.Guesses = make([]string, 0)
+	ws.Players = make(map[int]string)
 	ws.Attempts = 0
-	ws.Unlock()
-	saveWordleStateAsync(chatID, ws)
+	ws.MaxAttempts = settings.roundAttempts()
+	ws.HardMode = settings.HardMode && settings.Boards <= 1
+	ws.Words, ws.SolvedBy, ws.SolvedAt = nil, nil, nil
+	if settings.Boards > 1 {
+		ws.Words = boardWords(settings.WordLength, settings.Boards)
+		ws.Word = ws.Words[0]
+		ws.SolvedBy = make([]int, settings.Boards)
+		ws.SolvedAt = make([]int, settings.Boards)
+	}
+}
 
+// sendWordleStarted announces a game started under the chat's rules.
+func sendWordleStarted(bot *tgbotapi.BotAPI, chatID int64, settings ChatSettings) {
 	buttons := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("Change Layout ⚙️", "setting_wordle_view_new"),
@@ -454,25 +512,26 @@ func HandleWordleCommand(bot *tgbotapi.BotAPI, chatID int64, username string, cl
 		),
 	)
 
-	settings := GetChatSettings(chatID, client)
-	missEmoji := "🟥"
-	if settings.WordleColor == "dark" {
-		missEmoji = "⬛"
-	} else if settings.WordleColor == "light" {
-		missEmoji = "⬜"
+	words := fmt.Sprintf("🔡 — The word consists of %d letters.", settings.WordLength)
+	if settings.Boards > 1 {
+		words = fmt.Sprintf("🔡 — %d hidden words of %d letters, each on its own board. Every guess is played on all of them.", settings.Boards, settings.WordLength)
 	}
-
-	msg := fmt.Sprintf("🐊 🖼 *Wordle started!* ✨\n\n🔡 — The word consists of 5 letters.\n🎯 — You have %d attempts.\n\n💡 Hints:\n🟩 Correct letter in the right spot\n🟨 Correct letter but in the wrong spot\n%s Letter is not in the word\n\nSend a 5-letter word to guess.", ws.MaxAttempts, missEmoji)
+	hard := ""
+	if settings.HardMode && settings.Boards <= 1 {
+		hard = "💪 — Hard mode: letters you find must be used in every later guess, greens in place.\n"
+	}
+	msg := fmt.Sprintf("🐊 🖼 *%s started!* ✨\n\n%s\n🎯 — You have %d attempts.\n%s\n💡 Hints:\n🟩 Correct letter in the right spot\n🟨 Correct letter but in the wrong spot\n%s Letter is not in the word\n\nSend a %d-letter word to guess.",
+		ModeName(settings.Boards), words, settings.roundAttempts(), hard, missColor(settings.WordleColor), settings.WordLength)
 	view.SendMessageWithButtons(bot, chatID, msg, buttons)
 }
 
 // CancelPendingGame cancels an ongoing new game request
-func CancelPendingGame(bot *tgbotapi.BotAPI, chatID int64, username string) bool {
+func CancelPendingGame(bot *tgbotapi.BotAPI, chatID int64, username string, store repository.Store) bool {
 	ws := GetOrCreateWordleState(chatID)
 	ws.Lock()
 	defer func() {
 		ws.Unlock()
-		saveWordleStateAsync(chatID, ws)
+		saveWordleStateAsync(store, chatID, ws)
 	}()
 
 	if ws.PendingNewGame {
@@ -487,61 +546,63 @@ func CancelPendingGame(bot *tgbotapi.BotAPI, chatID int64, username string) bool
 	return false
 }
 
-// HandleGuess processes a guess for a Wordle game
-func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.Client, chatID int64, text string) {
+// CancelWordle ends the Wordle game in the chat along with any pending new
+// game request. It returns false if no game was running.
+func CancelWordle(chatID int64, store repository.Store) bool {
 	ws := GetOrCreateWordleState(chatID)
 	ws.Lock()
-	defer func() {
-		ws.Unlock()
-		saveWordleStateAsync(chatID, ws)
-	}()
-
 	if !ws.Active {
-		return
+		ws.Unlock()
+		return false
 	}
-
-	guess := strings.ToLower(strings.TrimSpace(text))
-
-	// Check if it's exactly 5 bytes (ASCII check)
-	if len(guess) != 5 {
-		return // Not a valid guess format, ignore
+	ws.Active = false
+	ws.PendingNewGame = false
+	select {
+	case ws.CancelChan <- true:
+	default:
 	}
+	ws.Unlock()
 
-	// Ensure all characters are valid English alphabet letters
-	for i := 0; i < len(guess); i++ {
-		if guess[i] < 'a' || guess[i] > 'z' {
-			return // Ignore non-alphabetical inputs completely
-		}
-	}
+	saveWordleStateAsync(store, chatID, ws)
+	return true
+}
 
-	wordsMutex.RLock()
-	isValid := validWordleWords[guess]
-	wordsMutex.RUnlock()
+// HandleGuess processes a guess for a Wordle game. It returns the result when
+// the guess ended the round: the winner against everyone else who guessed.
+func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, store repository.Store, chatID int64, text string) *game.Result {
+	ws := GetOrCreateWordleState(chatID)
+	ws.Lock()
+	defer func() {
+		ws.Unlock()
+		saveWordleStateAsync(store, chatID, ws)
+	}()
 
-	if !isValid {
-		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ %s is not a valid word. Try again!", strings.ToUpper(guess)))
-		bot.Send(msg)
-		return
+	if !ws.Active {
+		return nil
 	}
 
-	alreadyGuessed := false
-	for _, g := range ws.Guesses {
-		if g == guess {
-			alreadyGuessed = true
-			break
-		}
+	guess, ok := checkGuess(bot, chatID, text, len(ws.Word), ws.Guesses)
+	if !ok {
+		return nil
 	}
-
-	if alreadyGuessed {
-		msg := tgbotapi.NewMessage(chatID, "⚠️ This word was already guessed! Try again!")
-		bot.Send(msg)
-		return
+	if ws.HardMode {
+		if why := hardModeViolation(guess, ws.Guesses, ws.Word); why != "" {
+			bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("💪 Hard mode: %s. Try again!", why)))
+			return nil
+		}
 	}
 
 	ws.Guesses = append(ws.Guesses, guess)
 	ws.Attempts++
+	if ws.Players == nil {
+		ws.Players = make(map[int]string)
+	}
+	ws.Players[message.From.ID] = message.From.FirstName
 
-	settings := GetChatSettings(chatID, client)
+	settings := GetChatSettings(chatID, store)
+	if len(ws.Words) > 0 {
+		return handleBoardsGuess(bot, message, store, chatID, ws, guess, settings)
+	}
 	isImage := settings.WordleViewType == "image"
 	var board string
 	var imgData []byte
@@ -578,8 +639,8 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 				meaning = "\n\n```Meaning\n" + meaning + "\n```"
 			}
 
-			msg := fmt.Sprintf("🟩 🟩 🟩 🟩 🟩  %s   [+%d💎]\n🎉 [%s](tg://user?id=%d) guessed it in %d attempts!%s",
-				strings.ToUpper(ws.Word), points, message.From.FirstName, message.From.ID, ws.Attempts, meaning)
+			msg := fmt.Sprintf("%s  %s   [+%d💎]\n🎉 [%s](tg://user?id=%d) guessed it in %d attempts!%s",
+				allGreen(len(ws.Word)), strings.ToUpper(ws.Word), points, message.From.FirstName, message.From.ID, ws.Attempts, meaning)
 			view.ReplyToMessageWithPhotoAndButtons(bot, message.MessageID, chatID, imgData, msg, buttons)
 		} else {
 			meaning := model.GetWordMeaning(ws.Word)
@@ -587,25 +648,33 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 				meaning = "\n\n```Meaning\n" + meaning + "\n```"
 			}
 
-			msg := fmt.Sprintf("%s\n\n🟩 🟩 🟩 🟩 🟩  %s   [+%d💎]\n🎉 [%s](tg://user?id=%d) guessed it in %d attempts!%s",
-				board, strings.ToUpper(ws.Word), points, message.From.FirstName, message.From.ID, ws.Attempts, meaning)
+			msg := fmt.Sprintf("%s\n\n%s  %s   [+%d💎]\n🎉 [%s](tg://user?id=%d) guessed it in %d attempts!%s",
+				board, allGreen(len(ws.Word)), strings.ToUpper(ws.Word), points, message.From.FirstName, message.From.ID, ws.Attempts, meaning)
 			view.ReplyToMessageWithButtons(bot, message.MessageID, chatID, msg, buttons)
 		}
 
-		go repository.InsertWordleDoc(message.From.ID, message.From.FirstName, chatID, client, "WordleEn", ws.Attempts)
+		go func(userID int, name string, attempts int) {
+			// Pay out before the leaderboard doc so a first-time winner's
+			// opening balance does not count this win twice.
+			if _, err := ledger.Credit(store, ledger.Key("wordle", chatID, message.MessageID), userID, name, chatID, points, "Wordle win"); err != nil {
+				log.Printf("Failed to credit Wordle win to %d: %v", userID, err)
+			}
+			store.InsertWordleDoc(userID, name, chatID, "WordleEn", attempts)
+		}(message.From.ID, message.From.FirstName, ws.Attempts)
 
 		go func(uID int64, username string) {
-			if client != nil {
-				service.AwardGameResult(client, uID, username, true) // Winner
+			if store != nil {
+				service.AwardGameResult(store, uID, username, true) // Winner
 			}
 		}(int64(message.From.ID), message.From.FirstName)
 
+		return roundResult(ws, message.From.ID)
 	} else if ws.Attempts >= ws.MaxAttempts {
 		ws.Active = false
 
 		go func(uID int64, username string) {
-			if client != nil {
-				service.AwardGameResult(client, uID, username, false) // Loser, but gets participation
+			if store != nil {
+				service.AwardGameResult(store, uID, username, false) // Loser, but gets participation
 			}
 		}(int64(message.From.ID), message.From.FirstName)
 
@@ -633,6 +702,7 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 			msg := fmt.Sprintf("%s\n\n❌ Out of attempts! The word was %s.%s", board, strings.ToUpper(ws.Word), meaning)
 			view.ReplyToMessageWithButtons(bot, message.MessageID, chatID, msg, buttons)
 		}
+		return roundResult(ws, 0)
 	} else {
 		if isImage {
 			view.ReplyToMessageWithPhotoAndButtons(bot, message.MessageID, chatID, imgData, "", tgbotapi.InlineKeyboardMarkup{})
@@ -640,4 +710,50 @@ func HandleGuess(bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *mongo.
 			view.ReplyToMessage(bot, message.MessageID, chatID, board)
 		}
 	}
+	return nil
+}
+
+// checkGuess reads text as a guess at an n-letter word. Anything but n
+// English letters is ignored; a word that is not in the list or was already
+// guessed is pointed out. ok is true for a guess that counts.
+func checkGuess(bot *tgbotapi.BotAPI, chatID int64, text string, n int, guesses []string) (guess string, ok bool) {
+	guess = strings.ToLower(strings.TrimSpace(text))
+
+	// Ignore anything that is not a word of the right length
+	if len(guess) != n || !isLetters(guess) {
+		return "", false
+	}
+
+	wordsMutex.RLock()
+	isValid := wordleDictionaries[n] != nil && wordleDictionaries[n].valid[guess]
+	wordsMutex.RUnlock()
+
+	if !isValid {
+		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ %s is not a valid word. Try again!", strings.ToUpper(guess)))
+		bot.Send(msg)
+		return "", false
+	}
+
+	for _, g := range guesses {
+		if g == guess {
+			msg := tgbotapi.NewMessage(chatID, "⚠️ This word was already guessed! Try again!")
+			bot.Send(msg)
+			return "", false
+		}
+	}
+	return guess, true
+}
+
+// roundResult scores a finished round: a point for winnerID, if anyone won,
+// and none for everyone else who guessed.
+func roundResult(ws *WordleState, winnerID int) *game.Result {
+	res := &game.Result{}
+	for id, name := range ws.Players {
+		points := 0
+		if id == winnerID {
+			points = 1
+		}
+		res.Scores = append(res.Scores, game.Score{Player: game.Player{ID: id, Name: name}, Points: points})
+	}
+	return res
 }
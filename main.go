@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
 	"sync"
+	"syscall"
+	"time"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller"
 	categorybot "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/categoryBot"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/fontbot"
@@ -12,121 +17,109 @@ import (
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/modbot"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/schedulebot"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/translator"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/webhook"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordbank"
 )
 
-func main() {
-	variable := os.Getenv("TEST")
-	CategoryVariable := os.Getenv("CATEGORY")
-	charades := variable
-	categorycharades := CategoryVariable
-	instagram := "7995903003:AAEcvtxq1Swak9W_uuMwQ-Jv-YXKOp_i-pw"
-	fontbotToken := "YOUR_FONT_BOT_TOKEN_HERE" // Replace with your actual font bot token
-	modbotToken := os.Getenv("MOD_BOT_TOKEN")  // Token for the moderator bot
-	scheduleBotToken := os.Getenv("SCHEDULE_BOT_TOKEN")
-
-	// WaitGroup to wait for all goroutines to finish
-	var wg sync.WaitGroup
+// shutdownTimeout bounds the whole shutdown. Render waits 30 seconds after
+// SIGTERM before it kills the process.
+const shutdownTimeout = 25 * time.Second
 
-	// Add seven tasks to the WaitGroup
-	wg.Add(7)
-
-	// Start bots in separate goroutines
-	go runWordBot(charades, &wg)
-	go runCategoryBot(categorycharades, &wg)
-	go runInstagramBot(instagram, &wg)
-	go runTranslatorBot(&wg)
-	go runFontBot(fontbotToken, &wg)
-	go runModBot(modbotToken, &wg)
-	go runScheduleBot(scheduleBotToken, &wg)
-
-	// Wait for goroutines to complete
-	wg.Wait()
-
-	log.Println("All bots stopped running.")
-}
-
-func runTranslatorBot(wg *sync.WaitGroup) {
-	defer wg.Done()
-	translator.Bot()
-}
-
-// Function to start a bot with error handling
-func runWordBot(botToken string, wg *sync.WaitGroup) {
-	defer wg.Done() // Decrement the wait group counter when this goroutine completes
-	err := controller.StartBot(botToken)
+func main() {
+	cfg, err := config.Load()
 	if err != nil {
-		log.Printf("Error starting bot with token %s: %v\n", botToken, err)
+		log.Fatalf("Invalid configuration:\n%v", err)
 	}
 
-}
+	signals, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
 
-// Function to start a bot with error handling
-func runInstagramBot(botToken string, wg *sync.WaitGroup) {
-	defer wg.Done() // Decrement the wait group counter when this goroutine completes
-	err := instagrambot.StartBot(botToken)
-	if err != nil {
-		log.Printf("Error starting bot with token %s: %v\n", botToken, err)
+	// The health check and, in webhook mode, every bot's updates share one server.
+	if cfg.Features.HealthServer || cfg.Webhook.Enabled() {
+		go func() {
+			if err := webhook.ListenAndServe(cfg.Features.HTTPAddr); err != nil {
+				log.Fatal(err)
+			}
+		}()
 	}
 
-}
+	// Bots run until ctx is cancelled, then drain their in-flight handlers.
+	ctx, stopBots := context.WithCancel(context.Background())
+	defer stopBots()
+	var wg sync.WaitGroup
 
-// // Function to start a bot with error handling
-// func runAnimeBot(botToken string, wg *sync.WaitGroup) {
-// 	defer wg.Done() // Decrement the wait group counter when this goroutine completes
-// 	word, err := model.GetRandomCar()
-// 	if err != nil {
-// 		log.Printf("Error starting bot with token %s: %v\n", botToken, err)
-// 	}
-// 	fmt.Println("word", word)
-
-// }
-
-// Function to start a bot with error handling
-func runCategoryBot(botToken string, wg *sync.WaitGroup) {
-	defer wg.Done() // Decrement the wait group counter when this goroutine completes
-	err := categorybot.StartBot(botToken)
-	if err != nil {
-		log.Printf("Error starting bot with token %s: %v\n", botToken, err)
+	// The word-guess bots share one word bank.
+	if cfg.Bots.Word.Enabled() || cfg.Bots.Category.Enabled() {
+		wordbank.Default.SetWindow(cfg.Words.NoRepeat)
+		for _, lang := range wordbank.Languages() {
+			wordbank.For(lang).SetWindow(cfg.Words.NoRepeat)
+		}
+		go wordbank.Refresh(ctx, wordbank.Default, cfg.Words)
 	}
 
-}
-
-// Function to start the font bot with error handling
-func runFontBot(botToken string, wg *sync.WaitGroup) {
-	defer wg.Done() // Decrement the wait group counter when this goroutine completes
-	err := fontbot.StartFormatBot(botToken)
-	if err != nil {
-		log.Printf("Error starting font bot with token %s: %v\n", botToken, err)
+	// Start bots in separate goroutines
+	run(&wg, "word", cfg.Bots.Word, func() error { return controller.StartBot(ctx, cfg) })
+	run(&wg, "category", cfg.Bots.Category, func() error { return categorybot.StartBot(ctx, cfg) })
+	run(&wg, "instagram", cfg.Bots.Instagram, func() error { return instagrambot.StartBot(ctx, cfg) })
+	run(&wg, "translator", cfg.Bots.Translator, func() error { translator.Bot(ctx, cfg); return nil })
+	run(&wg, "font", cfg.Bots.Font, func() error { return fontbot.StartFormatBot(ctx, cfg) })
+	run(&wg, "mod", cfg.Bots.Mod, func() error { return modbot.StartModBot(ctx, cfg) })
+	run(&wg, "schedule", cfg.Bots.Schedule, func() error { return schedulebot.StartScheduleBot(ctx, cfg) })
+
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-signals.Done():
+		log.Println("Shutting down...")
+	case <-stopped:
+		log.Println("All bots stopped running.")
 	}
+	shutdown(stopBots, stopped)
 }
 
-// Function to start the mod bot with error handling
-func runModBot(botToken string, wg *sync.WaitGroup) {
-	defer wg.Done() // Decrement the wait group counter when this goroutine completes
+// shutdown stops taking webhook deliveries, stops the bots and waits for their
+// handlers, then writes out pending game state and closes MongoDB.
+func shutdown(stopBots context.CancelFunc, stopped <-chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
 
-	// Skip starting if token is empty to avoid panic locally
-	if botToken == "" {
-		log.Println("MOD_BOT_TOKEN is empty. Skipping ModBot startup.")
-		return
+	if err := webhook.Shutdown(ctx); err != nil {
+		log.Printf("Failed to stop the HTTP server: %v", err)
 	}
 
-	err := modbot.StartModBot(botToken)
-	if err != nil {
-		log.Printf("Error starting mod bot with token %s: %v\n", botToken, err)
+	stopBots()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Println("Timed out waiting for bot handlers to finish.")
 	}
-}
 
-// Function to start the schedule bot with error handling
-func runScheduleBot(botToken string, wg *sync.WaitGroup) {
-	defer wg.Done() // Decrement the wait group counter when this goroutine completes
+	if err := repository.FlushSaves(ctx); err != nil {
+		log.Printf("Failed to flush pending state saves: %v", err)
+	}
+	if err := repository.Disconnect(ctx); err != nil {
+		log.Printf("Failed to disconnect from MongoDB: %v", err)
+	}
+	log.Println("Shutdown complete.")
+}
 
-	if botToken == "" {
-		log.Println("SCHEDULE_BOT_TOKEN is empty. Skipping ScheduleBot startup.")
+// run starts a bot in its own goroutine unless it is disabled or has no token.
+func run(wg *sync.WaitGroup, name string, bot config.BotConfig, start func() error) {
+	if !bot.Enabled() {
+		log.Printf("%s bot is disabled or has no token. Skipping startup.", name)
 		return
 	}
 
-	err := schedulebot.StartScheduleBot(botToken)
-	if err != nil {
-		log.Printf("Error starting schedule bot with token %s: %v\n", botToken, err)
-	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done() // Decrement the wait group counter when this goroutine completes
+		if err := start(); err != nil {
+			log.Printf("Error starting %s bot: %v\n", name, err)
+		}
+	}()
 }
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// Season is the archive of a finished season: its final standings for every
+// game, globally and per chat.
+type Season struct {
+	Number   int           `bson:"_id"`
+	Start    time.Time     `bson:"start"`
+	End      time.Time     `bson:"end"`
+	ClosedAt time.Time     `bson:"closed_at"`
+	Boards   []SeasonBoard `bson:"boards"`
+	Rewarded bool          `bson:"rewarded"` // every reward of the season was paid
+}
+
+// SeasonBoard is the final standings of one game.
+type SeasonBoard struct {
+	Collection string           `bson:"collection"`
+	Title      string           `bson:"title"`
+	Global     []SeasonStanding `bson:"global"`
+	Chats      []SeasonChat     `bson:"chats"`
+}
+
+// SeasonChat is the final standings of one game in one chat.
+type SeasonChat struct {
+	ChatID    int64            `bson:"chat_id"`
+	Standings []SeasonStanding `bson:"standings"`
+}
+
+// SeasonStanding is one player's final place.
+type SeasonStanding struct {
+	Rank   int    `bson:"rank"`
+	UserID int    `bson:"user_id"`
+	Name   string `bson:"name"`
+	Score  int    `bson:"score"`
+}
+
+// Chat returns the board's standings in chatID.
+func (b SeasonBoard) Chat(chatID int64) []SeasonStanding {
+	for _, c := range b.Chats {
+		if c.ChatID == chatID {
+			return c.Standings
+		}
+	}
+	return nil
+}
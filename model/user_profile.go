@@ -23,6 +23,7 @@ type UserProfile struct {
 	ActiveDaysThisWeek []string           `bson:"active_days_this_week"` // e.g. "2023-10-01"
 	GamesPlayed        int                `bson:"games_played"`
 	Wins               int                `bson:"wins"`
+	ClaimedRewards     []string           `bson:"claimed_rewards,omitempty"` // keys of one-off rewards already paid
 	CreatedAt          time.Time          `bson:"created_at"`
 	UpdatedAt          time.Time          `bson:"updated_at"`
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model/collectible"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -12,16 +13,23 @@ import (
 )
 
 const (
-	dbName              = "Telegram"
 	templatesCollection = "CollectibleTemplates"
 	itemsCollection     = "Collectibles"
 	listingsCollection  = "MarketListings"
 	counterCollection   = "Counters" // Used for generating unique serial numbers
 )
 
+var (
+	// ErrListingGone is returned when a listing was bought, withdrawn or
+	// repriced before the purchase went through.
+	ErrListingGone = errors.New("listing not found or already purchased")
+	// ErrItemMoved is returned when a listed item no longer belongs to the seller.
+	ErrItemMoved = errors.New("the seller no longer owns this item")
+)
+
 // GetTemplates returns all available collectible templates
 func GetTemplates(client *mongo.Client) ([]collectible.Template, error) {
-	collection := client.Database(dbName).Collection(templatesCollection)
+	collection := client.Database(config.App.Mongo.Database).Collection(templatesCollection)
 	var templates []collectible.Template
 	cursor, err := collection.Find(context.TODO(), bson.M{})
 	if err != nil {
@@ -45,7 +53,7 @@ func BootstrapTemplates(client *mongo.Client) error {
 		return nil // Already seeded
 	}
 
-	collection := client.Database(dbName).Collection(templatesCollection)
+	collection := client.Database(config.App.Mongo.Database).Collection(templatesCollection)
 	initialTemplates := []interface{}{
 		collectible.Template{ID: primitive.NewObjectID().Hex(), Name: "Naruto Run", Rarity: collectible.RarityCommon, Emoji: "🏃", ImageURL: "https://encrypted-tbn0.gstatic.com/images?q=tbn:ANd9GcTBq1hBIt2Ve2P6TfmCehLeuNvVEubCxTRphQ&s"},
 		collectible.Template{ID: primitive.NewObjectID().Hex(), Name: "Goku Hair", Rarity: collectible.RarityUncommon, Emoji: "🔥"},
@@ -60,7 +68,11 @@ func BootstrapTemplates(client *mongo.Client) error {
 
 // GetNextSerialNumber returns the next available serial number for a given template ID
 func GetNextSerialNumber(client *mongo.Client, templateID string) (int, error) {
-	collection := client.Database(dbName).Collection(counterCollection)
+	return nextSerialNumber(context.TODO(), client, templateID)
+}
+
+func nextSerialNumber(ctx context.Context, client *mongo.Client, templateID string) (int, error) {
+	collection := client.Database(config.App.Mongo.Database).Collection(counterCollection)
 
 	filter := bson.M{"_id": "serial_" + templateID}
 	update := bson.M{"$inc": bson.M{"seq": 1}}
@@ -69,7 +81,7 @@ func GetNextSerialNumber(client *mongo.Client, templateID string) (int, error) {
 	var result struct {
 		Seq int `bson:"seq"`
 	}
-	err := collection.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&result)
+	err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
 	if err != nil {
 		return 0, err
 	}
@@ -78,19 +90,34 @@ func GetNextSerialNumber(client *mongo.Client, templateID string) (int, error) {
 
 // MintItem creates a new collectible item for a user
 func MintItem(client *mongo.Client, item collectible.Item) (collectible.Item, error) {
-	collection := client.Database(dbName).Collection(itemsCollection)
+	return mintItem(context.TODO(), client, item)
+}
+
+// MintItemWithSerial gives item the next serial number of its template and
+// inserts it. Run it inside a transaction to mint only if the rest succeeds.
+func MintItemWithSerial(ctx context.Context, client *mongo.Client, item collectible.Item) (collectible.Item, error) {
+	serial, err := nextSerialNumber(ctx, client, item.TemplateID)
+	if err != nil {
+		return collectible.Item{}, err
+	}
+	item.SerialNumber = serial
+	return mintItem(ctx, client, item)
+}
+
+func mintItem(ctx context.Context, client *mongo.Client, item collectible.Item) (collectible.Item, error) {
+	collection := client.Database(config.App.Mongo.Database).Collection(itemsCollection)
 
 	if item.ID == "" {
 		item.ID = primitive.NewObjectID().Hex()
 	}
 
-	_, err := collection.InsertOne(context.TODO(), item)
+	_, err := collection.InsertOne(ctx, item)
 	return item, err
 }
 
 // GetUserInventory returns all items owned by a specific user
 func GetUserInventory(client *mongo.Client, userID int) ([]collectible.Item, error) {
-	collection := client.Database(dbName).Collection(itemsCollection)
+	collection := client.Database(config.App.Mongo.Database).Collection(itemsCollection)
 	var items []collectible.Item
 	cursor, err := collection.Find(context.TODO(), bson.M{"owner_id": userID})
 	if err != nil {
@@ -106,7 +133,7 @@ func GetUserInventory(client *mongo.Client, userID int) ([]collectible.Item, err
 
 // GetItemByID returns a specific item by its ID
 func GetItemByID(client *mongo.Client, itemID string) (collectible.Item, error) {
-	collection := client.Database(dbName).Collection(itemsCollection)
+	collection := client.Database(config.App.Mongo.Database).Collection(itemsCollection)
 	var item collectible.Item
 	err := collection.FindOne(context.TODO(), bson.M{"_id": itemID}).Decode(&item)
 	return item, err
@@ -114,7 +141,7 @@ func GetItemByID(client *mongo.Client, itemID string) (collectible.Item, error)
 
 // CreateListing creates a new marketplace listing
 func CreateListing(client *mongo.Client, listing collectible.MarketListing) error {
-	collection := client.Database(dbName).Collection(listingsCollection)
+	collection := client.Database(config.App.Mongo.Database).Collection(listingsCollection)
 
 	// Prevent duplicate listings for the same item
 	count, err := collection.CountDocuments(context.TODO(), bson.M{"item_id": listing.ItemID})
@@ -135,7 +162,7 @@ func CreateListing(client *mongo.Client, listing collectible.MarketListing) erro
 
 // GetListings returns all active marketplace listings
 func GetListings(client *mongo.Client) ([]collectible.MarketListing, error) {
-	collection := client.Database(dbName).Collection(listingsCollection)
+	collection := client.Database(config.App.Mongo.Database).Collection(listingsCollection)
 	var listings []collectible.MarketListing
 	cursor, err := collection.Find(context.TODO(), bson.M{})
 	if err != nil {
@@ -151,39 +178,48 @@ func GetListings(client *mongo.Client) ([]collectible.MarketListing, error) {
 
 // GetListingByID returns a specific listing
 func GetListingByID(client *mongo.Client, listingID string) (collectible.MarketListing, error) {
-	collection := client.Database(dbName).Collection(listingsCollection)
+	collection := client.Database(config.App.Mongo.Database).Collection(listingsCollection)
 	var listing collectible.MarketListing
 	err := collection.FindOne(context.TODO(), bson.M{"_id": listingID}).Decode(&listing)
 	return listing, err
 }
 
-// ProcessPurchase transfers ownership and removes the listing atomically (in concept)
-func ProcessPurchase(client *mongo.Client, listingID string, itemID string, buyerID int) error {
-	// In MongoDB standalone, we do this sequentially. In replica sets, we could use transactions.
-
-	// 1. Delete listing
-	listingsColl := client.Database(dbName).Collection(listingsCollection)
-	res, err := listingsColl.DeleteOne(context.TODO(), bson.M{"_id": listingID})
+// TransferListing removes listing and hands its item to the buyer. Both steps
+// are optimistic: the listing must still exist exactly as the buyer saw it
+// (ErrListingGone) and the item must still belong to the seller
+// (ErrItemMoved). Run it inside a transaction together with the payment.
+func TransferListing(ctx context.Context, client *mongo.Client, listing collectible.MarketListing, buyerID int) error {
+	listingsColl := client.Database(config.App.Mongo.Database).Collection(listingsCollection)
+	res, err := listingsColl.DeleteOne(ctx, bson.M{
+		"_id":       listing.ID,
+		"item_id":   listing.ItemID,
+		"seller_id": listing.SellerID,
+		"price":     listing.Price,
+	})
 	if err != nil {
 		return err
 	}
 	if res.DeletedCount == 0 {
-		return errors.New("listing not found or already purchased")
+		return ErrListingGone
 	}
 
-	// 2. Transfer ownership
-	itemsColl := client.Database(dbName).Collection(itemsCollection)
-	_, err = itemsColl.UpdateOne(
-		context.TODO(),
-		bson.M{"_id": itemID},
+	itemsColl := client.Database(config.App.Mongo.Database).Collection(itemsCollection)
+	upd, err := itemsColl.UpdateOne(ctx,
+		bson.M{"_id": listing.ItemID, "owner_id": listing.SellerID},
 		bson.M{"$set": bson.M{"owner_id": buyerID}},
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	if upd.MatchedCount == 0 {
+		return ErrItemMoved
+	}
+	return nil
 }
 
 // DeleteListing completely removes a listing (e.g., when a user cancels it)
 func DeleteListing(client *mongo.Client, listingID string) error {
-	collection := client.Database(dbName).Collection(listingsCollection)
+	collection := client.Database(config.App.Mongo.Database).Collection(listingsCollection)
 	_, err := collection.DeleteOne(context.TODO(), bson.M{"_id": listingID})
 	return err
 }
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model/collectible"
+	collectibleRepo "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository/collectible"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrListingGone is returned when a listing was bought, withdrawn or
+	// repriced before the purchase went through.
+	ErrListingGone = collectibleRepo.ErrListingGone
+	// ErrItemMoved is returned when a listed item no longer belongs to the seller.
+	ErrItemMoved = collectibleRepo.ErrItemMoved
+)
+
+// BuyPack charges payment and mints item with the next serial number of its
+// template, in one transaction: either the user pays and gets the item, or
+// neither happens.
+func BuyPack(client *mongo.Client, payment PointsEntry, item collectible.Item) (collectible.Item, error) {
+	if client == nil {
+		return collectible.Item{}, fmt.Errorf("MongoDB client is nil")
+	}
+	if err := ensurePointAccount(client, payment.UserID); err != nil {
+		return collectible.Item{}, err
+	}
+
+	var minted collectible.Item
+	err := withTransaction(client, func(ctx mongo.SessionContext) error {
+		if err := applyPointsTx(ctx, client, payment); err != nil {
+			return err
+		}
+		var err error
+		minted, err = collectibleRepo.MintItemWithSerial(ctx, client, item)
+		return err
+	})
+	return minted, err
+}
+
+// BuyListing sells listing to payment.UserID in one transaction: the listing
+// is removed, the item changes hands, the buyer is charged payment and the
+// seller is paid sale. It fails with ErrListingGone when another buyer got
+// there first, ErrItemMoved when the seller no longer owns the item, and
+// ErrInsufficientPoints when the buyer cannot pay.
+func BuyListing(client *mongo.Client, listing collectible.MarketListing, payment, sale PointsEntry) error {
+	if client == nil {
+		return fmt.Errorf("MongoDB client is nil")
+	}
+	for _, userID := range []int{payment.UserID, sale.UserID} {
+		if err := ensurePointAccount(client, userID); err != nil {
+			return err
+		}
+	}
+
+	return withTransaction(client, func(ctx mongo.SessionContext) error {
+		if err := collectibleRepo.TransferListing(ctx, client, listing, payment.UserID); err != nil {
+			return err
+		}
+		if err := applyPointsTx(ctx, client, payment); err != nil {
+			return err
+		}
+		return applyPointsTx(ctx, client, sale)
+	})
+}
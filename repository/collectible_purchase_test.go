@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model/collectible"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoTestStore connects to MONGO_TEST_URI and points the repository at a
+// throwaway database. The transactional paths need a real server, and a
+// replica set at that, so the test is skipped when none is configured; a
+// single-node replica set (mongod --replSet rs0) is enough.
+func mongoTestStore(t *testing.T) *MongoStore {
+	t.Helper()
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI not set; skipping test against a real MongoDB replica set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := config.App.Mongo.Database
+	config.App.Mongo.Database = fmt.Sprintf("bot_test_%d", time.Now().UnixNano())
+	t.Cleanup(func() {
+		client.Database(config.App.Mongo.Database).Drop(context.Background())
+		config.App.Mongo.Database = saved
+		client.Disconnect(context.Background())
+	})
+	return NewMongoStore(client)
+}
+
+func TestMongoBuyListing(t *testing.T) {
+	store := mongoTestStore(t)
+
+	if _, err := store.ApplyPoints(PointsEntry{Key: "seed", UserID: 2, Amount: 300, Reason: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	item, err := store.MintItem(collectible.Item{TemplateID: "t1", SerialNumber: 1, OwnerID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listing := collectible.MarketListing{ID: "l1", ItemID: item.ID, SellerID: 1, SellerName: "seller", Price: 120}
+	if err := store.CreateListing(listing); err != nil {
+		t.Fatal(err)
+	}
+
+	payment := PointsEntry{Key: "l1:payment", UserID: 2, Name: "buyer", Amount: -120, Counterparty: 1}
+	sale := PointsEntry{Key: "l1:sale", UserID: 1, Name: "seller", Amount: 120, Counterparty: 2}
+	if err := store.BuyListing(listing, payment, sale); err != nil {
+		t.Fatalf("BuyListing = %v", err)
+	}
+	if err := store.BuyListing(listing, payment, sale); err != ErrListingGone {
+		t.Errorf("second BuyListing = %v; want ErrListingGone", err)
+	}
+
+	if got := store.GetCurrentPoints(2); got != 180 {
+		t.Errorf("buyer balance = %d; want 180", got)
+	}
+	if got := store.GetCurrentPoints(1); got != 120 {
+		t.Errorf("seller balance = %d; want 120", got)
+	}
+	if owned, _ := store.GetItemByID(item.ID); owned.OwnerID != 2 {
+		t.Errorf("item owner = %d; want 2", owned.OwnerID)
+	}
+
+	// The buyer cannot afford a second item: nothing may change hands.
+	other, _ := store.MintItem(collectible.Item{TemplateID: "t1", SerialNumber: 2, OwnerID: 1})
+	listing = collectible.MarketListing{ID: "l2", ItemID: other.ID, SellerID: 1, Price: 500}
+	store.CreateListing(listing)
+	payment = PointsEntry{Key: "l2:payment", UserID: 2, Amount: -500, Counterparty: 1}
+	sale = PointsEntry{Key: "l2:sale", UserID: 1, Amount: 500, Counterparty: 2}
+	if err := store.BuyListing(listing, payment, sale); err != ErrInsufficientPoints {
+		t.Errorf("BuyListing = %v; want ErrInsufficientPoints", err)
+	}
+	if owned, _ := store.GetItemByID(other.ID); owned.OwnerID != 1 {
+		t.Errorf("item moved to %d on a failed purchase", owned.OwnerID)
+	}
+	if _, err := store.GetListingByID("l2"); err != nil {
+		t.Errorf("listing gone after a failed purchase: %v", err)
+	}
+	if got := store.GetCurrentPoints(1); got != 120 {
+		t.Errorf("seller balance = %d after a failed purchase; want 120", got)
+	}
+}
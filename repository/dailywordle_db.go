@@ -0,0 +1,38 @@
+package repository
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// Settings-database collections of the Daily Wordle holding user data: each
+// player's streak, keyed by user ID, and each group's board of the day.
+const (
+	DailyWordleStreaksCollection = "DailyWordleStreaks"
+	DailyWordleBoardsCollection  = "DailyWordleBoards"
+)
+
+// dailyBoardDoc is a group's saved Daily Wordle board, as far as user data
+// goes.
+type dailyBoardDoc struct {
+	ChatID  int64             `bson:"_id"`
+	Day     int               `bson:"day"`
+	Entries []dailyBoardEntry `bson:"entries"`
+}
+
+type dailyBoardEntry struct {
+	ID      int    `bson:"id"`
+	Name    string `bson:"name"`
+	Guesses int    `bson:"guesses"`
+	Won     bool   `bson:"won"`
+}
+
+// dailyBoardEntriesOf returns the user's results on the groups' boards.
+func dailyBoardEntriesOf(boards []dailyBoardDoc, userID int) []bson.M {
+	var entries []bson.M
+	for _, b := range boards {
+		for _, e := range b.Entries {
+			if e.ID == userID {
+				entries = append(entries, bson.M{"chat_id": b.ChatID, "day": b.Day, "name": e.Name, "guesses": e.Guesses, "won": e.Won})
+			}
+		}
+	}
+	return entries
+}
@@ -4,12 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"net/url"
 	"sync"
 	"sync/atomic"
 
 	"time"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -42,9 +42,11 @@ func DbManager() *mongo.Client {
 	}
 
 	fmt.Print("into DBmanager")
-	passsword := "pass@123"
-	encodedPassword := url.QueryEscape(passsword)
-	clientOptions := options.Client().ApplyURI("mongodb+srv://Mkhan62608gmailcom:" + encodedPassword + "@cluster0.zuzzadg.mongodb.net/?retryWrites=true&w=majority")
+	if config.App.Mongo.URI == "" {
+		log.Print("MongoDB URI is not configured; set MONGO_URI or mongo.uri in the config file")
+		return nil
+	}
+	clientOptions := options.Client().ApplyURI(config.App.Mongo.URI)
 
 	// Connect to MongoDB
 	client, err := mongo.Connect(context.TODO(), clientOptions)
@@ -63,6 +65,19 @@ func DbManager() *mongo.Client {
 
 	return client
 }
+
+// Disconnect closes the shared MongoDB client if DbManager opened one.
+func Disconnect(ctx context.Context) error {
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+
+	client := clientInstance.Swap(nil)
+	if client == nil {
+		return nil
+	}
+	return client.Disconnect(ctx)
+}
+
 func InsertDoc(ID int, Name string, chatID int64, client *mongo.Client, collection string) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -76,7 +91,7 @@ func InsertDoc(ID int, Name string, chatID int64, client *mongo.Client, collecti
 	}
 
 	// Select the database and collections
-	database := client.Database("Telegram")
+	database := client.Database(config.App.Mongo.Database)
 	// movieCollection := database.Collection("CrocEn")
 	commentCollection := database.Collection(collection)
 
@@ -95,6 +110,7 @@ func InsertDoc(ID int, Name string, chatID int64, client *mongo.Client, collecti
 		{Key: "ID", Value: ID},
 		{Key: "Name", Value: Name},
 		{Key: "chat_ID", Value: chatID}, // Pass the ObjectId here
+		{Key: "created_at", Value: time.Now()},
 	}
 
 	// Insert the comment into the NewCollection
@@ -118,7 +134,7 @@ func InsertWordleBonusDoc(ID int, Name string, chatID int64, client *mongo.Clien
 		return
 	}
 
-	database := client.Database("Telegram")
+	database := client.Database(config.App.Mongo.Database)
 	commentCollection := database.Collection(collection)
 
 	comment := bson.D{
@@ -126,6 +142,7 @@ func InsertWordleBonusDoc(ID int, Name string, chatID int64, client *mongo.Clien
 		{Key: "Name", Value: Name},
 		{Key: "chat_ID", Value: chatID},
 		{Key: "Points", Value: points},
+		{Key: "created_at", Value: time.Now()},
 	}
 
 	insertResult, err := commentCollection.InsertOne(context.TODO(), comment)
@@ -148,7 +165,7 @@ func InsertWordleDoc(ID int, Name string, chatID int64, client *mongo.Client, co
 		return
 	}
 
-	database := client.Database("Telegram")
+	database := client.Database(config.App.Mongo.Database)
 	commentCollection := database.Collection(collection)
 
 	points := 25 - attempts + 1
@@ -161,6 +178,7 @@ func InsertWordleDoc(ID int, Name string, chatID int64, client *mongo.Client, co
 		{Key: "Name", Value: Name},
 		{Key: "chat_ID", Value: chatID},
 		{Key: "Points", Value: points},
+		{Key: "created_at", Value: time.Now()},
 	}
 
 	insertResult, err := commentCollection.InsertOne(context.TODO(), comment)
@@ -172,13 +190,14 @@ func InsertWordleDoc(ID int, Name string, chatID int64, client *mongo.Client, co
 }
 
 func ReadAllDoc(client *mongo.Client, collection string) []bson.M {
-	database := client.Database("Telegram")
+	database := client.Database(config.App.Mongo.Database)
 	// movieCollection := database.Collection("CrocEn")
 	commentCollection := database.Collection(collection)
 	// Optionally, print all comments from the collection
 	cursor, err := commentCollection.Find(context.TODO(), bson.D{})
 	if err != nil {
 		log.Println(err)
+		return nil
 	}
 	defer cursor.Close(context.TODO())
 	var results []bson.M
@@ -186,17 +205,18 @@ func ReadAllDoc(client *mongo.Client, collection string) []bson.M {
 		var result bson.M
 		if err := cursor.Decode(&result); err != nil {
 			log.Print(err)
+			continue
 		}
-		fmt.Println(result)
 		results = append(results, result)
 	}
 	return results
 
 }
 
-// Function to count occurrences of each ID along with the Name
-func CountIDOccurrences(client *mongo.Client, collection string, chatID int64) ([]map[string]interface{}, error) {
-	database := client.Database("Telegram")
+// Function to count occurrences of each ID along with the Name, counting only
+// the documents scored within window
+func CountIDOccurrences(client *mongo.Client, collection string, chatID int64, window Window) ([]map[string]interface{}, error) {
+	database := client.Database(config.App.Mongo.Database)
 	commentCollection := database.Collection(collection)
 
 	// Aggregation pipeline to count occurrences of each ID and include the Name
@@ -206,28 +226,16 @@ func CountIDOccurrences(client *mongo.Client, collection string, chatID int64) (
 	if chatID != 0 {
 		pipeline = append(pipeline, bson.D{{"$match", bson.D{{Key: "chat_ID", Value: chatID}}}})
 	}
-
-	var groupStage bson.D
-	if collection == "WordleEn" {
-		groupStage = bson.D{{"$group", bson.D{
-			{Key: "_id", Value: "$ID"},
-			{Key: "count", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$Points", 25}}}}}},
-			{Key: "Name", Value: bson.D{{Key: "$first", Value: "$Name"}}},
-		}}}
-	} else if collection == "ScramyEn" || collection == "GeographyPoints" || collection == "WordGridPoints" {
-		groupStage = bson.D{{"$group", bson.D{
-			{Key: "_id", Value: "$ID"},
-			{Key: "count", Value: bson.D{{Key: "$sum", Value: "$Points"}}},
-			{Key: "Name", Value: bson.D{{Key: "$first", Value: "$Name"}}},
-		}}}
-	} else {
-		groupStage = bson.D{{"$group", bson.D{
-			{Key: "_id", Value: "$ID"},
-			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
-			{Key: "Name", Value: bson.D{{Key: "$first", Value: "$Name"}}},
-		}}}
+	if filter := windowFilter(window); filter != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: filter}})
 	}
 
+	groupStage := bson.D{{Key: "$group", Value: bson.D{
+		{Key: "_id", Value: "$ID"},
+		{Key: "count", Value: bson.D{{Key: "$sum", Value: scoreExpr(collection)}}},
+		{Key: "Name", Value: bson.D{{Key: "$first", Value: "$Name"}}},
+	}}}
+
 	pipeline = append(pipeline,
 		// Group by ID, count occurrences (or points), and include Name
 		groupStage,
@@ -258,8 +266,23 @@ func CountIDOccurrences(client *mongo.Client, collection string, chatID int64) (
 
 	return results, nil
 }
+
+// scoreExpr is what one document of collection adds to a player's score:
+// its points in the points collections (25 for old Wordle wins without any),
+// otherwise 1 per win.
+func scoreExpr(collection string) interface{} {
+	switch collection {
+	case "WordleEn":
+		return bson.D{{Key: "$ifNull", Value: bson.A{"$Points", 25}}}
+	case "ScramyEn", "GeographyPoints", "WordGridPoints":
+		return "$Points"
+	default:
+		return 1
+	}
+}
+
 func InsertUserInfo(userInfo model.UserInfo, client *mongo.Client) {
-	database := client.Database("Telegram")
+	database := client.Database(config.App.Mongo.Database)
 	// movieCollection := database.Collection("CrocEn")
 	commentCollection := database.Collection("UserInfo")
 	commentCollection.InsertOne(context.TODO(), userInfo)
@@ -271,7 +294,7 @@ func SaveGameState(client *mongo.Client, collectionName string, chatID int64, st
 		return fmt.Errorf("MongoDB client is nil")
 	}
 
-	collection := client.Database("Telegram").Collection(collectionName)
+	collection := client.Database(config.App.Mongo.Database).Collection(collectionName)
 	filter := bson.M{"_id": chatID}
 	update := bson.M{"$set": state}
 	opts := options.Update().SetUpsert(true)
@@ -289,7 +312,7 @@ func LoadAllGameStates(client *mongo.Client, collectionName string, target inter
 		return fmt.Errorf("MongoDB client is nil")
 	}
 
-	collection := client.Database("Telegram").Collection(collectionName)
+	collection := client.Database(config.App.Mongo.Database).Collection(collectionName)
 	cursor, err := collection.Find(context.TODO(), bson.M{})
 	if err != nil {
 		return err
@@ -301,7 +324,7 @@ func LoadAllGameStates(client *mongo.Client, collectionName string, target inter
 
 // GetUserStatsByID returns the count and name for a specific user ID from the given collection
 func GetUserStatsByID(client *mongo.Client, collection string, userID int) (map[string]interface{}, error) {
-	database := client.Database("Telegram")
+	database := client.Database(config.App.Mongo.Database)
 	commentCollection := database.Collection(collection)
 
 	var groupStage bson.D
@@ -355,7 +378,7 @@ func HasFreeEordle(client *mongo.Client, userID int) bool {
 		return false
 	}
 
-	database := client.Database("Telegram")
+	database := client.Database(config.App.Mongo.Database)
 	collection := database.Collection("EordleUsage")
 
 	today := time.Now().Format("2006-01-02")
@@ -384,7 +407,7 @@ func UseFreeEordle(client *mongo.Client, userID int) {
 		return
 	}
 
-	database := client.Database("Telegram")
+	database := client.Database(config.App.Mongo.Database)
 	collection := database.Collection("EordleUsage")
 
 	today := time.Now().Format("2006-01-02")
@@ -400,36 +423,9 @@ func UseFreeEordle(client *mongo.Client, userID int) {
 	}
 }
 
-// GetCurrentPoints gets the current points of a user from WordleEn collection
-func GetCurrentPoints(client *mongo.Client, userID int) int {
-	stats, err := GetUserStatsByID(client, "WordleEn", userID)
-	if err != nil {
-		return 0
-	}
-
-	if val, ok := stats["count"]; ok {
-		switch v := val.(type) {
-		case int32:
-			return int(v)
-		case int64:
-			return int(v)
-		case int:
-			return v
-		case float64:
-			return int(v)
-		}
-	}
-	return 0
-}
-
-// DeductWordlePoints deducts a given amount of points from a user
-func DeductWordlePoints(client *mongo.Client, userID int, name string, chatID int64, points int) {
-	InsertWordleBonusDoc(userID, name, chatID, client, "WordleEn", -points)
-}
-
 // GetEquippedEmojis returns the list of emojis equipped by a user
 func GetEquippedEmojis(client *mongo.Client, userID int) ([]string, error) {
-	database := client.Database("Telegram")
+	database := client.Database(config.App.Mongo.Database)
 	collection := database.Collection("UserEmojis")
 
 	filter := bson.M{"UserID": userID}
@@ -449,7 +445,7 @@ func GetEquippedEmojis(client *mongo.Client, userID int) ([]string, error) {
 
 // GetPurchasedEmojis returns the list of emojis purchased by a user
 func GetPurchasedEmojis(client *mongo.Client, userID int) ([]string, error) {
-	database := client.Database("Telegram")
+	database := client.Database(config.App.Mongo.Database)
 	collection := database.Collection("UserEmojis")
 
 	filter := bson.M{"UserID": userID}
@@ -469,7 +465,7 @@ func GetPurchasedEmojis(client *mongo.Client, userID int) ([]string, error) {
 
 // PurchaseEmoji adds an emoji to the user's purchased list
 func PurchaseEmoji(client *mongo.Client, userID int, emoji string) error {
-	database := client.Database("Telegram")
+	database := client.Database(config.App.Mongo.Database)
 	collection := database.Collection("UserEmojis")
 
 	filter := bson.M{"UserID": userID}
@@ -484,7 +480,7 @@ func PurchaseEmoji(client *mongo.Client, userID int, emoji string) error {
 
 // ToggleEquipEmoji toggles whether an emoji is equipped for a user
 func ToggleEquipEmoji(client *mongo.Client, userID int, emoji string) (bool, error) {
-	database := client.Database("Telegram")
+	database := client.Database(config.App.Mongo.Database)
 	collection := database.Collection("UserEmojis")
 
 	filter := bson.M{"UserID": userID}
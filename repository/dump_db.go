@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Bulk access to whole collections, for exports, imports and backups.
+
+// ErrDatabaseNotEmpty is returned by a restore into a database that already
+// holds documents in one of the restored collections.
+var ErrDatabaseNotEmpty = errors.New("database is not empty")
+
+// The fields documents keep their chat and user in. Scores were written before
+// the naming settled, so several spellings are in use.
+var (
+	chatFields = []string{"chat_ID", "chat_id"}
+	userFields = []string{"ID", "user_id", "owner_id"}
+)
+
+// DocFilter selects documents by chat, user and time. Zero fields match
+// everything. The time range is [Since, Until) and applies to when a document
+// was written, as for leaderboard windows.
+type DocFilter struct {
+	ChatID int64
+	UserID int
+	Since  time.Time
+	Until  time.Time
+}
+
+// BSON returns the MongoDB filter for f.
+func (f DocFilter) BSON() bson.D {
+	var and bson.A
+	anyOf := func(fields []string, value any) {
+		or := bson.A{}
+		for _, field := range fields {
+			or = append(or, bson.D{{Key: field, Value: value}})
+		}
+		and = append(and, bson.D{{Key: "$or", Value: or}})
+	}
+	if f.ChatID != 0 {
+		anyOf(chatFields, f.ChatID)
+	}
+	if f.UserID != 0 {
+		anyOf(userFields, f.UserID)
+	}
+	if t := timeRangeFilter(f.Since, f.Until); t != nil {
+		and = append(and, t)
+	}
+	if len(and) == 0 {
+		return bson.D{}
+	}
+	return bson.D{{Key: "$and", Value: and}}
+}
+
+// Collections returns the names of the collections in database, sorted.
+// System collections are left out.
+func Collections(ctx context.Context, client *mongo.Client, database string) ([]string, error) {
+	names, err := client.Database(database).ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	kept := names[:0]
+	for _, name := range names {
+		if !strings.HasPrefix(name, "system.") {
+			kept = append(kept, name)
+		}
+	}
+	sort.Strings(kept)
+	return kept, nil
+}
+
+// EachDoc calls fn with every document of the collection that matches filter,
+// in _id order. It stops at the first error fn returns. The document is only
+// valid until fn returns; copy it to keep it.
+func EachDoc(ctx context.Context, client *mongo.Client, database, collection string, filter bson.D, fn func(bson.Raw) error) error {
+	if filter == nil {
+		filter = bson.D{}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := client.Database(database).Collection(collection).Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		if err := fn(cursor.Current); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// UpsertResult counts what UpsertDocs did.
+type UpsertResult struct {
+	Inserted int
+	Replaced int
+}
+
+// UpsertDocs writes docs to the collection, replacing any document with the
+// same _id, so importing the same documents twice leaves one copy of each.
+// Every document must have an _id.
+func UpsertDocs(ctx context.Context, client *mongo.Client, database, collection string, docs []bson.Raw) (UpsertResult, error) {
+	if len(docs) == 0 {
+		return UpsertResult{}, nil
+	}
+	models := make([]mongo.WriteModel, len(docs))
+	for i, doc := range docs {
+		id, err := doc.LookupErr("_id")
+		if err != nil {
+			return UpsertResult{}, fmt.Errorf("document %d has no _id", i+1)
+		}
+		models[i] = mongo.NewReplaceOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: id}}).
+			SetReplacement(doc).
+			SetUpsert(true)
+	}
+	res, err := client.Database(database).Collection(collection).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return UpsertResult{}, err
+	}
+	return UpsertResult{Inserted: int(res.UpsertedCount), Replaced: int(res.MatchedCount)}, nil
+}
+
+// InsertDocs adds docs to the collection as they are.
+func InsertDocs(ctx context.Context, client *mongo.Client, database, collection string, docs []bson.Raw) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	batch := make([]interface{}, len(docs))
+	for i, doc := range docs {
+		batch[i] = doc
+	}
+	_, err := client.Database(database).Collection(collection).InsertMany(ctx, batch, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+// EnsureEmpty returns ErrDatabaseNotEmpty if any of the collections in
+// database holds a document.
+func EnsureEmpty(ctx context.Context, client *mongo.Client, database string, collections []string) error {
+	for _, name := range collections {
+		n, err := client.Database(database).Collection(name).CountDocuments(ctx, bson.D{}, options.Count().SetLimit(1))
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return fmt.Errorf("%w: %s.%s has documents", ErrDatabaseNotEmpty, database, name)
+		}
+	}
+	return nil
+}
+
+// Snapshot runs fn with a context whose reads all see the data as it was at
+// one point in time, so documents written while fn runs are not half
+// included. Like transactions, snapshot reads need a replica set.
+func Snapshot(ctx context.Context, client *mongo.Client, fn func(ctx context.Context) error) error {
+	session, err := client.StartSession(options.Session().SetSnapshot(true))
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+	return fn(mongo.NewSessionContext(ctx, session))
+}
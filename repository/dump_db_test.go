@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestDocFilter(t *testing.T) {
+	if f := (DocFilter{}).BSON(); len(f) != 0 {
+		t.Errorf("empty filter = %v, want no conditions", f)
+	}
+
+	f := DocFilter{ChatID: -100, UserID: 7, Since: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}.BSON()
+	if len(f) != 1 || f[0].Key != "$and" {
+		t.Fatalf("filter = %v, want one $and", f)
+	}
+	and := f[0].Value.(bson.A)
+	if len(and) != 3 {
+		t.Fatalf("got %d conditions, want chat, user and time", len(and))
+	}
+	users := and[1].(bson.D)[0].Value.(bson.A)
+	var fields []string
+	for _, cond := range users {
+		fields = append(fields, cond.(bson.D)[0].Key)
+	}
+	if len(fields) != len(userFields) || fields[0] != "ID" {
+		t.Errorf("user matched on %v, want %v", fields, userFields)
+	}
+}
@@ -5,13 +5,12 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-const ephemeralDatabase = "Telegram"
-
 // ---- EphemeralRequests ----
 // Tracks the pending /ephemeral prompt each user has open, so a following reply
 // can be routed as a whisper. Replaces the in-memory ephemeralRequests map.
@@ -26,7 +25,7 @@ func UpsertEphemeralRequest(client *mongo.Client, userID int64, chatID int64, ep
 	if client == nil {
 		return
 	}
-	coll := client.Database(ephemeralDatabase).Collection("EphemeralRequests")
+	coll := client.Database(config.App.Mongo.Database).Collection("EphemeralRequests")
 	_, err := coll.UpdateOne(context.TODO(),
 		bson.M{"_id": userID},
 		bson.M{"$set": bson.M{"chat_id": chatID, "ephemeral_msg_id": ephemeralMsgID}},
@@ -42,7 +41,7 @@ func TakeEphemeralRequest(client *mongo.Client, userID int64) (*EphemeralRequest
 	if client == nil {
 		return nil, fmt.Errorf("nil mongo client")
 	}
-	coll := client.Database(ephemeralDatabase).Collection("EphemeralRequests")
+	coll := client.Database(config.App.Mongo.Database).Collection("EphemeralRequests")
 	var doc EphemeralRequestDoc
 	err := coll.FindOneAndDelete(context.TODO(), bson.M{"_id": userID}).Decode(&doc)
 	if err == mongo.ErrNoDocuments {
@@ -66,7 +65,7 @@ func UpsertKnownUser(client *mongo.Client, username string, userID int64) {
 	if client == nil || username == "" {
 		return
 	}
-	coll := client.Database(ephemeralDatabase).Collection("KnownUserIDs")
+	coll := client.Database(config.App.Mongo.Database).Collection("KnownUserIDs")
 	_, err := coll.UpdateOne(context.TODO(),
 		bson.M{"_id": username},
 		bson.M{"$set": bson.M{"user_id": userID}},
@@ -80,7 +79,7 @@ func GetKnownUser(client *mongo.Client, username string) (int64, bool) {
 	if client == nil || username == "" {
 		return 0, false
 	}
-	coll := client.Database(ephemeralDatabase).Collection("KnownUserIDs")
+	coll := client.Database(config.App.Mongo.Database).Collection("KnownUserIDs")
 	var doc KnownUserDoc
 	err := coll.FindOne(context.TODO(), bson.M{"_id": username}).Decode(&doc)
 	if err != nil {
@@ -116,7 +115,7 @@ func AddStoredWhisper(client *mongo.Client, userID int64, w StoredWhisperDoc) {
 	if client == nil {
 		return
 	}
-	coll := client.Database(ephemeralDatabase).Collection("StoredWhispers")
+	coll := client.Database(config.App.Mongo.Database).Collection("StoredWhispers")
 	_, err := coll.UpdateOne(context.TODO(),
 		bson.M{"_id": userID},
 		bson.M{"$push": bson.M{"whispers": w}},
@@ -131,7 +130,7 @@ func TakeStoredWhispers(client *mongo.Client, userID int64) []StoredWhisperDoc {
 	if client == nil {
 		return nil
 	}
-	coll := client.Database(ephemeralDatabase).Collection("StoredWhispers")
+	coll := client.Database(config.App.Mongo.Database).Collection("StoredWhispers")
 	var doc StoredWhispersDoc
 	err := coll.FindOneAndDelete(context.TODO(), bson.M{"_id": userID}).Decode(&doc)
 	if err != nil {
@@ -156,7 +155,7 @@ func UpsertWhisperSource(client *mongo.Client, src WhisperSourceDoc) {
 	if client == nil {
 		return
 	}
-	coll := client.Database(ephemeralDatabase).Collection("WhisperEphemeralSources")
+	coll := client.Database(config.App.Mongo.Database).Collection("WhisperEphemeralSources")
 	_, err := coll.UpdateOne(context.TODO(),
 		bson.M{"_id": src.EphemeralMsgID},
 		bson.M{"$set": bson.M{
@@ -176,7 +175,7 @@ func TakeWhisperSource(client *mongo.Client, ephemeralMsgID int64) (*WhisperSour
 	if client == nil {
 		return nil, fmt.Errorf("nil mongo client")
 	}
-	coll := client.Database(ephemeralDatabase).Collection("WhisperEphemeralSources")
+	coll := client.Database(config.App.Mongo.Database).Collection("WhisperEphemeralSources")
 	var doc WhisperSourceDoc
 	err := coll.FindOneAndDelete(context.TODO(), bson.M{"_id": ephemeralMsgID}).Decode(&doc)
 	if err == mongo.ErrNoDocuments {
@@ -201,7 +200,7 @@ func SetLatestUserEphemeral(client *mongo.Client, userID int64, ephemeralMsgID i
 	if client == nil || userID == 0 || ephemeralMsgID == 0 {
 		return
 	}
-	coll := client.Database(ephemeralDatabase).Collection("LatestUserEphemeral")
+	coll := client.Database(config.App.Mongo.Database).Collection("LatestUserEphemeral")
 	_, err := coll.UpdateOne(context.TODO(),
 		bson.M{"_id": userID},
 		bson.M{"$set": bson.M{"ephemeral_msg_id": ephemeralMsgID}},
@@ -215,7 +214,7 @@ func GetLatestUserEphemeral(client *mongo.Client, userID int64) int64 {
 	if client == nil {
 		return 0
 	}
-	coll := client.Database(ephemeralDatabase).Collection("LatestUserEphemeral")
+	coll := client.Database(config.App.Mongo.Database).Collection("LatestUserEphemeral")
 	var doc LatestEphemeralDoc
 	err := coll.FindOne(context.TODO(), bson.M{"_id": userID}).Decode(&doc)
 	if err != nil {
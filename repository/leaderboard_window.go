@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Window is the period a leaderboard is ranked over. Periods are calendar
+// ones in UTC: today, this week (from Monday) and this month, or the current
+// season as configured in config.App.Seasons.
+type Window string
+
+const (
+	WindowDay    Window = "day"
+	WindowWeek   Window = "week"
+	WindowMonth  Window = "month"
+	WindowSeason Window = "season"
+	WindowAll    Window = "all"
+)
+
+// Windows lists the windows in the order they are offered to users.
+var Windows = []Window{WindowDay, WindowWeek, WindowMonth, WindowSeason, WindowAll}
+
+// ParseWindow returns the window called s, or WindowAll if there is none.
+func ParseWindow(s string) Window {
+	for _, w := range Windows {
+		if string(w) == s {
+			return w
+		}
+	}
+	return WindowAll
+}
+
+// Label is the window's name as shown on buttons and titles.
+func (w Window) Label() string {
+	switch w {
+	case WindowDay:
+		return "Today"
+	case WindowWeek:
+		return "This Week"
+	case WindowMonth:
+		return "This Month"
+	case WindowSeason:
+		return "This Season"
+	default:
+		return "All Time"
+	}
+}
+
+// Since returns when the window containing now started. It returns the zero
+// time for WindowAll, and for WindowSeason before season 1 has started.
+func (w Window) Since(now time.Time) time.Time {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch w {
+	case WindowDay:
+		return day
+	case WindowWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case WindowMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case WindowSeason:
+		n := config.App.Seasons.Number(now)
+		if n == 0 {
+			return time.Time{}
+		}
+		start, _ := config.App.Seasons.Bounds(n)
+		return start
+	default:
+		return time.Time{}
+	}
+}
+
+// windowFilter matches the scoring documents written since the start of w.
+func windowFilter(w Window) bson.D {
+	return timeRangeFilter(w.Since(time.Now()), time.Time{})
+}
+
+// timeRangeFilter matches the scoring documents written in [since, until); a
+// zero bound is open. Documents from before scores were timestamped have no
+// created_at; their ObjectID, which embeds the insert time, is used instead.
+func timeRangeFilter(since, until time.Time) bson.D {
+	if since.IsZero() && until.IsZero() {
+		return nil
+	}
+	createdAt, objectID := bson.D{}, bson.D{}
+	if !since.IsZero() {
+		createdAt = append(createdAt, bson.E{Key: "$gte", Value: since})
+		objectID = append(objectID, bson.E{Key: "$gte", Value: primitive.NewObjectIDFromTimestamp(since)})
+	}
+	if !until.IsZero() {
+		createdAt = append(createdAt, bson.E{Key: "$lt", Value: until})
+		objectID = append(objectID, bson.E{Key: "$lt", Value: primitive.NewObjectIDFromTimestamp(until)})
+	}
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "created_at", Value: createdAt}},
+		bson.D{
+			{Key: "created_at", Value: bson.D{{Key: "$exists", Value: false}}},
+			{Key: "_id", Value: objectID},
+		},
+	}}}
+}
+
+// scoredAt returns when doc was written, the in-memory counterpart of
+// timeRangeFilter.
+func scoredAt(doc bson.M) time.Time {
+	if t, ok := doc["created_at"].(time.Time); ok {
+		return t
+	}
+	if id, ok := doc["_id"].(primitive.ObjectID); ok {
+		return id.Timestamp()
+	}
+	return time.Time{}
+}
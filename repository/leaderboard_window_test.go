@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWindowSince(t *testing.T) {
+	// A Wednesday afternoon.
+	now := time.Date(2026, time.March, 18, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		w    Window
+		want time.Time
+	}{
+		{WindowDay, time.Date(2026, time.March, 18, 0, 0, 0, 0, time.UTC)},
+		{WindowWeek, time.Date(2026, time.March, 16, 0, 0, 0, 0, time.UTC)},
+		{WindowMonth, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{WindowAll, time.Time{}},
+	}
+	for _, tt := range tests {
+		if got := tt.w.Since(now); !got.Equal(tt.want) {
+			t.Errorf("%s.Since = %v; want %v", tt.w, got, tt.want)
+		}
+	}
+
+	// On a Sunday the week still started on Monday.
+	sunday := time.Date(2026, time.March, 22, 23, 0, 0, 0, time.UTC)
+	if got := WindowWeek.Since(sunday); !got.Equal(time.Date(2026, time.March, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("week of Sunday started %v", got)
+	}
+	if ParseWindow("fortnight") != WindowAll || ParseWindow("week") != WindowWeek {
+		t.Error("ParseWindow did not fall back to all time")
+	}
+}
+
+func TestMemoryStoreWindowedLeaderboard(t *testing.T) {
+	store := NewMemoryStore()
+	store.InsertWordleBonusDoc(1, "alice", 100, "WordleEn", 5)
+
+	// An old score, and one from before scores were timestamped.
+	lastYear := time.Now().AddDate(-1, 0, 0)
+	store.docs["WordleEn"] = append(store.docs["WordleEn"],
+		bson.M{"_id": primitive.NewObjectID(), "ID": 2, "Name": "bob", "chat_ID": int64(100), "Points": 50, "created_at": lastYear},
+		bson.M{"_id": primitive.NewObjectIDFromTimestamp(lastYear), "ID": 3, "Name": "carol", "chat_ID": int64(100), "Points": 40},
+	)
+
+	all, _ := store.CountIDOccurrences("WordleEn", 100, WindowAll)
+	if len(all) != 3 || all[0]["Name"] != "bob" {
+		t.Errorf("all-time leaderboard = %v", all)
+	}
+	for _, w := range []Window{WindowDay, WindowWeek, WindowMonth} {
+		got, _ := store.CountIDOccurrences("WordleEn", 100, w)
+		if len(got) != 1 || got[0]["Name"] != "alice" {
+			t.Errorf("%s leaderboard = %v; want only alice", w, got)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The points ledger. PointAccounts holds one stored balance per user and
+// PointEntries the history those balances are built from. Every entry has an
+// idempotency key as its _id, so retrying an operation never applies it twice.
+const (
+	PointAccountsCollection = "PointAccounts"
+	PointEntriesCollection  = "PointEntries"
+)
+
+var (
+	// ErrInsufficientPoints is returned when a debit would take a balance below zero.
+	ErrInsufficientPoints = errors.New("not enough points")
+	// ErrDuplicateEntry is returned when an entry with the same key was already applied.
+	ErrDuplicateEntry = errors.New("points entry already applied")
+)
+
+// PointsEntry is one movement of points on a user's account.
+type PointsEntry struct {
+	Key          string    `bson:"_id"`
+	UserID       int       `bson:"user_id"`
+	Name         string    `bson:"name"`
+	ChatID       int64     `bson:"chat_id"`
+	Amount       int       `bson:"amount"` // credits are positive, debits negative
+	Reason       string    `bson:"reason"`
+	Counterparty int       `bson:"counterparty,omitempty"` // the other user of a transfer
+	Applied      bool      `bson:"applied"`
+	CreatedAt    time.Time `bson:"created_at"`
+}
+
+// PointAccount is a user's stored balance.
+type PointAccount struct {
+	UserID    int       `bson:"_id"`
+	Balance   int       `bson:"balance"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// OpeningBalanceKey is the key of the entry that carries over a user's points
+// from before the ledger existed.
+func OpeningBalanceKey(userID int) string {
+	return fmt.Sprintf("opening:%d", userID)
+}
+
+// GetCurrentPoints returns the user's spendable balance.
+func GetCurrentPoints(client *mongo.Client, userID int) int {
+	if client == nil {
+		return 0
+	}
+	if err := ensurePointAccount(client, userID); err != nil {
+		log.Printf("Failed to open points account for user %d: %v", userID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var account PointAccount
+	err := pointAccounts(client).FindOne(ctx, bson.M{"_id": userID}).Decode(&account)
+	if err != nil {
+		return 0
+	}
+	return account.Balance
+}
+
+// ApplyPoints records entry and moves the user's balance by entry.Amount. A
+// debit only goes through if the balance covers it; otherwise nothing is
+// recorded and ErrInsufficientPoints is returned. If an entry with the same
+// key exists, nothing changes and ErrDuplicateEntry is returned. The new (or
+// current) balance is returned either way.
+//
+// The entry is written first and marked applied once the balance has moved,
+// so ReconcilePoints can tell a half-applied entry from a complete one.
+func ApplyPoints(client *mongo.Client, entry PointsEntry) (int, error) {
+	if client == nil {
+		return 0, fmt.Errorf("MongoDB client is nil")
+	}
+	if err := ensurePointAccount(client, entry.UserID); err != nil {
+		return 0, err
+	}
+	return applyPoints(client, entry)
+}
+
+func applyPoints(client *mongo.Client, entry PointsEntry) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	entry.Applied = false
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	if _, err := pointEntries(client).InsertOne(ctx, entry); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return currentBalance(ctx, client, entry.UserID), ErrDuplicateEntry
+		}
+		return 0, err
+	}
+
+	filter := bson.M{"_id": entry.UserID}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if entry.Amount < 0 {
+		filter["balance"] = bson.M{"$gte": -entry.Amount}
+	} else {
+		opts.SetUpsert(true)
+	}
+	update := bson.M{
+		"$inc": bson.M{"balance": entry.Amount},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	var account PointAccount
+	err := pointAccounts(client).FindOneAndUpdate(ctx, filter, update, opts).Decode(&account)
+	if err != nil {
+		pointEntries(client).DeleteOne(ctx, bson.M{"_id": entry.Key})
+		if err == mongo.ErrNoDocuments {
+			return currentBalance(ctx, client, entry.UserID), ErrInsufficientPoints
+		}
+		return 0, err
+	}
+
+	if _, err := pointEntries(client).UpdateOne(ctx, bson.M{"_id": entry.Key}, bson.M{"$set": bson.M{"applied": true}}); err != nil {
+		log.Printf("Failed to mark points entry %s applied: %v", entry.Key, err)
+	}
+	return account.Balance, nil
+}
+
+// applyPointsTx is applyPoints for use inside a transaction: a failed debit
+// aborts the whole transaction, so the entry is written as applied up front.
+func applyPointsTx(ctx context.Context, client *mongo.Client, entry PointsEntry) error {
+	entry.Applied = true
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	if _, err := pointEntries(client).InsertOne(ctx, entry); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrDuplicateEntry
+		}
+		return err
+	}
+
+	filter := bson.M{"_id": entry.UserID}
+	opts := options.Update()
+	if entry.Amount < 0 {
+		filter["balance"] = bson.M{"$gte": -entry.Amount}
+	} else {
+		opts.SetUpsert(true)
+	}
+	res, err := pointAccounts(client).UpdateOne(ctx, filter, bson.M{
+		"$inc": bson.M{"balance": entry.Amount},
+		"$set": bson.M{"updated_at": time.Now()},
+	}, opts)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 && res.UpsertedCount == 0 {
+		return ErrInsufficientPoints
+	}
+	return nil
+}
+
+// ReconcilePoints rebuilds every stored balance from the applied entries and
+// returns how many balances were wrong.
+func ReconcilePoints(client *mongo.Client) (int, error) {
+	if client == nil {
+		return 0, fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	cursor, err := pointEntries(client).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"applied": true}}},
+		{{Key: "$group", Value: bson.M{"_id": "$user_id", "balance": bson.M{"$sum": "$amount"}}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	var totals []PointAccount
+	if err := cursor.All(ctx, &totals); err != nil {
+		return 0, err
+	}
+
+	fixed := 0
+	for _, want := range totals {
+		var have PointAccount
+		err := pointAccounts(client).FindOne(ctx, bson.M{"_id": want.UserID}).Decode(&have)
+		if err == nil && have.Balance == want.Balance {
+			continue
+		}
+		if err != nil && err != mongo.ErrNoDocuments {
+			return fixed, err
+		}
+		_, err = pointAccounts(client).UpdateOne(ctx,
+			bson.M{"_id": want.UserID},
+			bson.M{"$set": bson.M{"balance": want.Balance, "updated_at": time.Now()}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return fixed, err
+		}
+		log.Printf("Reconciled points of user %d: %d -> %d", want.UserID, have.Balance, want.Balance)
+		fixed++
+	}
+	return fixed, nil
+}
+
+// ensurePointAccount opens the user's account on first use, carrying over the
+// points they had in WordleEn before the ledger existed. A negative carry-over,
+// left by double-spends under the old scheme, opens at zero.
+func ensurePointAccount(client *mongo.Client, userID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	n, err := pointAccounts(client).CountDocuments(ctx, bson.M{"_id": userID}, options.Count().SetLimit(1))
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+
+	_, err = applyPoints(client, PointsEntry{
+		Key:    OpeningBalanceKey(userID),
+		UserID: userID,
+		Amount: max(legacyPoints(client, userID), 0),
+		Reason: "Opening balance",
+	})
+	if err == ErrDuplicateEntry {
+		// Another call is opening the account at the same time. Wait for its
+		// balance to land, or a debit right after this could fail against an
+		// account it has not credited yet.
+		waitApplied(client, OpeningBalanceKey(userID))
+		return nil
+	}
+	return err
+}
+
+// waitApplied waits a few seconds at most for the entry with key to be
+// marked applied, then gives up quietly.
+func waitApplied(client *mongo.Client, key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for {
+		n, err := pointEntries(client).CountDocuments(ctx, bson.M{"_id": key, "applied": true}, options.Count().SetLimit(1))
+		if err != nil || n > 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+func currentBalance(ctx context.Context, client *mongo.Client, userID int) int {
+	var account PointAccount
+	if err := pointAccounts(client).FindOne(ctx, bson.M{"_id": userID}).Decode(&account); err != nil {
+		return 0
+	}
+	return account.Balance
+}
+
+// legacyPoints sums the user's WordleEn documents, which is where points were
+// kept before the ledger.
+func legacyPoints(client *mongo.Client, userID int) int {
+	stats, err := GetUserStatsByID(client, "WordleEn", userID)
+	if err != nil {
+		return 0
+	}
+
+	if val, ok := stats["count"]; ok {
+		switch v := val.(type) {
+		case int32:
+			return int(v)
+		case int64:
+			return int(v)
+		case int:
+			return v
+		case float64:
+			return int(v)
+		}
+	}
+	return 0
+}
+
+func pointAccounts(client *mongo.Client) *mongo.Collection {
+	return client.Database(config.App.Mongo.Database).Collection(PointAccountsCollection)
+}
+
+func pointEntries(client *mongo.Client) *mongo.Collection {
+	return client.Database(config.App.Mongo.Database).Collection(PointEntriesCollection)
+}
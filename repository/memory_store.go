@@ -0,0 +1,1285 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model/collectible"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryStore is an in-process Store. It mirrors the semantics of the Mongo
+// helpers (point aggregation rules, upserts, find-and-delete) closely enough
+// for unit tests and local runs without a cluster. All data is lost on exit.
+type MemoryStore struct {
+	mu sync.Mutex
+
+	docs     map[string][]bson.M
+	states   map[string]map[int64]bson.M
+	settings map[string]map[int64]bson.M
+	eordle   map[string]bool
+
+	pointAccounts map[int]int
+	pointEntries  map[string]PointsEntry
+
+	seasons     map[int]model.Season
+	firstSeason int
+	ratings     map[string]Rating
+
+	profiles map[int64]*model.UserProfile
+	emojis   map[int]*memoryEmojis
+
+	templates []collectible.Template
+	items     map[string]collectible.Item
+	listings  map[string]collectible.MarketListing
+	counters  map[string]int
+
+	ephemeralRequests map[int64]EphemeralRequestDoc
+	knownUsers        map[string]int64
+	storedWhispers    map[int64][]StoredWhisperDoc
+	whisperSources    map[int64]WhisperSourceDoc
+	latestEphemeral   map[int64]int64
+
+	wordPacks map[string]WordPack
+}
+
+type memoryEmojis struct {
+	Purchased []string
+	Equipped  []string
+}
+
+// NewMemoryStore returns an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		docs:              make(map[string][]bson.M),
+		states:            make(map[string]map[int64]bson.M),
+		settings:          make(map[string]map[int64]bson.M),
+		eordle:            make(map[string]bool),
+		pointAccounts:     make(map[int]int),
+		pointEntries:      make(map[string]PointsEntry),
+		seasons:           make(map[int]model.Season),
+		ratings:           make(map[string]Rating),
+		profiles:          make(map[int64]*model.UserProfile),
+		emojis:            make(map[int]*memoryEmojis),
+		items:             make(map[string]collectible.Item),
+		listings:          make(map[string]collectible.MarketListing),
+		counters:          make(map[string]int),
+		wordPacks:         make(map[string]WordPack),
+		ephemeralRequests: make(map[int64]EphemeralRequestDoc),
+		knownUsers:        make(map[string]int64),
+		storedWhispers:    make(map[int64][]StoredWhisperDoc),
+		whisperSources:    make(map[int64]WhisperSourceDoc),
+		latestEphemeral:   make(map[int64]int64),
+	}
+}
+
+// ---- Points ----
+
+func (s *MemoryStore) insert(collection string, doc bson.M) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc["_id"] = primitive.NewObjectID()
+	doc["created_at"] = time.Now()
+	s.docs[collection] = append(s.docs[collection], doc)
+}
+
+func (s *MemoryStore) InsertDoc(ID int, Name string, chatID int64, collection string) {
+	s.insert(collection, bson.M{"ID": ID, "Name": Name, "chat_ID": chatID})
+}
+
+func (s *MemoryStore) InsertWordleDoc(ID int, Name string, chatID int64, collection string, attempts int) {
+	points := 25 - attempts + 1
+	if points < 1 {
+		points = 1
+	}
+	s.insert(collection, bson.M{"ID": ID, "Name": Name, "chat_ID": chatID, "Points": points})
+}
+
+func (s *MemoryStore) InsertWordleBonusDoc(ID int, Name string, chatID int64, collection string, points int) {
+	s.insert(collection, bson.M{"ID": ID, "Name": Name, "chat_ID": chatID, "Points": points})
+}
+
+func (s *MemoryStore) ReadAllDoc(collection string) []bson.M {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := make([]bson.M, 0, len(s.docs[collection]))
+	for _, doc := range s.docs[collection] {
+		results = append(results, copyDoc(doc))
+	}
+	return results
+}
+
+// docScore returns what a single document contributes to a leaderboard,
+// following the same per-collection rules as the aggregation pipelines.
+func docScore(collection string, doc bson.M) int {
+	switch collection {
+	case "WordleEn":
+		if p, ok := doc["Points"]; ok {
+			return toInt(p)
+		}
+		return 25
+	case "ScramyEn", "GeographyPoints", "WordGridPoints":
+		return toInt(doc["Points"])
+	default:
+		return 1
+	}
+}
+
+func (s *MemoryStore) group(collection string, match func(bson.M) bool) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var order []int
+	totals := make(map[int]int)
+	names := make(map[int]interface{})
+	for _, doc := range s.docs[collection] {
+		if !match(doc) {
+			continue
+		}
+		id := toInt(doc["ID"])
+		if _, seen := totals[id]; !seen {
+			order = append(order, id)
+			names[id] = doc["Name"]
+		}
+		totals[id] += docScore(collection, doc)
+	}
+
+	results := make([]map[string]interface{}, 0, len(order))
+	for _, id := range order {
+		results = append(results, map[string]interface{}{
+			"_id":   int32(id),
+			"count": int32(totals[id]),
+			"Name":  names[id],
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i]["count"].(int32) > results[j]["count"].(int32)
+	})
+	return results
+}
+
+func (s *MemoryStore) CountIDOccurrences(collection string, chatID int64, window Window) ([]map[string]interface{}, error) {
+	since := window.Since(time.Now())
+	return s.group(collection, func(doc bson.M) bool {
+		return (chatID == 0 || toInt64(doc["chat_ID"]) == chatID) && !scoredAt(doc).Before(since)
+	}), nil
+}
+
+func (s *MemoryStore) GetUserStatsByID(collection string, userID int) (map[string]interface{}, error) {
+	results := s.group(collection, func(doc bson.M) bool {
+		return toInt(doc["ID"]) == userID
+	})
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no stats found for user ID %d", userID)
+	}
+	return results[0], nil
+}
+
+// ---- Points ledger ----
+
+func (s *MemoryStore) GetCurrentPoints(userID int) int {
+	s.ensurePointAccount(userID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pointAccounts[userID]
+}
+
+func (s *MemoryStore) ApplyPoints(entry PointsEntry) (int, error) {
+	s.ensurePointAccount(entry.UserID)
+	return s.applyPoints(entry)
+}
+
+func (s *MemoryStore) ReconcilePoints() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	totals := make(map[int]int)
+	for _, e := range s.pointEntries {
+		if e.Applied {
+			totals[e.UserID] += e.Amount
+		}
+	}
+	fixed := 0
+	for userID, balance := range totals {
+		if have, ok := s.pointAccounts[userID]; !ok || have != balance {
+			s.pointAccounts[userID] = balance
+			fixed++
+		}
+	}
+	return fixed, nil
+}
+
+func (s *MemoryStore) ScoresBetween(collection string, since, until time.Time) ([]ChatScore, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	type key struct {
+		chatID int64
+		userID int
+	}
+	var order []key
+	totals := make(map[key]*ChatScore)
+	for _, doc := range s.docs[collection] {
+		at := scoredAt(doc)
+		if at.Before(since) || (!until.IsZero() && !at.Before(until)) {
+			continue
+		}
+		k := key{toInt64(doc["chat_ID"]), toInt(doc["ID"])}
+		if totals[k] == nil {
+			name, _ := doc["Name"].(string)
+			totals[k] = &ChatScore{ChatID: k.chatID, UserID: k.userID, Name: name}
+			order = append(order, k)
+		}
+		totals[k].Score += docScore(collection, doc)
+	}
+
+	scores := make([]ChatScore, 0, len(order))
+	for _, k := range order {
+		scores = append(scores, *totals[k])
+	}
+	return scores, nil
+}
+
+func (s *MemoryStore) ArchiveSeason(season model.Season) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.seasons[season.Number]; ok {
+		return ErrSeasonArchived
+	}
+	s.seasons[season.Number] = season
+	return nil
+}
+
+func (s *MemoryStore) MarkSeasonRewarded(n int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	season, ok := s.seasons[n]
+	if !ok {
+		return ErrSeasonNotFound
+	}
+	season.Rewarded = true
+	s.seasons[n] = season
+	return nil
+}
+
+func (s *MemoryStore) FirstSeason(n int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.firstSeason == 0 {
+		s.firstSeason = n
+	}
+	return s.firstSeason, nil
+}
+
+func (s *MemoryStore) GetSeason(n int) (model.Season, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	season, ok := s.seasons[n]
+	if !ok {
+		return model.Season{}, ErrSeasonNotFound
+	}
+	return season, nil
+}
+
+func (s *MemoryStore) GetRatings(game string, userIDs []int) (map[int]Rating, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	found := make(map[int]Rating)
+	for _, id := range userIDs {
+		if r, ok := s.ratings[RatingKey(game, id)]; ok {
+			found[id] = r
+		}
+	}
+	return found, nil
+}
+
+func (s *MemoryStore) SaveRatings(ratings []Rating) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range ratings {
+		r.ID = RatingKey(r.Game, r.UserID)
+		s.ratings[r.ID] = r
+	}
+	return nil
+}
+
+func (s *MemoryStore) TopRatings(game string, limit int) ([]Rating, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var top []Rating
+	for _, r := range s.ratings {
+		if r.Game == game {
+			top = append(top, r)
+		}
+	}
+	sort.Slice(top, func(i, j int) bool { return top[i].Rating > top[j].Rating })
+	if len(top) > limit {
+		top = top[:limit]
+	}
+	return top, nil
+}
+
+func (s *MemoryStore) UserRatings(userID int) ([]Rating, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var rs []Rating
+	for _, r := range s.ratings {
+		if r.UserID == userID {
+			rs = append(rs, r)
+		}
+	}
+	sort.Slice(rs, func(i, j int) bool { return rs[i].Game < rs[j].Game })
+	return rs, nil
+}
+
+// ensurePointAccount opens the account with the user's WordleEn points, like
+// the Mongo ledger does.
+func (s *MemoryStore) ensurePointAccount(userID int) {
+	s.mu.Lock()
+	_, ok := s.pointAccounts[userID]
+	s.mu.Unlock()
+	if ok {
+		return
+	}
+
+	legacy := 0
+	if stats, err := s.GetUserStatsByID("WordleEn", userID); err == nil {
+		legacy = max(toInt(stats["count"]), 0)
+	}
+	s.applyPoints(PointsEntry{Key: OpeningBalanceKey(userID), UserID: userID, Amount: legacy, Reason: "Opening balance"})
+}
+
+func (s *MemoryStore) applyPoints(entry PointsEntry) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.applyPointsLocked(entry)
+}
+
+func (s *MemoryStore) applyPointsLocked(entry PointsEntry) (int, error) {
+	balance, open := s.pointAccounts[entry.UserID]
+	if _, dup := s.pointEntries[entry.Key]; dup {
+		return balance, ErrDuplicateEntry
+	}
+	if entry.Amount < 0 && (!open || balance < -entry.Amount) {
+		return balance, ErrInsufficientPoints
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	entry.Applied = true
+	s.pointEntries[entry.Key] = entry
+	s.pointAccounts[entry.UserID] = balance + entry.Amount
+	return balance + entry.Amount, nil
+}
+
+// ---- Game state and settings ----
+
+func (s *MemoryStore) SaveGameState(collectionName string, chatID int64, state interface{}) error {
+	fields, err := toDoc(state)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mergeInto(s.states, collectionName, chatID, fields)
+	return nil
+}
+
+func (s *MemoryStore) LoadAllGameStates(collectionName string, target interface{}) error {
+	s.mu.Lock()
+	var docs []bson.M
+	for _, doc := range s.states[collectionName] {
+		docs = append(docs, copyDoc(doc))
+	}
+	s.mu.Unlock()
+
+	sort.Slice(docs, func(i, j int) bool {
+		return toInt64(docs[i]["_id"]) < toInt64(docs[j]["_id"])
+	})
+	return decodeAll(docs, target)
+}
+
+func (s *MemoryStore) LoadChatSettings(collectionName string, chatID int64, target interface{}) error {
+	s.mu.Lock()
+	doc, ok := s.settings[collectionName][chatID]
+	if ok {
+		doc = copyDoc(doc)
+	}
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return fromDoc(doc, target)
+}
+
+func (s *MemoryStore) SaveChatSettings(collectionName string, chatID int64, fields bson.M) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mergeInto(s.settings, collectionName, chatID, copyDoc(fields))
+	return nil
+}
+
+func (s *MemoryStore) HasFreeEordle(userID int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.eordle[eordleKey(userID)]
+}
+
+func (s *MemoryStore) UseFreeEordle(userID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.eordle[eordleKey(userID)] = true
+}
+
+func eordleKey(userID int) string {
+	return fmt.Sprintf("%d|%s", userID, time.Now().Format("2006-01-02"))
+}
+
+// ---- Profiles ----
+
+func (s *MemoryStore) GetUserProfile(userID int64, username string) (*model.UserProfile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	profile, ok := s.profiles[userID]
+	if !ok {
+		profile = &model.UserProfile{
+			ID:                 primitive.NewObjectID(),
+			UserID:             userID,
+			Level:              1,
+			ActiveDaysThisWeek: []string{},
+			CreatedAt:          now,
+		}
+		s.profiles[userID] = profile
+	}
+	profile.Username = username
+	profile.UpdatedAt = now
+
+	copied := *profile
+	copied.ActiveDaysThisWeek = append([]string(nil), profile.ActiveDaysThisWeek...)
+	return &copied, nil
+}
+
+func (s *MemoryStore) UpdateUserProfile(profile *model.UserProfile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.profiles[profile.UserID]; !ok {
+		return nil // UpdateOne without upsert matches nothing
+	}
+	profile.UpdatedAt = time.Now()
+	copied := *profile
+	s.profiles[profile.UserID] = &copied
+	return nil
+}
+
+func (s *MemoryStore) UpdateUserProfileFields(userID int64, updateFields bson.M) error {
+	updateFields["updated_at"] = time.Now()
+	return s.patchProfile(userID, func(doc bson.M) {
+		for k, v := range updateFields {
+			doc[k] = v
+		}
+	})
+}
+
+func (s *MemoryStore) IncrementUserProfileStats(userID int64, incFields bson.M) error {
+	return s.patchProfile(userID, func(doc bson.M) {
+		for k, v := range incFields {
+			doc[k] = toInt(doc[k]) + toInt(v)
+		}
+		doc["updated_at"] = time.Now()
+	})
+}
+
+func (s *MemoryStore) ClaimUserProfileReward(userID int64, key string, incFields bson.M) (bool, error) {
+	s.mu.Lock()
+	profile, ok := s.profiles[userID]
+	if !ok || slices.Contains(profile.ClaimedRewards, key) {
+		s.mu.Unlock()
+		return false, nil
+	}
+	profile.ClaimedRewards = append(profile.ClaimedRewards[:len(profile.ClaimedRewards):len(profile.ClaimedRewards)], key)
+	s.mu.Unlock()
+	return true, s.IncrementUserProfileStats(userID, incFields)
+}
+
+// patchProfile applies a document-level edit to a stored profile by
+// round-tripping it through BSON, so field names match the Mongo schema.
+func (s *MemoryStore) patchProfile(userID int64, patch func(bson.M)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.profiles[userID]
+	if !ok {
+		return nil
+	}
+	doc, err := toDoc(profile)
+	if err != nil {
+		return err
+	}
+	patch(doc)
+
+	var updated model.UserProfile
+	if err := fromDoc(doc, &updated); err != nil {
+		return err
+	}
+	s.profiles[userID] = &updated
+	return nil
+}
+
+// ---- Emojis ----
+
+func (s *MemoryStore) GetEquippedEmojis(userID int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.emojis[userID]; ok {
+		return append([]string{}, e.Equipped...), nil
+	}
+	return []string{}, nil
+}
+
+func (s *MemoryStore) GetPurchasedEmojis(userID int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.emojis[userID]; ok {
+		return append([]string{}, e.Purchased...), nil
+	}
+	return []string{}, nil
+}
+
+func (s *MemoryStore) PurchaseEmoji(userID int, emoji string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := s.emojiDoc(userID)
+	e.Purchased = addToSet(e.Purchased, emoji)
+	return nil
+}
+
+func (s *MemoryStore) ToggleEquipEmoji(userID int, emoji string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := s.emojiDoc(userID)
+	for i, existing := range e.Equipped {
+		if existing == emoji {
+			e.Equipped = append(e.Equipped[:i], e.Equipped[i+1:]...)
+			return false, nil
+		}
+	}
+	e.Equipped = append(e.Equipped, emoji)
+	return true, nil
+}
+
+func (s *MemoryStore) emojiDoc(userID int) *memoryEmojis {
+	e, ok := s.emojis[userID]
+	if !ok {
+		e = &memoryEmojis{}
+		s.emojis[userID] = e
+	}
+	return e
+}
+
+// ---- Collectibles ----
+
+func (s *MemoryStore) GetTemplates() ([]collectible.Template, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]collectible.Template(nil), s.templates...), nil
+}
+
+func (s *MemoryStore) BootstrapTemplates() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.templates) > 0 {
+		return nil
+	}
+	s.templates = []collectible.Template{
+		{ID: primitive.NewObjectID().Hex(), Name: "Naruto Run", Rarity: collectible.RarityCommon, Emoji: "🏃"},
+		{ID: primitive.NewObjectID().Hex(), Name: "Goku Hair", Rarity: collectible.RarityUncommon, Emoji: "🔥"},
+		{ID: primitive.NewObjectID().Hex(), Name: "Sakura Shrine", Rarity: collectible.RarityRare, Emoji: "🌸"},
+		{ID: primitive.NewObjectID().Hex(), Name: "Gojo Eyes", Rarity: collectible.RarityEpic, Emoji: "🌌"},
+		{ID: primitive.NewObjectID().Hex(), Name: "Levi Sword", Rarity: collectible.RarityLegendary, Emoji: "⚔️"},
+	}
+	return nil
+}
+
+// AddTemplate seeds a single template; useful for deterministic tests.
+func (s *MemoryStore) AddTemplate(t collectible.Template) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t.ID == "" {
+		t.ID = primitive.NewObjectID().Hex()
+	}
+	s.templates = append(s.templates, t)
+}
+
+func (s *MemoryStore) GetNextSerialNumber(templateID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counters["serial_"+templateID]++
+	return s.counters["serial_"+templateID], nil
+}
+
+func (s *MemoryStore) MintItem(item collectible.Item) (collectible.Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if item.ID == "" {
+		item.ID = primitive.NewObjectID().Hex()
+	}
+	if _, exists := s.items[item.ID]; exists {
+		return item, errors.New("duplicate item id")
+	}
+	s.items[item.ID] = item
+	return item, nil
+}
+
+func (s *MemoryStore) GetUserInventory(userID int) ([]collectible.Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []collectible.Item
+	for _, item := range s.items {
+		if item.OwnerID == userID {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items, nil
+}
+
+func (s *MemoryStore) GetItemByID(itemID string) (collectible.Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.items[itemID]
+	if !ok {
+		return collectible.Item{}, mongo.ErrNoDocuments
+	}
+	return item, nil
+}
+
+func (s *MemoryStore) CreateListing(listing collectible.MarketListing) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.listings {
+		if existing.ItemID == listing.ItemID {
+			return errors.New("item is already listed on the marketplace")
+		}
+	}
+	if listing.ID == "" {
+		listing.ID = primitive.NewObjectID().Hex()
+	}
+	s.listings[listing.ID] = listing
+	return nil
+}
+
+func (s *MemoryStore) GetListings() ([]collectible.MarketListing, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var listings []collectible.MarketListing
+	for _, l := range s.listings {
+		listings = append(listings, l)
+	}
+	sort.Slice(listings, func(i, j int) bool { return listings[i].ID < listings[j].ID })
+	return listings, nil
+}
+
+func (s *MemoryStore) GetListingByID(listingID string) (collectible.MarketListing, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.listings[listingID]
+	if !ok {
+		return collectible.MarketListing{}, mongo.ErrNoDocuments
+	}
+	return l, nil
+}
+
+func (s *MemoryStore) BuyPack(payment PointsEntry, item collectible.Item) (collectible.Item, error) {
+	s.ensurePointAccount(payment.UserID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item.ID == "" {
+		item.ID = primitive.NewObjectID().Hex()
+	}
+	if _, exists := s.items[item.ID]; exists {
+		return collectible.Item{}, errors.New("duplicate item id")
+	}
+	if _, err := s.applyPointsLocked(payment); err != nil {
+		return collectible.Item{}, err
+	}
+	s.counters["serial_"+item.TemplateID]++
+	item.SerialNumber = s.counters["serial_"+item.TemplateID]
+	s.items[item.ID] = item
+	return item, nil
+}
+
+func (s *MemoryStore) BuyListing(listing collectible.MarketListing, payment, sale PointsEntry) error {
+	s.ensurePointAccount(payment.UserID)
+	s.ensurePointAccount(sale.UserID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.listings[listing.ID]
+	if !ok || current.ItemID != listing.ItemID || current.SellerID != listing.SellerID || current.Price != listing.Price {
+		return ErrListingGone
+	}
+	item, ok := s.items[listing.ItemID]
+	if !ok || item.OwnerID != listing.SellerID {
+		return ErrItemMoved
+	}
+	// Check the sale key before charging so nothing needs undoing.
+	if _, dup := s.pointEntries[sale.Key]; dup {
+		return ErrDuplicateEntry
+	}
+	if _, err := s.applyPointsLocked(payment); err != nil {
+		return err
+	}
+	s.applyPointsLocked(sale)
+
+	delete(s.listings, listing.ID)
+	item.OwnerID = payment.UserID
+	s.items[item.ID] = item
+	return nil
+}
+
+func (s *MemoryStore) DeleteListing(listingID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.listings, listingID)
+	return nil
+}
+
+// ---- Ephemeral whispers ----
+
+func (s *MemoryStore) UpsertEphemeralRequest(userID int64, chatID int64, ephemeralMsgID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ephemeralRequests[userID] = EphemeralRequestDoc{UserID: userID, ChatID: chatID, EphemeralMsgID: ephemeralMsgID}
+}
+
+func (s *MemoryStore) TakeEphemeralRequest(userID int64) (*EphemeralRequestDoc, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, ok := s.ephemeralRequests[userID]
+	if !ok {
+		return nil, nil
+	}
+	delete(s.ephemeralRequests, userID)
+	return &doc, nil
+}
+
+func (s *MemoryStore) UpsertKnownUser(username string, userID int64) {
+	if username == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.knownUsers[username] = userID
+}
+
+func (s *MemoryStore) GetKnownUser(username string) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.knownUsers[username]
+	return id, ok
+}
+
+func (s *MemoryStore) AddStoredWhisper(userID int64, w StoredWhisperDoc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.storedWhispers[userID] = append(s.storedWhispers[userID], w)
+}
+
+func (s *MemoryStore) TakeStoredWhispers(userID int64) []StoredWhisperDoc {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	whispers := s.storedWhispers[userID]
+	delete(s.storedWhispers, userID)
+	return whispers
+}
+
+func (s *MemoryStore) UpsertWhisperSource(src WhisperSourceDoc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.whisperSources[src.EphemeralMsgID] = src
+}
+
+func (s *MemoryStore) TakeWhisperSource(ephemeralMsgID int64) (*WhisperSourceDoc, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	src, ok := s.whisperSources[ephemeralMsgID]
+	if !ok {
+		return nil, nil
+	}
+	delete(s.whisperSources, ephemeralMsgID)
+	return &src, nil
+}
+
+func (s *MemoryStore) SetLatestUserEphemeral(userID int64, ephemeralMsgID int64) {
+	if userID == 0 || ephemeralMsgID == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latestEphemeral[userID] = ephemeralMsgID
+}
+
+func (s *MemoryStore) GetLatestUserEphemeral(userID int64) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.latestEphemeral[userID]
+}
+
+// ---- Word packs ----
+
+func (s *MemoryStore) SaveWordPack(pack WordPack) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pack.ID = WordPackKey(pack.ChatID, pack.Name)
+	s.wordPacks[pack.ID] = pack
+	return nil
+}
+
+func (s *MemoryStore) ChatWordPacks(chatID int64) ([]WordPack, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var packs []WordPack
+	for _, p := range s.wordPacks {
+		if p.ChatID == chatID {
+			packs = append(packs, p)
+		}
+	}
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs, nil
+}
+
+func (s *MemoryStore) DeleteWordPack(chatID int64, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := WordPackKey(chatID, name)
+	if _, ok := s.wordPacks[key]; !ok {
+		return ErrWordPackNotFound
+	}
+	delete(s.wordPacks, key)
+	return nil
+}
+
+// ---- Personal data ----
+
+func (s *MemoryStore) GetUserData(userID int) (UserData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data := make(UserData)
+	add := func(collection string, v interface{}) {
+		doc, err := toDoc(v)
+		if err == nil {
+			data[collection] = append(data[collection], doc)
+		}
+	}
+
+	for _, src := range userDataSources {
+		for _, doc := range s.sourceDocs(src) {
+			if src.forget == forgetPull {
+				if entries := userEntries(src, doc, userID); len(entries) > 0 {
+					data[src.collection] = append(data[src.collection], entries...)
+				}
+			} else if toInt(doc[src.field]) == userID {
+				data[src.collection] = append(data[src.collection], copyDoc(doc))
+			}
+		}
+	}
+	if balance, ok := s.pointAccounts[userID]; ok {
+		add(PointAccountsCollection, PointAccount{UserID: userID, Balance: balance})
+	}
+	for _, e := range s.pointEntries {
+		if e.UserID == userID {
+			add(PointEntriesCollection, e)
+		}
+	}
+	if p, ok := s.profiles[int64(userID)]; ok {
+		add(userProfilesCollection, p)
+	}
+	if e, ok := s.emojis[userID]; ok {
+		add("UserEmojis", bson.M{"UserID": userID, "PurchasedEmojis": e.Purchased, "EquippedEmojis": e.Equipped})
+	}
+	for _, r := range s.ratings {
+		if r.UserID == userID {
+			add(RatingsCollection, r)
+		}
+	}
+	prefix := fmt.Sprintf("%d|", userID)
+	for key := range s.eordle {
+		if strings.HasPrefix(key, prefix) {
+			add("EordleUsage", bson.M{"ID": userID, "Date": strings.TrimPrefix(key, prefix)})
+		}
+	}
+	for _, item := range s.items {
+		if item.OwnerID == userID {
+			add("Collectibles", item)
+		}
+	}
+	for _, l := range s.listings {
+		if l.SellerID == userID {
+			add("MarketListings", l)
+		}
+	}
+	if whispers, ok := s.storedWhispers[int64(userID)]; ok {
+		add("StoredWhispers", StoredWhispersDoc{UserID: int64(userID), Whispers: whispers})
+	}
+	for _, src := range s.whisperSources {
+		if src.SenderID == int64(userID) {
+			add("WhisperEphemeralSources", src)
+		}
+	}
+	if id, ok := s.latestEphemeral[int64(userID)]; ok {
+		add("LatestUserEphemeral", LatestEphemeralDoc{UserID: int64(userID), EphemeralMsgID: id})
+	}
+	if r, ok := s.ephemeralRequests[int64(userID)]; ok {
+		add("EphemeralRequests", r)
+	}
+	for username, id := range s.knownUsers {
+		if id == int64(userID) {
+			add("KnownUserIDs", KnownUserDoc{Username: username, UserID: id})
+		}
+	}
+	for _, p := range s.wordPacks {
+		if p.UploadedBy == userID {
+			add(WordPacksCollection, p)
+		}
+	}
+
+	var holders []StoredWhispersDoc
+	for recipient, whispers := range s.storedWhispers {
+		holders = append(holders, StoredWhispersDoc{UserID: recipient, Whispers: whispers})
+	}
+	if sent := sentWhispers(holders, userID); len(sent) > 0 {
+		data[userDataSentWhispers] = sent
+	}
+	var seasons []model.Season
+	for _, season := range s.seasons {
+		seasons = append(seasons, season)
+	}
+	sort.Slice(seasons, func(i, j int) bool { return seasons[i].Number < seasons[j].Number })
+	if standings := seasonStandingsOf(seasons, userID); len(standings) > 0 {
+		data[userDataSeasonStandings] = standings
+	}
+	if entries := dailyBoardEntriesOf(s.dailyBoards(), userID); len(entries) > 0 {
+		data[userDataDailyBoards] = entries
+	}
+	return data, nil
+}
+
+// sourceDocs returns the documents of the source's collection. The caller
+// holds s.mu.
+func (s *MemoryStore) sourceDocs(src userDataSource) []bson.M {
+	if !src.settings {
+		return s.docs[src.collection]
+	}
+	docs := make([]bson.M, 0, len(s.settings[src.collection]))
+	for _, doc := range s.settings[src.collection] {
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// pullUser removes the user's entries from the arrays of a forgetPull
+// source's document and reports whether there were any.
+func pullUser(src userDataSource, doc bson.M, userID int) bool {
+	pulled := false
+	for _, array := range src.arrays {
+		entries := arrayEntries(doc, array)
+		kept := entries[:0]
+		for _, e := range entries {
+			if toInt(e[src.field]) != userID {
+				kept = append(kept, e)
+			}
+		}
+		if len(kept) < len(entries) {
+			doc[array] = kept
+			pulled = true
+		}
+	}
+	return pulled
+}
+
+// dailyBoards returns the saved Daily Wordle boards. The caller holds s.mu.
+func (s *MemoryStore) dailyBoards() []dailyBoardDoc {
+	var boards []dailyBoardDoc
+	for _, doc := range s.settings[DailyWordleBoardsCollection] {
+		var b dailyBoardDoc
+		if err := fromDoc(doc, &b); err == nil {
+			boards = append(boards, b)
+		}
+	}
+	return boards
+}
+
+func (s *MemoryStore) ForgetUser(userID int) (ForgetReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report := make(ForgetReport)
+	uid := int64(userID)
+
+	for _, src := range userDataSources {
+		if src.forget == forgetKeep {
+			continue
+		}
+		if src.forget == forgetPull {
+			for _, doc := range s.sourceDocs(src) {
+				if pullUser(src, doc, userID) {
+					report[src.collection]++
+				}
+			}
+			continue
+		}
+		if src.settings {
+			for id, doc := range s.settings[src.collection] {
+				if toInt(doc[src.field]) != userID {
+					continue
+				}
+				report[src.collection]++
+				if src.forget == forgetAnonymise {
+					for k, v := range src.set {
+						doc[k] = v
+					}
+				} else {
+					delete(s.settings[src.collection], id)
+				}
+			}
+			continue
+		}
+		docs := s.docs[src.collection]
+		kept := docs[:0]
+		for _, doc := range docs {
+			if toInt(doc[src.field]) != userID {
+				kept = append(kept, doc)
+				continue
+			}
+			report[src.collection]++
+			if src.forget == forgetAnonymise {
+				for k, v := range src.set {
+					doc[k] = v
+				}
+				kept = append(kept, doc)
+			}
+		}
+		s.docs[src.collection] = kept
+	}
+	if _, ok := s.pointAccounts[userID]; ok {
+		delete(s.pointAccounts, userID)
+		report[PointAccountsCollection]++
+	}
+	for key, e := range s.pointEntries {
+		switch {
+		case e.UserID == userID:
+			delete(s.pointEntries, key)
+			report[PointEntriesCollection]++
+		case e.Counterparty == userID:
+			e.Counterparty = 0
+			s.pointEntries[key] = e
+			report[PointEntriesCollection]++
+		}
+	}
+	if _, ok := s.profiles[uid]; ok {
+		delete(s.profiles, uid)
+		report[userProfilesCollection]++
+	}
+	if _, ok := s.emojis[userID]; ok {
+		delete(s.emojis, userID)
+		report["UserEmojis"]++
+	}
+	for key, r := range s.ratings {
+		if r.UserID == userID {
+			delete(s.ratings, key)
+			report[RatingsCollection]++
+		}
+	}
+	prefix := fmt.Sprintf("%d|", userID)
+	for key := range s.eordle {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.eordle, key)
+			report["EordleUsage"]++
+		}
+	}
+	for key, item := range s.items {
+		if item.OwnerID == userID {
+			item.OwnerID = 0
+			s.items[key] = item
+			report["Collectibles"]++
+		}
+	}
+	for key, l := range s.listings {
+		if l.SellerID == userID {
+			delete(s.listings, key)
+			report["MarketListings"]++
+		}
+	}
+	if _, ok := s.storedWhispers[uid]; ok {
+		delete(s.storedWhispers, uid)
+		report["StoredWhispers"]++
+	}
+	for recipient, whispers := range s.storedWhispers {
+		kept := whispers[:0]
+		for _, w := range whispers {
+			if w.SenderID != uid {
+				kept = append(kept, w)
+			}
+		}
+		if len(kept) != len(whispers) {
+			s.storedWhispers[recipient] = kept
+			report["StoredWhispers"]++
+		}
+	}
+	for key, src := range s.whisperSources {
+		if src.SenderID == uid {
+			delete(s.whisperSources, key)
+			report["WhisperEphemeralSources"]++
+		}
+	}
+	if _, ok := s.latestEphemeral[uid]; ok {
+		delete(s.latestEphemeral, uid)
+		report["LatestUserEphemeral"]++
+	}
+	if _, ok := s.ephemeralRequests[uid]; ok {
+		delete(s.ephemeralRequests, uid)
+		report["EphemeralRequests"]++
+	}
+	for username, id := range s.knownUsers {
+		if id == uid {
+			delete(s.knownUsers, username)
+			report["KnownUserIDs"]++
+		}
+	}
+	for key, p := range s.wordPacks {
+		if p.UploadedBy == userID {
+			p.UploadedBy = 0
+			s.wordPacks[key] = p
+			report[WordPacksCollection]++
+		}
+	}
+	for n, season := range s.seasons {
+		if anonymiseStandings(&season, userID) {
+			s.seasons[n] = season
+			report[SeasonsCollection]++
+		}
+	}
+	for _, b := range s.dailyBoards() {
+		kept := b.Entries[:0]
+		for _, e := range b.Entries {
+			if e.ID != userID {
+				kept = append(kept, e)
+			}
+		}
+		if len(kept) < len(b.Entries) {
+			s.settings[DailyWordleBoardsCollection][b.ChatID]["entries"] = kept
+			report[DailyWordleBoardsCollection]++
+		}
+	}
+
+	entry, err := toDoc(PrivacyAuditEntry{UserID: userID, Action: "forget", Affected: report, At: time.Now()})
+	if err != nil {
+		return report, err
+	}
+	s.docs[PrivacyAuditCollection] = append(s.docs[PrivacyAuditCollection], entry)
+	return report, nil
+}
+
+// ---- helpers ----
+
+func toDoc(v interface{}) (bson.M, error) {
+	raw, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc bson.M
+	err = bson.Unmarshal(raw, &doc)
+	return doc, err
+}
+
+func fromDoc(doc bson.M, target interface{}) error {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(raw, target)
+}
+
+// decodeAll decodes docs into target, which must be a pointer to a slice,
+// the same contract as cursor.All.
+func decodeAll(docs []bson.M, target interface{}) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return errors.New("target must be a pointer to a slice")
+	}
+	slice := rv.Elem()
+	elemType := slice.Type().Elem()
+	out := reflect.MakeSlice(slice.Type(), 0, len(docs))
+	for _, doc := range docs {
+		elem := reflect.New(elemType)
+		if err := fromDoc(doc, elem.Interface()); err != nil {
+			return err
+		}
+		out = reflect.Append(out, elem.Elem())
+	}
+	slice.Set(out)
+	return nil
+}
+
+// mergeInto emulates an upserting $set on the document keyed by chatID.
+func mergeInto(coll map[string]map[int64]bson.M, name string, chatID int64, fields bson.M) {
+	if coll[name] == nil {
+		coll[name] = make(map[int64]bson.M)
+	}
+	doc, ok := coll[name][chatID]
+	if !ok {
+		doc = bson.M{}
+		coll[name][chatID] = doc
+	}
+	for k, v := range fields {
+		doc[k] = v
+	}
+	doc["_id"] = chatID
+}
+
+func copyDoc(doc bson.M) bson.M {
+	copied := make(bson.M, len(doc))
+	for k, v := range doc {
+		copied[k] = v
+	}
+	return copied
+}
+
+func addToSet(list []string, v string) []string {
+	for _, existing := range list {
+		if existing == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
+
+func toInt(v interface{}) int {
+	return int(toInt64(v))
+}
+
+func toInt64(v interface{}) int64 {
+	switch n := v.(type) {
+	case int:
+		return int64(n)
+	case int32:
+		return int64(n)
+	case int64:
+		return n
+	case float64:
+		return int64(n)
+	}
+	return 0
+}
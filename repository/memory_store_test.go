@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMemoryStorePointsAggregation(t *testing.T) {
+	store := NewMemoryStore()
+
+	store.InsertWordleDoc(1, "alice", 100, "WordleEn", 5) // 21 points
+	store.InsertWordleBonusDoc(2, "bob", 100, "WordleEn", 50)
+	store.InsertWordleBonusDoc(1, "alice", 200, "WordleEn", 10)
+	store.InsertWordleBonusDoc(2, "bob", 100, "WordleEn", -20) // spent before the ledger
+
+	if got := store.GetCurrentPoints(1); got != 31 {
+		t.Errorf("GetCurrentPoints(alice) = %d; want 31", got)
+	}
+	if got := store.GetCurrentPoints(2); got != 30 {
+		t.Errorf("GetCurrentPoints(bob) = %d; want 30", got)
+	}
+
+	group, err := store.CountIDOccurrences("WordleEn", 100, WindowAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(group) != 2 || group[0]["Name"] != "bob" || group[0]["count"] != int32(30) {
+		t.Errorf("unexpected group leaderboard: %v", group)
+	}
+
+	store.InsertDoc(3, "carol", 100, "CrocEn")
+	store.InsertDoc(3, "carol", 100, "CrocEn")
+	stats, err := store.GetUserStatsByID("CrocEn", 3)
+	if err != nil || stats["count"] != int32(2) {
+		t.Errorf("GetUserStatsByID(CrocEn) = %v, %v; want count 2", stats, err)
+	}
+}
+
+func TestMemoryStoreLedger(t *testing.T) {
+	store := NewMemoryStore()
+	store.InsertWordleBonusDoc(1, "alice", 100, "WordleEn", 50)
+
+	// The first touch carries over the WordleEn points.
+	if got := store.GetCurrentPoints(1); got != 50 {
+		t.Fatalf("opening balance = %d; want 50", got)
+	}
+
+	debit := PointsEntry{Key: "shop:1", UserID: 1, Amount: -30, Reason: "Shop"}
+	if balance, err := store.ApplyPoints(debit); err != nil || balance != 20 {
+		t.Fatalf("debit = %d, %v; want 20, nil", balance, err)
+	}
+	if balance, err := store.ApplyPoints(debit); err != ErrDuplicateEntry || balance != 20 {
+		t.Errorf("repeated debit = %d, %v; want 20, ErrDuplicateEntry", balance, err)
+	}
+	if _, err := store.ApplyPoints(PointsEntry{Key: "shop:2", UserID: 1, Amount: -21}); err != ErrInsufficientPoints {
+		t.Errorf("overdraft err = %v; want ErrInsufficientPoints", err)
+	}
+	if _, err := store.ApplyPoints(PointsEntry{Key: "shop:2", UserID: 1, Amount: -20}); err != nil {
+		t.Errorf("rejected key could not be reused: %v", err)
+	}
+	if got := store.GetCurrentPoints(1); got != 0 {
+		t.Errorf("balance = %d; want 0", got)
+	}
+
+	// An account that never had WordleEn points opens at zero.
+	if _, err := store.ApplyPoints(PointsEntry{Key: "win:1", UserID: 2, Amount: 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	store.mu.Lock()
+	store.pointAccounts[1] = 999
+	store.mu.Unlock()
+	fixed, err := store.ReconcilePoints()
+	if err != nil || fixed != 1 {
+		t.Errorf("ReconcilePoints = %d, %v; want 1, nil", fixed, err)
+	}
+	if got := store.GetCurrentPoints(1); got != 0 {
+		t.Errorf("reconciled balance = %d; want 0", got)
+	}
+	if got := store.GetCurrentPoints(2); got != 7 {
+		t.Errorf("balance of user 2 = %d; want 7", got)
+	}
+}
+
+func TestMemoryStoreConcurrentDebits(t *testing.T) {
+	store := NewMemoryStore()
+	store.InsertWordleBonusDoc(1, "alice", 100, "WordleEn", 100)
+
+	var wg sync.WaitGroup
+	var ok atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := store.ApplyPoints(PointsEntry{Key: fmt.Sprintf("buy:%d", i), UserID: 1, Amount: -30}); err == nil {
+				ok.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ok.Load() != 3 {
+		t.Errorf("%d debits of 30 went through on 100 points; want 3", ok.Load())
+	}
+	if got := store.GetCurrentPoints(1); got != 10 {
+		t.Errorf("balance = %d; want 10", got)
+	}
+}
+
+func TestMemoryStoreGameStateRoundTrip(t *testing.T) {
+	type stateDoc struct {
+		ChatID  int64    `bson:"_id"`
+		Word    string   `bson:"word"`
+		Guesses []string `bson:"guesses"`
+	}
+
+	store := NewMemoryStore()
+	if err := store.SaveGameState("States", 7, stateDoc{ChatID: 7, Word: "apple", Guesses: []string{"crane"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveGameState("States", 7, bson.M{"word": "pearl"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var loaded []stateDoc
+	if err := store.LoadAllGameStates("States", &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 1 || loaded[0].ChatID != 7 || loaded[0].Word != "pearl" || len(loaded[0].Guesses) != 1 {
+		t.Errorf("unexpected loaded states: %+v", loaded)
+	}
+}
+
+func TestMemoryStoreProfileIncrements(t *testing.T) {
+	store := NewMemoryStore()
+
+	if _, err := store.GetUserProfile(9, "dan"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.IncrementUserProfileStats(9, bson.M{"xp": 30, "coins": 15, "wins": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpdateUserProfileFields(9, bson.M{"level": 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	profile, _ := store.GetUserProfile(9, "dan")
+	if profile.XP != 30 || profile.Coins != 15 || profile.Wins != 1 || profile.Level != 2 {
+		t.Errorf("unexpected profile: %+v", profile)
+	}
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+// Game states are saved in the background so handlers never wait on Mongo.
+// The saves are tracked here so a shutdown can wait for them instead of
+// losing the last moves of every running game.
+var (
+	savesMu  sync.Mutex
+	saves    sync.WaitGroup
+	flushing bool
+)
+
+// SaveAsync runs save in the background. Once FlushSaves has been called,
+// saves run synchronously in the caller instead.
+func SaveAsync(save func()) {
+	savesMu.Lock()
+	if flushing {
+		savesMu.Unlock()
+		save()
+		return
+	}
+	saves.Add(1)
+	savesMu.Unlock()
+
+	go func() {
+		defer saves.Done()
+		save()
+	}()
+}
+
+// FlushSaves waits for every pending SaveAsync call to finish, or for ctx to
+// be done.
+func FlushSaves(ctx context.Context) error {
+	savesMu.Lock()
+	flushing = true
+	savesMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		saves.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func resetSaves() {
+	savesMu.Lock()
+	flushing = false
+	savesMu.Unlock()
+}
+
+func TestFlushSavesWaitsForPendingSaves(t *testing.T) {
+	defer resetSaves()
+
+	var saved atomic.Int32
+	release := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		SaveAsync(func() {
+			<-release
+			saved.Add(1)
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := FlushSaves(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("FlushSaves with blocked saves = %v, want deadline exceeded", err)
+	}
+
+	close(release)
+	if err := FlushSaves(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if saved.Load() != 3 {
+		t.Errorf("%d saves finished, want 3", saved.Load())
+	}
+
+	// Saves made after the flush started run before SaveAsync returns.
+	SaveAsync(func() { saved.Add(1) })
+	if saved.Load() != 4 {
+		t.Error("save after flush did not run synchronously")
+	}
+}
+
+func TestDisconnectWithoutClient(t *testing.T) {
+	if err := Disconnect(context.Background()); err != nil {
+		t.Errorf("Disconnect() = %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RatingsCollection holds one Rating per player and game.
+const RatingsCollection = "Ratings"
+
+// Rating is a player's skill rating in one game.
+type Rating struct {
+	ID        string    `bson:"_id"` // RatingKey(Game, UserID)
+	Game      string    `bson:"game"`
+	UserID    int       `bson:"user_id"`
+	Name      string    `bson:"name"`
+	Rating    float64   `bson:"rating"`
+	Rounds    int       `bson:"rounds"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// RatingKey is the _id of the user's rating in game.
+func RatingKey(game string, userID int) string {
+	return fmt.Sprintf("%s:%d", game, userID)
+}
+
+// GetRatings returns the ratings in game of those of userIDs who have one.
+func GetRatings(client *mongo.Client, game string, userIDs []int) (map[int]Rating, error) {
+	if client == nil {
+		return nil, fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := ratings(client).Find(ctx, bson.M{"game": game, "user_id": bson.M{"$in": userIDs}})
+	if err != nil {
+		return nil, err
+	}
+	var found []Rating
+	if err := cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+	byUser := make(map[int]Rating, len(found))
+	for _, r := range found {
+		byUser[r.UserID] = r
+	}
+	return byUser, nil
+}
+
+// SaveRatings writes every rating in rs, replacing the stored ones.
+func SaveRatings(client *mongo.Client, rs []Rating) error {
+	if client == nil {
+		return fmt.Errorf("MongoDB client is nil")
+	}
+	if len(rs) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	writes := make([]mongo.WriteModel, 0, len(rs))
+	for _, r := range rs {
+		r.ID = RatingKey(r.Game, r.UserID)
+		writes = append(writes, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": r.ID}).SetReplacement(r).SetUpsert(true))
+	}
+	_, err := ratings(client).BulkWrite(ctx, writes)
+	return err
+}
+
+// TopRatings returns the limit best rated players of game, best first.
+func TopRatings(client *mongo.Client, game string, limit int) ([]Rating, error) {
+	if client == nil {
+		return nil, fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "rating", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := ratings(client).Find(ctx, bson.M{"game": game}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var top []Rating
+	err = cursor.All(ctx, &top)
+	return top, err
+}
+
+// UserRatings returns the user's ratings in every game they were rated in.
+func UserRatings(client *mongo.Client, userID int) ([]Rating, error) {
+	if client == nil {
+		return nil, fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "game", Value: 1}})
+	cursor, err := ratings(client).Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var rs []Rating
+	err = cursor.All(ctx, &rs)
+	return rs, err
+}
+
+func ratings(client *mongo.Client) *mongo.Collection {
+	return client.Database(config.App.Mongo.Database).Collection(RatingsCollection)
+}
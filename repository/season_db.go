@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SeasonsCollection holds one archived model.Season per finished season.
+const SeasonsCollection = "Seasons"
+
+// seasonMarkersCollection holds the first season that may be closed.
+const seasonMarkersCollection = "SeasonMarkers"
+
+var (
+	// ErrSeasonArchived is returned when a season is archived a second time.
+	ErrSeasonArchived = errors.New("season already archived")
+	// ErrSeasonNotFound is returned for a season that has not been archived.
+	ErrSeasonNotFound = errors.New("season not found")
+)
+
+// ChatScore is a player's total in one chat over some period.
+type ChatScore struct {
+	ChatID int64  `bson:"chat_id"`
+	UserID int    `bson:"user_id"`
+	Name   string `bson:"name"`
+	Score  int    `bson:"score"`
+}
+
+// ScoresBetween totals collection per chat and player over the documents
+// written in [since, until).
+func ScoresBetween(client *mongo.Client, collection string, since, until time.Time) ([]ChatScore, error) {
+	if client == nil {
+		return nil, fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var pipeline mongo.Pipeline
+	if filter := timeRangeFilter(since, until); filter != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: filter}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "chat", Value: "$chat_ID"}, {Key: "user", Value: "$ID"}}},
+			{Key: "score", Value: bson.D{{Key: "$sum", Value: scoreExpr(collection)}}},
+			{Key: "name", Value: bson.D{{Key: "$first", Value: "$Name"}}},
+		}}},
+		bson.D{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "chat_id", Value: "$_id.chat"},
+			{Key: "user_id", Value: "$_id.user"},
+			{Key: "name", Value: 1},
+			{Key: "score", Value: 1},
+		}}},
+	)
+
+	cursor, err := client.Database(config.App.Mongo.Database).Collection(collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var scores []ChatScore
+	if err := cursor.All(ctx, &scores); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// ArchiveSeason stores season. It returns ErrSeasonArchived if the season
+// was already archived, so only one closer ever hands out its rewards.
+func ArchiveSeason(client *mongo.Client, season model.Season) error {
+	if client == nil {
+		return fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := client.Database(config.App.Mongo.Database).Collection(SeasonsCollection).InsertOne(ctx, season)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrSeasonArchived
+	}
+	return err
+}
+
+// MarkSeasonRewarded records that every reward of archived season n was
+// paid.
+func MarkSeasonRewarded(client *mongo.Client, n int) error {
+	if client == nil {
+		return fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := client.Database(config.App.Mongo.Database).Collection(SeasonsCollection).UpdateOne(ctx,
+		bson.M{"_id": n},
+		bson.M{"$set": bson.M{"rewarded": true}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrSeasonNotFound
+	}
+	return nil
+}
+
+// FirstSeason returns the first season that may be closed. The first call
+// ever records n; every later one returns what it recorded, so seasons that
+// ended before the bot first looked are never closed.
+func FirstSeason(client *mongo.Client, n int) (int, error) {
+	if client == nil {
+		return 0, fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var marker struct {
+		Season int `bson:"season"`
+	}
+	err := client.Database(config.App.Mongo.Database).Collection(seasonMarkersCollection).FindOneAndUpdate(ctx,
+		bson.M{"_id": "first"},
+		bson.M{"$setOnInsert": bson.M{"season": n}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&marker)
+	return marker.Season, err
+}
+
+// GetSeason returns the archive of season number n.
+func GetSeason(client *mongo.Client, n int) (model.Season, error) {
+	if client == nil {
+		return model.Season{}, fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var season model.Season
+	err := client.Database(config.App.Mongo.Database).Collection(SeasonsCollection).FindOne(ctx, bson.M{"_id": n}).Decode(&season)
+	if err == mongo.ErrNoDocuments {
+		return model.Season{}, ErrSeasonNotFound
+	}
+	return season, err
+}
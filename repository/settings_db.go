@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LoadChatSettings decodes the settings document for a chat into target.
+// A missing document is not an error; target keeps its defaults.
+func LoadChatSettings(client *mongo.Client, collectionName string, chatID int64, target interface{}) error {
+	if client == nil {
+		return fmt.Errorf("MongoDB client is nil")
+	}
+
+	collection := client.Database(config.App.Mongo.SettingsDatabase).Collection(collectionName)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := collection.FindOne(ctx, bson.M{"_id": chatID}).Decode(target)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	return err
+}
+
+// SaveChatSettings upserts the given fields into the settings document for a chat.
+func SaveChatSettings(client *mongo.Client, collectionName string, chatID int64, fields bson.M) error {
+	if client == nil {
+		return fmt.Errorf("MongoDB client is nil")
+	}
+
+	collection := client.Database(config.App.Mongo.SettingsDatabase).Collection(collectionName)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Update().SetUpsert(true)
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": chatID}, bson.M{"$set": fields}, opts)
+	return err
+}
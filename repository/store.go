@@ -0,0 +1,360 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model/collectible"
+	collectibleRepo "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository/collectible"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Store is the persistence boundary used by the games and services. MongoStore
+// backs it with the live cluster; MemoryStore keeps everything in process so the
+// same logic can run in unit tests and local development.
+type Store interface {
+	// Points
+	InsertDoc(ID int, Name string, chatID int64, collection string)
+	InsertWordleDoc(ID int, Name string, chatID int64, collection string, attempts int)
+	InsertWordleBonusDoc(ID int, Name string, chatID int64, collection string, points int)
+	ReadAllDoc(collection string) []bson.M
+	CountIDOccurrences(collection string, chatID int64, window Window) ([]map[string]interface{}, error)
+	GetUserStatsByID(collection string, userID int) (map[string]interface{}, error)
+
+	// Points ledger
+	GetCurrentPoints(userID int) int
+	ApplyPoints(entry PointsEntry) (int, error)
+	ReconcilePoints() (int, error)
+
+	// Seasons
+	ScoresBetween(collection string, since, until time.Time) ([]ChatScore, error)
+	ArchiveSeason(season model.Season) error
+	MarkSeasonRewarded(n int) error
+	GetSeason(n int) (model.Season, error)
+	FirstSeason(n int) (int, error)
+
+	// Skill ratings
+	GetRatings(game string, userIDs []int) (map[int]Rating, error)
+	SaveRatings(ratings []Rating) error
+	TopRatings(game string, limit int) ([]Rating, error)
+	UserRatings(userID int) ([]Rating, error)
+
+	// Game state and per-chat settings
+	SaveGameState(collectionName string, chatID int64, state interface{}) error
+	LoadAllGameStates(collectionName string, target interface{}) error
+	LoadChatSettings(collectionName string, chatID int64, target interface{}) error
+	SaveChatSettings(collectionName string, chatID int64, fields bson.M) error
+
+	// Eordle
+	HasFreeEordle(userID int) bool
+	UseFreeEordle(userID int)
+
+	// Profiles
+	GetUserProfile(userID int64, username string) (*model.UserProfile, error)
+	UpdateUserProfile(profile *model.UserProfile) error
+	UpdateUserProfileFields(userID int64, updateFields bson.M) error
+	IncrementUserProfileStats(userID int64, incFields bson.M) error
+	ClaimUserProfileReward(userID int64, key string, incFields bson.M) (bool, error)
+
+	// Emojis
+	GetEquippedEmojis(userID int) ([]string, error)
+	GetPurchasedEmojis(userID int) ([]string, error)
+	PurchaseEmoji(userID int, emoji string) error
+	ToggleEquipEmoji(userID int, emoji string) (bool, error)
+
+	// Collectibles
+	GetTemplates() ([]collectible.Template, error)
+	BootstrapTemplates() error
+	GetNextSerialNumber(templateID string) (int, error)
+	MintItem(item collectible.Item) (collectible.Item, error)
+	GetUserInventory(userID int) ([]collectible.Item, error)
+	GetItemByID(itemID string) (collectible.Item, error)
+	CreateListing(listing collectible.MarketListing) error
+	GetListings() ([]collectible.MarketListing, error)
+	GetListingByID(listingID string) (collectible.MarketListing, error)
+	BuyPack(payment PointsEntry, item collectible.Item) (collectible.Item, error)
+	BuyListing(listing collectible.MarketListing, payment, sale PointsEntry) error
+	DeleteListing(listingID string) error
+
+	// Ephemeral whispers
+	UpsertEphemeralRequest(userID int64, chatID int64, ephemeralMsgID int)
+	TakeEphemeralRequest(userID int64) (*EphemeralRequestDoc, error)
+	UpsertKnownUser(username string, userID int64)
+	GetKnownUser(username string) (int64, bool)
+	AddStoredWhisper(userID int64, w StoredWhisperDoc)
+	TakeStoredWhispers(userID int64) []StoredWhisperDoc
+	UpsertWhisperSource(src WhisperSourceDoc)
+	TakeWhisperSource(ephemeralMsgID int64) (*WhisperSourceDoc, error)
+	SetLatestUserEphemeral(userID int64, ephemeralMsgID int64)
+	GetLatestUserEphemeral(userID int64) int64
+
+	// Word packs
+	SaveWordPack(pack WordPack) error
+	ChatWordPacks(chatID int64) ([]WordPack, error)
+	DeleteWordPack(chatID int64, name string) error
+
+	// Personal data
+	GetUserData(userID int) (UserData, error)
+	ForgetUser(userID int) (ForgetReport, error)
+}
+
+// MongoStore implements Store on top of the package-level MongoDB helpers.
+type MongoStore struct {
+	client *mongo.Client
+}
+
+// NewMongoStore wraps an already connected client.
+func NewMongoStore(client *mongo.Client) *MongoStore {
+	return &MongoStore{client: client}
+}
+
+// Client exposes the underlying client for code paths not yet moved onto Store.
+func (s *MongoStore) Client() *mongo.Client {
+	return s.client
+}
+
+func (s *MongoStore) InsertDoc(ID int, Name string, chatID int64, collection string) {
+	InsertDoc(ID, Name, chatID, s.client, collection)
+}
+
+func (s *MongoStore) InsertWordleDoc(ID int, Name string, chatID int64, collection string, attempts int) {
+	InsertWordleDoc(ID, Name, chatID, s.client, collection, attempts)
+}
+
+func (s *MongoStore) InsertWordleBonusDoc(ID int, Name string, chatID int64, collection string, points int) {
+	InsertWordleBonusDoc(ID, Name, chatID, s.client, collection, points)
+}
+
+func (s *MongoStore) ReadAllDoc(collection string) []bson.M {
+	return ReadAllDoc(s.client, collection)
+}
+
+func (s *MongoStore) CountIDOccurrences(collection string, chatID int64, window Window) ([]map[string]interface{}, error) {
+	return CountIDOccurrences(s.client, collection, chatID, window)
+}
+
+func (s *MongoStore) GetUserStatsByID(collection string, userID int) (map[string]interface{}, error) {
+	return GetUserStatsByID(s.client, collection, userID)
+}
+
+func (s *MongoStore) GetCurrentPoints(userID int) int {
+	return GetCurrentPoints(s.client, userID)
+}
+
+func (s *MongoStore) ApplyPoints(entry PointsEntry) (int, error) {
+	return ApplyPoints(s.client, entry)
+}
+
+func (s *MongoStore) ReconcilePoints() (int, error) {
+	return ReconcilePoints(s.client)
+}
+
+func (s *MongoStore) ScoresBetween(collection string, since, until time.Time) ([]ChatScore, error) {
+	return ScoresBetween(s.client, collection, since, until)
+}
+
+func (s *MongoStore) ArchiveSeason(season model.Season) error {
+	return ArchiveSeason(s.client, season)
+}
+
+func (s *MongoStore) MarkSeasonRewarded(n int) error {
+	return MarkSeasonRewarded(s.client, n)
+}
+
+func (s *MongoStore) GetSeason(n int) (model.Season, error) {
+	return GetSeason(s.client, n)
+}
+
+func (s *MongoStore) FirstSeason(n int) (int, error) {
+	return FirstSeason(s.client, n)
+}
+
+func (s *MongoStore) GetRatings(game string, userIDs []int) (map[int]Rating, error) {
+	return GetRatings(s.client, game, userIDs)
+}
+
+func (s *MongoStore) SaveRatings(ratings []Rating) error {
+	return SaveRatings(s.client, ratings)
+}
+
+func (s *MongoStore) TopRatings(game string, limit int) ([]Rating, error) {
+	return TopRatings(s.client, game, limit)
+}
+
+func (s *MongoStore) UserRatings(userID int) ([]Rating, error) {
+	return UserRatings(s.client, userID)
+}
+
+func (s *MongoStore) SaveGameState(collectionName string, chatID int64, state interface{}) error {
+	return SaveGameState(s.client, collectionName, chatID, state)
+}
+
+func (s *MongoStore) LoadAllGameStates(collectionName string, target interface{}) error {
+	return LoadAllGameStates(s.client, collectionName, target)
+}
+
+func (s *MongoStore) LoadChatSettings(collectionName string, chatID int64, target interface{}) error {
+	return LoadChatSettings(s.client, collectionName, chatID, target)
+}
+
+func (s *MongoStore) SaveChatSettings(collectionName string, chatID int64, fields bson.M) error {
+	return SaveChatSettings(s.client, collectionName, chatID, fields)
+}
+
+func (s *MongoStore) HasFreeEordle(userID int) bool {
+	return HasFreeEordle(s.client, userID)
+}
+
+func (s *MongoStore) UseFreeEordle(userID int) {
+	UseFreeEordle(s.client, userID)
+}
+
+func (s *MongoStore) GetUserProfile(userID int64, username string) (*model.UserProfile, error) {
+	return GetUserProfile(s.client, userID, username)
+}
+
+func (s *MongoStore) UpdateUserProfile(profile *model.UserProfile) error {
+	return UpdateUserProfile(s.client, profile)
+}
+
+func (s *MongoStore) UpdateUserProfileFields(userID int64, updateFields bson.M) error {
+	return UpdateUserProfileFields(s.client, userID, updateFields)
+}
+
+func (s *MongoStore) IncrementUserProfileStats(userID int64, incFields bson.M) error {
+	return IncrementUserProfileStats(s.client, userID, incFields)
+}
+
+func (s *MongoStore) ClaimUserProfileReward(userID int64, key string, incFields bson.M) (bool, error) {
+	return ClaimUserProfileReward(s.client, userID, key, incFields)
+}
+
+func (s *MongoStore) GetEquippedEmojis(userID int) ([]string, error) {
+	return GetEquippedEmojis(s.client, userID)
+}
+
+func (s *MongoStore) GetPurchasedEmojis(userID int) ([]string, error) {
+	return GetPurchasedEmojis(s.client, userID)
+}
+
+func (s *MongoStore) PurchaseEmoji(userID int, emoji string) error {
+	return PurchaseEmoji(s.client, userID, emoji)
+}
+
+func (s *MongoStore) ToggleEquipEmoji(userID int, emoji string) (bool, error) {
+	return ToggleEquipEmoji(s.client, userID, emoji)
+}
+
+func (s *MongoStore) GetTemplates() ([]collectible.Template, error) {
+	return collectibleRepo.GetTemplates(s.client)
+}
+
+func (s *MongoStore) BootstrapTemplates() error {
+	return collectibleRepo.BootstrapTemplates(s.client)
+}
+
+func (s *MongoStore) GetNextSerialNumber(templateID string) (int, error) {
+	return collectibleRepo.GetNextSerialNumber(s.client, templateID)
+}
+
+func (s *MongoStore) MintItem(item collectible.Item) (collectible.Item, error) {
+	return collectibleRepo.MintItem(s.client, item)
+}
+
+func (s *MongoStore) GetUserInventory(userID int) ([]collectible.Item, error) {
+	return collectibleRepo.GetUserInventory(s.client, userID)
+}
+
+func (s *MongoStore) GetItemByID(itemID string) (collectible.Item, error) {
+	return collectibleRepo.GetItemByID(s.client, itemID)
+}
+
+func (s *MongoStore) CreateListing(listing collectible.MarketListing) error {
+	return collectibleRepo.CreateListing(s.client, listing)
+}
+
+func (s *MongoStore) GetListings() ([]collectible.MarketListing, error) {
+	return collectibleRepo.GetListings(s.client)
+}
+
+func (s *MongoStore) GetListingByID(listingID string) (collectible.MarketListing, error) {
+	return collectibleRepo.GetListingByID(s.client, listingID)
+}
+
+func (s *MongoStore) BuyPack(payment PointsEntry, item collectible.Item) (collectible.Item, error) {
+	return BuyPack(s.client, payment, item)
+}
+
+func (s *MongoStore) BuyListing(listing collectible.MarketListing, payment, sale PointsEntry) error {
+	return BuyListing(s.client, listing, payment, sale)
+}
+
+func (s *MongoStore) DeleteListing(listingID string) error {
+	return collectibleRepo.DeleteListing(s.client, listingID)
+}
+
+func (s *MongoStore) UpsertEphemeralRequest(userID int64, chatID int64, ephemeralMsgID int) {
+	UpsertEphemeralRequest(s.client, userID, chatID, ephemeralMsgID)
+}
+
+func (s *MongoStore) TakeEphemeralRequest(userID int64) (*EphemeralRequestDoc, error) {
+	return TakeEphemeralRequest(s.client, userID)
+}
+
+func (s *MongoStore) UpsertKnownUser(username string, userID int64) {
+	UpsertKnownUser(s.client, username, userID)
+}
+
+func (s *MongoStore) GetKnownUser(username string) (int64, bool) {
+	return GetKnownUser(s.client, username)
+}
+
+func (s *MongoStore) AddStoredWhisper(userID int64, w StoredWhisperDoc) {
+	AddStoredWhisper(s.client, userID, w)
+}
+
+func (s *MongoStore) TakeStoredWhispers(userID int64) []StoredWhisperDoc {
+	return TakeStoredWhispers(s.client, userID)
+}
+
+func (s *MongoStore) UpsertWhisperSource(src WhisperSourceDoc) {
+	UpsertWhisperSource(s.client, src)
+}
+
+func (s *MongoStore) TakeWhisperSource(ephemeralMsgID int64) (*WhisperSourceDoc, error) {
+	return TakeWhisperSource(s.client, ephemeralMsgID)
+}
+
+func (s *MongoStore) SetLatestUserEphemeral(userID int64, ephemeralMsgID int64) {
+	SetLatestUserEphemeral(s.client, userID, ephemeralMsgID)
+}
+
+func (s *MongoStore) GetLatestUserEphemeral(userID int64) int64 {
+	return GetLatestUserEphemeral(s.client, userID)
+}
+
+var (
+	_ Store = (*MongoStore)(nil)
+	_ Store = (*MemoryStore)(nil)
+)
+
+func (s *MongoStore) SaveWordPack(pack WordPack) error {
+	return SaveWordPack(s.client, pack)
+}
+
+func (s *MongoStore) ChatWordPacks(chatID int64) ([]WordPack, error) {
+	return ChatWordPacks(s.client, chatID)
+}
+
+func (s *MongoStore) DeleteWordPack(chatID int64, name string) error {
+	return DeleteWordPack(s.client, chatID, name)
+}
+
+func (s *MongoStore) GetUserData(userID int) (UserData, error) {
+	return GetUserData(s.client, userID)
+}
+
+func (s *MongoStore) ForgetUser(userID int) (ForgetReport, error) {
+	return ForgetUser(s.client, userID)
+}
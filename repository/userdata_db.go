@@ -0,0 +1,414 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// PrivacyAuditCollection records every ForgetUser run.
+const PrivacyAuditCollection = "PrivacyAudit"
+
+// DeletedUserName replaces a forgotten user's name where a record has to stay,
+// such as an archived season's standings.
+const DeletedUserName = "Deleted user"
+
+// UserData is everything stored about one user, by collection.
+type UserData map[string][]bson.M
+
+// ForgetReport counts the documents ForgetUser deleted or anonymised, by
+// collection.
+type ForgetReport map[string]int
+
+// PrivacyAuditEntry is the audit record of a ForgetUser run.
+type PrivacyAuditEntry struct {
+	UserID   int          `bson:"user_id"`
+	Action   string       `bson:"action"`
+	Affected ForgetReport `bson:"affected"`
+	Error    string       `bson:"error,omitempty"`
+	At       time.Time    `bson:"at"`
+}
+
+// What ForgetUser does with a user's documents in a collection.
+type forgetMode int
+
+const (
+	forgetDelete    forgetMode = iota
+	forgetAnonymise            // apply the source's set, unlinking the document from the user
+	forgetPull                 // remove the user's entries from the source's arrays, keeping the document
+	forgetKeep                 // shown by GetUserData, never removed
+)
+
+// userDataSource is a collection holding documents keyed to a user.
+type userDataSource struct {
+	collection string
+	field      string // the field holding the user's ID, within an entry for forgetPull
+	forget     forgetMode
+	set        bson.M   // for forgetAnonymise
+	arrays     []string // for forgetPull: the arrays of per-user entries
+	settings   bool     // the collection is in the settings database
+}
+
+// scoreCollections hold one document per point scored, keyed by "ID".
+var scoreCollections = []string{"CrocEn", "CrocEnLeader", "WordleEn", "ScramyEn", "GeographyPoints", "WordGridPoints", "AnimePoints"}
+
+// userDataSources lists every collection with documents keyed to a user. A
+// new collection holding user data must be added here, or /mydata and
+// /forgetme will miss it. Whispers a user sent, ledger counterparties, season
+// standings and Daily Wordle board entries sit inside other documents and are
+// handled separately.
+var userDataSources = func() []userDataSource {
+	var sources []userDataSource
+	for _, c := range scoreCollections {
+		sources = append(sources, userDataSource{collection: c, field: "ID", forget: forgetDelete})
+	}
+	return append(sources,
+		userDataSource{collection: PointAccountsCollection, field: "_id", forget: forgetDelete},
+		userDataSource{collection: PointEntriesCollection, field: "user_id", forget: forgetDelete},
+		userDataSource{collection: userProfilesCollection, field: "user_id", forget: forgetDelete},
+		userDataSource{collection: "UserEmojis", field: "UserID", forget: forgetDelete},
+		userDataSource{collection: RatingsCollection, field: "user_id", forget: forgetDelete},
+		userDataSource{collection: "EordleUsage", field: "ID", forget: forgetDelete},
+		// Collectibles carry serial numbers, so they stay minted but ownerless.
+		userDataSource{collection: "Collectibles", field: "owner_id", forget: forgetAnonymise, set: bson.M{"owner_id": 0}},
+		userDataSource{collection: "MarketListings", field: "seller_id", forget: forgetDelete},
+		userDataSource{collection: "StoredWhispers", field: "_id", forget: forgetDelete},
+		userDataSource{collection: "WhisperEphemeralSources", field: "sender_id", forget: forgetDelete},
+		userDataSource{collection: "LatestUserEphemeral", field: "_id", forget: forgetDelete},
+		userDataSource{collection: "EphemeralRequests", field: "_id", forget: forgetDelete},
+		userDataSource{collection: "KnownUserIDs", field: "user_id", forget: forgetDelete},
+		userDataSource{collection: "ModViolations", field: "user_id", forget: forgetDelete},
+		// Scheduled messages belong to their chat; only the author is removed.
+		userDataSource{collection: "ScheduledMessages", field: "added_by", forget: forgetAnonymise, set: bson.M{"added_by": 0}},
+		// Word packs belong to the chat they were uploaded to.
+		userDataSource{collection: WordPacksCollection, field: "uploaded_by", forget: forgetAnonymise, set: bson.M{"uploaded_by": 0}},
+		userDataSource{collection: DailyWordleStreaksCollection, field: "_id", forget: forgetDelete, settings: true},
+		// A chat's leader queue and teams keep everyone else.
+		userDataSource{collection: LeaderRotationCollection, field: "id", forget: forgetPull, arrays: []string{"players"}, settings: true},
+		userDataSource{collection: TeamMatchesCollection, field: "id", forget: forgetPull, arrays: []string{"red", "blue"}, settings: true},
+		// Bans and admin grants must outlive a deletion request, or a banned
+		// user could lift their own ban.
+		userDataSource{collection: "ModGlobalBans", field: "_id", forget: forgetKeep},
+		userDataSource{collection: "ModGlobalAdmins", field: "_id", forget: forgetKeep},
+	)
+}()
+
+// Settings-database collections of per-chat state that lists players: the
+// leader rotation queues and the team matches.
+const (
+	LeaderRotationCollection = "LeaderRotation"
+	TeamMatchesCollection    = "TeamMatches"
+)
+
+// Keys of the UserData entries that are pieces of other documents.
+const (
+	userDataSentWhispers    = "StoredWhispers (sent)"
+	userDataSeasonStandings = SeasonsCollection
+	userDataDailyBoards     = DailyWordleBoardsCollection
+)
+
+// sourceFilter matches the documents of the source that hold the user.
+func sourceFilter(src userDataSource, userID int) bson.M {
+	if src.forget != forgetPull {
+		return bson.M{src.field: userID}
+	}
+	or := bson.A{}
+	for _, array := range src.arrays {
+		or = append(or, bson.M{array + "." + src.field: userID})
+	}
+	return bson.M{"$or": or}
+}
+
+// userEntries returns the user's entries in the arrays of a forgetPull
+// source's document, each with the document's _id as chat_id and the array
+// it came from.
+func userEntries(src userDataSource, doc bson.M, userID int) []bson.M {
+	var entries []bson.M
+	for _, array := range src.arrays {
+		for _, e := range arrayEntries(doc, array) {
+			if toInt(e[src.field]) != userID {
+				continue
+			}
+			e = copyDoc(e)
+			e["chat_id"], e["list"] = doc["_id"], array
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// arrayEntries returns the documents in doc's array field, whatever type it
+// was stored as.
+func arrayEntries(doc bson.M, array string) []bson.M {
+	wrapped, err := toDoc(bson.M{"a": doc[array]})
+	if err != nil {
+		return nil
+	}
+	list, _ := wrapped["a"].(bson.A)
+	entries := make([]bson.M, 0, len(list))
+	for _, v := range list {
+		if e, ok := v.(bson.M); ok {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// sourceDB returns the database holding the source's collection.
+func sourceDB(client *mongo.Client, src userDataSource) *mongo.Database {
+	if src.settings {
+		return client.Database(config.App.Mongo.SettingsDatabase)
+	}
+	return client.Database(config.App.Mongo.Database)
+}
+
+// GetUserData returns every document keyed to the user, and the pieces of
+// other documents that are about them. Collections with nothing are left out.
+func GetUserData(client *mongo.Client, userID int) (UserData, error) {
+	if client == nil {
+		return nil, fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	db := client.Database(config.App.Mongo.Database)
+
+	data := make(UserData)
+	for _, src := range userDataSources {
+		cursor, err := sourceDB(client, src).Collection(src.collection).Find(ctx, sourceFilter(src, userID))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", src.collection, err)
+		}
+		var docs []bson.M
+		if err := cursor.All(ctx, &docs); err != nil {
+			return nil, fmt.Errorf("%s: %w", src.collection, err)
+		}
+		if src.forget == forgetPull {
+			var entries []bson.M
+			for _, doc := range docs {
+				entries = append(entries, userEntries(src, doc, userID)...)
+			}
+			docs = entries
+		}
+		if len(docs) > 0 {
+			data[src.collection] = docs
+		}
+	}
+
+	var holders []StoredWhispersDoc
+	cursor, err := db.Collection("StoredWhispers").Find(ctx, bson.M{"whispers.sender_id": userID})
+	if err == nil {
+		err = cursor.All(ctx, &holders)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sent whispers: %w", err)
+	}
+	if sent := sentWhispers(holders, userID); len(sent) > 0 {
+		data[userDataSentWhispers] = sent
+	}
+
+	var seasons []model.Season
+	cursor, err = db.Collection(SeasonsCollection).Find(ctx, seasonsOfUser(userID))
+	if err == nil {
+		err = cursor.All(ctx, &seasons)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("seasons: %w", err)
+	}
+	if standings := seasonStandingsOf(seasons, userID); len(standings) > 0 {
+		data[userDataSeasonStandings] = standings
+	}
+
+	settingsDB := client.Database(config.App.Mongo.SettingsDatabase)
+	var boards []dailyBoardDoc
+	cursor, err = settingsDB.Collection(DailyWordleBoardsCollection).Find(ctx, bson.M{"entries.id": userID})
+	if err == nil {
+		err = cursor.All(ctx, &boards)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("daily boards: %w", err)
+	}
+	if entries := dailyBoardEntriesOf(boards, userID); len(entries) > 0 {
+		data[userDataDailyBoards] = entries
+	}
+	return data, nil
+}
+
+// ForgetUser deletes or anonymises everything keyed to the user, as
+// userDataSources says, and records the run in PrivacyAuditCollection. It
+// carries on past a failing collection so one error does not leave the rest
+// untouched; the errors are returned together.
+func ForgetUser(client *mongo.Client, userID int) (ForgetReport, error) {
+	if client == nil {
+		return nil, fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	db := client.Database(config.App.Mongo.Database)
+
+	report := make(ForgetReport)
+	var errs []error
+	count := func(collection string, n int64, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", collection, err))
+		}
+		if n > 0 {
+			report[collection] += int(n)
+		}
+	}
+
+	for _, src := range userDataSources {
+		coll := sourceDB(client, src).Collection(src.collection)
+		filter := sourceFilter(src, userID)
+		switch src.forget {
+		case forgetDelete:
+			res, err := coll.DeleteMany(ctx, filter)
+			count(src.collection, deletedCount(res), err)
+		case forgetAnonymise:
+			res, err := coll.UpdateMany(ctx, filter, bson.M{"$set": src.set})
+			count(src.collection, modifiedCount(res), err)
+		case forgetPull:
+			pull := bson.M{}
+			for _, array := range src.arrays {
+				pull[array] = bson.M{src.field: userID}
+			}
+			res, err := coll.UpdateMany(ctx, filter, bson.M{"$pull": pull})
+			count(src.collection, modifiedCount(res), err)
+		}
+	}
+
+	res, err := db.Collection(PointEntriesCollection).UpdateMany(ctx,
+		bson.M{"counterparty": userID},
+		bson.M{"$unset": bson.M{"counterparty": ""}})
+	count(PointEntriesCollection, modifiedCount(res), err)
+
+	res, err = db.Collection("StoredWhispers").UpdateMany(ctx,
+		bson.M{"whispers.sender_id": userID},
+		bson.M{"$pull": bson.M{"whispers": bson.M{"sender_id": userID}}})
+	count("StoredWhispers", modifiedCount(res), err)
+
+	var seasons []model.Season
+	cursor, err := db.Collection(SeasonsCollection).Find(ctx, seasonsOfUser(userID))
+	if err == nil {
+		err = cursor.All(ctx, &seasons)
+	}
+	count(SeasonsCollection, 0, err)
+	for _, season := range seasons {
+		if !anonymiseStandings(&season, userID) {
+			continue
+		}
+		_, err := db.Collection(SeasonsCollection).ReplaceOne(ctx, bson.M{"_id": season.Number}, season)
+		if err == nil {
+			report[SeasonsCollection]++
+		}
+		count(SeasonsCollection, 0, err)
+	}
+
+	// A group's board keeps everyone else's results.
+	res, err = client.Database(config.App.Mongo.SettingsDatabase).Collection(DailyWordleBoardsCollection).UpdateMany(ctx,
+		bson.M{"entries.id": userID},
+		bson.M{"$pull": bson.M{"entries": bson.M{"id": userID}}})
+	count(DailyWordleBoardsCollection, modifiedCount(res), err)
+
+	forgetErr := errors.Join(errs...)
+	entry := PrivacyAuditEntry{UserID: userID, Action: "forget", Affected: report, At: time.Now()}
+	if forgetErr != nil {
+		entry.Error = forgetErr.Error()
+	}
+	if _, err := db.Collection(PrivacyAuditCollection).InsertOne(ctx, entry); err != nil {
+		forgetErr = errors.Join(forgetErr, fmt.Errorf("audit entry: %w", err))
+	}
+	return report, forgetErr
+}
+
+func seasonsOfUser(userID int) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"boards.global.user_id": userID},
+		bson.M{"boards.chats.standings.user_id": userID},
+	}}
+}
+
+// sentWhispers returns the undelivered whispers the user sent, out of the
+// stored whispers of their recipients.
+func sentWhispers(holders []StoredWhispersDoc, userID int) []bson.M {
+	var sent []bson.M
+	for _, h := range holders {
+		for _, w := range h.Whispers {
+			if w.SenderID != int64(userID) {
+				continue
+			}
+			if doc, err := toDoc(w); err == nil {
+				doc["recipient_id"] = h.UserID
+				sent = append(sent, doc)
+			}
+		}
+	}
+	return sent
+}
+
+// seasonStandingsOf returns the user's places in archived season standings.
+func seasonStandingsOf(seasons []model.Season, userID int) []bson.M {
+	var places []bson.M
+	add := func(season model.Season, board model.SeasonBoard, chatID int64, standings []model.SeasonStanding) {
+		for _, s := range standings {
+			if s.UserID != userID {
+				continue
+			}
+			place := bson.M{"season": season.Number, "board": board.Title, "rank": s.Rank, "name": s.Name, "score": s.Score}
+			if chatID != 0 {
+				place["chat_id"] = chatID
+			}
+			places = append(places, place)
+		}
+	}
+	for _, season := range seasons {
+		for _, board := range season.Boards {
+			add(season, board, 0, board.Global)
+			for _, chat := range board.Chats {
+				add(season, board, chat.ChatID, chat.Standings)
+			}
+		}
+	}
+	return places
+}
+
+// anonymiseStandings replaces the user's name and ID in season's standings
+// and reports whether there were any.
+func anonymiseStandings(season *model.Season, userID int) bool {
+	changed := false
+	anonymise := func(standings []model.SeasonStanding) {
+		for i := range standings {
+			if standings[i].UserID == userID {
+				standings[i].UserID = 0
+				standings[i].Name = DeletedUserName
+				changed = true
+			}
+		}
+	}
+	for _, board := range season.Boards {
+		anonymise(board.Global)
+		for _, chat := range board.Chats {
+			anonymise(chat.Standings)
+		}
+	}
+	return changed
+}
+
+func deletedCount(res *mongo.DeleteResult) int64 {
+	if res == nil {
+		return 0
+	}
+	return res.DeletedCount
+}
+
+func modifiedCount(res *mongo.UpdateResult) int64 {
+	if res == nil {
+		return 0
+	}
+	return res.ModifiedCount
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model/collectible"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// seedUserData stores something about users 1 and 2 in every kind of record.
+func seedUserData(t *testing.T, store *MemoryStore) {
+	t.Helper()
+	store.InsertDoc(1, "alice", 100, "CrocEn")
+	store.InsertDoc(2, "bob", 100, "CrocEn")
+	store.InsertWordleDoc(1, "alice", 100, "WordleEn", 3)
+	store.GetCurrentPoints(1) // opens the points account
+	if _, err := store.ApplyPoints(PointsEntry{Key: "transfer:1", UserID: 2, Amount: 5, Reason: "Gift", Counterparty: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveRatings([]Rating{{Game: "wordle", UserID: 1, Rating: 1550}, {Game: "wordle", UserID: 2, Rating: 1450}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.GetUserProfile(1, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.PurchaseEmoji(1, "🔥"); err != nil {
+		t.Fatal(err)
+	}
+	store.UseFreeEordle(1)
+	if _, err := store.MintItem(collectible.Item{ID: "item1", TemplateID: "t", SerialNumber: 7, OwnerID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateListing(collectible.MarketListing{ID: "listing1", ItemID: "item1", SellerID: 1, Price: 10}); err != nil {
+		t.Fatal(err)
+	}
+	store.UpsertKnownUser("alice", 1)
+	store.AddStoredWhisper(1, StoredWhisperDoc{Text: "for alice", SenderID: 2})
+	store.AddStoredWhisper(2, StoredWhisperDoc{Text: "from alice", SenderID: 1})
+	store.AddStoredWhisper(2, StoredWhisperDoc{Text: "from carol", SenderID: 3})
+	store.UpsertWhisperSource(WhisperSourceDoc{EphemeralMsgID: 55, SenderID: 1})
+	store.SetLatestUserEphemeral(1, 55)
+	store.UpsertEphemeralRequest(1, 100, 55)
+	store.SaveChatSettings(DailyWordleStreaksCollection, 1, bson.M{"current": 3, "max": 3})
+	store.SaveChatSettings(DailyWordleStreaksCollection, 2, bson.M{"current": 1, "max": 4})
+	store.SaveChatSettings(DailyWordleBoardsCollection, 100, bson.M{"day": 9, "entries": []bson.M{
+		{"id": 1, "name": "alice", "guesses": 3, "won": true},
+		{"id": 2, "name": "bob", "guesses": 5, "won": true},
+	}})
+	store.SaveChatSettings(LeaderRotationCollection, 100, bson.M{"enabled": true, "players": []bson.M{
+		{"id": 2, "name": "bob"},
+		{"id": 1, "name": "alice"},
+	}})
+	store.SaveChatSettings(TeamMatchesCollection, 100, bson.M{"active": true, "red": []bson.M{{"id": 1, "name": "alice", "guessed": 2}}, "blue": []bson.M{{"id": 2, "name": "bob"}}})
+	standings := []model.SeasonStanding{{Rank: 1, UserID: 1, Name: "alice", Score: 20}, {Rank: 2, UserID: 2, Name: "bob", Score: 10}}
+	if err := store.ArchiveSeason(model.Season{Number: 1, Boards: []model.SeasonBoard{{
+		Collection: "WordleEn",
+		Title:      "Wordle",
+		Global:     standings,
+		Chats:      []model.SeasonChat{{ChatID: 100, Standings: append([]model.SeasonStanding(nil), standings...)}},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMemoryStoreUserData(t *testing.T) {
+	store := NewMemoryStore()
+	seedUserData(t, store)
+
+	data, err := store.GetUserData(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for collection, want := range map[string]int{
+		"CrocEn":                     1,
+		"WordleEn":                   1,
+		PointAccountsCollection:      1,
+		userProfilesCollection:       1,
+		"UserEmojis":                 1,
+		RatingsCollection:            1,
+		"EordleUsage":                1,
+		"Collectibles":               1,
+		"MarketListings":             1,
+		"StoredWhispers":             1,
+		"WhisperEphemeralSources":    1,
+		"LatestUserEphemeral":        1,
+		"EphemeralRequests":          1,
+		"KnownUserIDs":               1,
+		userDataSentWhispers:         1,
+		userDataSeasonStandings:      2,
+		DailyWordleStreaksCollection: 1,
+		userDataDailyBoards:          1,
+		LeaderRotationCollection:     1,
+		TeamMatchesCollection:        1,
+	} {
+		if got := len(data[collection]); got != want {
+			t.Errorf("%s: %d documents, want %d", collection, got, want)
+		}
+	}
+	if got := data[userDataSentWhispers][0]["recipient_id"]; got != int64(2) {
+		t.Errorf("sent whisper recipient = %v, want 2", got)
+	}
+	if team := data[TeamMatchesCollection][0]; team["list"] != "red" || team["chat_id"] != int64(100) || toInt(team["guessed"]) != 2 {
+		t.Errorf("team entry = %v, want alice's place on red in chat 100", team)
+	}
+	for _, e := range data[PointEntriesCollection] {
+		if toInt(e["user_id"]) != 1 {
+			t.Errorf("exported ledger entry %v of another user", e["_id"])
+		}
+	}
+}
+
+func TestMemoryStoreForgetUser(t *testing.T) {
+	store := NewMemoryStore()
+	seedUserData(t, store)
+
+	report, err := store.ForgetUser(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report["CrocEn"] != 1 || report["Collectibles"] != 1 || report[SeasonsCollection] != 1 ||
+		report[DailyWordleStreaksCollection] != 1 || report[DailyWordleBoardsCollection] != 1 ||
+		report[LeaderRotationCollection] != 1 || report[TeamMatchesCollection] != 1 {
+		t.Errorf("report = %v", report)
+	}
+
+	data, err := store.GetUserData(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 0 {
+		t.Errorf("data left after forgetting: %v", data)
+	}
+
+	// Other users keep their records, minus the references to the user.
+	if got := store.GetCurrentPoints(2); got != 5 {
+		t.Errorf("bob's points = %d, want 5", got)
+	}
+	for _, e := range store.pointEntries {
+		if e.Counterparty == 1 {
+			t.Errorf("entry %s still names the user as counterparty", e.Key)
+		}
+	}
+	if whispers := store.storedWhispers[2]; len(whispers) != 1 || whispers[0].SenderID != 3 {
+		t.Errorf("bob's whispers = %+v, want only carol's", whispers)
+	}
+	item, err := store.GetItemByID("item1")
+	if err != nil || item.OwnerID != 0 || item.SerialNumber != 7 {
+		t.Errorf("item = %+v, %v; want it kept without an owner", item, err)
+	}
+	season, err := store.GetSeason(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := season.Boards[0]
+	for _, standings := range [][]model.SeasonStanding{board.Global, board.Chat(100)} {
+		if s := standings[0]; s.UserID != 0 || s.Name != DeletedUserName || s.Score != 20 {
+			t.Errorf("first place = %+v, want it anonymised", s)
+		}
+		if standings[1].Name != "bob" {
+			t.Errorf("second place = %+v, want bob", standings[1])
+		}
+	}
+	var streak struct {
+		Max int `bson:"max"`
+	}
+	if err := store.LoadChatSettings(DailyWordleStreaksCollection, 2, &streak); err != nil || streak.Max != 4 {
+		t.Errorf("bob's streak = %+v, %v; want it kept", streak, err)
+	}
+	var b dailyBoardDoc
+	if err := store.LoadChatSettings(DailyWordleBoardsCollection, 100, &b); err != nil || len(b.Entries) != 1 || b.Entries[0].ID != 2 {
+		t.Errorf("daily board = %+v, %v; want only bob's result", b, err)
+	}
+	type member struct {
+		ID int `bson:"id"`
+	}
+	var queue struct {
+		Enabled bool     `bson:"enabled"`
+		Players []member `bson:"players"`
+	}
+	if err := store.LoadChatSettings(LeaderRotationCollection, 100, &queue); err != nil || !queue.Enabled || len(queue.Players) != 1 || queue.Players[0].ID != 2 {
+		t.Errorf("leader queue = %+v, %v; want only bob", queue, err)
+	}
+	var match struct {
+		Red  []member `bson:"red"`
+		Blue []member `bson:"blue"`
+	}
+	if err := store.LoadChatSettings(TeamMatchesCollection, 100, &match); err != nil || len(match.Red) != 0 || len(match.Blue) != 1 {
+		t.Errorf("teams = %+v, %v; want bob left on blue", match, err)
+	}
+	if audit := store.ReadAllDoc(PrivacyAuditCollection); len(audit) != 1 || toInt(audit[0]["user_id"]) != 1 {
+		t.Errorf("audit = %v, want one entry for the user", audit)
+	}
+}
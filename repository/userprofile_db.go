@@ -15,7 +15,7 @@ const userProfilesCollection = "UserProfiles"
 
 // GetUserProfile fetches a user profile by ID, creating one if it doesn't exist.
 func GetUserProfile(client *mongo.Client, userID int64, username string) (*model.UserProfile, error) {
-	collection := client.Database(config.App.DatabaseName).Collection(userProfilesCollection)
+	collection := client.Database(config.App.Mongo.Database).Collection(userProfilesCollection)
 
 	filter := bson.M{"user_id": userID}
 	update := bson.M{
@@ -49,7 +49,7 @@ func GetUserProfile(client *mongo.Client, userID int64, username string) (*model
 
 // UpdateUserProfile updates an existing user profile.
 func UpdateUserProfile(client *mongo.Client, profile *model.UserProfile) error {
-	collection := client.Database(config.App.DatabaseName).Collection(userProfilesCollection)
+	collection := client.Database(config.App.Mongo.Database).Collection(userProfilesCollection)
 
 	profile.UpdatedAt = time.Now()
 	filter := bson.M{"user_id": profile.UserID}
@@ -61,7 +61,7 @@ func UpdateUserProfile(client *mongo.Client, profile *model.UserProfile) error {
 
 // UpdateUserProfileFields updates specific fields of a user profile.
 func UpdateUserProfileFields(client *mongo.Client, userID int64, updateFields bson.M) error {
-	collection := client.Database(config.App.DatabaseName).Collection(userProfilesCollection)
+	collection := client.Database(config.App.Mongo.Database).Collection(userProfilesCollection)
 
 	updateFields["updated_at"] = time.Now()
 	filter := bson.M{"user_id": userID}
@@ -71,9 +71,29 @@ func UpdateUserProfileFields(client *mongo.Client, userID int64, updateFields bs
 	return err
 }
 
+// ClaimUserProfileReward increments incFields like IncrementUserProfileStats,
+// once per key: it reports false, changing nothing, if the reward with that
+// key was paid before. The profile must exist.
+func ClaimUserProfileReward(client *mongo.Client, userID int64, key string, incFields bson.M) (bool, error) {
+	collection := client.Database(config.App.Mongo.Database).Collection(userProfilesCollection)
+
+	filter := bson.M{"user_id": userID, "claimed_rewards": bson.M{"$ne": key}}
+	update := bson.M{
+		"$inc":      incFields,
+		"$addToSet": bson.M{"claimed_rewards": key},
+		"$set":      bson.M{"updated_at": time.Now()},
+	}
+
+	res, err := collection.UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
 // IncrementUserProfileStats increments numerical fields like XP, Coins, etc.
 func IncrementUserProfileStats(client *mongo.Client, userID int64, incFields bson.M) error {
-	collection := client.Database(config.App.DatabaseName).Collection(userProfilesCollection)
+	collection := client.Database(config.App.Mongo.Database).Collection(userProfilesCollection)
 
 	filter := bson.M{"user_id": userID}
 	update := bson.M{
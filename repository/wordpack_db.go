@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WordPacksCollection holds the word packs chats uploaded for the word-guess
+// games.
+const WordPacksCollection = "WordPacks"
+
+// ErrWordPackNotFound is returned when a chat has no pack by that name.
+var ErrWordPackNotFound = errors.New("word pack not found")
+
+// WordPack is a chat's own word pack.
+type WordPack struct {
+	ID         string              `bson:"_id"` // WordPackKey(ChatID, Name)
+	ChatID     int64               `bson:"chat_id"`
+	Name       string              `bson:"name"`
+	Title      string              `bson:"title"`
+	Words      map[string][]string `bson:"words"` // by difficulty
+	UploadedBy int                 `bson:"uploaded_by"`
+	CreatedAt  time.Time           `bson:"created_at"`
+}
+
+// WordPackKey is the _id of the chat's pack called name.
+func WordPackKey(chatID int64, name string) string {
+	return fmt.Sprintf("%d:%s", chatID, name)
+}
+
+// SaveWordPack stores the pack, replacing the chat's pack of the same name.
+func SaveWordPack(client *mongo.Client, pack WordPack) error {
+	if client == nil {
+		return fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pack.ID = WordPackKey(pack.ChatID, pack.Name)
+	_, err := wordPacks(client).ReplaceOne(ctx, bson.M{"_id": pack.ID}, pack, options.Replace().SetUpsert(true))
+	return err
+}
+
+// ChatWordPacks returns the chat's packs, by name.
+func ChatWordPacks(client *mongo.Client, chatID int64) ([]WordPack, error) {
+	if client == nil {
+		return nil, fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := wordPacks(client).Find(ctx, bson.M{"chat_id": chatID}, options.Find().SetSort(bson.M{"name": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var packs []WordPack
+	if err := cursor.All(ctx, &packs); err != nil {
+		return nil, err
+	}
+	return packs, nil
+}
+
+// DeleteWordPack removes the chat's pack called name.
+func DeleteWordPack(client *mongo.Client, chatID int64, name string) error {
+	if client == nil {
+		return fmt.Errorf("MongoDB client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := wordPacks(client).DeleteOne(ctx, bson.M{"_id": WordPackKey(chatID, name)})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrWordPackNotFound
+	}
+	return nil
+}
+
+func wordPacks(client *mongo.Client) *mongo.Collection {
+	return client.Database(config.App.Mongo.Database).Collection(WordPacksCollection)
+}
@@ -0,0 +1,114 @@
+// Package closeguess tells players of the guessing games when a wrong guess
+// was nearly right, without letting the chat drown in "so close!" replies.
+package closeguess
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/agnivade/levenshtein"
+)
+
+// MaxEdits is how many edits past what a game accepts still count as close.
+const MaxEdits = 2
+
+// How often a chat may get a "so close!" reply, and a player a reaction.
+const (
+	ReplyEvery = 30 * time.Second
+	ReactEvery = 5 * time.Second
+)
+
+// Feedback is what to do about a guess.
+type Feedback int
+
+const (
+	None  Feedback = iota // not close, feedback is off or throttled
+	React                 // react to the guess
+	Reply                 // reply "so close!"
+)
+
+// key lowercases s and keeps only its letters and digits, so spacing,
+// punctuation and diacritics do not count as edits.
+func key(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Near reports whether guess is wrong but within MaxEdits of answer past the
+// allowed edits the game already accepts as right. At least half of the
+// answer has to survive the edits, so a short word is not close to anything.
+func Near(guess, answer string, allowed int) bool {
+	g, a := key(guess), key(answer)
+	if g == "" || g == a {
+		return false
+	}
+	d := levenshtein.ComputeDistance(g, a)
+	return d > allowed && d <= allowed+MaxEdits && 2*d < len([]rune(a))
+}
+
+type reactKey struct {
+	chatID int64
+	userID int
+}
+
+// Throttle decides, per chat, how to answer close guesses: a reply at most
+// every ReplyEvery, and in between a reaction at most every ReactEvery per
+// player.
+type Throttle struct {
+	mu      sync.Mutex
+	replied map[int64]time.Time
+	reacted map[reactKey]time.Time
+	now     func() time.Time
+}
+
+// NewThrottle returns a throttle that has answered nothing yet.
+func NewThrottle() *Throttle {
+	return &Throttle{replied: make(map[int64]time.Time), reacted: make(map[reactKey]time.Time), now: time.Now}
+}
+
+// Default is the throttle the bots share.
+var Default = NewThrottle()
+
+// Check returns the feedback the player's guess of answer earns in the chat.
+// allowed is how many edits the game accepts as a right answer.
+func (t *Throttle) Check(chatID int64, userID int, guess, answer string, allowed int) Feedback {
+	if !Near(guess, answer, allowed) {
+		return None
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	if now.Sub(t.replied[chatID]) >= ReplyEvery {
+		t.replied[chatID] = now
+		return Reply
+	}
+	k := reactKey{chatID, userID}
+	if now.Sub(t.reacted[k]) < ReactEvery {
+		return None
+	}
+	t.reacted[k] = now
+	if len(t.reacted) > 1000 {
+		for k, at := range t.reacted {
+			if now.Sub(at) >= ReactEvery {
+				delete(t.reacted, k)
+			}
+		}
+	}
+	return React
+}
+
+// Check is Default.Check in chats that have the feedback on.
+func Check(store repository.Store, chatID int64, userID int, guess, answer string, allowed int) Feedback {
+	if !Enabled(chatID, store) {
+		return None
+	}
+	return Default.Check(chatID, userID, guess, answer, allowed)
+}
@@ -0,0 +1,80 @@
+package closeguess
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+)
+
+func TestNear(t *testing.T) {
+	for _, tc := range []struct {
+		guess, answer string
+		allowed       int
+		want          bool
+	}{
+		{"elefant", "elephant", 0, true},
+		{"Elephent!", "elephant", 0, true},
+		{"elephant", "elephant", 0, false},
+		{"giraffe", "elephant", 0, false},
+		{"cot", "cat", 0, true},
+		{"ox", "on", 0, false},
+		{"", "cat", 0, false},
+		{"narutoo", "naruto", 1, false},
+		{"narutooo", "naruto", 1, true},
+		{"морской конек", "морской конёк", 0, true},
+	} {
+		if got := Near(tc.guess, tc.answer, tc.allowed); got != tc.want {
+			t.Errorf("Near(%q, %q, %d) = %v, want %v", tc.guess, tc.answer, tc.allowed, got, tc.want)
+		}
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	th := NewThrottle()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	th.now = func() time.Time { return now }
+
+	if got := th.Check(1, 7, "elefant", "elephant", 0); got != Reply {
+		t.Fatalf("first close guess = %v, want Reply", got)
+	}
+	if got := th.Check(1, 7, "elephent", "elephant", 0); got != React {
+		t.Errorf("close guess after a reply = %v, want React", got)
+	}
+	if got := th.Check(1, 7, "elephan", "elephant", 0); got != None {
+		t.Errorf("second reaction within %v = %v, want None", ReactEvery, got)
+	}
+	if got := th.Check(1, 8, "elephan", "elephant", 0); got != React {
+		t.Errorf("another player's close guess = %v, want React", got)
+	}
+	if got := th.Check(2, 7, "elefant", "elephant", 0); got != Reply {
+		t.Errorf("close guess in another chat = %v, want Reply", got)
+	}
+	now = now.Add(ReplyEvery)
+	if got := th.Check(1, 7, "elefant", "elephant", 0); got != Reply {
+		t.Errorf("close guess after %v = %v, want Reply", ReplyEvery, got)
+	}
+	if got := th.Check(1, 9, "giraffe", "elephant", 0); got != None {
+		t.Errorf("far guess = %v, want None", got)
+	}
+}
+
+func TestSetEnabled(t *testing.T) {
+	store := repository.NewMemoryStore()
+	if !Enabled(-3001, store) {
+		t.Fatal("feedback is off by default")
+	}
+	if err := SetEnabled(-3001, false, store); err != nil {
+		t.Fatal(err)
+	}
+	if got := Check(store, -3001, 7, "elefant", "elephant", 0); got != None {
+		t.Errorf("Check with feedback off = %v, want None", got)
+	}
+
+	settingsMutex.Lock()
+	delete(settingsCache, -3001)
+	settingsMutex.Unlock()
+	if Enabled(-3001, store) {
+		t.Error("feedback is back on after reloading the settings")
+	}
+}
@@ -0,0 +1,60 @@
+package closeguess
+
+import (
+	"log"
+	"sync"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ChatSettings are a chat's close-guess settings. Feedback is on unless the
+// chat turned it off.
+type ChatSettings struct {
+	ChatID int64 `bson:"_id"`
+	Off    bool  `bson:"off"`
+}
+
+const settingsCollection = "CloseGuessSettings"
+
+var (
+	settingsCache = make(map[int64]*ChatSettings)
+	settingsMutex sync.RWMutex
+)
+
+// Enabled reports whether the chat gets close-guess feedback, loading its
+// settings on first use.
+func Enabled(chatID int64, store repository.Store) bool {
+	settingsMutex.RLock()
+	settings, ok := settingsCache[chatID]
+	settingsMutex.RUnlock()
+	if ok {
+		return !settings.Off
+	}
+
+	settings = &ChatSettings{ChatID: chatID}
+	if store != nil {
+		if err := store.LoadChatSettings(settingsCollection, chatID, settings); err != nil {
+			log.Printf("Failed to load close-guess settings for chat %d: %v", chatID, err)
+		}
+	}
+
+	settingsMutex.Lock()
+	settingsCache[chatID] = settings
+	settingsMutex.Unlock()
+	return !settings.Off
+}
+
+// SetEnabled turns the chat's close-guess feedback on or off.
+func SetEnabled(chatID int64, on bool, store repository.Store) error {
+	Enabled(chatID, store)
+
+	settingsMutex.Lock()
+	settingsCache[chatID].Off = !on
+	settingsMutex.Unlock()
+
+	if store != nil {
+		return store.SaveChatSettings(settingsCollection, chatID, bson.M{"off": !on})
+	}
+	return nil
+}
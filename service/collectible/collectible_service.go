@@ -2,37 +2,36 @@ package collectible
 
 import (
 	"errors"
+	"log"
 	"math/rand"
 	"time"
 
 	model "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model/collectible"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
-	repo "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository/collectible"
-	"go.mongodb.org/mongo-driver/mongo"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/ledger"
 )
 
 const PackPrice = 201
 
-// OpenPack handles the logic for a user buying and opening a collectible pack
-func OpenPack(client *mongo.Client, userID int, name string, chatID int64) (model.Item, model.Template, error) {
-	// 1. Check user points
-	currentPoints := repository.GetCurrentPoints(client, userID)
-	if currentPoints < PackPrice {
+// OpenPack handles the logic for a user buying and opening a collectible pack.
+// key identifies the purchase, so a repeated request is charged only once.
+// The charge and the mint happen in one transaction: the user either pays and
+// gets the item, or keeps their points.
+func OpenPack(store repository.Store, key string, userID int, name string, chatID int64) (model.Item, model.Template, error) {
+	// 1. Check the user can afford the pack before rolling anything
+	if ledger.Balance(store, userID) < PackPrice {
 		return model.Item{}, model.Template{}, errors.New("not enough points")
 	}
 
-	// 2. Deduct points
-	repository.DeductWordlePoints(client, userID, name, chatID, PackPrice)
-
-	// 3. Roll for rarity
+	// 2. Roll for rarity
 	rarity := rollRarity()
 
-	// 4. Fetch templates and filter by rolled rarity
-	templates, err := repo.GetTemplates(client)
+	// 3. Fetch templates and filter by rolled rarity
+	templates, err := store.GetTemplates()
 	if err != nil || len(templates) == 0 {
 		// Fallback in case templates aren't loaded yet
-		_ = repo.BootstrapTemplates(client)
-		templates, _ = repo.GetTemplates(client)
+		_ = store.BootstrapTemplates()
+		templates, _ = store.GetTemplates()
 	}
 
 	var eligibleTemplates []model.Template
@@ -46,32 +45,36 @@ func OpenPack(client *mongo.Client, userID int, name string, chatID int64) (mode
 		return model.Item{}, model.Template{}, errors.New("no templates available for rolled rarity")
 	}
 
-	// 5. Select a random template from the eligible ones
+	// 4. Select a random template from the eligible ones
 	rand.Seed(time.Now().UnixNano())
 	selectedTemplate := eligibleTemplates[rand.Intn(len(eligibleTemplates))]
 
-	// 6. Get next serial number for this template
-	serialNum, err := repo.GetNextSerialNumber(client, selectedTemplate.ID)
-	if err != nil {
-		repository.InsertWordleBonusDoc(userID, name, chatID, client, "WordleEn", PackPrice)
-		return model.Item{}, model.Template{}, err
+	// 5. Charge the user and mint the item with the next serial number
+	payment := repository.PointsEntry{
+		Key:    key,
+		UserID: userID,
+		Name:   name,
+		ChatID: chatID,
+		Amount: -PackPrice,
+		Reason: "Collectible pack",
 	}
-
-	// 7. Mint the item
 	newItem := model.Item{
-		TemplateID:   selectedTemplate.ID,
-		SerialNumber: serialNum,
-		OwnerID:      userID,
-		MintedAt:     time.Now(),
+		TemplateID: selectedTemplate.ID,
+		OwnerID:    userID,
+		MintedAt:   time.Now(),
 	}
 
-	mintedItem, err := repo.MintItem(client, newItem)
-	if err != nil {
-		repository.InsertWordleBonusDoc(userID, name, chatID, client, "WordleEn", PackPrice)
+	mintedItem, err := store.BuyPack(payment, newItem)
+	switch err {
+	case nil:
+		return mintedItem, selectedTemplate, nil
+	case ledger.ErrInsufficientPoints:
+		return model.Item{}, model.Template{}, errors.New("not enough points")
+	case ledger.ErrDuplicate:
+		return model.Item{}, model.Template{}, errors.New("this pack was already opened")
+	default:
 		return model.Item{}, model.Template{}, err
 	}
-
-	return mintedItem, selectedTemplate, nil
 }
 
 // rollRarity simulates the gacha roll based on predefined probabilities
@@ -94,13 +97,13 @@ func rollRarity() model.Rarity {
 }
 
 // GetUserInventoryWithTemplates returns items alongside their templates for UI rendering
-func GetUserInventoryWithTemplates(client *mongo.Client, userID int) ([]model.Item, map[string]model.Template, error) {
-	items, err := repo.GetUserInventory(client, userID)
+func GetUserInventoryWithTemplates(store repository.Store, userID int) ([]model.Item, map[string]model.Template, error) {
+	items, err := store.GetUserInventory(userID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	templates, err := repo.GetTemplates(client)
+	templates, err := store.GetTemplates()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -114,13 +117,13 @@ func GetUserInventoryWithTemplates(client *mongo.Client, userID int) ([]model.It
 }
 
 // GetMarketplaceListingsWithDetails returns active listings alongside item and template info
-func GetMarketplaceListingsWithDetails(client *mongo.Client) ([]model.MarketListing, map[string]model.Item, map[string]model.Template, error) {
-	listings, err := repo.GetListings(client)
+func GetMarketplaceListingsWithDetails(store repository.Store) ([]model.MarketListing, map[string]model.Item, map[string]model.Template, error) {
+	listings, err := store.GetListings()
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	templates, err := repo.GetTemplates(client)
+	templates, err := store.GetTemplates()
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -131,7 +134,7 @@ func GetMarketplaceListingsWithDetails(client *mongo.Client) ([]model.MarketList
 
 	itemMap := make(map[string]model.Item)
 	for _, listing := range listings {
-		item, err := repo.GetItemByID(client, listing.ItemID)
+		item, err := store.GetItemByID(listing.ItemID)
 		if err == nil {
 			itemMap[item.ID] = item
 		}
@@ -140,9 +143,11 @@ func GetMarketplaceListingsWithDetails(client *mongo.Client) ([]model.MarketList
 	return listings, itemMap, templateMap, nil
 }
 
-// BuyItemFromMarketplace handles point deduction, seller reward, and ownership transfer
-func BuyItemFromMarketplace(client *mongo.Client, listingID string, buyerID int, buyerName string, chatID int64) error {
-	listing, err := repo.GetListingByID(client, listingID)
+// BuyItemFromMarketplace charges the buyer, pays the seller and hands over the
+// item in one transaction. If another buyer gets there first, or the listing
+// changed since it was read, nothing is charged.
+func BuyItemFromMarketplace(store repository.Store, listingID string, buyerID int, buyerName string, chatID int64) error {
+	listing, err := store.GetListingByID(listingID)
 	if err != nil {
 		return errors.New("listing not found")
 	}
@@ -151,29 +156,39 @@ func BuyItemFromMarketplace(client *mongo.Client, listingID string, buyerID int,
 		return errors.New("you cannot buy your own listing")
 	}
 
-	// Check buyer points
-	currentPoints := repository.GetCurrentPoints(client, buyerID)
-	if currentPoints < listing.Price {
+	payment := repository.PointsEntry{
+		Key:          ledger.Key("market", listingID, "payment"),
+		UserID:       buyerID,
+		Name:         buyerName,
+		ChatID:       chatID,
+		Amount:       -listing.Price,
+		Reason:       "Marketplace purchase",
+		Counterparty: listing.SellerID,
+	}
+	sale := repository.PointsEntry{
+		Key:          ledger.Key("market", listingID, "sale"),
+		UserID:       listing.SellerID,
+		Name:         listing.SellerName,
+		ChatID:       chatID,
+		Amount:       listing.Price,
+		Reason:       "Marketplace sale",
+		Counterparty: buyerID,
+	}
+
+	switch err := store.BuyListing(listing, payment, sale); err {
+	case nil:
+		return nil
+	case ledger.ErrInsufficientPoints:
 		return errors.New("not enough points")
-	}
-
-	// 1. Deduct points from buyer
-	repository.DeductWordlePoints(client, buyerID, buyerName, chatID, listing.Price)
-
-	// 2. Transfer item ownership & delete listing (simulate atomic action)
-	err = repo.ProcessPurchase(client, listingID, listing.ItemID, buyerID)
-	if err != nil {
-		// If this fails, we should ideally refund the buyer.
-		// For MVP, we refund immediately if transfer fails.
-		repository.InsertWordleBonusDoc(buyerID, buyerName, chatID, client, "WordleEn", listing.Price)
+	case repository.ErrListingGone, ledger.ErrDuplicate:
+		return errors.New("this item has already been sold")
+	case repository.ErrItemMoved:
+		// The listing can never be bought now, so take it off the market.
+		if err := store.DeleteListing(listing.ID); err != nil {
+			log.Printf("Failed to delete stale listing %s: %v", listing.ID, err)
+		}
+		return errors.New("the seller no longer owns this item")
+	default:
 		return err
 	}
-
-	// 3. Credit points to seller
-	// Note: We don't have the seller's name or chatID easily accessible here, so we might insert a placeholder or look it up.
-	// For now, we use a placeholder "Market Sale" name and a default chatID (0).
-	// The DB point aggregation only strictly requires UserID.
-	repository.InsertWordleBonusDoc(listing.SellerID, "Market Sale", 0, client, "WordleEn", listing.Price)
-
-	return nil
 }
@@ -0,0 +1,206 @@
+package collectible
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	model "github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model/collectible"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+)
+
+func TestBuyItemFromMarketplace(t *testing.T) {
+	store := repository.NewMemoryStore()
+	store.InsertWordleBonusDoc(1, "seller", 10, "WordleEn", 0)
+	store.InsertWordleBonusDoc(2, "buyer", 10, "WordleEn", 300)
+
+	item, _ := store.MintItem(model.Item{TemplateID: "t1", SerialNumber: 1, OwnerID: 1})
+	if err := store.CreateListing(model.MarketListing{ID: "l1", ItemID: item.ID, SellerID: 1, SellerName: "seller", Price: 120}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := BuyItemFromMarketplace(store, "l1", 1, "seller", 10); err == nil {
+		t.Error("seller should not be able to buy their own listing")
+	}
+	if err := BuyItemFromMarketplace(store, "l1", 2, "buyer", 10); err != nil {
+		t.Fatalf("purchase failed: %v", err)
+	}
+
+	if got := store.GetCurrentPoints(2); got != 180 {
+		t.Errorf("buyer balance = %d; want 180", got)
+	}
+	if got := store.GetCurrentPoints(1); got != 120 {
+		t.Errorf("seller balance = %d; want 120", got)
+	}
+	owned, _ := store.GetItemByID(item.ID)
+	if owned.OwnerID != 2 {
+		t.Errorf("item owner = %d; want 2", owned.OwnerID)
+	}
+	if err := BuyItemFromMarketplace(store, "l1", 2, "buyer", 10); err == nil {
+		t.Error("listing should be gone after purchase")
+	}
+
+	data, _ := store.GetUserData(1)
+	name := "no sale entry"
+	for _, e := range data[repository.PointEntriesCollection] {
+		if e["_id"] == "market:l1:sale" {
+			name = fmt.Sprint(e["name"])
+		}
+	}
+	if name != "seller" {
+		t.Errorf("sale entry name = %q; want the seller's name", name)
+	}
+}
+
+func TestBuyMovedItemDropsListing(t *testing.T) {
+	store := repository.NewMemoryStore()
+	store.InsertWordleBonusDoc(2, "buyer", 10, "WordleEn", 300)
+
+	// The seller gave the item away after listing it.
+	item, _ := store.MintItem(model.Item{TemplateID: "t1", SerialNumber: 1, OwnerID: 3})
+	store.CreateListing(model.MarketListing{ID: "l1", ItemID: item.ID, SellerID: 1, Price: 50})
+
+	if err := BuyItemFromMarketplace(store, "l1", 2, "buyer", 10); err == nil {
+		t.Fatal("expected the purchase of a moved item to fail")
+	}
+	if _, err := store.GetListingByID("l1"); err == nil {
+		t.Error("stale listing still on the market")
+	}
+	if got := store.GetCurrentPoints(2); got != 300 {
+		t.Errorf("buyer balance = %d; want 300", got)
+	}
+}
+
+func TestOpenPackRequiresPoints(t *testing.T) {
+	store := repository.NewMemoryStore()
+	store.InsertWordleBonusDoc(5, "poor", 10, "WordleEn", PackPrice-1)
+
+	if _, _, err := OpenPack(store, "pack:1", 5, "poor", 10); err == nil {
+		t.Fatal("expected not enough points error")
+	}
+	if got := store.GetCurrentPoints(5); got != PackPrice-1 {
+		t.Errorf("balance changed to %d on failed pack open", got)
+	}
+}
+
+func TestConcurrentBuyersOneWinner(t *testing.T) {
+	store := repository.NewMemoryStore()
+	store.InsertWordleBonusDoc(1, "seller", 10, "WordleEn", 0)
+	const buyers = 20
+	for id := 2; id < 2+buyers; id++ {
+		store.InsertWordleBonusDoc(id, "buyer", 10, "WordleEn", 100)
+	}
+
+	item, _ := store.MintItem(model.Item{TemplateID: "t1", SerialNumber: 1, OwnerID: 1})
+	if err := store.CreateListing(model.MarketListing{ID: "l1", ItemID: item.ID, SellerID: 1, Price: 60}); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, buyers)
+	for i := range buyers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = BuyItemFromMarketplace(store, "l1", i+2, fmt.Sprint("buyer", i), 10)
+		}()
+	}
+	wg.Wait()
+
+	winner := 0
+	for i, err := range errs {
+		if err != nil {
+			if got := store.GetCurrentPoints(i + 2); got != 100 {
+				t.Errorf("losing buyer %d has %d points; want 100", i+2, got)
+			}
+			continue
+		}
+		if winner != 0 {
+			t.Fatalf("buyers %d and %d both bought the item", winner, i+2)
+		}
+		winner = i + 2
+	}
+	if winner == 0 {
+		t.Fatal("no buyer got the item")
+	}
+
+	if got := store.GetCurrentPoints(winner); got != 40 {
+		t.Errorf("winner balance = %d; want 40", got)
+	}
+	if got := store.GetCurrentPoints(1); got != 60 {
+		t.Errorf("seller balance = %d; want 60", got)
+	}
+	owned, _ := store.GetItemByID(item.ID)
+	if owned.OwnerID != winner {
+		t.Errorf("item owner = %d; want %d", owned.OwnerID, winner)
+	}
+}
+
+func TestBuyListingChangedSinceRead(t *testing.T) {
+	store := repository.NewMemoryStore()
+	store.InsertWordleBonusDoc(2, "buyer", 10, "WordleEn", 300)
+	payment := repository.PointsEntry{Key: "p", UserID: 2, Amount: -50}
+	sale := repository.PointsEntry{Key: "s", UserID: 1, Amount: 50}
+
+	// The listing was repriced after the buyer read it.
+	item, _ := store.MintItem(model.Item{TemplateID: "t1", SerialNumber: 1, OwnerID: 1})
+	listing := model.MarketListing{ID: "l1", ItemID: item.ID, SellerID: 1, Price: 50}
+	store.CreateListing(model.MarketListing{ID: "l1", ItemID: item.ID, SellerID: 1, Price: 500})
+	if err := store.BuyListing(listing, payment, sale); err != repository.ErrListingGone {
+		t.Errorf("BuyListing = %v; want ErrListingGone", err)
+	}
+
+	// The item changed hands after it was listed.
+	moved, _ := store.MintItem(model.Item{TemplateID: "t1", SerialNumber: 2, OwnerID: 3})
+	listing = model.MarketListing{ID: "l2", ItemID: moved.ID, SellerID: 1, Price: 50}
+	store.CreateListing(listing)
+	if err := store.BuyListing(listing, payment, sale); err != repository.ErrItemMoved {
+		t.Errorf("BuyListing = %v; want ErrItemMoved", err)
+	}
+
+	if got := store.GetCurrentPoints(2); got != 300 {
+		t.Errorf("buyer balance = %d; want 300", got)
+	}
+}
+
+func TestConcurrentPackOpensLimitedByBalance(t *testing.T) {
+	store := repository.NewMemoryStore()
+	store.BootstrapTemplates()
+	store.InsertWordleBonusDoc(5, "collector", 10, "WordleEn", 3*PackPrice)
+
+	const attempts = 10
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	opened := 0
+	for i := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := OpenPack(store, fmt.Sprint("pack:", i), 5, "collector", 10); err == nil {
+				mu.Lock()
+				opened++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if opened != 3 {
+		t.Errorf("opened %d packs; want 3", opened)
+	}
+	if got := store.GetCurrentPoints(5); got != 0 {
+		t.Errorf("balance = %d; want 0", got)
+	}
+	items, _ := store.GetUserInventory(5)
+	if len(items) != opened {
+		t.Errorf("inventory has %d items; want %d", len(items), opened)
+	}
+	serials := make(map[string]bool)
+	for _, item := range items {
+		key := fmt.Sprint(item.TemplateID, "#", item.SerialNumber)
+		if serials[key] {
+			t.Errorf("serial %s minted twice", key)
+		}
+		serials[key] = true
+	}
+}
@@ -0,0 +1,133 @@
+// Package daily keeps the Daily Wordle: one word a day for everyone, the
+// players' win streaks and each group's board of the day.
+package daily
+
+import (
+	"slices"
+	"time"
+)
+
+// MaxGuesses is how many guesses a player gets at the day's word.
+const MaxGuesses = 6
+
+// epoch is the day of puzzle #1.
+var epoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Day returns the number of the puzzle for t. Days start at midnight UTC.
+func Day(t time.Time) int {
+	return int(t.UTC().Sub(epoch).Hours()/24) + 1
+}
+
+// Today returns the number of today's puzzle.
+func Today() int {
+	return Day(time.Now())
+}
+
+// Word returns the word of the day's puzzle from words. Every word comes up
+// once before any repeats, in an order that does not follow the list.
+func Word(words []string, day int) string {
+	n := len(words)
+	if n == 0 {
+		return ""
+	}
+	stride := 7919 % n
+	for gcd(stride, n) != 1 {
+		stride++
+	}
+	i := ((day-1)%n + n) % n
+	return words[i*stride%n]
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// Streak is a player's record at the Daily Wordle.
+type Streak struct {
+	Current int `bson:"current"`  // puzzles won in a row, up to LastDay
+	Max     int `bson:"max"`      // the longest run of wins
+	LastDay int `bson:"last_day"` // the last puzzle finished
+	Played  int `bson:"played"`
+	Won     int `bson:"won"`
+}
+
+// Record counts the finished puzzle of the day. It returns false if the day
+// was already counted.
+func (s *Streak) Record(day int, won bool) bool {
+	if day <= s.LastDay {
+		return false
+	}
+	s.Played++
+	switch {
+	case !won:
+		s.Current = 0
+	case s.LastDay == day-1:
+		s.Won++
+		s.Current++
+	default:
+		s.Won++
+		s.Current = 1
+	}
+	s.Max = max(s.Max, s.Current)
+	s.LastDay = day
+	return true
+}
+
+// On returns the streak as of the day: one that skipped a puzzle is over.
+func (s Streak) On(day int) int {
+	if s.LastDay < day-1 {
+		return 0
+	}
+	return s.Current
+}
+
+// Entry is a player's result on a group's board.
+type Entry struct {
+	ID      int    `bson:"id"`
+	Name    string `bson:"name"`
+	Guesses int    `bson:"guesses"`
+	Won     bool   `bson:"won"`
+}
+
+// Board is a group's results for one puzzle.
+type Board struct {
+	Day     int     `bson:"day"`
+	Entries []Entry `bson:"entries"`
+}
+
+// Add puts the player's result for the day on the board, clearing it first
+// if it was for another day. It returns false if they were already on it.
+func (b *Board) Add(day int, e Entry) bool {
+	if b.Day != day {
+		b.Day, b.Entries = day, nil
+	}
+	for _, got := range b.Entries {
+		if got.ID == e.ID {
+			return false
+		}
+	}
+	b.Entries = append(b.Entries, e)
+	return true
+}
+
+// Ranked returns the entries best first: solvers by fewest guesses, then
+// everyone else. Ties keep the order the results were posted in.
+func (b Board) Ranked() []Entry {
+	ranked := slices.Clone(b.Entries)
+	slices.SortStableFunc(ranked, func(x, y Entry) int {
+		if x.Won != y.Won {
+			if x.Won {
+				return -1
+			}
+			return 1
+		}
+		if !x.Won {
+			return 0
+		}
+		return x.Guesses - y.Guesses
+	})
+	return ranked
+}
@@ -0,0 +1,89 @@
+package daily
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDay(t *testing.T) {
+	if got := Day(time.Date(2026, 1, 1, 23, 59, 0, 0, time.UTC)); got != 1 {
+		t.Errorf("Day(Jan 1) = %d, want 1", got)
+	}
+	// Midnight UTC, whatever the zone the time is in.
+	late := time.Date(2026, 1, 2, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*3600))
+	if got := Day(late); got != 1 {
+		t.Errorf("Day(Jan 2 01:00 UTC+3) = %d, want 1", got)
+	}
+	if got := Day(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)); got != 32 {
+		t.Errorf("Day(Feb 1) = %d, want 32", got)
+	}
+}
+
+func TestWordCyclesThroughList(t *testing.T) {
+	words := []string{"apple", "bread", "crane", "drive", "eagle", "flame"}
+	seen := map[string]bool{}
+	for day := 1; day <= len(words); day++ {
+		w := Word(words, day)
+		if seen[w] {
+			t.Fatalf("day %d repeats %q", day, w)
+		}
+		seen[w] = true
+		if again := Word(words, day); again != w {
+			t.Errorf("day %d gave %q then %q", day, w, again)
+		}
+	}
+	if Word(words, 1) != Word(words, len(words)+1) {
+		t.Error("the words do not cycle")
+	}
+	if Word(nil, 1) != "" {
+		t.Error("Word of no words is not empty")
+	}
+}
+
+func TestStreak(t *testing.T) {
+	var s Streak
+	s.Record(10, true)
+	s.Record(11, true)
+	if s.Record(11, false) {
+		t.Error("the same day counted twice")
+	}
+	s.Record(12, true)
+	if s.Current != 3 || s.Max != 3 || s.Played != 3 || s.Won != 3 {
+		t.Errorf("after three wins: %+v", s)
+	}
+	if s.On(13) != 3 || s.On(14) != 0 {
+		t.Errorf("On(13), On(14) = %d, %d, want 3, 0", s.On(13), s.On(14))
+	}
+	s.Record(13, false)
+	if s.Current != 0 || s.Max != 3 {
+		t.Errorf("after a loss: %+v", s)
+	}
+	s.Record(15, true)
+	s.Record(17, true)
+	if s.Current != 1 || s.Won != 5 {
+		t.Errorf("after a skipped day: %+v", s)
+	}
+}
+
+func TestBoard(t *testing.T) {
+	var b Board
+	b.Add(5, Entry{ID: 1, Name: "Ann", Guesses: 6, Won: false})
+	b.Add(5, Entry{ID: 2, Name: "Bo", Guesses: 4, Won: true})
+	b.Add(5, Entry{ID: 3, Name: "Cy", Guesses: 3, Won: true})
+	b.Add(5, Entry{ID: 4, Name: "Di", Guesses: 4, Won: true})
+	if b.Add(5, Entry{ID: 2, Name: "Bo", Guesses: 2, Won: true}) {
+		t.Error("a player was added twice")
+	}
+	var order []int
+	for _, e := range b.Ranked() {
+		order = append(order, e.ID)
+	}
+	if want := []int{3, 2, 4, 1}; !slices.Equal(order, want) {
+		t.Errorf("ranked = %v, want %v", order, want)
+	}
+	b.Add(6, Entry{ID: 1, Name: "Ann", Guesses: 2, Won: true})
+	if len(b.Entries) != 1 || b.Day != 6 {
+		t.Errorf("board for a new day = %+v", b)
+	}
+}
@@ -0,0 +1,172 @@
+package dataio
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ArchiveVersion is the layout version written to new archives.
+const ArchiveVersion = 1
+
+const manifestName = "manifest.json"
+
+// Manifest describes a backup archive. It is the archive's first entry; the
+// documents of each collection follow as JSON Lines in <key>/<name>.jsonl.
+type Manifest struct {
+	Version   int               `json:"version"`
+	CreatedAt time.Time         `json:"created_at"`
+	Databases []ArchiveDatabase `json:"databases"`
+}
+
+// ArchiveDatabase is one database in an archive. Key names its role, such as
+// "main", so it can be restored into a database with another name.
+type ArchiveDatabase struct {
+	Key         string              `json:"key"`
+	Name        string              `json:"name"`
+	Collections []ArchiveCollection `json:"collections"`
+}
+
+// ArchiveCollection is one collection in an archive and how many documents
+// it had.
+type ArchiveCollection struct {
+	Name      string `json:"name"`
+	Documents int    `json:"documents"`
+}
+
+// ArchiveEntry is the path of a collection's documents, inside an archive and
+// in the directory WriteArchive packs.
+func ArchiveEntry(key, collection string) string {
+	return path.Join(key, collection+"."+JSONL.Ext())
+}
+
+// WriteArchive writes m and, for every collection it lists, the JSON Lines
+// file dir/ArchiveEntry(key, name) as a gzipped tar to w.
+func WriteArchive(w io.Writer, m Manifest, dir string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	hdr := &tar.Header{Name: manifestName, Mode: 0o644, Size: int64(len(manifest)), ModTime: m.CreatedAt}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		return err
+	}
+
+	for _, db := range m.Databases {
+		for _, c := range db.Collections {
+			entry := ArchiveEntry(db.Key, c.Name)
+			if err := addFile(tw, entry, filepath.Join(dir, filepath.FromSlash(entry)), m.CreatedAt); err != nil {
+				return err
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func addFile(tw *tar.Writer, name, file string, modTime time.Time) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: info.Size(), ModTime: modTime}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// ReadArchive reads an archive written by WriteArchive. It calls manifest
+// once, before any document, then doc with every document. It fails if a
+// collection has a different number of documents than the manifest says, so
+// a truncated archive is never restored as if it were complete.
+func ReadArchive(r io.Reader, manifest func(Manifest) error, doc func(key, collection string, d bson.Raw) error) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	hdr, err := tr.Next()
+	if err != nil {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+	if hdr.Name != manifestName {
+		return fmt.Errorf("archive starts with %s, want %s", hdr.Name, manifestName)
+	}
+	var m Manifest
+	if err := json.NewDecoder(tr).Decode(&m); err != nil {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+	if m.Version != ArchiveVersion {
+		return fmt.Errorf("unsupported archive version %d", m.Version)
+	}
+	if err := manifest(m); err != nil {
+		return err
+	}
+
+	want := make(map[string]int)
+	for _, db := range m.Databases {
+		for _, c := range db.Collections {
+			want[ArchiveEntry(db.Key, c.Name)] = c.Documents
+		}
+	}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		n, listed := want[hdr.Name]
+		if !listed {
+			return fmt.Errorf("archive entry %s is not in the manifest", hdr.Name)
+		}
+		key, file := path.Split(hdr.Name)
+		key, collection := strings.TrimSuffix(key, "/"), strings.TrimSuffix(file, "."+JSONL.Ext())
+		got := 0
+		err = ReadJSONL(tr, func(d bson.Raw) error {
+			got++
+			return doc(key, collection, d)
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", hdr.Name, err)
+		}
+		if got != n {
+			return fmt.Errorf("%s has %d documents, the manifest says %d", hdr.Name, got, n)
+		}
+		delete(want, hdr.Name)
+	}
+	if len(want) > 0 {
+		var missing []string
+		for name := range want {
+			missing = append(missing, name)
+		}
+		return errors.New("archive is missing " + strings.Join(missing, ", "))
+	}
+	return nil
+}
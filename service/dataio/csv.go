@@ -0,0 +1,195 @@
+package dataio
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The column types of a CSV file. A column whose values are not all of one of
+// the scalar types holds each value as canonical Extended JSON instead.
+const (
+	typeString   = "string"
+	typeInt32    = "int32"
+	typeInt64    = "int64"
+	typeDouble   = "double"
+	typeBool     = "bool"
+	typeDate     = "date"
+	typeObjectID = "objectId"
+	typeJSON     = "json"
+)
+
+var scalarTypes = map[bsontype.Type]string{
+	bsontype.String:   typeString,
+	bsontype.Int32:    typeInt32,
+	bsontype.Int64:    typeInt64,
+	bsontype.Double:   typeDouble,
+	bsontype.Boolean:  typeBool,
+	bsontype.DateTime: typeDate,
+	bsontype.ObjectID: typeObjectID,
+}
+
+// WriteCSV writes docs as CSV, one column per top-level field in the order
+// fields are first seen. Each header cell is "name:type", so ReadCSV can
+// rebuild the values with their types. A field a document lacks is an empty
+// cell; an empty cell in a string column reads back as an empty string.
+//
+// The column types depend on every document, so the whole export is held in
+// memory; use JSON Lines for very large collections.
+func WriteCSV(w io.Writer, docs []bson.Raw) error {
+	var names []string
+	types := make(map[string]string)
+	for _, doc := range docs {
+		elems, err := doc.Elements()
+		if err != nil {
+			return err
+		}
+		for _, e := range elems {
+			name, t := e.Key(), scalarTypes[e.Value().Type]
+			if t == "" {
+				t = typeJSON
+			}
+			switch have, seen := types[name]; {
+			case !seen:
+				names = append(names, name)
+				types[name] = t
+			case have != t:
+				types[name] = typeJSON
+			}
+		}
+	}
+
+	out := csv.NewWriter(w)
+	header := make([]string, len(names))
+	for i, name := range names {
+		header[i] = name + ":" + types[name]
+	}
+	if err := out.Write(header); err != nil {
+		return err
+	}
+	row := make([]string, len(names))
+	for _, doc := range docs {
+		for i, name := range names {
+			row[i] = ""
+			v, err := doc.LookupErr(name)
+			if err != nil {
+				continue
+			}
+			if row[i], err = formatCell(v, types[name]); err != nil {
+				return fmt.Errorf("field %s: %w", name, err)
+			}
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// ReadCSV calls fn with each document in a file written by WriteCSV.
+func ReadCSV(r io.Reader, fn func(bson.Raw) error) error {
+	in := csv.NewReader(r)
+	header, err := in.Read()
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	names := make([]string, len(header))
+	types := make([]string, len(header))
+	for i, h := range header {
+		cut := strings.LastIndex(h, ":")
+		if cut < 0 {
+			return fmt.Errorf("column %q has no type, want name:type", h)
+		}
+		names[i], types[i] = h[:cut], h[cut+1:]
+	}
+
+	for line := 2; ; line++ {
+		row, err := in.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var doc bson.D
+		for i, cell := range row {
+			if cell == "" && types[i] != typeString {
+				continue
+			}
+			v, err := parseCell(cell, types[i])
+			if err != nil {
+				return fmt.Errorf("line %d, column %s: %w", line, names[i], err)
+			}
+			doc = append(doc, bson.E{Key: names[i], Value: v})
+		}
+		raw, err := bson.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := fn(raw); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+func formatCell(v bson.RawValue, t string) (string, error) {
+	switch t {
+	case typeString:
+		return v.StringValue(), nil
+	case typeInt32:
+		return strconv.FormatInt(int64(v.Int32()), 10), nil
+	case typeInt64:
+		return strconv.FormatInt(v.Int64(), 10), nil
+	case typeDouble:
+		return strconv.FormatFloat(v.Double(), 'g', -1, 64), nil
+	case typeBool:
+		return strconv.FormatBool(v.Boolean()), nil
+	case typeDate:
+		return v.Time().UTC().Format(time.RFC3339Nano), nil
+	case typeObjectID:
+		return v.ObjectID().Hex(), nil
+	}
+	wrapped, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: v}}, true, false)
+	if err != nil {
+		return "", err
+	}
+	s := string(wrapped)
+	return strings.TrimSuffix(strings.TrimPrefix(s, `{"v":`), "}"), nil
+}
+
+func parseCell(cell, t string) (any, error) {
+	switch t {
+	case typeString:
+		return cell, nil
+	case typeInt32:
+		n, err := strconv.ParseInt(cell, 10, 32)
+		return int32(n), err
+	case typeInt64:
+		return strconv.ParseInt(cell, 10, 64)
+	case typeDouble:
+		return strconv.ParseFloat(cell, 64)
+	case typeBool:
+		return strconv.ParseBool(cell)
+	case typeDate:
+		ts, err := time.Parse(time.RFC3339Nano, cell)
+		return primitive.NewDateTimeFromTime(ts), err
+	case typeObjectID:
+		return primitive.ObjectIDFromHex(cell)
+	case typeJSON:
+		var wrapped bson.Raw
+		if err := bson.UnmarshalExtJSON([]byte(`{"v":`+cell+`}`), true, &wrapped); err != nil {
+			return nil, err
+		}
+		return wrapped.Lookup("v"), nil
+	}
+	return nil, fmt.Errorf("unknown column type %q", t)
+}
@@ -0,0 +1,107 @@
+// Package dataio reads and writes MongoDB documents as files: JSON Lines and
+// CSV for exports and imports, and tar.gz archives for backups. Values keep
+// their BSON types through a round trip, so an import or restore writes back
+// exactly what was exported.
+package dataio
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Format is a file format for a collection's documents.
+type Format string
+
+const (
+	// JSONL is one document per line in canonical MongoDB Extended JSON.
+	JSONL Format = "jsonl"
+	// CSV is one document per row, one top-level field per column. The
+	// header names each column's type; see WriteCSV.
+	CSV Format = "csv"
+)
+
+// ParseFormat returns the format called s.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case JSONL, CSV:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown format %q, want jsonl or csv", s)
+}
+
+// FormatOf returns the format of a file from its extension.
+func FormatOf(path string) (Format, error) {
+	return ParseFormat(strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// Ext is the file extension of f, without the dot.
+func (f Format) Ext() string {
+	return string(f)
+}
+
+// maxLine bounds a JSON Lines document. MongoDB documents are at most 16MB,
+// and Extended JSON is larger than BSON.
+const maxLine = 64 << 20
+
+// JSONLWriter writes documents as JSON Lines.
+type JSONLWriter struct {
+	w *bufio.Writer
+}
+
+// NewJSONLWriter returns a writer to w. Call Flush when done.
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	return &JSONLWriter{w: bufio.NewWriter(w)}
+}
+
+// Write writes doc as one line.
+func (j *JSONLWriter) Write(doc bson.Raw) error {
+	line, err := bson.MarshalExtJSON(doc, true, false)
+	if err != nil {
+		return err
+	}
+	if _, err := j.w.Write(line); err != nil {
+		return err
+	}
+	return j.w.WriteByte('\n')
+}
+
+// Flush writes any buffered lines.
+func (j *JSONLWriter) Flush() error {
+	return j.w.Flush()
+}
+
+// ReadJSONL calls fn with each document in r. Blank lines are skipped.
+func ReadJSONL(r io.Reader, fn func(bson.Raw) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLine)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var doc bson.Raw
+		if err := bson.UnmarshalExtJSON(line, true, &doc); err != nil {
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+		if err := fn(doc); err != nil {
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// Read calls fn with each document in r, which is in format f.
+func Read(r io.Reader, f Format, fn func(bson.Raw) error) error {
+	if f == CSV {
+		return ReadCSV(r, fn)
+	}
+	return ReadJSONL(r, fn)
+}
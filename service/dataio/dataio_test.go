@@ -0,0 +1,207 @@
+package dataio
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func sampleDocs(t *testing.T) []bson.Raw {
+	t.Helper()
+	created := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	var docs []bson.Raw
+	for _, d := range []bson.D{
+		{
+			{Key: "_id", Value: primitive.NewObjectIDFromTimestamp(created)},
+			{Key: "ID", Value: int64(1006461736)},
+			{Key: "Name", Value: "Ann, \"the\" best"},
+			{Key: "chat_ID", Value: int64(-100123)},
+			{Key: "Points", Value: int32(25)},
+			{Key: "created_at", Value: primitive.NewDateTimeFromTime(created)},
+		},
+		{
+			{Key: "_id", Value: primitive.NewObjectIDFromTimestamp(created.Add(time.Hour))},
+			{Key: "ID", Value: int64(42)},
+			{Key: "Name", Value: ""},
+			{Key: "Points", Value: "not a number"},
+			{Key: "tags", Value: bson.A{"a", int32(2)}},
+			{Key: "ratio", Value: 0.25},
+			{Key: "ok", Value: true},
+		},
+	} {
+		raw, err := bson.Marshal(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs = append(docs, raw)
+	}
+	return docs
+}
+
+func readAll(t *testing.T, f Format, data []byte) []bson.Raw {
+	t.Helper()
+	var docs []bson.Raw
+	if err := Read(bytes.NewReader(data), f, func(doc bson.Raw) error {
+		docs = append(docs, doc)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return docs
+}
+
+func assertSameDocs(t *testing.T, got, want []bson.Raw) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d documents, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !bytes.Equal(got[i], want[i]) {
+			t.Errorf("document %d:\n got %s\nwant %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestJSONLRoundTrip(t *testing.T) {
+	docs := sampleDocs(t)
+	var buf bytes.Buffer
+	w := NewJSONLWriter(&buf)
+	for _, doc := range docs {
+		if err := w.Write(doc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != len(docs) {
+		t.Errorf("wrote %d lines, want %d", lines, len(docs))
+	}
+	assertSameDocs(t, readAll(t, JSONL, buf.Bytes()), docs)
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	docs := sampleDocs(t)
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, docs); err != nil {
+		t.Fatal(err)
+	}
+	header := strings.SplitN(buf.String(), "\n", 2)[0]
+	for _, col := range []string{"_id:objectId", "ID:int64", "Name:string", "Points:json", "created_at:date"} {
+		if !strings.Contains(header, col) {
+			t.Errorf("header %q lacks %s", header, col)
+		}
+	}
+	assertSameDocs(t, readAll(t, CSV, buf.Bytes()), docs)
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"untyped column": "_id,Name\n1,Ann\n",
+		"bad value":      "_id:int32,Name:string\nabc,Ann\n",
+		"unknown type":   "_id:int32,Name:text\n1,Ann\n",
+	} {
+		if err := ReadCSV(strings.NewReader(data), func(bson.Raw) error { return nil }); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func TestFormats(t *testing.T) {
+	if f, err := FormatOf("out/WordleEn.csv"); err != nil || f != CSV {
+		t.Errorf("FormatOf(.csv) = %q, %v", f, err)
+	}
+	if f, err := ParseFormat("JSONL"); err != nil || f != JSONL {
+		t.Errorf("ParseFormat(JSONL) = %q, %v", f, err)
+	}
+	if _, err := FormatOf("dump.json"); err == nil {
+		t.Error("FormatOf accepted .json")
+	}
+}
+
+// writeBackupDir lays out docs as WriteArchive expects and returns the
+// manifest describing them.
+func writeBackupDir(t *testing.T, dir string, docs []bson.Raw) Manifest {
+	t.Helper()
+	m := Manifest{
+		Version:   ArchiveVersion,
+		CreatedAt: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		Databases: []ArchiveDatabase{
+			{Key: "main", Name: "Telegram", Collections: []ArchiveCollection{{Name: "WordleEn", Documents: len(docs)}, {Name: "Empty"}}},
+		},
+	}
+	for _, c := range m.Databases[0].Collections {
+		path := filepath.Join(dir, filepath.FromSlash(ArchiveEntry("main", c.Name)))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if c.Documents > 0 {
+			w := NewJSONLWriter(&buf)
+			for _, doc := range docs {
+				w.Write(doc)
+			}
+			w.Flush()
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return m
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	docs := sampleDocs(t)
+	dir := t.TempDir()
+	m := writeBackupDir(t, dir, docs)
+	var archive bytes.Buffer
+	if err := WriteArchive(&archive, m, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	var gotManifest Manifest
+	var got []bson.Raw
+	err := ReadArchive(bytes.NewReader(archive.Bytes()),
+		func(m Manifest) error {
+			gotManifest = m
+			return nil
+		},
+		func(key, collection string, doc bson.Raw) error {
+			if key != "main" || collection != "WordleEn" {
+				t.Errorf("document from %s/%s", key, collection)
+			}
+			if gotManifest.Version == 0 {
+				t.Error("document read before the manifest")
+			}
+			got = append(got, doc)
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gotManifest.CreatedAt.Equal(m.CreatedAt) || len(gotManifest.Databases[0].Collections) != 2 {
+		t.Errorf("manifest %+v, want %+v", gotManifest, m)
+	}
+	assertSameDocs(t, got, docs)
+}
+
+func TestArchiveCountMismatch(t *testing.T) {
+	docs := sampleDocs(t)
+	dir := t.TempDir()
+	m := writeBackupDir(t, dir, docs)
+	m.Databases[0].Collections[0].Documents++ // as if a document went missing
+	var archive bytes.Buffer
+	if err := WriteArchive(&archive, m, dir); err != nil {
+		t.Fatal(err)
+	}
+	err := ReadArchive(&archive, func(Manifest) error { return nil }, func(string, string, bson.Raw) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "the manifest says") {
+		t.Errorf("err = %v, want a document count mismatch", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"image/color"
 	"log"
+	"strconv"
 	"strings"
 
 	"image"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model/collectible"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/rating"
 	"github.com/fogleman/gg"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/golang/freetype/truetype"
@@ -22,17 +24,67 @@ import (
 	"golang.org/x/image/font/gofont/goregular"
 )
 
-// GenerateLeaderboardImage queries the leaderboard and generates an image scorecard.
-func GenerateLeaderboardImage(client *mongo.Client, collection string, chatID int64, title string) ([]byte, error) {
-	idCounts, err := repository.CountIDOccurrences(client, collection, chatID)
+// GenerateLeaderboardImage queries the leaderboard within window and generates an image scorecard.
+func GenerateLeaderboardImage(client *mongo.Client, collection string, chatID int64, window repository.Window, title string) ([]byte, error) {
+	idCounts, err := repository.CountIDOccurrences(client, collection, chatID, window)
 	if err != nil {
 		log.Printf("Error getting leaderboard for image: %v", err)
 	}
 
-	limit := 10
-	if len(idCounts) < limit {
-		limit = len(idCounts)
+	var rows []leaderboardRow
+	for i := 0; i < len(idCounts) && i < 10; i++ {
+		count := idCounts[i]
+		var userID int
+		if id, ok := count["_id"]; ok {
+			switch v := id.(type) {
+			case int32:
+				userID = int(v)
+			case int64:
+				userID = int(v)
+			case int:
+				userID = v
+			}
+		}
+
+		score := fmt.Sprintf("%v", count["count"])
+		if collection == "WordleEn" {
+			score += " pts"
+		} else if collection == "ScramyEn" {
+			score += " pts"
+		} else if collection == "GeographyPoints" {
+			score += " pts"
+		}
+		rows = append(rows, leaderboardRow{UserID: userID, Name: fmt.Sprintf("%v", count["Name"]), Score: score})
 	}
+	return drawLeaderboard(client, title, "Score", rows)
+}
+
+// GenerateRatingLeaderboardImage generates an image scorecard of the best
+// rated players of game.
+func GenerateRatingLeaderboardImage(client *mongo.Client, game, title string) ([]byte, error) {
+	ratings, err := repository.TopRatings(client, game, 10)
+	if err != nil {
+		log.Printf("Error getting ratings for image: %v", err)
+	}
+
+	rows := make([]leaderboardRow, len(ratings))
+	for i, r := range ratings {
+		rows[i] = leaderboardRow{UserID: r.UserID, Name: r.Name, Score: strconv.Itoa(rating.Display(r.Rating))}
+	}
+	return drawLeaderboard(client, title, "Rating", rows)
+}
+
+// leaderboardRow is one line of a leaderboard image.
+type leaderboardRow struct {
+	UserID int
+	Name   string
+	Score  string
+}
+
+// drawLeaderboard renders rows, best first, under title with the score column
+// headed scoreHeader.
+func drawLeaderboard(client *mongo.Client, title, scoreHeader string, rows []leaderboardRow) ([]byte, error) {
+	limit := len(rows)
 
 	// Layout parameters
 	width := 800
@@ -73,7 +125,7 @@ func GenerateLeaderboardImage(client *mongo.Client, collection string, chatID in
 	y := 110.0
 	dc.DrawStringAnchored("Rank", 100, y, 0.5, 0.5)
 	dc.DrawStringAnchored("Player", 350, y, 0.5, 0.5)
-	dc.DrawStringAnchored("Score", 650, y, 0.5, 0.5)
+	dc.DrawStringAnchored(scoreHeader, 650, y, 0.5, 0.5)
 
 	// Draw line under header
 	dc.SetLineWidth(2)
@@ -89,36 +141,17 @@ func GenerateLeaderboardImage(client *mongo.Client, collection string, chatID in
 		dc.SetFontFace(faceRow)
 		for i := 0; i < limit; i++ {
 			y += 50
-			count := idCounts[i]
-			name := fmt.Sprintf("%v", count["Name"])
+			row := rows[i]
+			name := row.Name
 
 			// Try getting emojis, but `gg` might not render unicode emojis well.
 			// We will try our best.
-			var userID int
-			if id, ok := count["_id"]; ok {
-				switch v := id.(type) {
-				case int32:
-					userID = int(v)
-				case int64:
-					userID = int(v)
-				case int:
-					userID = v
-				}
-			}
-
-			equippedEmojis, err := repository.GetEquippedEmojis(client, userID)
+			equippedEmojis, err := repository.GetEquippedEmojis(client, row.UserID)
 			if err == nil && len(equippedEmojis) > 0 {
 				name += " " + strings.Join(equippedEmojis, "")
 			}
 
-			score := fmt.Sprintf("%v", count["count"])
-			if collection == "WordleEn" {
-				score += " pts"
-			} else if collection == "ScramyEn" {
-				score += " pts"
-			} else if collection == "GeographyPoints" {
-				score += " pts"
-			}
+			score := row.Score
 
 			rankDisplay := fmt.Sprintf("#%d", i+1)
 
@@ -4,13 +4,15 @@ import (
 	"bytes"
 	"os/exec"
 	"strings"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
 )
 
 func RunOllama(prompt string) (<-chan string, <-chan error) {
 	lineChannel := make(chan string)
 	errChannel := make(chan error, 1)
 
-	cmd := exec.Command("ollama", "run", "super-mario-llama")
+	cmd := exec.Command("ollama", "run", config.App.LLM.OllamaModel)
 
 	// Set up input
 	cmd.Stdin = bytes.NewBufferString(prompt)
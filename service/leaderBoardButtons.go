@@ -10,8 +10,11 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func LeaderBoardListButtons(client *mongo.Client, collection string, chatID int64, callbackData string) *view.CustomInlineKeyboardMarkup {
-	idCounts, err := repository.CountIDOccurrences(client, collection, chatID)
+// LeaderBoardListButtons renders the top 10 of collection within window as
+// buttons, followed by buttons to switch game and period. callbackData is the
+// statsglobal_/statsgroup_ data of the board shown, without the window.
+func LeaderBoardListButtons(client *mongo.Client, collection string, chatID int64, callbackData string, window repository.Window) *view.CustomInlineKeyboardMarkup {
+	idCounts, err := repository.CountIDOccurrences(client, collection, chatID, window)
 	if err != nil {
 		log.Printf("Error getting leaderboard: %v", err)
 	}
@@ -125,21 +128,33 @@ func LeaderBoardListButtons(client *mongo.Client, collection string, chatID int6
 		wordGridLabel += " Group"
 	}
 
+	windowSuffix := "_" + string(window)
 	navRow1 := []view.CustomInlineKeyboardButton{
-		{Text: wordGuessLabel, CallbackData: navPrefix + "wordguess"},
-		{Text: wordleLabel, CallbackData: navPrefix + "wordle"},
+		{Text: wordGuessLabel, CallbackData: navPrefix + "wordguess" + windowSuffix},
+		{Text: wordleLabel, CallbackData: navPrefix + "wordle" + windowSuffix},
 	}
 	navRow2 := []view.CustomInlineKeyboardButton{
-		{Text: scramyLabel, CallbackData: navPrefix + "scramy"},
-		{Text: geographyLabel, CallbackData: navPrefix + "geography"},
+		{Text: scramyLabel, CallbackData: navPrefix + "scramy" + windowSuffix},
+		{Text: geographyLabel, CallbackData: navPrefix + "geography" + windowSuffix},
 	}
 	navRow3 := []view.CustomInlineKeyboardButton{
-		{Text: wordGridLabel, CallbackData: navPrefix + "wordgrid"},
+		{Text: wordGridLabel, CallbackData: navPrefix + "wordgrid" + windowSuffix},
+	}
+
+	// Period buttons keep the game and switch the window
+	var windowRow []view.CustomInlineKeyboardButton
+	for _, w := range repository.Windows {
+		label := w.Label()
+		if w == window {
+			label = "✅ " + label
+		}
+		windowRow = append(windowRow, view.CustomInlineKeyboardButton{Text: label, CallbackData: callbackData + "_" + string(w)})
 	}
 
 	buttons = append(buttons, navRow1)
 	buttons = append(buttons, navRow2)
 	buttons = append(buttons, navRow3)
+	buttons = append(buttons, windowRow)
 
 	return &view.CustomInlineKeyboardMarkup{
 		InlineKeyboard: buttons,
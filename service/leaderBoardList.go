@@ -11,7 +11,7 @@ import (
 )
 
 func LeaderBoardList(client *mongo.Client, collection string, chatID int64) string {
-	idCounts, err := repository.CountIDOccurrences(client, collection, chatID)
+	idCounts, err := repository.CountIDOccurrences(client, collection, chatID, repository.WindowAll)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,78 @@
+// Package ledger moves points between users and the house. Every call takes an
+// idempotency key built from something unique to the action (a message, a
+// callback or a listing), so a retried or duplicated update never pays or
+// charges twice.
+package ledger
+
+import (
+	"fmt"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+)
+
+var (
+	// ErrInsufficientPoints is returned when the payer cannot cover a debit.
+	ErrInsufficientPoints = repository.ErrInsufficientPoints
+	// ErrDuplicate is returned when the key was already used; nothing changed.
+	ErrDuplicate = repository.ErrDuplicateEntry
+)
+
+// Key joins parts into an idempotency key, e.g. Key("wordle", chatID, msgID).
+func Key(parts ...any) string {
+	key := ""
+	for i, p := range parts {
+		if i > 0 {
+			key += ":"
+		}
+		key += fmt.Sprint(p)
+	}
+	return key
+}
+
+// Balance returns the user's spendable points.
+func Balance(store repository.Store, userID int) int {
+	return store.GetCurrentPoints(userID)
+}
+
+// Credit gives the user amount points and returns the new balance.
+func Credit(store repository.Store, key string, userID int, name string, chatID int64, amount int, reason string) (int, error) {
+	if amount < 0 {
+		return 0, fmt.Errorf("credit of %d points", amount)
+	}
+	return store.ApplyPoints(repository.PointsEntry{Key: key, UserID: userID, Name: name, ChatID: chatID, Amount: amount, Reason: reason})
+}
+
+// Debit takes amount points from the user and returns the new balance. It
+// fails with ErrInsufficientPoints rather than go below zero.
+func Debit(store repository.Store, key string, userID int, name string, chatID int64, amount int, reason string) (int, error) {
+	if amount < 0 {
+		return 0, fmt.Errorf("debit of %d points", amount)
+	}
+	return store.ApplyPoints(repository.PointsEntry{Key: key, UserID: userID, Name: name, ChatID: chatID, Amount: -amount, Reason: reason})
+}
+
+// Refund gives back a debit made under key. It is safe to call more than once.
+func Refund(store repository.Store, key string, userID int, name string, chatID int64, amount int, reason string) error {
+	_, err := Credit(store, key+":refund", userID, name, chatID, amount, reason)
+	if err == ErrDuplicate {
+		return nil
+	}
+	return err
+}
+
+// Transfer moves amount points from one user to another. The payer is
+// debited first, so a failure never credits the payee without charging.
+func Transfer(store repository.Store, key string, from int, fromName string, to int, toName string, chatID int64, amount int, reason string) error {
+	if amount < 0 {
+		return fmt.Errorf("transfer of %d points", amount)
+	}
+	_, err := store.ApplyPoints(repository.PointsEntry{Key: key + ":debit", UserID: from, Name: fromName, ChatID: chatID, Amount: -amount, Reason: reason, Counterparty: to})
+	if err != nil && err != ErrDuplicate {
+		return err
+	}
+	_, err = store.ApplyPoints(repository.PointsEntry{Key: key + ":credit", UserID: to, Name: toName, ChatID: chatID, Amount: amount, Reason: reason, Counterparty: from})
+	if err == ErrDuplicate {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+)
+
+func TestDebitAndRefund(t *testing.T) {
+	store := repository.NewMemoryStore()
+	if _, err := Credit(store, Key("win", 1), 1, "alice", 10, 100, "Win"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Debit(store, Key("pack", "cb1"), 1, "alice", 10, 80, "Pack"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Debit(store, Key("pack", "cb1"), 1, "alice", 10, 80, "Pack"); err != ErrDuplicate {
+		t.Errorf("repeated debit err = %v; want ErrDuplicate", err)
+	}
+	if _, err := Debit(store, Key("pack", "cb2"), 1, "alice", 10, 80, "Pack"); err != ErrInsufficientPoints {
+		t.Errorf("overdraft err = %v; want ErrInsufficientPoints", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := Refund(store, Key("pack", "cb1"), 1, "alice", 10, 80, "Pack refund"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := Balance(store, 1); got != 100 {
+		t.Errorf("balance after refund = %d; want 100", got)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	store := repository.NewMemoryStore()
+	Credit(store, "seed", 1, "alice", 0, 50, "Seed")
+
+	if err := Transfer(store, "gift", 1, "alice", 2, "bob", 0, 80, "Gift"); err != ErrInsufficientPoints {
+		t.Errorf("overdrawn transfer err = %v; want ErrInsufficientPoints", err)
+	}
+	if got := Balance(store, 2); got != 0 {
+		t.Errorf("payee credited %d on a failed transfer", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := Transfer(store, "gift", 1, "alice", 2, "bob", 0, 30, "Gift"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if a, b := Balance(store, 1), Balance(store, 2); a != 20 || b != 30 {
+		t.Errorf("balances = %d, %d; want 20, 30", a, b)
+	}
+}
+
+func TestCreditRejectsNegativeAmounts(t *testing.T) {
+	store := repository.NewMemoryStore()
+	if _, err := Credit(store, "x", 1, "", 0, -1, ""); err == nil {
+		t.Error("negative credit accepted")
+	}
+	if _, err := Debit(store, "y", 1, "", 0, -1, ""); err == nil {
+		t.Error("negative debit accepted")
+	}
+}
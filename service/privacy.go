@@ -0,0 +1,44 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ExportUserData returns everything stored about the user as an indented JSON
+// document, with the collections in name order. Values are written as relaxed
+// extended JSON, so dates and IDs stay readable.
+func ExportUserData(store repository.Store, userID int, now time.Time) ([]byte, error) {
+	data, err := store.GetUserData(userID)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	collections := bson.D{}
+	for _, name := range names {
+		collections = append(collections, bson.E{Key: name, Value: data[name]})
+	}
+
+	raw, err := bson.MarshalExtJSON(bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "exported_at", Value: now.UTC()},
+		{Key: "collections", Value: collections},
+	}, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, raw, "", "  "); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+)
+
+func TestExportUserData(t *testing.T) {
+	store := repository.NewMemoryStore()
+	store.InsertDoc(1, "alice", 100, "CrocEn")
+	store.InsertDoc(2, "bob", 100, "CrocEn")
+	store.UpsertKnownUser("alice", 1)
+
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	data, err := ExportUserData(store, 1, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bundle struct {
+		UserID      int                         `json:"user_id"`
+		ExportedAt  map[string]string           `json:"exported_at"`
+		Collections map[string][]map[string]any `json:"collections"`
+	}
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		t.Fatalf("bundle is not JSON: %v\n%s", err, data)
+	}
+	if bundle.UserID != 1 || bundle.ExportedAt["$date"] != "2026-05-01T09:00:00Z" {
+		t.Errorf("bundle header = %d, %v", bundle.UserID, bundle.ExportedAt)
+	}
+	if len(bundle.Collections["CrocEn"]) != 1 || len(bundle.Collections["KnownUserIDs"]) != 1 {
+		t.Errorf("collections = %v", bundle.Collections)
+	}
+	if name := bundle.Collections["CrocEn"][0]["Name"]; name != "alice" {
+		t.Errorf("CrocEn document name = %v, want alice", name)
+	}
+}
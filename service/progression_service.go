@@ -7,16 +7,15 @@ import (
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config/progression"
 	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // AwardGameResult rewards a user with XP and Coins after playing a game.
 // It also increments their games played and wins, and automatically levels them up if necessary.
-func AwardGameResult(client *mongo.Client, userID int64, username string, won bool) error {
+func AwardGameResult(store repository.Store, userID int64, username string, won bool) error {
 	// Automatically record that they were active today
-	_ = UpdateActiveDay(client, userID, username)
+	_ = UpdateActiveDay(store, userID, username)
 
-	profile, err := repository.GetUserProfile(client, userID, username)
+	profile, err := store.GetUserProfile(userID, username)
 	if err != nil {
 		return err
 	}
@@ -41,7 +40,7 @@ func AwardGameResult(client *mongo.Client, userID int64, username string, won bo
 	newLevel := progression.GetLevelFromXP(newXP)
 
 	if newLevel > profile.Level {
-		err = repository.UpdateUserProfileFields(client, userID, bson.M{
+		err = store.UpdateUserProfileFields(userID, bson.M{
 			"level": newLevel,
 		})
 		if err != nil {
@@ -50,13 +49,13 @@ func AwardGameResult(client *mongo.Client, userID int64, username string, won bo
 		// Notice: In the future we can trigger a level up notification here
 	}
 
-	return repository.IncrementUserProfileStats(client, userID, incFields)
+	return store.IncrementUserProfileStats(userID, incFields)
 }
 
 // ClaimDailyReward allows a user to claim their daily reward once every 24 hours.
 // Returns an error if they are on cooldown, otherwise returns the xp and coins earned.
-func ClaimDailyReward(client *mongo.Client, userID int64, username string) (int, int, error) {
-	profile, err := repository.GetUserProfile(client, userID, username)
+func ClaimDailyReward(store repository.Store, userID int64, username string) (int, int, error) {
+	profile, err := store.GetUserProfile(userID, username)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -87,12 +86,12 @@ func ClaimDailyReward(client *mongo.Client, userID int64, username string) (int,
 		updateFields["level"] = newLevel
 	}
 
-	err = repository.UpdateUserProfileFields(client, userID, updateFields)
+	err = store.UpdateUserProfileFields(userID, updateFields)
 	if err != nil {
 		return 0, 0, err
 	}
 
-	err = repository.IncrementUserProfileStats(client, userID, bson.M{
+	err = store.IncrementUserProfileStats(userID, bson.M{
 		"xp":    xpEarned,
 		"coins": coinsEarned,
 	})
@@ -101,7 +100,7 @@ func ClaimDailyReward(client *mongo.Client, userID int64, username string) (int,
 	}
 
 	// Update active days for weekly reward
-	err = UpdateActiveDay(client, userID, username)
+	err = UpdateActiveDay(store, userID, username)
 	if err != nil {
 		fmt.Println("Error updating active day:", err)
 	}
@@ -111,8 +110,8 @@ func ClaimDailyReward(client *mongo.Client, userID int64, username string) (int,
 
 // ClaimWeeklyReward allows a user to claim their weekly reward once every 7 days,
 // provided they have been active on at least 5 different days.
-func ClaimWeeklyReward(client *mongo.Client, userID int64, username string) (int, int, error) {
-	profile, err := repository.GetUserProfile(client, userID, username)
+func ClaimWeeklyReward(store repository.Store, userID int64, username string) (int, int, error) {
+	profile, err := store.GetUserProfile(userID, username)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -143,12 +142,12 @@ func ClaimWeeklyReward(client *mongo.Client, userID int64, username string) (int
 		updateFields["level"] = newLevel
 	}
 
-	err = repository.UpdateUserProfileFields(client, userID, updateFields)
+	err = store.UpdateUserProfileFields(userID, updateFields)
 	if err != nil {
 		return 0, 0, err
 	}
 
-	err = repository.IncrementUserProfileStats(client, userID, bson.M{
+	err = store.IncrementUserProfileStats(userID, bson.M{
 		"xp":    xpEarned,
 		"coins": coinsEarned,
 	})
@@ -156,9 +155,45 @@ func ClaimWeeklyReward(client *mongo.Client, userID int64, username string) (int
 	return xpEarned, coinsEarned, err
 }
 
+// AwardSeasonPlace rewards a user for finishing a season leaderboard in place
+// (1 is first). First place gets the full SeasonReward amounts, each lower
+// rewarded place one share less. Places outside the rewarded ones get nothing.
+// The reward is paid once per key: a second call with it pays nothing.
+func AwardSeasonPlace(store repository.Store, key string, userID int64, username string, place int) (int, int, error) {
+	places := progression.Config.SeasonRewardPlaces
+	if place < 1 || place > places {
+		return 0, 0, nil
+	}
+
+	profile, err := store.GetUserProfile(userID, username)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	share := places - place + 1
+	xpEarned := progression.Config.SeasonRewardXP * share / places
+	coinsEarned := progression.Config.SeasonRewardCoins * share / places
+
+	paid, err := store.ClaimUserProfileReward(userID, key, bson.M{
+		"xp":    xpEarned,
+		"coins": coinsEarned,
+	})
+	if err != nil || !paid {
+		return 0, 0, err
+	}
+
+	newLevel := progression.GetLevelFromXP(profile.XP + xpEarned)
+	if newLevel > profile.Level {
+		err = store.UpdateUserProfileFields(userID, bson.M{
+			"level": newLevel,
+		})
+	}
+	return xpEarned, coinsEarned, err
+}
+
 // UpdateActiveDay records today as an active day if not already present.
-func UpdateActiveDay(client *mongo.Client, userID int64, username string) error {
-	profile, err := repository.GetUserProfile(client, userID, username)
+func UpdateActiveDay(store repository.Store, userID int64, username string) error {
+	profile, err := store.GetUserProfile(userID, username)
 	if err != nil {
 		return err
 	}
@@ -177,7 +212,7 @@ func UpdateActiveDay(client *mongo.Client, userID int64, username string) error
 
 	newDays := append(profile.ActiveDaysThisWeek, todayStr)
 
-	return repository.UpdateUserProfileFields(client, userID, bson.M{
+	return store.UpdateUserProfileFields(userID, bson.M{
 		"active_days_this_week": newDays,
 	})
 }
@@ -0,0 +1,122 @@
+// Package rating keeps a skill rating per player and game. Ratings are Elo:
+// after every round each player is scored against everyone else who took
+// part, so beating strong players is worth more than beating weak ones and
+// playing a lot is worth nothing by itself.
+package rating
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+)
+
+const (
+	// Initial is the rating of a player's first round.
+	Initial = 1500.0
+	// provisionalRounds is how many rounds a new rating moves faster for.
+	provisionalRounds = 10
+	kProvisional      = 40.0
+	kEstablished      = 20.0
+)
+
+// Result is how one player did in a round. Higher scores beat lower ones and
+// equal scores draw. An empty Name keeps the one already stored.
+type Result struct {
+	UserID int
+	Name   string
+	Score  int
+}
+
+// mu serializes updates, so two rounds finishing at once cannot both read a
+// player's old rating and one overwrite the other.
+var mu sync.Mutex
+
+// Update rates a finished round of game and returns the new ratings, in the
+// order of results. Rounds with fewer than two players change nothing: there
+// is nobody to be measured against.
+func Update(store repository.Store, game string, results []Result) ([]repository.Rating, error) {
+	if len(results) < 2 {
+		return nil, nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+
+	ids := make([]int, len(results))
+	for i, r := range results {
+		ids[i] = r.UserID
+	}
+	stored, err := store.GetRatings(game, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	before := make([]repository.Rating, len(results))
+	for i, r := range results {
+		rating, ok := stored[r.UserID]
+		if !ok {
+			rating = repository.Rating{Game: game, UserID: r.UserID, Rating: Initial}
+		}
+		before[i] = rating
+	}
+
+	now := time.Now()
+	after := make([]repository.Rating, len(results))
+	opponents := float64(len(results) - 1)
+	for i, r := range results {
+		delta := 0.0
+		for j, other := range results {
+			if i == j {
+				continue
+			}
+			delta += actual(r.Score, other.Score) - Expected(before[i].Rating, before[j].Rating)
+		}
+		after[i] = before[i]
+		if r.Name != "" {
+			after[i].Name = r.Name
+		}
+		after[i].Rating = before[i].Rating + k(before[i].Rounds)*delta/opponents
+		after[i].Rounds++
+		after[i].UpdatedAt = now
+	}
+
+	if err := store.SaveRatings(after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// Expected is the score a player rated a is expected to get against one
+// rated b: 1 for a sure win, 0.5 for an even match.
+func Expected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// Display rounds a rating for showing to users.
+func Display(r float64) int {
+	return int(math.Round(r))
+}
+
+// Top returns the limit best rated players of game.
+func Top(store repository.Store, game string, limit int) ([]repository.Rating, error) {
+	return store.TopRatings(game, limit)
+}
+
+func actual(score, other int) float64 {
+	switch {
+	case score > other:
+		return 1
+	case score < other:
+		return 0
+	default:
+		return 0.5
+	}
+}
+
+func k(rounds int) float64 {
+	if rounds < provisionalRounds {
+		return kProvisional
+	}
+	return kEstablished
+}
@@ -0,0 +1,75 @@
+package rating
+
+import (
+	"testing"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+)
+
+func TestUpdateWinnerGainsLoserLoses(t *testing.T) {
+	store := repository.NewMemoryStore()
+	after, err := Update(store, "wordle", []Result{
+		{UserID: 1, Name: "Ann", Score: 1},
+		{UserID: 2, Name: "Bob", Score: 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after[0].Rating != Initial+kProvisional/2 || after[1].Rating != Initial-kProvisional/2 {
+		t.Errorf("ratings %v and %v, want %v and %v", after[0].Rating, after[1].Rating, Initial+kProvisional/2, Initial-kProvisional/2)
+	}
+
+	stored, err := store.GetRatings("wordle", []int{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored[1].Rounds != 1 || stored[1].Name != "Ann" || stored[2].Rating != after[1].Rating {
+		t.Errorf("stored %+v, want the returned ratings", stored)
+	}
+	if other, _ := store.GetRatings("scramy", []int{1}); len(other) != 0 {
+		t.Errorf("rating leaked into another game: %+v", other)
+	}
+}
+
+func TestUpdateNeedsTwoPlayers(t *testing.T) {
+	store := repository.NewMemoryStore()
+	if _, err := Update(store, "wordle", []Result{{UserID: 1, Score: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if top, _ := store.TopRatings("wordle", 10); len(top) != 0 {
+		t.Errorf("solo round was rated: %+v", top)
+	}
+}
+
+func TestUpdateDrawAndUpset(t *testing.T) {
+	store := repository.NewMemoryStore()
+	store.SaveRatings([]repository.Rating{
+		{ID: repository.RatingKey("wordle", 1), Game: "wordle", UserID: 1, Name: "Strong", Rating: 1800, Rounds: 50},
+		{ID: repository.RatingKey("wordle", 2), Game: "wordle", UserID: 2, Name: "Weak", Rating: 1400, Rounds: 50},
+	})
+
+	draw, err := Update(store, "wordle", []Result{{UserID: 1}, {UserID: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draw[0].Rating >= 1800 || draw[1].Rating <= 1400 {
+		t.Errorf("a draw against a weaker player should cost the stronger one: %v, %v", draw[0].Rating, draw[1].Rating)
+	}
+	if draw[0].Name != "Strong" {
+		t.Errorf("name %q, want the stored one kept", draw[0].Name)
+	}
+
+	upset, err := Update(store, "wordle", []Result{{UserID: 1, Score: 0}, {UserID: 2, Score: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gain := upset[1].Rating - draw[1].Rating; gain <= kEstablished/2 {
+		t.Errorf("upset win gained %v, want more than an even match's %v", gain, kEstablished/2)
+	}
+}
+
+func TestProvisionalK(t *testing.T) {
+	if k(0) != kProvisional || k(provisionalRounds-1) != kProvisional || k(provisionalRounds) != kEstablished {
+		t.Error("new ratings should move faster for the first rounds only")
+	}
+}
@@ -0,0 +1,314 @@
+// Package rotation decides who leads the next word-guess round in chats that
+// turned leader rotation on. Players join the chat's queue, and whoever led
+// least recently is offered the next turn; an offer that is declined or runs
+// out passes to the next player in line.
+package rotation
+
+import (
+	"container/heap"
+	"log"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+)
+
+// OfferTimeout is how long a player has to take an offered turn.
+const OfferTimeout = 45 * time.Second
+
+// Player is a member of a chat's queue.
+type Player struct {
+	ID      int       `bson:"id"`
+	Name    string    `bson:"name"`
+	Joined  time.Time `bson:"joined"`
+	LastLed time.Time `bson:"last_led"` // zero until they lead
+}
+
+// Queue is what is kept of a chat's rotation.
+type Queue struct {
+	Enabled bool     `bson:"enabled"`
+	Players []Player `bson:"players"`
+}
+
+// Offer is a turn offered to one player.
+type Offer struct {
+	UserID  int
+	Name    string
+	Expires time.Time
+}
+
+type chat struct {
+	Queue
+	offer   *Offer
+	skipped map[int]bool // declined or let their offer run out since the last round
+}
+
+// Rotation holds the queues of every chat.
+type Rotation struct {
+	mu    sync.Mutex
+	chats map[int64]*chat
+	load  func(chatID int64) (Queue, error)
+	save  func(chatID int64, q Queue) error
+	now   func() time.Time
+}
+
+// New returns a rotation that keeps its queues in memory only.
+func New() *Rotation {
+	return &Rotation{chats: make(map[int64]*chat), now: time.Now}
+}
+
+// Default is the rotation the word-guess bots share.
+var Default = New()
+
+// SetStorage makes the rotation read a chat's queue with load the first time
+// it is needed and write it with save whenever it changes.
+func (r *Rotation) SetStorage(load func(chatID int64) (Queue, error), save func(chatID int64, q Queue) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.load, r.save = load, save
+	r.chats = make(map[int64]*chat)
+}
+
+// chat returns the chat's state, loading it if needed. The caller holds r.mu.
+// A queue that fails to load starts empty.
+func (r *Rotation) chat(chatID int64) *chat {
+	if c, ok := r.chats[chatID]; ok {
+		return c
+	}
+	c := &chat{skipped: make(map[int]bool)}
+	if r.load != nil {
+		q, err := r.load(chatID)
+		if err != nil {
+			log.Printf("Failed to load the leader queue of chat %d: %v", chatID, err)
+		}
+		c.Queue = q
+	}
+	r.chats[chatID] = c
+	return c
+}
+
+// persist writes the chat's queue. The caller holds r.mu.
+func (r *Rotation) persist(chatID int64, c *chat) error {
+	if r.save == nil {
+		return nil
+	}
+	return r.save(chatID, Queue{Enabled: c.Enabled, Players: append([]Player(nil), c.Players...)})
+}
+
+// Enabled reports whether the chat turned rotation on.
+func (r *Rotation) Enabled(chatID int64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.chat(chatID).Enabled
+}
+
+// SetEnabled turns rotation on or off for the chat.
+func (r *Rotation) SetEnabled(chatID int64, on bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.chat(chatID)
+	c.Enabled = on
+	c.offer = nil
+	c.skipped = make(map[int]bool)
+	return r.persist(chatID, c)
+}
+
+// Join adds the player to the chat's queue. It reports false if they were
+// already in it.
+func (r *Rotation) Join(chatID int64, userID int, name string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.chat(chatID)
+	for _, p := range c.Players {
+		if p.ID == userID {
+			return false, nil
+		}
+	}
+	c.Players = append(c.Players, Player{ID: userID, Name: name, Joined: r.now()})
+	return true, r.persist(chatID, c)
+}
+
+// Leave takes the player out of the chat's queue, withdrawing a turn offered
+// to them. It reports false if they were not in it.
+func (r *Rotation) Leave(chatID int64, userID int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.chat(chatID)
+	for i, p := range c.Players {
+		if p.ID != userID {
+			continue
+		}
+		c.Players = append(c.Players[:i:i], c.Players[i+1:]...)
+		if c.offer != nil && c.offer.UserID == userID {
+			c.offer = nil
+		}
+		delete(c.skipped, userID)
+		return true, r.persist(chatID, c)
+	}
+	return false, nil
+}
+
+// Forget takes the player out of every queue held in memory, after their
+// stored entries were deleted. Nothing is written back.
+func (r *Rotation) Forget(userID int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range r.chats {
+		c.Players = slices.DeleteFunc(c.Players, func(p Player) bool { return p.ID == userID })
+		if c.offer != nil && c.offer.UserID == userID {
+			c.offer = nil
+		}
+		delete(c.skipped, userID)
+	}
+}
+
+// Order returns the chat's queue in the order turns are offered.
+func (r *Rotation) Order(chatID int64) []Player {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.chat(chatID)
+	pq := queueOf(c.Players, nil)
+	order := make([]Player, 0, pq.Len())
+	for pq.Len() > 0 {
+		order = append(order, c.Players[indexOf(heap.Pop(pq).(*service.Item))])
+	}
+	return order
+}
+
+// Current returns the turn on offer in the chat, if any.
+func (r *Rotation) Current(chatID int64) (Offer, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.chat(chatID)
+	if c.offer == nil {
+		return Offer{}, false
+	}
+	return *c.offer, true
+}
+
+// Claim is called when the user wants to lead a round that has no leader. It
+// reports whether they may. If not, offer is the player whose turn it is, and
+// created tells whether the offer was made just now and so still has to be
+// announced. Anyone may lead when rotation is off, when the queue is empty and
+// once everyone in it has passed on their turn.
+func (r *Rotation) Claim(chatID int64, userID int) (ok bool, offer Offer, created bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.chat(chatID)
+	if !c.Enabled {
+		return true, Offer{}, false
+	}
+	if c.offer != nil {
+		if c.offer.UserID == userID {
+			return true, Offer{}, false
+		}
+		return false, *c.offer, false
+	}
+	next, found := r.next(c)
+	if !found || next.ID == userID {
+		return true, Offer{}, false
+	}
+	c.offer = &Offer{UserID: next.ID, Name: next.Name, Expires: r.now().Add(OfferTimeout)}
+	return false, *c.offer, true
+}
+
+// Led records that the user took the lead, which ends the offer and puts them
+// at the back of the queue.
+func (r *Rotation) Led(chatID int64, userID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.chat(chatID)
+	c.offer = nil
+	c.skipped = make(map[int]bool)
+	for i := range c.Players {
+		if c.Players[i].ID == userID {
+			c.Players[i].LastLed = r.now()
+			return r.persist(chatID, c)
+		}
+	}
+	return nil
+}
+
+// Decline passes the user's offered turn to the next player. It reports false
+// if no turn was on offer to the user. When next is false everyone has passed
+// and the turn is open to anyone.
+func (r *Rotation) Decline(chatID int64, userID int) (declined bool, offer Offer, next bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.chat(chatID)
+	if c.offer == nil || c.offer.UserID != userID {
+		return false, Offer{}, false
+	}
+	offer, next = r.pass(c)
+	return true, offer, next
+}
+
+// Expire passes the offer on if it is still the chat's current one and has
+// run out, like Decline. It reports false if the offer was taken or withdrawn
+// in the meantime.
+func (r *Rotation) Expire(chatID int64, o Offer) (expired bool, offer Offer, next bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.chat(chatID)
+	if c.offer == nil || *c.offer != o || r.now().Before(o.Expires) {
+		return false, Offer{}, false
+	}
+	offer, next = r.pass(c)
+	return true, offer, next
+}
+
+// pass skips the player the turn is offered to and offers it to the next one.
+// The caller holds r.mu.
+func (r *Rotation) pass(c *chat) (Offer, bool) {
+	c.skipped[c.offer.UserID] = true
+	c.offer = nil
+	p, ok := r.next(c)
+	if !ok {
+		return Offer{}, false
+	}
+	c.offer = &Offer{UserID: p.ID, Name: p.Name, Expires: r.now().Add(OfferTimeout)}
+	return *c.offer, true
+}
+
+// next returns the player who has gone longest without leading, leaving out
+// those who passed this time. The caller holds r.mu.
+func (r *Rotation) next(c *chat) (Player, bool) {
+	pq := queueOf(c.Players, c.skipped)
+	if pq.Len() == 0 {
+		return Player{}, false
+	}
+	return c.Players[indexOf(heap.Pop(pq).(*service.Item))], true
+}
+
+// queueOf puts the players, bar the skipped ones, in a priority queue whose
+// items hold their index in players.
+func queueOf(players []Player, skipped map[int]bool) *service.PriorityQueue {
+	pq := make(service.PriorityQueue, 0, len(players))
+	for i, p := range players {
+		if skipped[p.ID] {
+			continue
+		}
+		pq = append(pq, &service.Item{Value: strconv.Itoa(i), Priority: priority(p), Index: len(pq)})
+	}
+	heap.Init(&pq)
+	return &pq
+}
+
+// neverLed puts players who have not led yet ahead of everyone who has.
+const neverLed = 1 << 62
+
+// priority is higher the longer ago the player led. Players who never led
+// come first, in the order they joined.
+func priority(p Player) int {
+	if p.LastLed.IsZero() {
+		return neverLed - int(p.Joined.UnixNano()/1e3)
+	}
+	return -int(p.LastLed.UnixNano() / 1e3)
+}
+
+func indexOf(item *service.Item) int {
+	i, _ := strconv.Atoi(item.Value)
+	return i
+}
@@ -0,0 +1,144 @@
+package rotation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+)
+
+// testRotation returns a rotation with rotation on in chat 1 and a clock
+// that moves a second on every reading.
+func testRotation(t *testing.T, players ...int) (*Rotation, *time.Time) {
+	t.Helper()
+	r := New()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	if err := r.SetEnabled(1, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range players {
+		if _, err := r.Join(1, id, string(rune('A'+id-1))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return r, &now
+}
+
+func order(r *Rotation) []int {
+	var ids []int
+	for _, p := range r.Order(1) {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func TestClaimOffersLeastRecentLeader(t *testing.T) {
+	r, _ := testRotation(t, 1, 2, 3)
+
+	// Player 1 is first in line, so tapping leads straight away.
+	if ok, _, _ := r.Claim(1, 1); !ok {
+		t.Fatal("first in line could not lead")
+	}
+	r.Led(1, 1)
+	if got := order(r); len(got) != 3 || got[0] != 2 || got[2] != 1 {
+		t.Fatalf("order after 1 led = %v, want 2 3 1", got)
+	}
+
+	// Someone else tapping offers the turn to player 2.
+	ok, offer, created := r.Claim(1, 3)
+	if ok || !created || offer.UserID != 2 {
+		t.Fatalf("Claim by 3 = %v %+v %v, want an offer to 2", ok, offer, created)
+	}
+	if ok, _, created := r.Claim(1, 42); ok || created {
+		t.Error("a second tap made another offer")
+	}
+	if ok, _, _ := r.Claim(1, 2); !ok {
+		t.Error("player 2 could not take the offered turn")
+	}
+}
+
+func TestDeclineAndExpire(t *testing.T) {
+	r, now := testRotation(t, 1, 2)
+
+	_, offer, _ := r.Claim(1, 99)
+	if declined, _, _ := r.Decline(1, 2); declined {
+		t.Error("player 2 declined player 1's turn")
+	}
+	declined, next, ok := r.Decline(1, 1)
+	if !declined || !ok || next.UserID != 2 {
+		t.Fatalf("Decline = %v %+v %v, want the turn passed to 2", declined, next, ok)
+	}
+	if expired, _, _ := r.Expire(1, offer); expired {
+		t.Error("an offer that was passed on expired")
+	}
+
+	if expired, _, _ := r.Expire(1, next); expired {
+		t.Error("offer expired early")
+	}
+	*now = next.Expires
+	expired, _, ok := r.Expire(1, next)
+	if !expired || ok {
+		t.Fatalf("Expire = %v %v, want expired with nobody left", expired, ok)
+	}
+
+	// With everyone passed, anyone may lead; leading starts a new cycle.
+	if ok, _, _ := r.Claim(1, 99); !ok {
+		t.Fatal("the open turn could not be taken")
+	}
+	r.Led(1, 99)
+	if ok, offer, _ := r.Claim(1, 99); ok || offer.UserID != 1 {
+		t.Errorf("next round offer = %+v, want player 1 again", offer)
+	}
+}
+
+func TestRotationOffAndLeave(t *testing.T) {
+	r, _ := testRotation(t, 1, 2)
+	r.SetEnabled(1, false)
+	if ok, _, _ := r.Claim(1, 99); !ok {
+		t.Error("rotation off, but the tap was refused")
+	}
+
+	r.SetEnabled(1, true)
+	r.Claim(1, 99)
+	if left, _ := r.Leave(1, 1); !left {
+		t.Fatal("player 1 could not leave")
+	}
+	if _, ok := r.Current(1); ok {
+		t.Error("the offer to a player who left stands")
+	}
+	if ok, offer, _ := r.Claim(1, 99); ok || offer.UserID != 2 {
+		t.Errorf("offer = %+v, want player 2", offer)
+	}
+}
+
+func TestForget(t *testing.T) {
+	r, _ := testRotation(t, 1, 2)
+	r.Claim(1, 99)
+	r.Forget(1)
+	if _, ok := r.Current(1); ok {
+		t.Error("the offer to a forgotten player stands")
+	}
+	if got := order(r); len(got) != 1 || got[0] != 2 {
+		t.Errorf("order = %v, want [2]", got)
+	}
+}
+
+func TestUseStore(t *testing.T) {
+	store := repository.NewMemoryStore()
+	r := New()
+	UseStore(r, store)
+	r.SetEnabled(1, true)
+	r.Join(1, 7, "Gus")
+	r.Led(1, 7)
+
+	reloaded := New()
+	UseStore(reloaded, store)
+	players := reloaded.Order(1)
+	if !reloaded.Enabled(1) || len(players) != 1 || players[0].Name != "Gus" || players[0].LastLed.IsZero() {
+		t.Errorf("reloaded queue = %v %+v", reloaded.Enabled(1), players)
+	}
+}
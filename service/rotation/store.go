@@ -0,0 +1,26 @@
+package rotation
+
+import (
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// settingsCollection keeps each chat's queue.
+const settingsCollection = repository.LeaderRotationCollection
+
+// UseStore makes r keep the chats' queues in store.
+func UseStore(r *Rotation, store repository.Store) {
+	r.SetStorage(
+		func(chatID int64) (Queue, error) {
+			var q Queue
+			err := store.LoadChatSettings(settingsCollection, chatID, &q)
+			return q, err
+		},
+		func(chatID int64, q Queue) error {
+			if q.Players == nil {
+				q.Players = []Player{}
+			}
+			return store.SaveChatSettings(settingsCollection, chatID, bson.M{"enabled": q.Enabled, "players": q.Players})
+		},
+	)
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config/progression"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+)
+
+// SeasonBoards are the leaderboards that restart every season, in the order
+// they are shown.
+var SeasonBoards = []struct {
+	Collection string
+	Title      string
+}{
+	{"CrocEn", "Word Guess"},
+	{"WordleEn", "Wordle"},
+	{"ScramyEn", "Scramy"},
+	{"GeographyPoints", "Geography"},
+	{"AnimePoints", "Anime"},
+	{"WordGridPoints", "Word Grid"},
+}
+
+// seasonArchiveDepth is how many places of each standings are archived.
+const seasonArchiveDepth = 10
+
+// seasonCheckInterval is how often RunSeasons looks for a finished season.
+const seasonCheckInterval = time.Hour
+
+// RunSeasons closes each season once it has ended, until ctx is cancelled.
+func RunSeasons(ctx context.Context, store repository.Store) {
+	for {
+		if _, err := CloseFinishedSeasons(store, time.Now()); err != nil {
+			log.Printf("Failed to close seasons: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(seasonCheckInterval):
+		}
+	}
+}
+
+// FirstSeason returns the first season that is closed. It is the one
+// running when the bot first looked, or season 1 if that had not started:
+// scores from before seasons were turned on are never archived or rewarded.
+func FirstSeason(store repository.Store, now time.Time) (int, error) {
+	return store.FirstSeason(max(config.App.Seasons.Number(now), 1))
+}
+
+// CloseFinishedSeasons closes every season from the first one that ended
+// before now, oldest first, so seasons that ended while the bot was down are
+// still archived and rewarded, and pays what is still owed for seasons
+// archived before. It returns how many seasons it closed or finished paying;
+// a season that fails is skipped and its error returned along with the
+// others.
+func CloseFinishedSeasons(store repository.Store, now time.Time) (int, error) {
+	first, err := FirstSeason(store, now)
+	if err != nil {
+		return 0, fmt.Errorf("first season: %w", err)
+	}
+	closed := 0
+	var errs []error
+	for n := first; n < config.App.Seasons.Number(now); n++ {
+		season, err := store.GetSeason(n)
+		switch {
+		case err == nil && season.Rewarded:
+			continue
+		case err == nil:
+			err = rewardSeason(store, season)
+		case err == repository.ErrSeasonNotFound:
+			_, err = CloseSeason(store, n)
+		}
+		if err != nil {
+			if err != repository.ErrSeasonArchived {
+				errs = append(errs, fmt.Errorf("season %d: %w", n, err))
+			}
+			continue
+		}
+		closed++
+	}
+	return closed, errors.Join(errs...)
+}
+
+// CloseSeason archives the final standings of season n, per chat and
+// globally, and rewards the top of every global standings. It returns
+// repository.ErrSeasonArchived, and rewards nobody, if the season was
+// already closed; CloseFinishedSeasons pays whatever such a closer left
+// unpaid.
+func CloseSeason(store repository.Store, n int) (model.Season, error) {
+	start, end := config.App.Seasons.Bounds(n)
+	season := model.Season{Number: n, Start: start, End: end, ClosedAt: time.Now()}
+
+	for _, b := range SeasonBoards {
+		scores, err := store.ScoresBetween(b.Collection, start, end)
+		if err != nil {
+			return model.Season{}, fmt.Errorf("%s standings: %w", b.Title, err)
+		}
+		season.Boards = append(season.Boards, seasonBoard(b.Collection, b.Title, scores))
+	}
+
+	if err := store.ArchiveSeason(season); err != nil {
+		return model.Season{}, err
+	}
+	log.Printf("Closed season %d", n)
+
+	if err := rewardSeason(store, season); err != nil {
+		return season, err
+	}
+	season.Rewarded = true
+	return season, nil
+}
+
+// rewardSeason pays the top of every global standings of the archived
+// season and marks it rewarded. Every place is paid at most once, so it can
+// run again after failing or being cut off halfway.
+func rewardSeason(store repository.Store, season model.Season) error {
+	var errs []error
+	for _, board := range season.Boards {
+		key := fmt.Sprintf("season:%d:%s", season.Number, board.Collection)
+		for _, s := range board.Global {
+			xp, coins, err := AwardSeasonPlace(store, key, int64(s.UserID), s.Name, s.Rank)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s place %d: %w", board.Title, s.Rank, err))
+			} else if xp > 0 || coins > 0 {
+				log.Printf("Season %d: %s place %d, user %d earned %d XP and %d coins", season.Number, board.Title, s.Rank, s.UserID, xp, coins)
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	return store.MarkSeasonRewarded(season.Number)
+}
+
+// seasonBoard ranks scores per chat and, summed over all chats, globally.
+func seasonBoard(collection, title string, scores []repository.ChatScore) model.SeasonBoard {
+	board := model.SeasonBoard{Collection: collection, Title: title}
+
+	byChat := make(map[int64][]repository.ChatScore)
+	var chats []int64
+	global := make(map[int]*repository.ChatScore)
+	for _, s := range scores {
+		if _, ok := byChat[s.ChatID]; !ok {
+			chats = append(chats, s.ChatID)
+		}
+		byChat[s.ChatID] = append(byChat[s.ChatID], s)
+		if global[s.UserID] == nil {
+			global[s.UserID] = &repository.ChatScore{UserID: s.UserID, Name: s.Name}
+		}
+		global[s.UserID].Score += s.Score
+	}
+
+	sort.Slice(chats, func(i, j int) bool { return chats[i] < chats[j] })
+	for _, chatID := range chats {
+		if standings := rankScores(byChat[chatID]); len(standings) > 0 {
+			board.Chats = append(board.Chats, model.SeasonChat{ChatID: chatID, Standings: standings})
+		}
+	}
+
+	totals := make([]repository.ChatScore, 0, len(global))
+	for _, s := range global {
+		totals = append(totals, *s)
+	}
+	board.Global = rankScores(totals)
+	return board
+}
+
+// rankScores returns the top seasonArchiveDepth positive scores, best first.
+func rankScores(scores []repository.ChatScore) []model.SeasonStanding {
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].UserID < scores[j].UserID
+	})
+
+	var standings []model.SeasonStanding
+	for _, s := range scores {
+		if s.Score <= 0 || len(standings) == seasonArchiveDepth {
+			break
+		}
+		standings = append(standings, model.SeasonStanding{
+			Rank:   len(standings) + 1,
+			UserID: s.UserID,
+			Name:   s.Name,
+			Score:  s.Score,
+		})
+	}
+	return standings
+}
+
+// FormatCurrentSeason describes the season running at now, in HTML. first is
+// the first season with standings.
+func FormatCurrentSeason(now time.Time, first int) string {
+	n := config.App.Seasons.Number(now)
+	if n == 0 {
+		start, _ := config.App.Seasons.Bounds(1)
+		return fmt.Sprintf("🏁 Season 1 starts on <b>%s</b>.", start.Format("2 Jan 2006"))
+	}
+	_, end := config.App.Seasons.Bounds(n)
+	text := fmt.Sprintf("🏁 <b>Season %d</b> ends on <b>%s</b> (UTC).\nThe top %d of every global leaderboard win XP and coins.",
+		n, end.Format("2 Jan 2006"), progression.Config.SeasonRewardPlaces)
+	switch {
+	case n-1 == first:
+		text += fmt.Sprintf("\n\nUse /season %d to see the last season.", first)
+	case n-1 > first:
+		text += fmt.Sprintf("\n\nUse /season %d to /season %d to see past seasons.", first, n-1)
+	}
+	return text
+}
+
+// FormatSeason renders the final standings of an archived season in HTML:
+// those of chatID, or the global ones when chatID is 0.
+func FormatSeason(season model.Season, chatID int64) string {
+	scope := "Global"
+	if chatID != 0 {
+		scope = "Group"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "🏆 <b>Season %d %s Standings</b>\n%s – %s\n",
+		season.Number, scope, season.Start.Format("2 Jan"), season.End.AddDate(0, 0, -1).Format("2 Jan 2006"))
+
+	rankEmojis := []string{"🥇", "🥈", "🥉"}
+	shown := 0
+	for _, board := range season.Boards {
+		standings := board.Global
+		if chatID != 0 {
+			standings = board.Chat(chatID)
+		}
+		if len(standings) == 0 {
+			continue
+		}
+		shown++
+		fmt.Fprintf(&sb, "\n<b>%s</b>\n<blockquote>", html.EscapeString(board.Title))
+		for i, s := range standings {
+			rank := fmt.Sprintf("%d.", s.Rank)
+			if s.Rank <= len(rankEmojis) {
+				rank = rankEmojis[s.Rank-1]
+			}
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			fmt.Fprintf(&sb, "%s %s — %d", rank, html.EscapeString(s.Name), s.Score)
+		}
+		sb.WriteString("</blockquote>")
+	}
+	if shown == 0 {
+		sb.WriteString("\nNobody scored this season.")
+	}
+	return sb.String()
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config/progression"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/model"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+)
+
+// useSeasons makes season 1 the week that started yesterday.
+func useSeasons(t *testing.T) {
+	saved := config.App.Seasons
+	t.Cleanup(func() { config.App.Seasons = saved })
+	config.App.Seasons = config.Seasons{
+		Start:      time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02"),
+		LengthDays: 7,
+	}
+}
+
+func TestCloseSeason(t *testing.T) {
+	useSeasons(t)
+	store := repository.NewMemoryStore()
+	store.InsertWordleBonusDoc(1, "alice", 100, "WordleEn", 30)
+	store.InsertWordleBonusDoc(2, "bob", 100, "WordleEn", 20)
+	store.InsertWordleBonusDoc(2, "bob", 200, "WordleEn", 20)
+	store.InsertDoc(3, "carol", 200, "CrocEn")
+
+	season, err := CloseSeason(store, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wordle := season.Boards[1]
+	if wordle.Collection != "WordleEn" {
+		t.Fatalf("board 1 is %s; want WordleEn", wordle.Collection)
+	}
+	if len(wordle.Global) != 2 || wordle.Global[0].Name != "bob" || wordle.Global[0].Score != 40 {
+		t.Errorf("global Wordle standings = %+v; want bob first with 40", wordle.Global)
+	}
+	if group := wordle.Chat(100); len(group) != 2 || group[0].Name != "alice" {
+		t.Errorf("chat 100 Wordle standings = %+v; want alice first", group)
+	}
+
+	// bob won Wordle, alice came second and carol won Word Guess.
+	places := progression.Config.SeasonRewardPlaces
+	for userID, want := range map[int64]int{1: progression.Config.SeasonRewardXP * (places - 1) / places, 2: progression.Config.SeasonRewardXP, 3: progression.Config.SeasonRewardXP} {
+		profile, _ := store.GetUserProfile(userID, "")
+		if profile.XP != want {
+			t.Errorf("user %d has %d XP; want %d", userID, profile.XP, want)
+		}
+	}
+
+	if _, err := CloseSeason(store, 1); err != repository.ErrSeasonArchived {
+		t.Errorf("closing twice = %v; want ErrSeasonArchived", err)
+	}
+	if profile, _ := store.GetUserProfile(2, ""); profile.XP != progression.Config.SeasonRewardXP {
+		t.Errorf("closing twice rewarded again: %d XP", profile.XP)
+	}
+
+	text := FormatSeason(season, 200)
+	if !strings.Contains(text, "Season 1 Group") || !strings.Contains(text, "carol") || strings.Contains(text, "alice") {
+		t.Errorf("chat 200 standings:\n%s", text)
+	}
+}
+
+func TestCloseFinishedSeasons(t *testing.T) {
+	useSeasons(t)
+	store := repository.NewMemoryStore()
+
+	if closed, err := CloseFinishedSeasons(store, time.Now()); closed != 0 || err != nil {
+		t.Errorf("closed a season still running: %v, %v", closed, err)
+	}
+	nextWeek := time.Now().AddDate(0, 0, 7)
+	if closed, err := CloseFinishedSeasons(store, nextWeek); closed != 1 || err != nil {
+		t.Fatalf("season 1 not closed: %v, %v", closed, err)
+	}
+	if closed, _ := CloseFinishedSeasons(store, nextWeek); closed != 0 {
+		t.Error("season 1 closed twice")
+	}
+	if _, err := store.GetSeason(1); err != nil {
+		t.Errorf("season 1 not archived: %v", err)
+	}
+
+	// Seasons that ended while nothing was checking are all caught up.
+	if closed, err := CloseFinishedSeasons(store, time.Now().AddDate(0, 0, 28)); closed != 3 || err != nil {
+		t.Fatalf("closed %d seasons, %v; want 2 to 4", closed, err)
+	}
+	for n := 2; n <= 4; n++ {
+		if _, err := store.GetSeason(n); err != nil {
+			t.Errorf("season %d not archived: %v", n, err)
+		}
+	}
+}
+
+func TestCloseFinishedSeasonsStartsAtFirstLook(t *testing.T) {
+	useSeasons(t)
+	store := repository.NewMemoryStore()
+	store.InsertDoc(1, "alice", 100, "CrocEn")
+
+	// The bot first looks during season 3: seasons 1 and 2 are left alone.
+	if closed, err := CloseFinishedSeasons(store, time.Now().AddDate(0, 0, 15)); closed != 0 || err != nil {
+		t.Errorf("closed %d seasons, %v; want none", closed, err)
+	}
+	if _, err := store.GetSeason(1); err != repository.ErrSeasonNotFound {
+		t.Errorf("season 1 = %v; want it never archived", err)
+	}
+	if profile, _ := store.GetUserProfile(1, ""); profile.XP != 0 {
+		t.Errorf("rewarded %d XP for a season before the first", profile.XP)
+	}
+	if closed, err := CloseFinishedSeasons(store, time.Now().AddDate(0, 0, 22)); closed != 1 || err != nil {
+		t.Errorf("closed %d seasons, %v; want season 3", closed, err)
+	}
+	if _, err := store.GetSeason(3); err != nil {
+		t.Errorf("season 3 not archived: %v", err)
+	}
+}
+
+func TestCloseFinishedSeasonsFinishesPaying(t *testing.T) {
+	useSeasons(t)
+	store := repository.NewMemoryStore()
+	CloseFinishedSeasons(store, time.Now())
+
+	// Season 1 was archived and its Wordle winner paid before the closer died.
+	standings := []model.SeasonStanding{{Rank: 1, UserID: 1, Name: "alice", Score: 9}}
+	season := model.Season{Number: 1, Boards: []model.SeasonBoard{
+		{Collection: "CrocEn", Title: "Word Guess", Global: standings},
+		{Collection: "WordleEn", Title: "Wordle", Global: standings},
+	}}
+	if err := store.ArchiveSeason(season); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := AwardSeasonPlace(store, "season:1:WordleEn", 1, "alice", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if closed, err := CloseFinishedSeasons(store, time.Now().AddDate(0, 0, 7)); closed != 1 || err != nil {
+		t.Fatalf("closed %d seasons, %v; want season 1 finished", closed, err)
+	}
+	if profile, _ := store.GetUserProfile(1, ""); profile.XP != 2*progression.Config.SeasonRewardXP {
+		t.Errorf("alice has %d XP; want both first places paid once", profile.XP)
+	}
+	if season, _ := store.GetSeason(1); !season.Rewarded {
+		t.Error("season 1 not marked rewarded")
+	}
+}
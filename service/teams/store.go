@@ -0,0 +1,37 @@
+package teams
+
+import (
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// settingsCollection keeps each chat's teams and match.
+const settingsCollection = repository.TeamMatchesCollection
+
+// UseStore makes t keep the chats' matches in store.
+func UseStore(t *Teams, store repository.Store) {
+	t.SetStorage(
+		func(chatID int64) (Match, error) {
+			var m Match
+			err := store.LoadChatSettings(settingsCollection, chatID, &m)
+			return m, err
+		},
+		func(chatID int64, m Match) error {
+			if m.Red == nil {
+				m.Red = []Member{}
+			}
+			if m.Blue == nil {
+				m.Blue = []Member{}
+			}
+			return store.SaveChatSettings(settingsCollection, chatID, bson.M{
+				"active":     m.Active,
+				"target":     m.Target,
+				"turn":       m.Turn,
+				"red":        m.Red,
+				"blue":       m.Blue,
+				"red_score":  m.RedScore,
+				"blue_score": m.BlueScore,
+			})
+		},
+	)
+}
@@ -0,0 +1,373 @@
+// Package teams runs Crocodile-style team matches of the word-guess round.
+// Players join team Red or Blue, the lead alternates between the teams, and a
+// word only scores when the team that is not leading guesses it. The first
+// team to the match's target score wins.
+package teams
+
+import (
+	"errors"
+	"log"
+	"sync"
+)
+
+// Team is one of the two sides of a match.
+type Team string
+
+const (
+	Red  Team = "red"
+	Blue Team = "blue"
+)
+
+// Other returns the opposing team.
+func (t Team) Other() Team {
+	if t == Red {
+		return Blue
+	}
+	return Red
+}
+
+// Label is the team's name as shown in the chat.
+func (t Team) Label() string {
+	switch t {
+	case Red:
+		return "🔴 Red"
+	case Blue:
+		return "🔵 Blue"
+	}
+	return "no team"
+}
+
+// Target scores a match can be played to.
+const (
+	DefaultTarget = 5
+	MaxTarget     = 50
+)
+
+var (
+	ErrMatchRunning = errors.New("teams: a match is running")
+	ErrTeamsShort   = errors.New("teams: both teams need a player")
+	ErrBadTarget    = errors.New("teams: target score out of range")
+)
+
+// Member is a player on a team, with what they did in the current match.
+type Member struct {
+	ID        int    `bson:"id"`
+	Name      string `bson:"name"`
+	Guessed   int    `bson:"guessed"`   // words they guessed for their team
+	Explained int    `bson:"explained"` // words of theirs the other team guessed
+}
+
+// Match is what is kept of a chat's teams. The rosters stay after a match so
+// the next one can start straight away; the scores stay until it does.
+type Match struct {
+	Active    bool     `bson:"active"`
+	Target    int      `bson:"target"`
+	Turn      Team     `bson:"turn"` // the team whose turn it is to lead
+	Red       []Member `bson:"red"`
+	Blue      []Member `bson:"blue"`
+	RedScore  int      `bson:"red_score"`
+	BlueScore int      `bson:"blue_score"`
+}
+
+// Roster returns the team's members.
+func (m Match) Roster(t Team) []Member {
+	if t == Red {
+		return m.Red
+	}
+	return m.Blue
+}
+
+// Score returns the team's score.
+func (m Match) Score(t Team) int {
+	if t == Red {
+		return m.RedScore
+	}
+	return m.BlueScore
+}
+
+// TeamOf returns the user's team, if they are on one.
+func (m Match) TeamOf(userID int) (Team, bool) {
+	for _, t := range []Team{Red, Blue} {
+		if memberIndex(m.Roster(t), userID) >= 0 {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// Winner returns the team ahead, or false on a draw.
+func (m Match) Winner() (Team, bool) {
+	switch {
+	case m.RedScore > m.BlueScore:
+		return Red, true
+	case m.BlueScore > m.RedScore:
+		return Blue, true
+	}
+	return "", false
+}
+
+func (m *Match) roster(t Team) *[]Member {
+	if t == Red {
+		return &m.Red
+	}
+	return &m.Blue
+}
+
+func (m *Match) score(t Team) *int {
+	if t == Red {
+		return &m.RedScore
+	}
+	return &m.BlueScore
+}
+
+// copy returns m with rosters that do not share memory with it.
+func (m Match) copy() Match {
+	m.Red = append([]Member(nil), m.Red...)
+	m.Blue = append([]Member(nil), m.Blue...)
+	return m
+}
+
+func memberIndex(members []Member, userID int) int {
+	for i, p := range members {
+		if p.ID == userID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Outcome is what a correct guess means for the match.
+type Outcome int
+
+const (
+	NoMatch    Outcome = iota // no match is running; the guess scores as usual
+	Scored                    // the opposing team guessed it and scores
+	OwnTeam                   // the leader's own team guessed it, which does not count
+	NotPlaying                // the guesser is on no team
+)
+
+// Result is the outcome of a correct guess.
+type Result struct {
+	Outcome Outcome
+	Team    Team  // the guesser's team
+	Won     bool  // the guess won the match, which is now over
+	Match   Match // the match after the guess
+}
+
+// Teams holds the matches of every chat.
+type Teams struct {
+	mu      sync.Mutex
+	matches map[int64]*Match
+	load    func(chatID int64) (Match, error)
+	save    func(chatID int64, m Match) error
+}
+
+// New returns teams that keep their matches in memory only.
+func New() *Teams {
+	return &Teams{matches: make(map[int64]*Match)}
+}
+
+// Default is the teams the word-guess bots share.
+var Default = New()
+
+// SetStorage makes t read a chat's match with load the first time it is
+// needed and write it with save whenever it changes.
+func (t *Teams) SetStorage(load func(chatID int64) (Match, error), save func(chatID int64, m Match) error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.load, t.save = load, save
+	t.matches = make(map[int64]*Match)
+}
+
+// match returns the chat's match, loading it if needed. The caller holds
+// t.mu. A match that fails to load starts empty.
+func (t *Teams) match(chatID int64) *Match {
+	if m, ok := t.matches[chatID]; ok {
+		return m
+	}
+	m := &Match{}
+	if t.load != nil {
+		loaded, err := t.load(chatID)
+		if err != nil {
+			log.Printf("Failed to load the team match of chat %d: %v", chatID, err)
+		}
+		*m = loaded
+	}
+	t.matches[chatID] = m
+	return m
+}
+
+// persist writes the chat's match. The caller holds t.mu.
+func (t *Teams) persist(chatID int64, m *Match) error {
+	if t.save == nil {
+		return nil
+	}
+	return t.save(chatID, m.copy())
+}
+
+// Match returns the chat's match.
+func (t *Teams) Match(chatID int64) Match {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.match(chatID).copy()
+}
+
+// Active reports whether a match is running in the chat.
+func (t *Teams) Active(chatID int64) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.match(chatID).Active
+}
+
+// Join puts the player on the team. It reports false if they were on it
+// already. Players cannot change sides while a match is running.
+func (t *Teams) Join(chatID int64, userID int, name string, team Team) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m := t.match(chatID)
+	current, ok := m.TeamOf(userID)
+	if ok && current == team {
+		return false, nil
+	}
+	if ok {
+		if m.Active {
+			return false, ErrMatchRunning
+		}
+		members := m.roster(current)
+		i := memberIndex(*members, userID)
+		*members = append((*members)[:i:i], (*members)[i+1:]...)
+	}
+	members := m.roster(team)
+	*members = append(*members, Member{ID: userID, Name: name})
+	return true, t.persist(chatID, m)
+}
+
+// Leave takes the player off their team. It reports false if they were on
+// none. Their team keeps what they scored.
+func (t *Teams) Leave(chatID int64, userID int) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m := t.match(chatID)
+	team, ok := m.TeamOf(userID)
+	if !ok {
+		return false, nil
+	}
+	members := m.roster(team)
+	i := memberIndex(*members, userID)
+	*members = append((*members)[:i:i], (*members)[i+1:]...)
+	return true, t.persist(chatID, m)
+}
+
+// Forget takes the player off every team held in memory, after their stored
+// entries were deleted. Nothing is written back.
+func (t *Teams) Forget(userID int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, m := range t.matches {
+		if team, ok := m.TeamOf(userID); ok {
+			members := m.roster(team)
+			i := memberIndex(*members, userID)
+			*members = append((*members)[:i:i], (*members)[i+1:]...)
+		}
+	}
+}
+
+// Start starts a match to the target score, with Red leading first.
+func (t *Teams) Start(chatID int64, target int) error {
+	if target < 1 || target > MaxTarget {
+		return ErrBadTarget
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m := t.match(chatID)
+	if m.Active {
+		return ErrMatchRunning
+	}
+	if len(m.Red) == 0 || len(m.Blue) == 0 {
+		return ErrTeamsShort
+	}
+	m.Active, m.Target, m.Turn = true, target, Red
+	m.RedScore, m.BlueScore = 0, 0
+	for _, team := range []Team{Red, Blue} {
+		members := *m.roster(team)
+		for i := range members {
+			members[i].Guessed, members[i].Explained = 0, 0
+		}
+	}
+	return t.persist(chatID, m)
+}
+
+// Stop ends the chat's match early. It reports false if none was running;
+// otherwise the match is returned as it ended.
+func (t *Teams) Stop(chatID int64) (Match, bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m := t.match(chatID)
+	if !m.Active {
+		return Match{}, false, nil
+	}
+	m.Active = false
+	return m.copy(), true, t.persist(chatID, m)
+}
+
+// CanLead reports whether the user may take the lead. While a match runs only
+// the team whose turn it is may, and turn is that team.
+func (t *Teams) CanLead(chatID int64, userID int) (ok bool, turn Team) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m := t.match(chatID)
+	if !m.Active {
+		return true, ""
+	}
+	team, _ := m.TeamOf(userID)
+	return team == m.Turn, m.Turn
+}
+
+// Led records that the user took the lead, which hands the next turn to the
+// other team.
+func (t *Teams) Led(chatID int64, userID int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m := t.match(chatID)
+	if !m.Active {
+		return nil
+	}
+	if team, ok := m.TeamOf(userID); !ok || team != m.Turn {
+		return nil
+	}
+	m.Turn = m.Turn.Other()
+	return t.persist(chatID, m)
+}
+
+// Guessed scores a correct guess of the leader's word. Only a guess from the
+// team the leader is not on scores; the team that reaches the target wins and
+// the match ends.
+func (t *Teams) Guessed(chatID int64, guesserID, leaderID int) (Result, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m := t.match(chatID)
+	if !m.Active {
+		return Result{Outcome: NoMatch}, nil
+	}
+	team, ok := m.TeamOf(guesserID)
+	if !ok {
+		return Result{Outcome: NotPlaying, Match: m.copy()}, nil
+	}
+	leaderTeam, _ := m.TeamOf(leaderID)
+	if leaderTeam == team {
+		return Result{Outcome: OwnTeam, Team: team, Match: m.copy()}, nil
+	}
+
+	*m.score(team)++
+	members := *m.roster(team)
+	members[memberIndex(members, guesserID)].Guessed++
+	if leaderTeam != "" {
+		leaders := *m.roster(leaderTeam)
+		leaders[memberIndex(leaders, leaderID)].Explained++
+	}
+	won := m.Score(team) >= m.Target
+	if won {
+		m.Active = false
+	}
+	return Result{Outcome: Scored, Team: team, Won: won, Match: m.copy()}, t.persist(chatID, m)
+}
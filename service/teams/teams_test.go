@@ -0,0 +1,116 @@
+package teams
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+)
+
+// testTeams returns teams with players 1 and 2 on Red and 3 and 4 on Blue in
+// chat 1.
+func testTeams(t *testing.T) *Teams {
+	t.Helper()
+	ts := New()
+	for id, team := range map[int]Team{1: Red, 2: Red, 3: Blue, 4: Blue} {
+		if _, err := ts.Join(1, id, string(rune('A'+id-1)), team); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ts
+}
+
+func TestStartNeedsBothTeams(t *testing.T) {
+	ts := New()
+	ts.Join(1, 1, "A", Red)
+	if err := ts.Start(1, 5); !errors.Is(err, ErrTeamsShort) {
+		t.Errorf("Start with one team = %v, want ErrTeamsShort", err)
+	}
+	ts.Join(1, 2, "B", Blue)
+	if err := ts.Start(1, 0); !errors.Is(err, ErrBadTarget) {
+		t.Errorf("Start to 0 = %v, want ErrBadTarget", err)
+	}
+	if err := ts.Start(1, 5); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ts.Join(1, 2, "B", Red); !errors.Is(err, ErrMatchRunning) {
+		t.Errorf("switching sides mid-match = %v, want ErrMatchRunning", err)
+	}
+}
+
+func TestLeadAlternates(t *testing.T) {
+	ts := testTeams(t)
+	if ok, _ := ts.CanLead(1, 3); !ok {
+		t.Error("anyone may lead before the match")
+	}
+	ts.Start(1, 5)
+	if ok, turn := ts.CanLead(1, 3); ok || turn != Red {
+		t.Errorf("CanLead(Blue) = %v %v, want Red's turn", ok, turn)
+	}
+	if ok, _ := ts.CanLead(1, 99); ok {
+		t.Error("a player on no team may lead")
+	}
+	ts.Led(1, 1)
+	if ok, _ := ts.CanLead(1, 2); ok {
+		t.Error("Red led twice in a row")
+	}
+	if ok, _ := ts.CanLead(1, 4); !ok {
+		t.Error("Blue may not lead after Red")
+	}
+}
+
+func TestOnlyOpposingTeamScores(t *testing.T) {
+	ts := testTeams(t)
+	if r, _ := ts.Guessed(1, 3, 1); r.Outcome != NoMatch {
+		t.Errorf("outcome without a match = %v", r.Outcome)
+	}
+	ts.Start(1, 2)
+
+	if r, _ := ts.Guessed(1, 2, 1); r.Outcome != OwnTeam || r.Match.RedScore != 0 {
+		t.Errorf("teammate guess = %+v, want OwnTeam and no score", r)
+	}
+	if r, _ := ts.Guessed(1, 99, 1); r.Outcome != NotPlaying {
+		t.Errorf("outsider guess = %v, want NotPlaying", r.Outcome)
+	}
+	r, err := ts.Guessed(1, 3, 1)
+	if err != nil || r.Outcome != Scored || r.Team != Blue || r.Won || r.Match.BlueScore != 1 {
+		t.Fatalf("Blue guess = %+v %v, want a point for Blue", r, err)
+	}
+	r, _ = ts.Guessed(1, 4, 2)
+	if !r.Won || r.Match.Active || r.Match.BlueScore != 2 {
+		t.Fatalf("winning guess = %+v", r)
+	}
+	if r.Match.Blue[1].Guessed != 1 || r.Match.Red[0].Explained != 1 || r.Match.Red[1].Explained != 1 {
+		t.Errorf("player tallies = %+v %+v", r.Match.Red, r.Match.Blue)
+	}
+	if w, ok := r.Match.Winner(); !ok || w != Blue {
+		t.Errorf("winner = %v %v, want Blue", w, ok)
+	}
+}
+
+func TestForget(t *testing.T) {
+	ts := testTeams(t)
+	ts.Forget(3)
+	m := ts.Match(1)
+	if _, ok := m.TeamOf(3); ok || len(m.Blue) != 1 || len(m.Red) != 2 {
+		t.Errorf("match = %+v, want player 3 gone from Blue", m)
+	}
+}
+
+func TestUseStore(t *testing.T) {
+	store := repository.NewMemoryStore()
+	ts := New()
+	UseStore(ts, store)
+	ts.Join(1, 7, "Gus", Red)
+	ts.Join(1, 8, "Ida", Blue)
+	ts.Start(1, 3)
+	ts.Led(1, 7)
+	ts.Guessed(1, 8, 7)
+
+	reloaded := New()
+	UseStore(reloaded, store)
+	m := reloaded.Match(1)
+	if !m.Active || m.Target != 3 || m.Turn != Blue || m.BlueScore != 1 || len(m.Red) != 1 || m.Blue[0].Guessed != 1 {
+		t.Errorf("reloaded match = %+v", m)
+	}
+}
@@ -0,0 +1,44 @@
+package wordbank
+
+import "log"
+
+// SetLoader makes the bank read a chat's packs with load the first time the
+// chat needs them.
+func (b *Bank) SetLoader(load func(chatID int64) (ChatPacks, error)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.load = load
+	b.chats = make(map[int64]ChatPacks)
+}
+
+// Chat returns the chat's packs, loading them if needed. A chat whose packs
+// fail to load plays with the shared packs until they load.
+func (b *Bank) Chat(chatID int64) ChatPacks {
+	b.mu.Lock()
+	chat, ok := b.chats[chatID]
+	load := b.load
+	b.mu.Unlock()
+	if ok || load == nil {
+		return chat
+	}
+
+	chat, err := load(chatID)
+	if err != nil {
+		log.Printf("Failed to load the word packs of chat %d: %v", chatID, err)
+		return ChatPacks{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if cached, ok := b.chats[chatID]; ok {
+		return cached
+	}
+	b.chats[chatID] = chat
+	return chat
+}
+
+// SetChat replaces the chat's packs.
+func (b *Bank) SetChat(chatID int64, chat ChatPacks) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.chats[chatID] = chat
+}
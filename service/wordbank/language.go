@@ -0,0 +1,73 @@
+package wordbank
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+)
+
+// languageBanks are the banks of the languages other than English, by code.
+// Each starts with its embedded packs; uploaded packs are English only.
+var languageBanks = func() map[string]*Bank {
+	entries, err := packFiles.ReadDir("packs")
+	if err != nil {
+		panic(fmt.Sprintf("wordbank: embedded packs: %v", err))
+	}
+	banks := make(map[string]*Bank)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		packs, err := EmbeddedLanguage(e.Name())
+		if err != nil {
+			panic(fmt.Sprintf("wordbank: embedded %s packs: %v", e.Name(), err))
+		}
+		b := New(config.App.Words.NoRepeat)
+		b.Add(packs...)
+		banks[e.Name()] = b
+	}
+	return banks
+}()
+
+// Languages returns the codes of the languages with a bank of their own.
+func Languages() []string {
+	langs := make([]string, 0, len(languageBanks))
+	for lang := range languageBanks {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// For returns the bank of the language with the code lang. English, and any
+// language without packs, use the default bank.
+func For(lang string) *Bank {
+	if b, ok := languageBanks[lang]; ok {
+		return b
+	}
+	return Default
+}
+
+// NextIn picks the chat's next word from the bank of the language lang.
+func NextIn(chatID int64, lang string) (string, error) {
+	w, err := For(lang).Pick(chatID, Filter{})
+	if err != nil {
+		return "", err
+	}
+	return w.Text, nil
+}
+
+// PackOf returns the shared pack the word is from, if any.
+func (b *Bank) PackOf(text string) (Pack, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, p := range b.packs {
+		for _, w := range p.Words {
+			if w.Text == text {
+				return p, true
+			}
+		}
+	}
+	return Pack{}, false
+}
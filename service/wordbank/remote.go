@@ -0,0 +1,113 @@
+package wordbank
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+)
+
+// RemotePack is the name of the pack filled from the remote list.
+const RemotePack = "remote"
+
+// Default is the bank the word-guess bots pick from. It starts with the
+// embedded packs.
+var Default = func() *Bank {
+	b := New(config.App.Words.NoRepeat)
+	packs, err := Embedded()
+	if err != nil {
+		// The packs are checked by the tests, so this is a build mistake.
+		panic(fmt.Sprintf("wordbank: embedded packs: %v", err))
+	}
+	b.Add(packs...)
+	return b
+}()
+
+// Next picks the chat's next word from any pack of the default bank.
+func Next(chatID int64) (string, error) {
+	w, err := Default.Pick(chatID, Filter{})
+	if err != nil {
+		return "", err
+	}
+	return w.Text, nil
+}
+
+// remoteList is the layout of the remote words.json.
+type remoteList struct {
+	CommonWords []string `json:"commonWords"`
+}
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// FetchRemote downloads the word list at url as a pack. The list has no
+// difficulties, so longer words count as harder.
+func FetchRemote(ctx context.Context, url string) (Pack, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Pack{}, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Pack{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Pack{}, fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+	var list remoteList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return Pack{}, fmt.Errorf("decoding %s: %w", url, err)
+	}
+
+	f := packFile{Name: RemotePack, Title: "Common words", Words: make(map[Difficulty][]string)}
+	for _, w := range list.CommonWords {
+		d := lengthDifficulty(strings.TrimSpace(w))
+		f.Words[d] = append(f.Words[d], w)
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return Pack{}, err
+	}
+	return ParsePack(data)
+}
+
+func lengthDifficulty(word string) Difficulty {
+	switch n := len([]rune(word)); {
+	case n <= 5:
+		return Easy
+	case n <= 8:
+		return Medium
+	default:
+		return Hard
+	}
+}
+
+// Refresh adds the remote list of cfg to b, then fetches it again every
+// cfg.RefreshMinutes until ctx is done. A failed fetch keeps the list from
+// the last one, or just the embedded packs. It returns at once when cfg has
+// no remote URL.
+func Refresh(ctx context.Context, b *Bank, cfg config.Words) {
+	if cfg.RemoteURL == "" {
+		return
+	}
+	interval := time.Duration(cfg.RefreshMinutes) * time.Minute
+	for {
+		p, err := FetchRemote(ctx, cfg.RemoteURL)
+		if err != nil {
+			log.Printf("Failed to refresh the remote word list: %v", err)
+		} else {
+			b.Add(p)
+			log.Printf("Loaded %d words from the remote word list", len(p.Words))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
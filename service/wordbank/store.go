@@ -0,0 +1,145 @@
+package wordbank
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// settingsCollection keeps each chat's active packs.
+const settingsCollection = "WordGuessSettings"
+
+type chatSettings struct {
+	WordPacks []string `bson:"word_packs"`
+}
+
+// UseStore makes b load each chat's packs from store.
+func UseStore(b *Bank, store repository.Store) {
+	b.SetLoader(func(chatID int64) (ChatPacks, error) {
+		docs, err := store.ChatWordPacks(chatID)
+		if err != nil {
+			return ChatPacks{}, err
+		}
+		var settings chatSettings
+		if err := store.LoadChatSettings(settingsCollection, chatID, &settings); err != nil {
+			return ChatPacks{}, err
+		}
+		chat := ChatPacks{Active: settings.WordPacks}
+		for _, doc := range docs {
+			p, err := packFromDoc(doc)
+			if err != nil {
+				return ChatPacks{}, fmt.Errorf("pack %s: %w", doc.Name, err)
+			}
+			chat.Custom = append(chat.Custom, p)
+		}
+		return chat, nil
+	})
+}
+
+func packFromDoc(doc repository.WordPack) (Pack, error) {
+	p := Pack{Name: doc.Name, Title: doc.Title}
+	for _, d := range Difficulties {
+		for _, w := range doc.Words[string(d)] {
+			p.Words = append(p.Words, Word{Text: w, Pack: doc.Name, Difficulty: d})
+		}
+	}
+	if len(p.Words) == 0 {
+		return Pack{}, fmt.Errorf("no words")
+	}
+	return p, nil
+}
+
+// SaveChatPack stores the chat's uploaded pack, replacing one of the same
+// name, and makes the chat play with it.
+func SaveChatPack(b *Bank, store repository.Store, chatID int64, userID int, p Pack) error {
+	if b.Has(p.Name) {
+		return fmt.Errorf("%q is the name of a built-in pack", p.Name)
+	}
+	doc := repository.WordPack{
+		ChatID:     chatID,
+		Name:       p.Name,
+		Title:      p.Title,
+		Words:      make(map[string][]string),
+		UploadedBy: userID,
+		CreatedAt:  time.Now(),
+	}
+	for _, w := range p.Words {
+		doc.Words[string(w.Difficulty)] = append(doc.Words[string(w.Difficulty)], w.Text)
+	}
+	if err := store.SaveWordPack(doc); err != nil {
+		return err
+	}
+
+	chat := b.Chat(chatID)
+	custom := []Pack{p}
+	for _, c := range chat.Custom {
+		if c.Name != p.Name {
+			custom = append(custom, c)
+		}
+	}
+	chat.Custom = custom
+	b.SetChat(chatID, chat)
+	return SetActivePacks(b, store, chatID, appendName(chat.Active, p.Name))
+}
+
+// DeleteChatPack removes the chat's uploaded pack and stops the chat playing
+// with it.
+func DeleteChatPack(b *Bank, store repository.Store, chatID int64, name string) error {
+	if err := store.DeleteWordPack(chatID, name); err != nil {
+		return err
+	}
+	chat := b.Chat(chatID)
+	var custom []Pack
+	for _, c := range chat.Custom {
+		if c.Name != name {
+			custom = append(custom, c)
+		}
+	}
+	chat.Custom = custom
+	b.SetChat(chatID, chat)
+	return SetActivePacks(b, store, chatID, removeName(chat.Active, name))
+}
+
+// SetActivePacks makes the chat play with the named packs; none means every
+// shared pack.
+func SetActivePacks(b *Bank, store repository.Store, chatID int64, names []string) error {
+	chat := b.Chat(chatID)
+	chat.Active = names
+	b.SetChat(chatID, chat)
+	if names == nil {
+		names = []string{}
+	}
+	return store.SaveChatSettings(settingsCollection, chatID, bson.M{"word_packs": names})
+}
+
+// TogglePack adds the named pack to the chat's active packs, or removes it.
+func TogglePack(b *Bank, store repository.Store, chatID int64, name string) error {
+	active := b.Chat(chatID).Active
+	for _, n := range active {
+		if n == name {
+			return SetActivePacks(b, store, chatID, removeName(active, name))
+		}
+	}
+	return SetActivePacks(b, store, chatID, appendName(active, name))
+}
+
+func appendName(names []string, name string) []string {
+	for _, n := range names {
+		if n == name {
+			return names
+		}
+	}
+	return append(append([]string(nil), names...), name)
+}
+
+func removeName(names []string, name string) []string {
+	var kept []string
+	for _, n := range names {
+		if n != name {
+			kept = append(kept, n)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,134 @@
+package wordbank
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Limits on uploaded packs.
+const (
+	MaxUploadBytes = 256 << 10
+	MaxPackWords   = 2000
+	MinPackWords   = 5
+	maxWordRunes   = 32
+)
+
+// packNamePattern is what a pack may be called; names go into button data.
+var packNamePattern = regexp.MustCompile(`^[a-z0-9_-]{1,24}$`)
+
+// ValidPackName reports whether name can name an uploaded pack.
+func ValidPackName(name string) bool {
+	return packNamePattern.MatchString(name)
+}
+
+// Upload is a pack read from an uploaded file, and the lines that were left
+// out of it.
+type Upload struct {
+	Pack     Pack
+	Rejected []string
+}
+
+// ParseUpload reads a word list. A .txt file has one word or short phrase per
+// line; a .csv file has the word in its first column and may give its
+// difficulty in the second. Blank lines, lines starting with # and a "word"
+// header are skipped; anything else that is not a valid word is rejected.
+// Words without a difficulty are medium.
+func ParseUpload(name, title, filename string, data []byte) (Upload, error) {
+	if !ValidPackName(name) {
+		return Upload{}, fmt.Errorf("pack names are 1-24 characters of a-z, 0-9, _ or -, not %q", name)
+	}
+	if len(data) > MaxUploadBytes {
+		return Upload{}, fmt.Errorf("the file is over %d KB", MaxUploadBytes>>10)
+	}
+
+	var rows [][]string
+	switch ext := strings.ToLower(filename[strings.LastIndex(filename, ".")+1:]); ext {
+	case "txt":
+		sc := bufio.NewScanner(bytes.NewReader(data))
+		for sc.Scan() {
+			rows = append(rows, []string{sc.Text()})
+		}
+		if err := sc.Err(); err != nil {
+			return Upload{}, err
+		}
+	case "csv":
+		r := csv.NewReader(bytes.NewReader(data))
+		r.FieldsPerRecord = -1
+		r.Comment = '#'
+		for {
+			row, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return Upload{}, fmt.Errorf("reading the CSV: %w", err)
+			}
+			rows = append(rows, row)
+		}
+	default:
+		return Upload{}, errors.New("send a .txt or .csv file")
+	}
+
+	u := Upload{Pack: Pack{Name: name, Title: title}}
+	if u.Pack.Title == "" {
+		u.Pack.Title = name
+	}
+	seen := make(map[string]bool)
+	for i, row := range rows {
+		text := strings.Join(strings.Fields(strings.ToLower(strings.TrimPrefix(row[0], "\ufeff"))), " ")
+		if text == "" || strings.HasPrefix(text, "#") || (i == 0 && text == "word") {
+			continue
+		}
+		d := Medium
+		if len(row) > 1 && strings.TrimSpace(row[1]) != "" {
+			d = Difficulty(strings.ToLower(strings.TrimSpace(row[1])))
+		}
+		if !validWord(text) || !validDifficulty(d) {
+			u.Rejected = append(u.Rejected, strings.Join(row, ","))
+			continue
+		}
+		if seen[text] {
+			continue
+		}
+		seen[text] = true
+		u.Pack.Words = append(u.Pack.Words, Word{Text: text, Pack: name, Difficulty: d})
+	}
+	if len(u.Pack.Words) > MaxPackWords {
+		return Upload{}, fmt.Errorf("a pack can have at most %d words, this one has %d", MaxPackWords, len(u.Pack.Words))
+	}
+	if len(u.Pack.Words) < MinPackWords {
+		return Upload{}, fmt.Errorf("a pack needs at least %d valid words, this one has %d", MinPackWords, len(u.Pack.Words))
+	}
+	return u, nil
+}
+
+// validWord reports whether text can be guessed: up to three words of
+// letters, with hyphens and apostrophes inside them.
+func validWord(text string) bool {
+	if n := len([]rune(text)); n < 2 || n > maxWordRunes {
+		return false
+	}
+	words := strings.Split(text, " ")
+	if len(words) > 3 {
+		return false
+	}
+	for _, w := range words {
+		for i, r := range w {
+			if unicode.IsLetter(r) {
+				continue
+			}
+			if (r == '-' || r == '\'') && i > 0 && i < len(w)-1 {
+				continue
+			}
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,343 @@
+// Package wordbank holds the words of the word-guess games. The packs built
+// into the binary are always there, so a round never waits on the network; a
+// remote list can be refreshed in the background on top of them.
+package wordbank
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Difficulty is how hard a word is to explain.
+type Difficulty string
+
+const (
+	Easy   Difficulty = "easy"
+	Medium Difficulty = "medium"
+	Hard   Difficulty = "hard"
+)
+
+// Difficulties lists the difficulties from easiest.
+var Difficulties = []Difficulty{Easy, Medium, Hard}
+
+// Word is one word of a pack.
+type Word struct {
+	Text       string
+	Pack       string // the name of the pack the word is from
+	Difficulty Difficulty
+}
+
+// Pack is a named set of words on one theme.
+type Pack struct {
+	Name  string
+	Title string
+	Words []Word
+}
+
+// ErrNoWords is returned when no word matches a filter.
+var ErrNoWords = errors.New("no words match")
+
+// packFile is the JSON layout of a pack: its words by difficulty.
+type packFile struct {
+	Name  string                  `json:"name"`
+	Title string                  `json:"title"`
+	Words map[Difficulty][]string `json:"words"`
+}
+
+// ParsePack reads a pack from its JSON form. Words are lowercased and
+// trimmed; blank and repeated words are dropped.
+func ParsePack(data []byte) (Pack, error) {
+	var f packFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return Pack{}, err
+	}
+	if f.Name == "" {
+		return Pack{}, errors.New("pack has no name")
+	}
+	for d := range f.Words {
+		if !validDifficulty(d) {
+			return Pack{}, fmt.Errorf("pack %s: unknown difficulty %q", f.Name, d)
+		}
+	}
+	p := Pack{Name: f.Name, Title: f.Title}
+	if p.Title == "" {
+		p.Title = f.Name
+	}
+	seen := make(map[string]bool)
+	for _, d := range Difficulties {
+		for _, w := range f.Words[d] {
+			w = strings.ToLower(strings.TrimSpace(w))
+			if w == "" || seen[w] {
+				continue
+			}
+			seen[w] = true
+			p.Words = append(p.Words, Word{Text: w, Pack: p.Name, Difficulty: d})
+		}
+	}
+	if len(p.Words) == 0 {
+		return Pack{}, fmt.Errorf("pack %s has no words", f.Name)
+	}
+	return p, nil
+}
+
+func validDifficulty(d Difficulty) bool {
+	for _, known := range Difficulties {
+		if d == known {
+			return true
+		}
+	}
+	return false
+}
+
+//go:embed packs/*.json packs/*/*.json
+var packFiles embed.FS
+
+// Embedded returns the English packs built into the binary, by name.
+func Embedded() ([]Pack, error) {
+	return embedded("packs")
+}
+
+// EmbeddedLanguage returns the packs built into the binary for the language
+// with the code lang, like "fr", by name.
+func EmbeddedLanguage(lang string) ([]Pack, error) {
+	return embedded(path.Join("packs", lang))
+}
+
+func embedded(dir string) ([]Pack, error) {
+	entries, err := packFiles.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var packs []Pack
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		data, err := packFiles.ReadFile(path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		p, err := ParsePack(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		packs = append(packs, p)
+	}
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs, nil
+}
+
+// Filter narrows the words Pick chooses from. Zero fields match everything.
+type Filter struct {
+	Packs      []string
+	Difficulty Difficulty
+}
+
+// ChatPacks is what a chat plays with: the packs it uploaded, and the names
+// of the packs its words come from. No names means every shared pack.
+type ChatPacks struct {
+	Custom []Pack
+	Active []string
+}
+
+// Bank indexes packs by name and difficulty and remembers each chat's recent
+// words and packs. It is safe for concurrent use.
+type Bank struct {
+	mu           sync.Mutex
+	packs        map[string]Pack
+	byDifficulty map[Difficulty][]Word
+	window       int
+	recent       map[int64]*history
+	chats        map[int64]ChatPacks
+	load         func(chatID int64) (ChatPacks, error)
+	rand         *rand.Rand
+}
+
+// New returns an empty bank that does not repeat a chat's last window words.
+func New(window int) *Bank {
+	return &Bank{
+		packs:        make(map[string]Pack),
+		byDifficulty: make(map[Difficulty][]Word),
+		window:       window,
+		recent:       make(map[int64]*history),
+		chats:        make(map[int64]ChatPacks),
+		rand:         rand.New(rand.NewSource(rand.Int63())),
+	}
+}
+
+// Has reports whether the bank has a shared pack called name.
+func (b *Bank) Has(name string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, ok := b.packs[name]
+	return ok
+}
+
+// Add adds the packs, replacing any pack with the same name.
+func (b *Bank) Add(packs ...Pack) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, p := range packs {
+		b.packs[p.Name] = p
+	}
+	b.byDifficulty = make(map[Difficulty][]Word)
+	for _, p := range b.packs {
+		for _, w := range p.Words {
+			b.byDifficulty[w.Difficulty] = append(b.byDifficulty[w.Difficulty], w)
+		}
+	}
+}
+
+// Packs returns the packs in the bank, by name.
+func (b *Bank) Packs() []Pack {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	packs := make([]Pack, 0, len(b.packs))
+	for _, p := range b.packs {
+		packs = append(packs, p)
+	}
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs
+}
+
+// SetWindow changes how many recent words of each chat are not repeated.
+// Chats keep the words they already had, up to the new window.
+func (b *Bank) SetWindow(window int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.window = window
+	for _, h := range b.recent {
+		h.resize(window)
+	}
+}
+
+// Pick returns a random word matching f that the chat has not had within the
+// window, and records it. When every matching word was had recently, the one
+// had longest ago is returned. A filter without packs picks from the chat's
+// active packs, or from every shared pack if none of those has a word left.
+func (b *Bank) Pick(chatID int64, f Filter) (Word, error) {
+	chat := b.Chat(chatID)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var candidates []Word
+	if len(f.Packs) == 0 && len(chat.Active) > 0 {
+		candidates = b.candidates(chat, Filter{Packs: chat.Active, Difficulty: f.Difficulty})
+	}
+	if len(candidates) == 0 {
+		candidates = b.candidates(chat, f)
+	}
+	if len(candidates) == 0 {
+		return Word{}, ErrNoWords
+	}
+	h := b.recent[chatID]
+	if h == nil {
+		h = newHistory(b.window)
+		b.recent[chatID] = h
+	}
+
+	var fresh []Word
+	for _, w := range candidates {
+		if !h.has(w.Text) {
+			fresh = append(fresh, w)
+		}
+	}
+	var word Word
+	if len(fresh) > 0 {
+		word = fresh[b.rand.Intn(len(fresh))]
+	} else {
+		word = h.oldest(candidates)
+	}
+	h.add(word.Text)
+	return word, nil
+}
+
+// candidates returns the words matching f, out of the shared packs and the
+// chat's own.
+func (b *Bank) candidates(chat ChatPacks, f Filter) []Word {
+	var pool []Word
+	if f.Difficulty != "" {
+		pool = b.byDifficulty[f.Difficulty]
+	} else {
+		for _, d := range Difficulties {
+			pool = append(pool, b.byDifficulty[d]...)
+		}
+	}
+	if len(f.Packs) == 0 {
+		return pool
+	}
+	for _, p := range chat.Custom {
+		for _, w := range p.Words {
+			if f.Difficulty == "" || w.Difficulty == f.Difficulty {
+				pool = append(pool, w)
+			}
+		}
+	}
+	wanted := make(map[string]bool, len(f.Packs))
+	for _, name := range f.Packs {
+		wanted[name] = true
+	}
+	var out []Word
+	for _, w := range pool {
+		if wanted[w.Pack] {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// history is a chat's last words, oldest first, capped at the window.
+type history struct {
+	words  []string
+	counts map[string]int
+	window int
+}
+
+func newHistory(window int) *history {
+	return &history{counts: make(map[string]int), window: window}
+}
+
+func (h *history) has(word string) bool {
+	return h.counts[word] > 0
+}
+
+func (h *history) add(word string) {
+	if h.window <= 0 {
+		return
+	}
+	h.words = append(h.words, word)
+	h.counts[word]++
+	h.resize(h.window)
+}
+
+func (h *history) resize(window int) {
+	h.window = window
+	for len(h.words) > max(window, 0) {
+		old := h.words[0]
+		h.words = h.words[1:]
+		if h.counts[old]--; h.counts[old] <= 0 {
+			delete(h.counts, old)
+		}
+	}
+}
+
+// oldest returns the candidate that was had longest ago.
+func (h *history) oldest(candidates []Word) Word {
+	byText := make(map[string]Word, len(candidates))
+	for _, w := range candidates {
+		byText[w.Text] = w
+	}
+	for _, text := range h.words {
+		if w, ok := byText[text]; ok {
+			return w
+		}
+	}
+	return candidates[0]
+}
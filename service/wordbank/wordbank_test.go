@@ -0,0 +1,265 @@
+package wordbank
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/config"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+)
+
+func TestEmbeddedPacks(t *testing.T) {
+	packs, err := Embedded()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packs) == 0 {
+		t.Fatal("no embedded packs")
+	}
+	// A word in two packs would be picked twice as often and hinted at with
+	// whichever pack PackOf finds first.
+	packOf := make(map[string]string)
+	for _, p := range packs {
+		counts := make(map[Difficulty]int)
+		for _, w := range p.Words {
+			counts[w.Difficulty]++
+			if other, dup := packOf[w.Text]; dup {
+				t.Errorf("%q is in both %s and %s", w.Text, other, p.Name)
+			}
+			packOf[w.Text] = p.Name
+		}
+		for _, d := range Difficulties {
+			if counts[d] == 0 {
+				t.Errorf("pack %s has no %s words", p.Name, d)
+			}
+		}
+	}
+}
+
+func TestLanguagePacks(t *testing.T) {
+	for _, lang := range []string{"ar", "fr", "ru"} {
+		b := For(lang)
+		if b == Default {
+			t.Fatalf("no %s bank", lang)
+		}
+		for _, d := range Difficulties {
+			if _, err := b.Pick(1, Filter{Difficulty: d}); err != nil {
+				t.Errorf("%s bank has no %s words: %v", lang, d, err)
+			}
+		}
+		w, _ := b.Pick(1, Filter{})
+		if p, ok := b.PackOf(w.Text); !ok || p.Name != w.Pack {
+			t.Errorf("PackOf(%q) = %v %v, want %s", w.Text, p.Name, ok, w.Pack)
+		}
+	}
+	if For("en") != Default {
+		t.Error("English does not use the default bank")
+	}
+}
+
+func TestParsePack(t *testing.T) {
+	p, err := ParsePack([]byte(`{"name": "x", "words": {"easy": [" Cat", "cat", ""], "hard": ["Platypus"]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Title != "x" || len(p.Words) != 2 || p.Words[0] != (Word{Text: "cat", Pack: "x", Difficulty: Easy}) {
+		t.Errorf("pack = %+v", p)
+	}
+	for name, data := range map[string]string{
+		"no name":            `{"words": {"easy": ["cat"]}}`,
+		"no words":           `{"name": "x", "words": {"easy": [" "]}}`,
+		"unknown difficulty": `{"name": "x", "words": {"tricky": ["cat"]}}`,
+	} {
+		if _, err := ParsePack([]byte(data)); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func testBank(window int) *Bank {
+	b := New(window)
+	b.Add(
+		Pack{Name: "a", Words: []Word{{"one", "a", Easy}, {"two", "a", Easy}, {"three", "a", Hard}}},
+		Pack{Name: "b", Words: []Word{{"four", "b", Easy}}},
+	)
+	return b
+}
+
+func TestPickFilter(t *testing.T) {
+	b := testBank(0)
+	for i := 0; i < 20; i++ {
+		w, err := b.Pick(1, Filter{Packs: []string{"a"}, Difficulty: Easy})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w.Pack != "a" || w.Difficulty != Easy {
+			t.Fatalf("picked %+v outside the filter", w)
+		}
+	}
+	if _, err := b.Pick(1, Filter{Packs: []string{"b"}, Difficulty: Hard}); err != ErrNoWords {
+		t.Errorf("err = %v, want ErrNoWords", err)
+	}
+}
+
+func TestPickNoRepeat(t *testing.T) {
+	b := testBank(3)
+	seen := make(map[string]bool)
+	var order []string
+	for i := 0; i < 4; i++ {
+		w, err := b.Pick(1, Filter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[w.Text] {
+			t.Fatalf("%q repeated within the window: %v", w.Text, order)
+		}
+		seen[w.Text] = true
+		order = append(order, w.Text)
+	}
+
+	// Only the oldest word is outside the window now.
+	if w, _ := b.Pick(1, Filter{}); w.Text != order[0] {
+		t.Errorf("picked %q, want %q as the only word out of the window", w.Text, order[0])
+	}
+	// Other chats have their own window.
+	if _, err := b.Pick(2, Filter{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// With every easy word recent, the one had longest ago comes back.
+	easy := New(10)
+	easy.Add(Pack{Name: "a", Words: []Word{{"one", "a", Easy}, {"two", "a", Easy}}})
+	first, _ := easy.Pick(1, Filter{})
+	easy.Pick(1, Filter{})
+	if w, _ := easy.Pick(1, Filter{}); w.Text != first.Text {
+		t.Errorf("picked %q, want the oldest word %q", w.Text, first.Text)
+	}
+}
+
+func TestFetchRemote(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"commonWords": ["tree", "Garden", "hippopotamus"]}`)
+	}))
+	defer srv.Close()
+
+	p, err := FetchRemote(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]Difficulty{"tree": Easy, "garden": Medium, "hippopotamus": Hard}
+	if len(p.Words) != len(want) {
+		t.Fatalf("words = %+v", p.Words)
+	}
+	for _, w := range p.Words {
+		if w.Pack != RemotePack || want[w.Text] != w.Difficulty {
+			t.Errorf("word %+v", w)
+		}
+	}
+
+	// A failed fetch leaves the bank as it was.
+	srv.Close()
+	b := testBank(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	Refresh(ctx, b, config.Words{RemoteURL: srv.URL, RefreshMinutes: 1})
+	if n := len(b.Packs()); n != 2 {
+		t.Errorf("bank has %d packs after a failed refresh, want 2", n)
+	}
+}
+
+func TestParseUpload(t *testing.T) {
+	txt := "# class vocabulary\nPhotosynthesis\n\nchlorophyll\nmitochondria\nosmosis\nosmosis\ncell wall\nH2O\nenzyme\n"
+	u, err := ParseUpload("biology", "", "Biology.TXT", []byte(txt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(u.Pack.Words) != 6 || u.Pack.Words[0].Text != "photosynthesis" || u.Pack.Words[0].Difficulty != Medium {
+		t.Errorf("words = %+v", u.Pack.Words)
+	}
+	if len(u.Rejected) != 1 || u.Rejected[0] != "H2O" {
+		t.Errorf("rejected = %q, want H2O", u.Rejected)
+	}
+
+	csvData := "word,difficulty\nnaruto,easy\nsasuke,easy\nkakashi,medium\nitachi,hard\nrock lee,\nmadara,legendary\n"
+	u, err = ParseUpload("naruto", "Naruto", "pack.csv", []byte(csvData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(u.Pack.Words) != 5 || u.Pack.Words[3].Difficulty != Hard || u.Pack.Words[4] != (Word{"rock lee", "naruto", Medium}) {
+		t.Errorf("words = %+v", u.Pack.Words)
+	}
+	if len(u.Rejected) != 1 {
+		t.Errorf("rejected = %q, want the unknown difficulty", u.Rejected)
+	}
+
+	for name, args := range map[string][3]string{
+		"bad name":      {"Bad Name", "a.txt", "one\ntwo\nthree\nfour\nfive"},
+		"too few words": {"x", "a.txt", "one\ntwo"},
+		"unknown type":  {"x", "a.pdf", "one\ntwo\nthree\nfour\nfive"},
+	} {
+		if _, err := ParseUpload(args[0], "", args[1], []byte(args[2])); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func TestChatPacks(t *testing.T) {
+	store := repository.NewMemoryStore()
+	b := testBank(0)
+	UseStore(b, store)
+
+	u, err := ParseUpload("class", "Class", "class.txt", []byte("alpha\nbeta\ngamma\ndelta\nepsilon"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveChatPack(b, store, 1, 42, u.Pack); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveChatPack(b, store, 1, 42, Pack{Name: "a"}); err == nil {
+		t.Error("saved a pack under a built-in name")
+	}
+	pickFrom := func(b *Bank, chatID int64) string {
+		t.Helper()
+		w, err := b.Pick(chatID, Filter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return w.Pack
+	}
+	for i := 0; i < 10; i++ {
+		if p := pickFrom(b, 1); p != "class" {
+			t.Fatalf("chat 1 picked from %s, want its own pack", p)
+		}
+		if p := pickFrom(b, 2); p == "class" {
+			t.Fatal("chat 2 picked from chat 1's pack")
+		}
+	}
+
+	// A fresh bank reads the chat's packs back from the store.
+	reloaded := testBank(0)
+	UseStore(reloaded, store)
+	if p := pickFrom(reloaded, 1); p != "class" {
+		t.Errorf("after a restart chat 1 picked from %s, want its own pack", p)
+	}
+
+	if err := TogglePack(b, store, 1, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if active := b.Chat(1).Active; len(active) != 2 {
+		t.Errorf("active = %v, want class and b", active)
+	}
+	if err := DeleteChatPack(b, store, 1, "class"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if p := pickFrom(b, 1); p != "b" {
+			t.Fatalf("picked from %s after deleting the chat's pack, want b", p)
+		}
+	}
+	if err := DeleteChatPack(b, store, 1, "class"); err != repository.ErrWordPackNotFound {
+		t.Errorf("err = %v, want ErrWordPackNotFound", err)
+	}
+}
@@ -0,0 +1,172 @@
+package wordguess
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordbank"
+)
+
+// What a correct guess is worth, and what each hint given for the word takes
+// off it.
+const (
+	GuessPoints = 10
+	HintCost    = 2
+)
+
+// PointsFor returns what a correct guess earns after the given number of
+// hints, never less than a point.
+func PointsFor(hints int) int {
+	return max(GuessPoints-HintCost*hints, 1)
+}
+
+// entry is what the definitions file knows of a word.
+type entry struct {
+	Definition string   `json:"definition"`
+	Synonyms   []string `json:"synonyms"`
+}
+
+//go:embed definitions.json
+var definitionsJSON []byte
+
+// definitions explains the words of the built-in English packs. Hints come
+// from the packs alone, never from a dictionary service, so they are quick
+// and work offline; a word without an entry skips the definition tier.
+var definitions = func() map[string]entry {
+	var m map[string]entry
+	if err := json.Unmarshal(definitionsJSON, &m); err != nil {
+		panic(fmt.Sprintf("wordguess: definitions: %v", err))
+	}
+	return m
+}()
+
+// HintTier is a kind of hint. A round's hints go through the tiers in order,
+// skipping those there is nothing to say for.
+type HintTier int
+
+const (
+	TierCategory   HintTier = iota // the pack the word is from
+	TierDefinition                 // what the word means
+	TierSynonym                    // another word for it
+	TierEnds                       // its first and last letters
+	TierLength                     // how many letters and words it has
+	hintTiers
+)
+
+// hintText is how a language words its hints. Languages without a
+// definition or synonym have no use for those tiers.
+type hintText struct {
+	prefix   string
+	category string
+	synonym  string
+	ends     string
+	letters  string // the number of letters
+	words    string // the number of letters and of words
+}
+
+var hintTexts = map[Language]hintText{
+	English: {
+		prefix:   "ⓘ Hint: ",
+		category: "it's from the %s pack.",
+		synonym:  "another word for it is \"%s\".",
+		ends:     "it starts with \"%s\" and ends with \"%s\".",
+		letters:  "it has %d letters.",
+		words:    "it has %d letters in %d words.",
+	},
+	Arabic: {
+		prefix:   "ⓘ تلميح: ",
+		category: "الفئة «%s».",
+		ends:     "تبدأ الكلمة بحرف «%s» وتنتهي بحرف «%s».",
+		letters:  "عدد الحروف: %d.",
+		words:    "عدد الحروف: %d، عدد الكلمات: %d.",
+	},
+	Russian: {
+		prefix:   "ⓘ Подсказка: ",
+		category: "категория «%s».",
+		ends:     "слово начинается на «%s» и заканчивается на «%s».",
+		letters:  "букв: %d.",
+		words:    "букв: %d, слов: %d.",
+	},
+	French: {
+		prefix:   "ⓘ Indice : ",
+		category: "catégorie « %s ».",
+		ends:     "le mot commence par « %s » et finit par « %s ».",
+		letters:  "%d lettres.",
+		words:    "%d lettres en %d mots.",
+	},
+}
+
+// Hint returns the n-th hint for the word in the language, counting from 0,
+// or false once the tiers run out.
+func Hint(lang Language, word string, n int) (string, bool) {
+	t, ok := hintTexts[lang]
+	if !ok {
+		lang, t = English, hintTexts[English]
+	}
+	for tier := TierCategory; tier < hintTiers; tier++ {
+		text := tierHint(t, lang, word, tier)
+		if text == "" {
+			continue
+		}
+		if n == 0 {
+			return t.prefix + text, true
+		}
+		n--
+	}
+	return "", false
+}
+
+// tierHint words the hint of one tier, or returns "" if there is nothing to
+// say.
+func tierHint(t hintText, lang Language, word string, tier HintTier) string {
+	var letters []rune
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			letters = append(letters, r)
+		}
+	}
+	switch tier {
+	case TierCategory:
+		if p, ok := wordbank.For(string(lang)).PackOf(word); ok && p.Name != wordbank.RemotePack {
+			return fmt.Sprintf(t.category, p.Title)
+		}
+	case TierDefinition:
+		if lang == English {
+			return definitions[word].Definition
+		}
+	case TierSynonym:
+		if e := definitions[word]; lang == English && len(e.Synonyms) > 0 {
+			return fmt.Sprintf(t.synonym, strings.Join(e.Synonyms, "\", \""))
+		}
+	case TierEnds:
+		if len(letters) > 1 {
+			return fmt.Sprintf(t.ends, strings.ToUpper(string(letters[0])), string(letters[len(letters)-1]))
+		}
+	case TierLength:
+		if n := len(strings.Fields(word)); n > 1 {
+			return fmt.Sprintf(t.words, len(letters), n)
+		}
+		return fmt.Sprintf(t.letters, len(letters))
+	}
+	return ""
+}
+
+// NextHint returns the hint that follows the given number of hints for the
+// word, or, with false, why there is none: the chat's hint budget is spent or
+// the tiers ran out.
+func NextHint(s ChatSettings, word string, given int) (string, bool) {
+	if s.HintBudget == 0 {
+		return "💡 Hints are turned off in this chat.", false
+	}
+	if given >= s.HintBudget {
+		return fmt.Sprintf("💡 No hints left: this chat allows %d per word.", s.HintBudget), false
+	}
+	hint, ok := Hint(s.Language, word, given)
+	if !ok {
+		return "💡 That's every hint there is for this word.", false
+	}
+	return fmt.Sprintf("%s\n(Hint %d of %d: a correct guess now earns %d points.)", hint, given+1, s.HintBudget, PointsFor(given+1)), true
+}
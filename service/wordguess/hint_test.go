@@ -0,0 +1,60 @@
+package wordguess
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefinitionsDoNotLeak(t *testing.T) {
+	for word, e := range definitions {
+		if e.Definition == "" {
+			t.Errorf("%s has no definition", word)
+		}
+		if leak := DetectLeak(e.Definition, word); leak != NoLeak {
+			t.Errorf("the definition of %s has %s in it: %q", word, leak, e.Definition)
+		}
+		for _, syn := range e.Synonyms {
+			if leak := DetectLeak(syn, word); leak != NoLeak {
+				t.Errorf("the synonym %q of %s has %s in it", syn, word, leak)
+			}
+		}
+	}
+}
+
+func TestEnglishHintTiers(t *testing.T) {
+	want := []string{"Animals pack", "sea creature with eight arms", "starts with \"O\" and ends with \"s\"", "7 letters"}
+	for n, w := range want {
+		h, ok := Hint(English, "octopus", n)
+		if !ok || !strings.Contains(h, w) {
+			t.Errorf("hint %d = %q %v, want %q", n, h, ok, w)
+		}
+	}
+	if _, ok := Hint(English, "octopus", len(want)); ok {
+		t.Error("octopus has a synonym hint, but none is in the file")
+	}
+	if h, _ := Hint(English, "sofa", 2); !strings.Contains(h, "couch") {
+		t.Errorf("third sofa hint = %q, want its synonyms", h)
+	}
+	// A word the packs know nothing of goes straight to its letters.
+	if h, _ := Hint(English, "quokkaroo", 0); !strings.Contains(h, "starts with \"Q\"") {
+		t.Errorf("first hint for an unknown word = %q, want its ends", h)
+	}
+}
+
+func TestNextHintBudget(t *testing.T) {
+	s := ChatSettings{Language: English, HintBudget: 2}
+	h, ok := NextHint(s, "cat", 1)
+	if !ok || !strings.Contains(h, "Hint 2 of 2") || !strings.Contains(h, "6 points") {
+		t.Errorf("second hint = %q %v", h, ok)
+	}
+	if h, ok := NextHint(s, "cat", 2); ok || !strings.Contains(h, "2 per word") {
+		t.Errorf("hint over budget = %q %v", h, ok)
+	}
+	s.HintBudget = 0
+	if _, ok := NextHint(s, "cat", 0); ok {
+		t.Error("hint given with hints off")
+	}
+	if PointsFor(0) != GuessPoints || PointsFor(100) != 1 {
+		t.Errorf("PointsFor = %d, %d", PointsFor(0), PointsFor(100))
+	}
+}
@@ -0,0 +1,115 @@
+package wordguess
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/controller/modbot"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service/wordbank"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Language is the language a chat plays the word game in, by its code. Each
+// has its own word bank, way of matching guesses and hints.
+type Language string
+
+const (
+	English Language = "en"
+	Arabic  Language = "ar"
+	Russian Language = "ru"
+	French  Language = "fr"
+)
+
+// Languages lists the languages a chat can pick.
+var Languages = []Language{English, Arabic, Russian, French}
+
+// Label is the language's name in itself.
+func (l Language) Label() string {
+	switch l {
+	case Arabic:
+		return "العربية"
+	case Russian:
+		return "Русский"
+	case French:
+		return "Français"
+	}
+	return "English"
+}
+
+// Valid reports whether l is one of the Languages.
+func (l Language) Valid() bool {
+	for _, known := range Languages {
+		if l == known {
+			return true
+		}
+	}
+	return false
+}
+
+// NextWord picks the chat's next word from the bank of its language.
+func NextWord(chatID int64, store repository.Store) (string, error) {
+	return wordbank.NextIn(chatID, string(GetChatSettings(chatID, store).Language))
+}
+
+// Matches reports whether guess is the word under the language's rules.
+// English allows simple plurals. Arabic ignores diacritics, letter variants
+// and the article; Russian folds case and ё; French ignores accents, a
+// leading article and a plural s or x. Outside English spaces and hyphens
+// do not count.
+func Matches(lang Language, guess, word string) bool {
+	switch lang {
+	case Arabic:
+		return arabicKey(guess) == arabicKey(word)
+	case Russian:
+		return cyrillicKey(guess) == cyrillicKey(word)
+	case French:
+		g, w := frenchKey(guess), frenchKey(word)
+		if len(g) > len(w) {
+			g, w = w, g
+		}
+		return g != "" && (g == w || w == g+"s" || w == g+"x")
+	}
+	return service.NormalizeAndComparePlural(guess, word)
+}
+
+func arabicKey(s string) string {
+	toks := tokens(modbot.ArabicNormalize(s))
+	for i, t := range toks {
+		if rest := strings.TrimPrefix(t, "ال"); rest != "" {
+			toks[i] = rest
+		}
+	}
+	return strings.Join(toks, "")
+}
+
+func cyrillicKey(s string) string {
+	// A Caser keeps state, so each call gets its own.
+	s = cases.Fold().String(s)
+	return strings.ReplaceAll(strings.Join(tokens(s), ""), "ё", "е")
+}
+
+// frenchArticles are dropped from the start of a French guess.
+var frenchArticles = map[string]bool{"le": true, "la": true, "les": true, "l": true, "un": true, "une": true, "des": true}
+
+func frenchKey(s string) string {
+	s = strings.NewReplacer("'", " ", "’", " ", "œ", "oe", "Œ", "oe", "æ", "ae", "Æ", "ae").Replace(s)
+	toks := tokens(stripAccents(s))
+	if len(toks) > 1 && frenchArticles[toks[0]] {
+		toks = toks[1:]
+	}
+	return strings.Join(toks, "")
+}
+
+// stripAccents takes the accents off the letters of s, so "é" reads as "e".
+func stripAccents(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
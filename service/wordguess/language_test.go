@@ -0,0 +1,74 @@
+package wordguess
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatches(t *testing.T) {
+	for _, tc := range []struct {
+		lang        Language
+		guess, word string
+		want        bool
+	}{
+		{English, "Elephants!", "elephant", true},
+		{English, "elefant", "elephant", false},
+		{Arabic, "قِطَّة", "قطة", true},
+		{Arabic, "القطه", "قطة", true},
+		{Arabic, "إسد", "أسد", true},
+		{Arabic, "خلد ماء", "خلد الماء", true},
+		{Arabic, "كلب", "قطة", false},
+		{Russian, "КОШКА", "кошка", true},
+		{Russian, "еж", "ёж", true},
+		{Russian, "Морской Конек", "морской конёк", true},
+		{Russian, "кошки", "кошка", false},
+		{French, "ecureuil", "écureuil", true},
+		{French, "l'écureuil", "écureuil", true},
+		{French, "les chevaux", "cheval", false},
+		{French, "les chats", "chat", true},
+		{French, "oeuf", "œuf", true},
+		{French, "chauve souris", "chauve-souris", true},
+		{French, "la", "la", true},
+		{French, "chien", "chat", false},
+	} {
+		if got := Matches(tc.lang, tc.guess, tc.word); got != tc.want {
+			t.Errorf("Matches(%s, %q, %q) = %v, want %v", tc.lang, tc.guess, tc.word, got, tc.want)
+		}
+	}
+}
+
+func TestHint(t *testing.T) {
+	hints := func(lang Language, word string) []string {
+		var hs []string
+		for n := 0; ; n++ {
+			h, ok := Hint(lang, word, n)
+			if !ok {
+				return hs
+			}
+			hs = append(hs, h)
+		}
+	}
+	fr := hints(French, "écureuil")
+	if len(fr) != 3 || !strings.Contains(fr[0], "Animaux") || !strings.Contains(fr[1], "« É »") || !strings.Contains(fr[2], "8 lettres") {
+		t.Errorf("French hints = %q, want the category, the ends and 8 letters", fr)
+	}
+	ru := hints(Russian, "морской конёк")
+	if len(ru) != 3 || !strings.Contains(ru[1], "«М»") || !strings.Contains(ru[1], "«к»") || !strings.Contains(ru[2], "букв: 12, слов: 2") {
+		t.Errorf("Russian hints = %q", ru)
+	}
+	if ar := hints(Arabic, "قطة"); len(ar) == 0 || !strings.Contains(ar[0], "حيوانات") {
+		t.Errorf("Arabic hints = %q, want the category first", ar)
+	}
+}
+
+func TestLanguageSetting(t *testing.T) {
+	if got := GetChatSettings(-2001, nil).Language; got != English {
+		t.Errorf("default language = %s, want English", got)
+	}
+	if err := UpdateLanguage(-2001, French, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := GetChatSettings(-2001, nil).Language; got != French {
+		t.Errorf("language = %s, want French", got)
+	}
+}
@@ -0,0 +1,202 @@
+// Package wordguess holds the rules of the word-guess round that the word and
+// category bots share: per-chat settings and checks on what the leader says.
+package wordguess
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/service"
+)
+
+// Leak is how a leader's message gave the word away.
+type Leak int
+
+const (
+	NoLeak          Leak = iota
+	LeakWord             // the word itself, or its plural
+	LeakVariant          // another form of it, like "danced" for "dancer"
+	LeakSpelled          // the word spelled out, like "c a t" or "ele phant"
+	LeakTranslation      // the word in another language
+)
+
+func (l Leak) String() string {
+	switch l {
+	case LeakWord:
+		return "the word"
+	case LeakVariant:
+		return "a form of the word"
+	case LeakSpelled:
+		return "the word spelled out"
+	case LeakTranslation:
+		return "a translation of the word"
+	}
+	return "nothing"
+}
+
+//go:embed translations.json
+var translationsJSON []byte
+
+// translations maps the easy words of the built-in packs to what they are
+// called in the other languages the groups speak. Words that are also common
+// English words are left out, or a leader could not use them.
+var translations = func() map[string][]string {
+	var m map[string][]string
+	if err := json.Unmarshal(translationsJSON, &m); err != nil {
+		panic(fmt.Sprintf("wordguess: translations: %v", err))
+	}
+	return m
+}()
+
+// Translations returns what the word is called in other languages.
+func Translations(word string) []string {
+	return translations[strings.ToLower(strings.TrimSpace(word))]
+}
+
+// DetectLeak checks a message from the leader for the word they are
+// explaining. Only English is understood: the plurals, word forms and
+// spellings it looks for are English ones, whatever the chat's language, and
+// the translations are of the English packs' words.
+func DetectLeak(text, word string) Leak {
+	words := tokens(word)
+	if len(words) == 0 {
+		return NoLeak
+	}
+	toks := tokens(text)
+	if matchesAny(toks, words) {
+		return LeakWord
+	}
+	if variantOf(toks, words) {
+		return LeakVariant
+	}
+	if spelledOut(text, strings.Join(words, "")) {
+		return LeakSpelled
+	}
+	for _, t := range Translations(word) {
+		if matchesAny(toks, tokens(t)) {
+			return LeakTranslation
+		}
+	}
+	return NoLeak
+}
+
+// tokens splits text into lower-case words, dropping the punctuation in and
+// around them so that "a-p-p-l-e" and "ap*ple" read as "apple".
+func tokens(text string) []string {
+	var toks []string
+	for _, f := range strings.Fields(text) {
+		if t := clean(f); t != "" {
+			toks = append(toks, t)
+		}
+	}
+	return toks
+}
+
+func clean(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// matchesAny reports whether the words, or their plural, appear in toks in a
+// row.
+func matchesAny(toks, words []string) bool {
+	want := strings.Join(words, " ")
+	for i := 0; i+len(words) <= len(toks); i++ {
+		if service.NormalizeAndComparePlural(strings.Join(toks[i:i+len(words)], " "), want) {
+			return true
+		}
+	}
+	return false
+}
+
+// minVariantLen is the shortest word whose other forms count as leaks; below
+// it the stems are too short to tell "car" from "care".
+const minVariantLen = 4
+
+// variantOf reports whether a token is another form of a single-word word.
+func variantOf(toks, words []string) bool {
+	if len(words) != 1 || len([]rune(words[0])) < minVariantLen {
+		return false
+	}
+	ws, wcut := stem(words[0])
+	for _, t := range toks {
+		ts, tcut := stem(t)
+		switch {
+		case ts == ws && (tcut || wcut):
+		case tcut && ts+"e" == words[0], wcut && ws+"e" == t:
+		default:
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// suffixes are the endings stem takes off, longest first.
+var suffixes = []string{"ings", "ing", "ies", "ers", "est", "ed", "er", "es", "ly", "s"}
+
+// stem takes the commonest English ending off w and reports whether it did.
+// It is rough, but the leader's words only need to be told apart from the one
+// word they are explaining.
+func stem(w string) (string, bool) {
+	for _, suf := range suffixes {
+		if !strings.HasSuffix(w, suf) || len(w)-len(suf) < 3 {
+			continue
+		}
+		s := strings.TrimSuffix(w, suf)
+		if suf == "ies" {
+			s += "y"
+		}
+		// "running" is "run" with the consonant doubled.
+		if n := len(s); n > 3 && s[n-1] == s[n-2] && !strings.ContainsRune("aeiou", rune(s[n-1])) {
+			s = s[:n-1]
+		}
+		return s, true
+	}
+	return w, false
+}
+
+// spelledOut reports whether the text spells word out in pieces: single
+// letters or short chunks with spaces between them, or two or three words run
+// together.
+func spelledOut(text, word string) bool {
+	toks := tokens(text)
+	// A run of one- and two-letter chunks that contains a single letter.
+	var run strings.Builder
+	single := false
+	flush := func() bool {
+		found := single && strings.Contains(run.String(), word)
+		run.Reset()
+		single = false
+		return found
+	}
+	for _, t := range toks {
+		if n := len([]rune(t)); n <= 2 {
+			run.WriteString(t)
+			single = single || n == 1
+			continue
+		}
+		if flush() {
+			return true
+		}
+	}
+	if flush() {
+		return true
+	}
+	for n := 2; n <= 3; n++ {
+		for i := 0; i+n <= len(toks); i++ {
+			if service.NormalizeAndComparePlural(strings.Join(toks[i:i+n], ""), word) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package wordguess
+
+import (
+	"testing"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+)
+
+func TestDetectLeak(t *testing.T) {
+	for _, tc := range []struct {
+		text, word string
+		want       Leak
+	}{
+		{"it's an Elephant!", "elephant", LeakWord},
+		{"lots of elephants here", "elephant", LeakWord},
+		{"I love ice cream", "ice cream", LeakWord},
+		{"she danced all night", "dancer", LeakVariant},
+		{"think of running", "run", NoLeak},
+		{"a painting on the wall", "painter", LeakVariant},
+		{"you take care of it", "car", NoLeak},
+		{"the ring on her finger", "ring", LeakWord},
+		{"c a t", "cat", LeakSpelled},
+		{"the word is e l e p h a n t", "elephant", LeakSpelled},
+		{"a-p-p-l-e", "apple", LeakWord},
+		{"ele phant", "elephant", LeakSpelled},
+		{"in hindi it is haathi", "elephant", LeakTranslation},
+		{"हाथी", "elephant", LeakTranslation},
+		{"chai pe charcha", "tea", LeakTranslation},
+		{"big grey animal with a trunk", "elephant", NoLeak},
+		{"it is a tall animal", "giraffe", NoLeak},
+	} {
+		if got := DetectLeak(tc.text, tc.word); got != tc.want {
+			t.Errorf("DetectLeak(%q, %q) = %v, want %v", tc.text, tc.word, got, tc.want)
+		}
+	}
+}
+
+func TestTranslationsAreNotTheWord(t *testing.T) {
+	for word, ts := range translations {
+		for _, tr := range ts {
+			if DetectLeak(word, tr) == LeakWord {
+				t.Errorf("%q lists itself as a translation", word)
+			}
+		}
+	}
+}
+
+func TestLeakPolicySetting(t *testing.T) {
+	store := repository.NewMemoryStore()
+	if p := GetChatSettings(-100, store).LeakPolicy; p != LeakWarn {
+		t.Errorf("default policy = %q, want %q", p, LeakWarn)
+	}
+	if err := UpdateLeakPolicy(-100, LeakEndRound, store); err != nil {
+		t.Fatal(err)
+	}
+	var saved ChatSettings
+	store.LoadChatSettings(settingsCollection, -100, &saved)
+	if saved.LeakPolicy != LeakEndRound || GetChatSettings(-100, store).LeakPolicy != LeakEndRound {
+		t.Errorf("saved %q, cached %q, want end_round", saved.LeakPolicy, GetChatSettings(-100, store).LeakPolicy)
+	}
+}
@@ -0,0 +1,132 @@
+package wordguess
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/MUSTAFA-A-KHAN/telegram-bot-anime/repository"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// LeakPolicy is what happens when the leader gives the word away. The message
+// is deleted and the leader warned under every policy but LeakOff.
+type LeakPolicy string
+
+const (
+	LeakOff      LeakPolicy = "off"
+	LeakWarn     LeakPolicy = "warn"
+	LeakEndRound LeakPolicy = "end_round" // the word is revealed and the leader loses the lead
+)
+
+// ChatSettings are a chat's word-guess settings. The word packs kept in the
+// same document belong to the wordbank package.
+type ChatSettings struct {
+	ChatID       int64      `bson:"_id"`
+	LeakPolicy   LeakPolicy `bson:"leak_policy"`
+	RoundSeconds int        `bson:"round_seconds"` // how long a leader has for a word
+	IdleSeconds  int        `bson:"idle_seconds"`  // how long a leader may stay silent; 0 for no limit
+	Language     Language   `bson:"language"`
+	HintBudget   int        `bson:"hint_budget"` // how many hints a word may have
+}
+
+// Defaults of the round timers and the hint budget.
+const (
+	DefaultRoundSeconds = 600
+	DefaultIdleSeconds  = 120
+	DefaultHintBudget   = 3
+)
+
+// Choices of the round timers and the hint budget offered in the settings.
+var (
+	RoundChoices      = []int{120, 300, 600, 900}
+	IdleChoices       = []int{0, 60, 120, 300}
+	HintBudgetChoices = []int{0, 1, 2, 3, 5}
+)
+
+// RoundTime is how long the leader has for a word.
+func (s ChatSettings) RoundTime() time.Duration {
+	return time.Duration(s.RoundSeconds) * time.Second
+}
+
+// IdleTime is how long the leader may stay silent before the round ends; 0
+// means they may take as long as the round lasts.
+func (s ChatSettings) IdleTime() time.Duration {
+	return time.Duration(s.IdleSeconds) * time.Second
+}
+
+const settingsCollection = "WordGuessSettings"
+
+var (
+	settingsCache = make(map[int64]*ChatSettings)
+	settingsMutex sync.RWMutex
+)
+
+// GetChatSettings returns the chat's settings, loading them on first use.
+func GetChatSettings(chatID int64, store repository.Store) ChatSettings {
+	settingsMutex.RLock()
+	settings, ok := settingsCache[chatID]
+	settingsMutex.RUnlock()
+	if ok {
+		return *settings
+	}
+
+	settings = &ChatSettings{
+		ChatID:       chatID,
+		LeakPolicy:   LeakWarn,
+		RoundSeconds: DefaultRoundSeconds,
+		IdleSeconds:  DefaultIdleSeconds,
+		Language:     English,
+		HintBudget:   DefaultHintBudget,
+	}
+	if store != nil {
+		if err := store.LoadChatSettings(settingsCollection, chatID, settings); err != nil {
+			log.Printf("Failed to load word-guess settings for chat %d: %v", chatID, err)
+		}
+	}
+
+	settingsMutex.Lock()
+	settingsCache[chatID] = settings
+	settingsMutex.Unlock()
+	return *settings
+}
+
+// updateSetting changes one field of the chat's settings and saves it.
+func updateSetting(chatID int64, store repository.Store, field string, value interface{}, apply func(*ChatSettings)) error {
+	GetChatSettings(chatID, store)
+
+	settingsMutex.Lock()
+	apply(settingsCache[chatID])
+	settingsMutex.Unlock()
+
+	if store != nil {
+		return store.SaveChatSettings(settingsCollection, chatID, bson.M{field: value})
+	}
+	return nil
+}
+
+// UpdateLeakPolicy sets what happens when the chat's leaders give the word
+// away.
+func UpdateLeakPolicy(chatID int64, policy LeakPolicy, store repository.Store) error {
+	return updateSetting(chatID, store, "leak_policy", policy, func(s *ChatSettings) { s.LeakPolicy = policy })
+}
+
+// UpdateRoundSeconds sets how long the chat's leaders have for a word.
+func UpdateRoundSeconds(chatID int64, seconds int, store repository.Store) error {
+	return updateSetting(chatID, store, "round_seconds", seconds, func(s *ChatSettings) { s.RoundSeconds = seconds })
+}
+
+// UpdateIdleSeconds sets how long the chat's leaders may stay silent.
+func UpdateIdleSeconds(chatID int64, seconds int, store repository.Store) error {
+	return updateSetting(chatID, store, "idle_seconds", seconds, func(s *ChatSettings) { s.IdleSeconds = seconds })
+}
+
+// UpdateLanguage sets the language the chat plays in.
+func UpdateLanguage(chatID int64, lang Language, store repository.Store) error {
+	return updateSetting(chatID, store, "language", lang, func(s *ChatSettings) { s.Language = lang })
+}
+
+// UpdateHintBudget sets how many hints a word may have in the chat.
+func UpdateHintBudget(chatID int64, hints int, store repository.Store) error {
+	return updateSetting(chatID, store, "hint_budget", hints, func(s *ChatSettings) { s.HintBudget = hints })
+}
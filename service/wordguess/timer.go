@@ -0,0 +1,70 @@
+package wordguess
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// Timer is the clock of a round with a leader. It is kept with the round so
+// that the countdown carries on after a restart.
+type Timer struct {
+	Ends       time.Time `bson:"ends"`        // when the word is revealed
+	LastActive time.Time `bson:"last_active"` // the leader's last message
+	MessageID  int       `bson:"message_id"`  // the countdown message
+}
+
+// NewTimer starts the clock of a round led from now under the settings.
+func NewTimer(s ChatSettings, now time.Time) Timer {
+	return Timer{Ends: now.Add(s.RoundTime()), LastActive: now}
+}
+
+// Expiry is why a round ran out.
+type Expiry int
+
+const (
+	NotExpired Expiry = iota
+	TimeUp            // the round's time is over
+	LeaderIdle        // the leader stopped explaining
+)
+
+// Expired tells whether the round is over at now, given how long the leader
+// may stay silent.
+func (t Timer) Expired(now time.Time, idle time.Duration) Expiry {
+	switch {
+	case t.Ends.IsZero():
+		return NotExpired
+	case !now.Before(t.Ends):
+		return TimeUp
+	case idle > 0 && now.Sub(t.LastActive) >= idle:
+		return LeaderIdle
+	}
+	return NotExpired
+}
+
+// Countdown is the HTML text of the countdown message at now. The time left is
+// rounded down to 30 seconds, and to 10 in the last minute, so the message
+// only needs editing that often.
+func (t Timer) Countdown(now time.Time, total time.Duration, leader string) string {
+	left := t.Ends.Sub(now)
+	if left < 0 {
+		left = 0
+	}
+	step := 30 * time.Second
+	if left <= time.Minute {
+		step = 10 * time.Second
+	}
+	left = left.Truncate(step)
+
+	const width = 10
+	filled := 0
+	if total > 0 {
+		filled = int((left*width + total - 1) / total)
+	}
+	if filled > width {
+		filled = width
+	}
+	bar := strings.Repeat("🟩", filled) + strings.Repeat("⬜", width-filled)
+	return fmt.Sprintf("⏳ <b>%d:%02d</b> left for %s's word\n%s", int(left.Minutes()), int(left.Seconds())%60, html.EscapeString(leader), bar)
+}
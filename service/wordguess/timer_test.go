@@ -0,0 +1,54 @@
+package wordguess
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimerExpired(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	timer := NewTimer(ChatSettings{RoundSeconds: 300}, start)
+
+	for _, tc := range []struct {
+		at         time.Duration
+		lastActive time.Duration
+		idle       time.Duration
+		want       Expiry
+	}{
+		{at: time.Minute, idle: 2 * time.Minute, want: NotExpired},
+		{at: 2 * time.Minute, idle: 2 * time.Minute, want: LeaderIdle},
+		{at: 4 * time.Minute, lastActive: 3 * time.Minute, idle: 2 * time.Minute, want: NotExpired},
+		{at: 4 * time.Minute, idle: 0, want: NotExpired},
+		{at: 5 * time.Minute, lastActive: 5 * time.Minute, idle: 2 * time.Minute, want: TimeUp},
+	} {
+		tm := timer
+		tm.LastActive = start.Add(tc.lastActive)
+		if got := tm.Expired(start.Add(tc.at), tc.idle); got != tc.want {
+			t.Errorf("at %v, active %v, idle %v: got %v, want %v", tc.at, tc.lastActive, tc.idle, got, tc.want)
+		}
+	}
+	if (Timer{}).Expired(start, time.Second) != NotExpired {
+		t.Error("a round without a timer expired")
+	}
+}
+
+func TestCountdown(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	timer := NewTimer(ChatSettings{RoundSeconds: 600}, start)
+
+	text := timer.Countdown(start.Add(61*time.Second), 10*time.Minute, "<Ann>")
+	if !strings.Contains(text, "<b>8:30</b>") || !strings.Contains(text, "&lt;Ann&gt;") {
+		t.Errorf("countdown = %q, want 8:30 left for an escaped name", text)
+	}
+	if strings.Count(text, "🟩") != 9 {
+		t.Errorf("countdown = %q, want 9 of 10 filled", text)
+	}
+	// In the last minute the time goes down in tens of seconds.
+	if text := timer.Countdown(start.Add(9*time.Minute+15*time.Second), 10*time.Minute, "Ann"); !strings.Contains(text, "0:40") {
+		t.Errorf("countdown = %q, want 0:40", text)
+	}
+	if text := timer.Countdown(start.Add(11*time.Minute), 10*time.Minute, "Ann"); !strings.Contains(text, "0:00") || strings.Contains(text, "🟩") {
+		t.Errorf("countdown after the end = %q", text)
+	}
+}
@@ -107,7 +107,7 @@ func SendMessageWithButtonsV2(chatID int64, text string, buttons tgbotapi.Inline
 	if len(buttons.InlineKeyboard) > 0 {
 		msg.ReplyMarkup = buttons
 	}
-	bot, _ := tgbotapiv5Ovy.NewBotAPI(config.App.CatTelegramToken)
+	bot, _ := tgbotapiv5Ovy.NewBotAPI(config.App.Bots.Category.Token)
 	_, err := bot.Send(msg)
 	// update, err := bot.HandleUpdate(nil)
 	// update.Message
@@ -129,7 +129,7 @@ func SendEphemeralMessage(chatID int64, text string, usrid int, msgID int, force
 	// if len(buttons.InlineKeyboard) > 0 {
 	// 	msg.ReplyMarkup = buttons
 	// }
-	bot, _ := tgbotapiv5Ovy.NewBotAPI(config.App.CatTelegramToken)
+	bot, _ := tgbotapiv5Ovy.NewBotAPI(config.App.Bots.Category.Token)
 	sent, err := bot.Send(msg)
 	// update, err := bot.HandleUpdate(nil)
 	// update.Message
@@ -202,15 +202,15 @@ func SendEphemeralMediaMessage(chatID int64, mediaType, fileID, caption string,
 		return fmt.Errorf("unsupported media type: %s", mediaType), tgbotapiv5Ovy.Message{}
 	}
 
-	bot, _ := tgbotapiv5Ovy.NewBotAPI(config.App.CatTelegramToken)
+	bot, _ := tgbotapiv5Ovy.NewBotAPI(config.App.Bots.Category.Token)
 	sent, err := bot.Send(c)
 	return err, sent
 }
 
 // SendRichMessage sends a rich message using the OvyFlash library with blocks (paragraphs, tables, images, etc.).
-// Uses the configured CatTelegramToken and no buttons.
+// Uses the configured category bot token and no buttons.
 func SendRichMessage(chatID int64, richMessage tgbotapiv5Ovy.InputRichMessage) error {
-	return SendRichMessageWithButtons(config.App.CatTelegramToken, chatID, richMessage, nil)
+	return SendRichMessageWithButtons(config.App.Bots.Category.Token, chatID, richMessage, nil)
 }
 
 // SendMessageWithButtons sends a message with inline keyboard buttons to the user